@@ -99,10 +99,130 @@ type ClusterColocationProfileSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Patch runtime.RawExtension `json:"patch,omitempty"`
+
+	// Rollout describes how the mutation is staged on the matched Pods. Both the webhook on the Pod creation and
+	// the colocation-profile controller on the existing Pods follow it and bucket the Pods the same way.
+	// If not specified, all the matched Pods are mutated at once.
+	// +optional
+	Rollout *ClusterColocationProfileRollout `json:"rollout,omitempty"`
+}
+
+// ClusterColocationProfileRollout describes a staged rollout of the profile on the matched Pods.
+// The rollout restarts from the first step when the mutation of the profile changes, while updating the
+// rollout itself (e.g. pausing or resuming) keeps the progress.
+type ClusterColocationProfileRollout struct {
+	// Steps are the percentages of the matched Pods to mutate over time.
+	// The rollout stays at the last step once reached.
+	// +optional
+	Steps []ClusterColocationProfileRolloutStep `json:"steps,omitempty"`
+
+	// Paused stops mutating the Pods, including the Pods being created, and holds the rollout at the current step.
+	// The current step restarts when the rollout is resumed.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ClusterColocationProfileRolloutStep is a step of the rollout.
+type ClusterColocationProfileRolloutStep struct {
+	// Percent is the percentage of the matched Pods to mutate in the step.
+	// The Pods are selected stably, so the Pods mutated in the previous steps keep selected.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent int32 `json:"percent"`
+
+	// Duration is the time the step lasts before moving to the next step.
+	// If not specified, the rollout stays at the step until the duration is set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
+const (
+	// ColocationProfileConditionReconciled indicates whether the last reconciliation of the profile succeeded.
+	ColocationProfileConditionReconciled = "Reconciled"
+)
+
 // ClusterColocationProfileStatus represents information about the status of a ClusterColocationProfile.
 type ClusterColocationProfileStatus struct {
+	// ObservedGeneration is the generation of the profile observed by the colocation-profile controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastReconcileTime is the last time the controller reconciled the Pods for the profile.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// MatchedPods is the number of the pending Pods matched by the profile in the last reconciliation.
+	// +optional
+	MatchedPods int32 `json:"matchedPods,omitempty"`
+
+	// MutatedPods is the number of the Pods patched by the profile in the last reconciliation.
+	// +optional
+	MutatedPods int32 `json:"mutatedPods,omitempty"`
+
+	// WebhookMutatedPods is the number of the existing Pods mutated by the profile on creation.
+	// The webhook records the profiles mutating a Pod, and the controller counts them in the last reconciliation.
+	// +optional
+	WebhookMutatedPods int32 `json:"webhookMutatedPods,omitempty"`
+
+	// SkippedPods is the number of the Pods skipped by the probability or held by the rollout
+	// in the last reconciliation.
+	// +optional
+	SkippedPods int32 `json:"skippedPods,omitempty"`
+
+	// RateLimitedPods is the number of the Pods not updated due to the rate limit in the last reconciliation.
+	// +optional
+	RateLimitedPods int32 `json:"rateLimitedPods,omitempty"`
+
+	// FailedPods is the number of the Pods failed to update in the last reconciliation.
+	// +optional
+	FailedPods int32 `json:"failedPods,omitempty"`
+
+	// Sampling is the outcome of the probability sampling in the last reconciliation.
+	// +optional
+	Sampling *ColocationProfileSamplingStatus `json:"sampling,omitempty"`
+
+	// Rollout is the progress of the staged rollout.
+	// +optional
+	Rollout *ColocationProfileRolloutStatus `json:"rollout,omitempty"`
+
+	// Conditions describe the errors in reconciling the profile.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ColocationProfileSamplingStatus is the outcome of the probability sampling.
+type ColocationProfileSamplingStatus struct {
+	// Percent is the effective probability in percentage.
+	Percent int32 `json:"percent"`
+	// SampledPods is the number of the Pods hit by the probability.
+	// +optional
+	SampledPods int32 `json:"sampledPods,omitempty"`
+	// UnsampledPods is the number of the Pods missed by the probability.
+	// +optional
+	UnsampledPods int32 `json:"unsampledPods,omitempty"`
+}
+
+// ColocationProfileRolloutStatus is the progress of the staged rollout.
+type ColocationProfileRolloutStatus struct {
+	// ProfileHash is the hash of the profile mutation the rollout works on.
+	// +optional
+	ProfileHash string `json:"profileHash,omitempty"`
+	// CurrentStep is the index of the current step.
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+	// Percent is the percentage of the matched Pods to mutate in the current step.
+	Percent int32 `json:"percent"`
+	// StepStartTime is the time the current step started.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+	// Paused indicates whether the rollout is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// HeldPods is the number of the Pods held by the rollout in the last reconciliation.
+	// +optional
+	HeldPods int32 `json:"heldPods,omitempty"`
 }
 
 // +genclient
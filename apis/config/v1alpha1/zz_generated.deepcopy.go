@@ -32,7 +32,7 @@ func (in *ClusterColocationProfile) DeepCopyInto(out *ClusterColocationProfile)
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfile.
@@ -85,6 +85,48 @@ func (in *ClusterColocationProfileList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterColocationProfileRollout) DeepCopyInto(out *ClusterColocationProfileRollout) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ClusterColocationProfileRolloutStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfileRollout.
+func (in *ClusterColocationProfileRollout) DeepCopy() *ClusterColocationProfileRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterColocationProfileRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterColocationProfileRolloutStep) DeepCopyInto(out *ClusterColocationProfileRolloutStep) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfileRolloutStep.
+func (in *ClusterColocationProfileRolloutStep) DeepCopy() *ClusterColocationProfileRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterColocationProfileRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterColocationProfileSpec) DeepCopyInto(out *ClusterColocationProfileSpec) {
 	*out = *in
@@ -144,6 +186,11 @@ func (in *ClusterColocationProfileSpec) DeepCopyInto(out *ClusterColocationProfi
 		}
 	}
 	in.Patch.DeepCopyInto(&out.Patch)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ClusterColocationProfileRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfileSpec.
@@ -159,6 +206,27 @@ func (in *ClusterColocationProfileSpec) DeepCopy() *ClusterColocationProfileSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterColocationProfileStatus) DeepCopyInto(out *ClusterColocationProfileStatus) {
 	*out = *in
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(ColocationProfileSamplingStatus)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ColocationProfileRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfileStatus.
@@ -170,3 +238,37 @@ func (in *ClusterColocationProfileStatus) DeepCopy() *ClusterColocationProfileSt
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColocationProfileRolloutStatus) DeepCopyInto(out *ColocationProfileRolloutStatus) {
+	*out = *in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColocationProfileRolloutStatus.
+func (in *ColocationProfileRolloutStatus) DeepCopy() *ColocationProfileRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ColocationProfileRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColocationProfileSamplingStatus) DeepCopyInto(out *ColocationProfileSamplingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColocationProfileSamplingStatus.
+func (in *ColocationProfileSamplingStatus) DeepCopy() *ColocationProfileSamplingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ColocationProfileSamplingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,13 @@ limitations under the License.
 package extension
 
 import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
 	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
 )
 
@@ -26,6 +33,13 @@ const (
 	// LabelControllerManaged indicates whether the colocation profile should be reconciled by the controller.
 	// If not specified, the controller only reconciles the profile if ReconcileByDefault is set to true.
 	LabelControllerManaged = "config.koordinator.sh/controller-managed"
+
+	// AnnotationColocationProfileRolloutBucket is the bucket in [0, 100) of the pod for the staged rollouts of the
+	// colocation profiles. The webhook assigns it on creation since the pod has no UID yet.
+	AnnotationColocationProfileRolloutBucket = "config.koordinator.sh/rollout-bucket"
+	// AnnotationMutatedByColocationProfiles lists the names of the colocation profiles which mutate the pod on
+	// creation, separated by commas.
+	AnnotationMutatedByColocationProfiles = "config.koordinator.sh/mutated-by-profiles"
 )
 
 func ShouldSkipUpdateResource(profile *configv1alpha1.ClusterColocationProfile) bool {
@@ -39,3 +53,76 @@ func ShouldSkipUpdateResource(profile *configv1alpha1.ClusterColocationProfile)
 func ShouldReconcileProfile(profile *configv1alpha1.ClusterColocationProfile) bool {
 	return profile != nil && profile.Labels != nil && profile.Labels[LabelControllerManaged] == "true"
 }
+
+// GetColocationProfileMutationHash returns the hash of the profile spec except the rollout.
+func GetColocationProfileMutationHash(profile *configv1alpha1.ClusterColocationProfile) string {
+	spec := profile.Spec.DeepCopy()
+	spec.Rollout = nil
+	data, _ := json.Marshal(spec)
+	h := fnv.New64a()
+	h.Write(data)
+	return strconv.FormatUint(h.Sum64(), 10)
+}
+
+// GetColocationProfileRolloutBucket returns the rollout bucket of the pod in [0, 100).
+// The bucket assigned on creation takes precedence, otherwise the pod is bucketed by the UID.
+func GetColocationProfileRolloutBucket(pod *corev1.Pod) int32 {
+	if s, ok := pod.Annotations[AnnotationColocationProfileRolloutBucket]; ok {
+		if bucket, err := strconv.ParseInt(s, 10, 32); err == nil && bucket >= 0 && bucket < 100 {
+			return int32(bucket)
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(pod.UID))
+	return int32(h.Sum32() % 100)
+}
+
+// IsPodInColocationProfileRollout checks if the pod is selected by the current step of the rollout.
+// The pods are bucketed stably, so the pods selected in the previous steps keep selected.
+func IsPodInColocationProfileRollout(rollout *configv1alpha1.ColocationProfileRolloutStatus, pod *corev1.Pod) bool {
+	if rollout == nil {
+		return true
+	}
+	if rollout.Paused {
+		return false
+	}
+	return GetColocationProfileRolloutBucket(pod) < rollout.Percent
+}
+
+// IsPodMutatedByColocationProfile checks if the profile mutates the pod on creation.
+func IsPodMutatedByColocationProfile(pod *corev1.Pod, profileName string) bool {
+	s, ok := pod.Annotations[AnnotationMutatedByColocationProfiles]
+	if !ok {
+		return false
+	}
+	for _, name := range strings.Split(s, ",") {
+		if name == profileName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetColocationProfilesMutatingPod returns the names of the profiles which mutate the pod on creation.
+func GetColocationProfilesMutatingPod(pod *corev1.Pod) []string {
+	s, ok := pod.Annotations[AnnotationMutatedByColocationProfiles]
+	if !ok || s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// SetPodMutatedByColocationProfile records the profile mutating the pod on creation.
+func SetPodMutatedByColocationProfile(pod *corev1.Pod, profileName string) {
+	if IsPodMutatedByColocationProfile(pod, profileName) {
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	if s := pod.Annotations[AnnotationMutatedByColocationProfiles]; s != "" {
+		pod.Annotations[AnnotationMutatedByColocationProfiles] = s + "," + profileName
+	} else {
+		pod.Annotations[AnnotationMutatedByColocationProfiles] = profileName
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+)
+
+func TestGetColocationProfileMutationHash(t *testing.T) {
+	profile := &configv1alpha1.ClusterColocationProfile{
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			QoSClass: string(QoSBE),
+		},
+	}
+	hash := GetColocationProfileMutationHash(profile)
+	profile.Spec.Rollout = &configv1alpha1.ClusterColocationProfileRollout{Paused: true}
+	assert.Equal(t, hash, GetColocationProfileMutationHash(profile))
+	profile.Spec.QoSClass = string(QoSLS)
+	assert.NotEqual(t, hash, GetColocationProfileMutationHash(profile))
+}
+
+func TestIsPodInColocationProfileRollout(t *testing.T) {
+	var pods []*corev1.Pod
+	for i := 0; i < 1000; i++ {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("pod-uid-%d", i))},
+		})
+	}
+	countInRollout := func(rollout *configv1alpha1.ColocationProfileRolloutStatus) map[types.UID]bool {
+		selected := map[types.UID]bool{}
+		for _, pod := range pods {
+			if IsPodInColocationProfileRollout(rollout, pod) {
+				selected[pod.UID] = true
+			}
+		}
+		return selected
+	}
+
+	assert.Equal(t, len(pods), len(countInRollout(nil)))
+	assert.Equal(t, 0, len(countInRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 0})))
+	assert.Equal(t, len(pods), len(countInRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 100})))
+	assert.Equal(t, 0, len(countInRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 100, Paused: true})))
+
+	selected30 := countInRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 30})
+	selected60 := countInRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 60})
+	assert.InDelta(t, 300, len(selected30), 60)
+	assert.InDelta(t, 600, len(selected60), 60)
+	for uid := range selected30 {
+		assert.True(t, selected60[uid], "pod %s selected at 30%% should be selected at 60%%", uid)
+	}
+}
+
+func TestGetColocationProfileRolloutBucket(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "pod-uid-1",
+			Annotations: map[string]string{
+				AnnotationColocationProfileRolloutBucket: "42",
+			},
+		},
+	}
+	assert.Equal(t, int32(42), GetColocationProfileRolloutBucket(pod))
+	assert.True(t, IsPodInColocationProfileRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 43}, pod))
+	assert.False(t, IsPodInColocationProfileRollout(&configv1alpha1.ColocationProfileRolloutStatus{Percent: 42}, pod))
+
+	// the invalid bucket falls back to the UID
+	pod.Annotations[AnnotationColocationProfileRolloutBucket] = "100"
+	got := GetColocationProfileRolloutBucket(pod)
+	delete(pod.Annotations, AnnotationColocationProfileRolloutBucket)
+	assert.Equal(t, GetColocationProfileRolloutBucket(pod), got)
+}
+
+func TestSetPodMutatedByColocationProfile(t *testing.T) {
+	pod := &corev1.Pod{}
+	assert.False(t, IsPodMutatedByColocationProfile(pod, "profile-a"))
+	SetPodMutatedByColocationProfile(pod, "profile-a")
+	SetPodMutatedByColocationProfile(pod, "profile-b")
+	SetPodMutatedByColocationProfile(pod, "profile-a")
+	assert.Equal(t, "profile-a,profile-b", pod.Annotations[AnnotationMutatedByColocationProfiles])
+	assert.True(t, IsPodMutatedByColocationProfile(pod, "profile-a"))
+	assert.True(t, IsPodMutatedByColocationProfile(pod, "profile-b"))
+	assert.False(t, IsPodMutatedByColocationProfile(pod, "profile"))
+}
@@ -35,6 +35,18 @@ const (
 	// If a batch of Pods to be evicted have the same priority, they will be sorted by cost,
 	// and the Pod with the smallest cost will be evicted.
 	AnnotationEvictionCost = SchedulingDomainPrefix + "/eviction-cost"
+
+	// AnnotationMigrationCost indicates the explicit migration cost of the Pod in the range [0, 100].
+	// The descheduler estimates the migration cost from the startup time, restart history, local storage and so on.
+	// Users can opt in to set the cost explicitly if the estimation does not fit the workload,
+	// e.g. a Pod holding a large cache that takes a long time to warm up.
+	// Pods with lower migration cost are preferred to be migrated before pods with higher migration cost.
+	AnnotationMigrationCost = SchedulingDomainPrefix + "/migration-cost"
+)
+
+const (
+	MinMigrationCost int64 = 0
+	MaxMigrationCost int64 = 100
 )
 
 const (
@@ -83,6 +95,26 @@ func GetEvictionCost(annotations map[string]string) (int32, error) {
 	return 0, nil
 }
 
+// GetMigrationCost returns the explicit migration cost of the Pod, the second return value indicates
+// whether the cost is set.
+func GetMigrationCost(annotations map[string]string) (int64, bool, error) {
+	value, exist := annotations[AnnotationMigrationCost]
+	if !exist {
+		return 0, false, nil
+	}
+	if !validFirstDigit(value) {
+		return 0, false, fmt.Errorf("invalid value %q", value)
+	}
+	cost, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if cost < MinMigrationCost || cost > MaxMigrationCost {
+		return 0, false, fmt.Errorf("migration cost %d is out of range [%d, %d]", cost, MinMigrationCost, MaxMigrationCost)
+	}
+	return cost, true, nil
+}
+
 func validFirstDigit(str string) bool {
 	if len(str) == 0 {
 		return false
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/apiserver/pkg/quota/v1"
 
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
@@ -37,6 +38,7 @@ const (
 	LabelAllowLentResource               = QuotaKoordinatorPrefix + "/allow-lent-resource"
 	LabelQuotaName                       = QuotaKoordinatorPrefix + "/name"
 	LabelQuotaProfile                    = QuotaKoordinatorPrefix + "/profile"
+	LabelQuotaFederation                 = QuotaKoordinatorPrefix + "/federation"
 	LabelQuotaIsRoot                     = QuotaKoordinatorPrefix + "/is-root"
 	LabelQuotaTreeID                     = QuotaKoordinatorPrefix + "/tree-id"
 	LabelQuotaIgnoreDefaultTree          = QuotaKoordinatorPrefix + "/ignore-default-tree"
@@ -56,6 +58,7 @@ const (
 	AnnotationNonPreemptibleUsed         = QuotaKoordinatorPrefix + "/non-preemptible-used"
 	AnnotationAdmission                  = QuotaKoordinatorPrefix + "/admission"
 	AnnotationMaxStrictCheckResourceKeys = QuotaKoordinatorPrefix + "/max-strict-check-resource-keys"
+	AnnotationNodePoolLabelKey           = QuotaKoordinatorPrefix + "/node-pool-label-key"
 )
 
 func GetParentQuotaName(quota *v1alpha1.ElasticQuota) string {
@@ -210,16 +213,96 @@ func GetUnschedulableResource(quota *v1alpha1.ElasticQuota) (corev1.ResourceList
 	return unschedulable, nil
 }
 
+// GetAdmission returns the admission limit of the quota.
 func GetAdmission(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, error) {
-	admission := corev1.ResourceList{}
-	if quota.Annotations[AnnotationAdmission] != "" {
-		if err := json.Unmarshal([]byte(quota.Annotations[AnnotationAdmission]), &admission); err != nil {
-			return admission, err
+	admission, err := GetElasticQuotaAdmission(quota)
+	if err != nil {
+		return corev1.ResourceList{}, err
+	}
+	if admission.Limit == nil {
+		return corev1.ResourceList{}, nil
+	}
+	return admission.Limit, nil
+}
+
+// ElasticQuotaAdmission is the value of AnnotationAdmission. A plain ResourceList is accepted as the Limit.
+type ElasticQuotaAdmission struct {
+	// Limit limits the requests admitted into the quota.
+	Limit corev1.ResourceList `json:"limit,omitempty"`
+	// Workloads are the Jobs and PodGroups admitted into the quota as a whole, keyed by kind/namespace/name.
+	Workloads map[string]AdmittedWorkload `json:"workloads,omitempty"`
+}
+
+// AdmittedWorkload is a Job or a PodGroup admitted into the ElasticQuota as a whole.
+type AdmittedWorkload struct {
+	UID types.UID `json:"uid"`
+	// Requests is the sum of the requests admitted for the members.
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Consumed is the part of the Requests taken by the members created, the rest is reserved in the quota.
+	Consumed corev1.ResourceList `json:"consumed,omitempty"`
+}
+
+// Reserved returns the admitted requests not taken by the members yet.
+func (w *AdmittedWorkload) Reserved() corev1.ResourceList {
+	reserved := corev1.ResourceList{}
+	for name, quantity := range w.Requests {
+		quantity = quantity.DeepCopy()
+		quantity.Sub(w.Consumed[name])
+		if quantity.Sign() > 0 {
+			reserved[name] = quantity
 		}
 	}
+	return reserved
+}
+
+func GetElasticQuotaAdmission(quota *v1alpha1.ElasticQuota) (*ElasticQuotaAdmission, error) {
+	admission := &ElasticQuotaAdmission{}
+	raw := quota.Annotations[AnnotationAdmission]
+	if raw == "" {
+		return admission, nil
+	}
+	limit := corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(raw), &limit); err == nil {
+		admission.Limit = limit
+		return admission, nil
+	}
+	if err := json.Unmarshal([]byte(raw), admission); err != nil {
+		return &ElasticQuotaAdmission{}, err
+	}
 	return admission, nil
 }
 
+// SetElasticQuotaAdmission sets the admission into the quota, and keeps the plain ResourceList of the Limit
+// if no workload is admitted.
+func SetElasticQuotaAdmission(quota *v1alpha1.ElasticQuota, admission *ElasticQuotaAdmission) error {
+	var value interface{} = admission
+	if len(admission.Workloads) == 0 {
+		if len(admission.Limit) == 0 {
+			delete(quota.Annotations, AnnotationAdmission)
+			return nil
+		}
+		value = admission.Limit
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if quota.Annotations == nil {
+		quota.Annotations = map[string]string{}
+	}
+	quota.Annotations[AnnotationAdmission] = string(data)
+	return nil
+}
+
+// GetAdmittedWorkloads returns the workloads admitted into the quota, keyed by kind/namespace/name.
+func GetAdmittedWorkloads(quota *v1alpha1.ElasticQuota) (map[string]AdmittedWorkload, error) {
+	admission, err := GetElasticQuotaAdmission(quota)
+	if err != nil || admission.Workloads == nil {
+		return map[string]AdmittedWorkload{}, err
+	}
+	return admission.Workloads, nil
+}
+
 func GetMaxStrictCheckResourceKeys(quota *v1alpha1.ElasticQuota) ([]corev1.ResourceName, error) {
 	if quota.Annotations[AnnotationMaxStrictCheckResourceKeys] == "" {
 		return nil, nil
@@ -18,6 +18,7 @@ package extension
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strconv"
 
@@ -38,6 +39,10 @@ const (
 	AnnotationGangNetworkTopologySpec = AnnotationGangPrefix + "/network-topology-spec"
 
 	AnnotationPodNetworkTopologySelector = SchedulingDomainPrefix + "/network-topology-selector"
+
+	// AnnotationPodNetworkTopologySpreadConstraints defines the hierarchical spread and pack constraints of the pod
+	// over the layers of the ClusterNetworkTopology.
+	AnnotationPodNetworkTopologySpreadConstraints = SchedulingDomainPrefix + "/network-topology-spread-constraints"
 )
 
 type NetworkTopologySpec struct {
@@ -105,3 +110,61 @@ func SortPodsByIndex(pods []*corev1.Pod) {
 func GetPodNetworkTopologySelector(obj metav1.Object) string {
 	return obj.GetAnnotations()[AnnotationPodNetworkTopologySelector]
 }
+
+type NetworkTopologySpreadPolicy string
+
+const (
+	// NetworkTopologySpreadPolicySpread spreads the matching pods evenly across the domains of the layer.
+	NetworkTopologySpreadPolicySpread NetworkTopologySpreadPolicy = "Spread"
+	// NetworkTopologySpreadPolicyPack packs the matching pods into as few domains of the layer as possible.
+	NetworkTopologySpreadPolicyPack NetworkTopologySpreadPolicy = "Pack"
+)
+
+// NetworkTopologySpreadConstraint describes how the matching pods are placed over a layer of the ClusterNetworkTopology.
+// A Spread constraint is evaluated among the sibling domains under the domain of the nearest ancestor Pack layer,
+// e.g. the pods can be spread across blocks while being packed within a spine.
+type NetworkTopologySpreadConstraint struct {
+	// Layer is the name of the topology layer, e.g. BlockLayer.
+	Layer schedulingv1alpha1.TopologyLayer `json:"layer"`
+	// Policy is Spread or Pack.
+	Policy NetworkTopologySpreadPolicy `json:"policy"`
+	// MaxSkew is the maximum permitted difference between the number of matching pods in a domain and the minimum
+	// number among the sibling domains. It only works for the Spread policy and defaults to 1.
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable indicates how to deal with a pod if it doesn't satisfy the constraint.
+	// DoNotSchedule filters the nodes, and ScheduleAnyway (default) only scores the nodes.
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+	// LabelSelector selects the pods in the same namespace to be counted.
+	// If not specified, the pods with the same labels as the incoming pod are counted.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+func GetNetworkTopologySpreadConstraints(obj metav1.Object) ([]NetworkTopologySpreadConstraint, error) {
+	data := obj.GetAnnotations()[AnnotationPodNetworkTopologySpreadConstraints]
+	if data == "" {
+		return nil, nil
+	}
+	var constraints []NetworkTopologySpreadConstraint
+	if err := json.Unmarshal([]byte(data), &constraints); err != nil {
+		return nil, err
+	}
+	for i := range constraints {
+		constraint := &constraints[i]
+		if constraint.Layer == "" {
+			return nil, fmt.Errorf("missing layer of network topology spread constraint %d", i)
+		}
+		if constraint.Policy != NetworkTopologySpreadPolicySpread && constraint.Policy != NetworkTopologySpreadPolicyPack {
+			return nil, fmt.Errorf("unsupported network topology spread policy %q", constraint.Policy)
+		}
+		if constraint.MaxSkew < 0 {
+			return nil, fmt.Errorf("invalid maxSkew %d of network topology spread constraint %d", constraint.MaxSkew, i)
+		}
+		if constraint.MaxSkew == 0 {
+			constraint.MaxSkew = 1
+		}
+		if constraint.WhenUnsatisfiable == "" {
+			constraint.WhenUnsatisfiable = corev1.ScheduleAnyway
+		}
+	}
+	return constraints, nil
+}
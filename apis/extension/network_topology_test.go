@@ -108,6 +108,66 @@ func TestGetNetworkTopologySpec(t *testing.T) {
 	}
 }
 
+func TestGetNetworkTopologySpreadConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       []NetworkTopologySpreadConstraint
+		wantErr    bool
+	}{
+		{
+			name: "annotation not present",
+		},
+		{
+			name:       "invalid json",
+			annotation: "invalid",
+			wantErr:    true,
+		},
+		{
+			name:       "missing layer",
+			annotation: `[{"policy":"Spread"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported policy",
+			annotation: `[{"layer":"BlockLayer","policy":"Balance"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "fill defaults",
+			annotation: `[{"layer":"SpineLayer","policy":"Pack","whenUnsatisfiable":"DoNotSchedule"},{"layer":"BlockLayer","policy":"Spread","labelSelector":{"matchLabels":{"app":"test"}}}]`,
+			want: []NetworkTopologySpreadConstraint{
+				{
+					Layer:             "SpineLayer",
+					Policy:            NetworkTopologySpreadPolicyPack,
+					MaxSkew:           1,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				},
+				{
+					Layer:             "BlockLayer",
+					Policy:            NetworkTopologySpreadPolicySpread,
+					MaxSkew:           1,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{}
+			if tt.annotation != "" {
+				pod.Annotations = map[string]string{AnnotationPodNetworkTopologySpreadConstraints: tt.annotation}
+			}
+			got, err := GetNetworkTopologySpreadConstraints(pod)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGetPodIndex(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
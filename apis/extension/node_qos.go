@@ -19,6 +19,7 @@ package extension
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -29,6 +30,13 @@ const (
 	AnnotationNodeBandwidth = NodeDomainPrefix + "/network-bandwidth"
 )
 
+const (
+	// ResourceNetworkBandwidth is the extended resource of the node network bandwidth. The node allocatable is
+	// calculated by the slo-controller from the total bandwidth of the node, and the pod request is guaranteed as the
+	// egress rate by the koordlet. Unit: bps.
+	ResourceNetworkBandwidth corev1.ResourceName = DomainPrefix + "net-bandwidth"
+)
+
 func GetNodeTotalBandwidth(annotations map[string]string) (*resource.Quantity, error) {
 	var (
 		val string
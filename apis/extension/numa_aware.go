@@ -105,6 +105,8 @@ const (
 	CPUBindPolicySpreadByPCPUs CPUBindPolicy = "SpreadByPCPUs"
 	// CPUBindPolicyConstrainedBurst constrains the CPU Shared Pool range of the Burstable Pod
 	CPUBindPolicyConstrainedBurst CPUBindPolicy = "ConstrainedBurst"
+	// CPUBindPolicyLLCAligned favor cpuset allocation that pack full physical cores in few last-level cache domains
+	CPUBindPolicyLLCAligned CPUBindPolicy = "LLCAligned"
 )
 
 type CPUExclusivePolicy string
@@ -190,6 +192,8 @@ type CPUInfo struct {
 	Core   int32 `json:"core"`
 	Socket int32 `json:"socket"`
 	Node   int32 `json:"node"`
+	// L3 is the ID of the L3 cache shared by the CPU, e.g. the CCX on AMD EPYC processors.
+	L3 int32 `json:"l3,omitempty"`
 }
 
 type PodCPUAlloc struct {
@@ -53,6 +53,14 @@ const (
 
 	// AnnotationReservationRestrictedOptions represent the Reservation Restricted options
 	AnnotationReservationRestrictedOptions = SchedulingDomainPrefix + "/reservation-restricted-options"
+
+	// AnnotationReservationIdleReclaimPolicy overrides the policy to reclaim the Reservation when it keeps idle,
+	// e.g. "Expire", "Shrink", "Migrate", or "None" to disable the idle reclaim for the Reservation.
+	AnnotationReservationIdleReclaimPolicy = SchedulingDomainPrefix + "/reservation-idle-reclaim-policy"
+
+	// AnnotationReservationIdleReplacement records the Reservation created to replace the idle Reservation
+	// when it is reclaimed by migrating.
+	AnnotationReservationIdleReplacement = SchedulingDomainPrefix + "/reservation-idle-replacement"
 )
 
 type ReservationAllocated struct {
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ElasticQuotaFederationSpec struct {
+	// QuotaName defines the name of the ElasticQuota managed in every member cluster.
+	// The quota is in the same namespace as the federation.
+	// +required
+	QuotaName string `json:"quotaName"`
+	// QuotaLabels defines the labels of the member quotas, e.g. the parent or the tree of the quota.
+	QuotaLabels map[string]string `json:"quotaLabels,omitempty"`
+	// Min is the global min which is split into the min of the member quotas.
+	Min corev1.ResourceList `json:"min,omitempty"`
+	// Max is the global max which is split into the max of the member quotas.
+	// The resources only in the min are capped by the min.
+	Max corev1.ResourceList `json:"max,omitempty"`
+	// Clusters defines the member clusters.
+	// +required
+	Clusters []ElasticQuotaFederationCluster `json:"clusters"`
+}
+
+// ElasticQuotaFederationCluster describes a member cluster of the federation.
+type ElasticQuotaFederationCluster struct {
+	// Name is the name of the member cluster, which is also the name of the Secret holding the access of the cluster.
+	// +required
+	Name string `json:"name"`
+	// Weight is the weight of the cluster to share the resource exceeding the demands of all the clusters.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+const (
+	// ElasticQuotaFederationConditionRebalanced indicates whether the global quota is split into all member quotas.
+	ElasticQuotaFederationConditionRebalanced = "Rebalanced"
+)
+
+type ElasticQuotaFederationStatus struct {
+	// ObservedGeneration is the generation of the federation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastRebalanceTime is the last time the global quota was split.
+	// +optional
+	LastRebalanceTime *metav1.Time `json:"lastRebalanceTime,omitempty"`
+	// Clusters is the breakdown of the global quota by the member clusters.
+	// +optional
+	Clusters []ElasticQuotaFederationClusterStatus `json:"clusters,omitempty"`
+	// Conditions describe the errors in reconciling the federation.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ElasticQuotaFederationClusterStatus describes the member quota of a cluster.
+type ElasticQuotaFederationClusterStatus struct {
+	// Name is the name of the member cluster.
+	Name string `json:"name"`
+	// Min is the min assigned to the member quota.
+	// +optional
+	Min corev1.ResourceList `json:"min,omitempty"`
+	// Max is the max assigned to the member quota.
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+	// Used is the used resource of the member quota reported by the scheduler of the cluster.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Request is the requested resource of the member quota reported by the scheduler of the cluster.
+	// +optional
+	Request corev1.ResourceList `json:"request,omitempty"`
+	// Message describes why the member quota is not rebalanced, e.g. the cluster is unreachable.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ElasticQuotaFederation is the Schema for the ElasticQuotaFederation API
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=eqf
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+type ElasticQuotaFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticQuotaFederationSpec   `json:"spec,omitempty"`
+	Status ElasticQuotaFederationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ElasticQuotaFederationList contains a list of ElasticQuotaFederation
+type ElasticQuotaFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticQuotaFederation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticQuotaFederation{}, &ElasticQuotaFederationList{})
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,7 +39,82 @@ type ElasticQuotaProfileSpec struct {
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
 }
 
+const (
+	// ElasticQuotaProfileConditionNodeSelectorValid indicates whether the node selector of the profile is valid.
+	ElasticQuotaProfileConditionNodeSelectorValid = "NodeSelectorValid"
+	// ElasticQuotaProfileConditionQuotaSynced indicates whether the root quota is synced with the profile.
+	ElasticQuotaProfileConditionQuotaSynced = "QuotaSynced"
+)
+
 type ElasticQuotaProfileStatus struct {
+	// ObservedGeneration is the generation of the profile observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// QuotaName is the name of the root quota generated by the profile.
+	// +optional
+	QuotaName string `json:"quotaName,omitempty"`
+	// QuotaTreeID is the id of the quota tree rooted at the generated quota.
+	// +optional
+	QuotaTreeID string `json:"quotaTreeID,omitempty"`
+	// MatchedNodes is the number of the nodes matched by the node selector.
+	// +optional
+	MatchedNodes int32 `json:"matchedNodes,omitempty"`
+	// UnschedulableNodes is the number of the matched nodes which are unschedulable or not ready.
+	// +optional
+	UnschedulableNodes int32 `json:"unschedulableNodes,omitempty"`
+	// ResourceRatio is the effective resource ratio applied on the total resource.
+	// +optional
+	ResourceRatio string `json:"resourceRatio,omitempty"`
+	// RawTotal is the total allocatable of the matched nodes.
+	// +optional
+	RawTotal corev1.ResourceList `json:"rawTotal,omitempty"`
+	// Total is the total resource adjusted by the resource ratio.
+	// +optional
+	Total corev1.ResourceList `json:"total,omitempty"`
+	// Unschedulable is the resource of the unschedulable nodes adjusted by the resource ratio,
+	// which is excluded from the quota by the scheduler.
+	// +optional
+	Unschedulable corev1.ResourceList `json:"unschedulable,omitempty"`
+	// Resources is the breakdown of the resources set as the min of the root quota.
+	// +optional
+	Resources []ElasticQuotaProfileResourceStatus `json:"resources,omitempty"`
+	// NodePools is the breakdown of the matched nodes by the node pools.
+	// The nodes are grouped by the label specified in the annotation quota.scheduling.koordinator.sh/node-pool-label-key.
+	// +optional
+	NodePools []ElasticQuotaProfileNodePoolStatus `json:"nodePools,omitempty"`
+	// Conditions describe the errors in reconciling the profile.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ElasticQuotaProfileResourceStatus describes how the resource of the root quota is computed.
+type ElasticQuotaProfileResourceStatus struct {
+	// Name is the resource name.
+	Name corev1.ResourceName `json:"name"`
+	// Raw is the total allocatable of the matched nodes.
+	Raw resource.Quantity `json:"raw"`
+	// Total is the total resource adjusted by the resource ratio, which is set as the min of the root quota.
+	Total resource.Quantity `json:"total"`
+	// Unschedulable is the resource of the unschedulable nodes adjusted by the resource ratio.
+	// +optional
+	Unschedulable resource.Quantity `json:"unschedulable,omitempty"`
+}
+
+// ElasticQuotaProfileNodePoolStatus describes the matched nodes in a node pool.
+type ElasticQuotaProfileNodePoolStatus struct {
+	// Name is the value of the node pool label, empty for the nodes without the label.
+	Name string `json:"name"`
+	// Nodes is the number of the matched nodes in the node pool.
+	// +optional
+	Nodes int32 `json:"nodes,omitempty"`
+	// UnschedulableNodes is the number of the unschedulable or not ready nodes in the node pool.
+	// +optional
+	UnschedulableNodes int32 `json:"unschedulableNodes,omitempty"`
+	// RawTotal is the total allocatable of the nodes in the node pool.
+	// +optional
+	RawTotal corev1.ResourceList `json:"rawTotal,omitempty"`
 }
 
 //  ElasticQuotaProfile is the Schema for the ElasticQuotaProfile API
@@ -46,6 +123,7 @@ type ElasticQuotaProfileStatus struct {
 // +genclient
 // +kubebuilder:resource:shortName=eqp
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 type ElasticQuotaProfile struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NodeDrainSpec struct {
+	// NodeName is the name of the node to be drained.
+	// +required
+	NodeName string `json:"nodeName"`
+
+	// Paused indicates whether the NodeDrain should stop migrating pods.
+	// The PodMigrationJobs not started yet are paused as well.
+	// Default is false
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Abort indicates the user wants to abort the NodeDrain.
+	// The PodMigrationJobs not started yet are aborted and the node is uncordoned if it was cordoned by the NodeDrain.
+	// Default is false
+	// +optional
+	Abort bool `json:"abort,omitempty"`
+
+	// MaxConcurrentMigrations is the maximum number of pods migrating at the same time in the NodeDrain.
+	// The limits of the MigrationController, e.g. MaxMigratingPerWorkload, are still respected.
+	// Default is unlimited.
+	// +optional
+	MaxConcurrentMigrations *int32 `json:"maxConcurrentMigrations,omitempty"`
+
+	// JobTTL controls the timeout duration of the PodMigrationJobs created by the NodeDrain.
+	// Default is the DefaultJobTTL of the MigrationController.
+	// +optional
+	JobTTL *metav1.Duration `json:"jobTTL,omitempty"`
+
+	// DeleteOptions defines the deleting options for the migrated Pods.
+	// +optional
+	DeleteOptions *metav1.DeleteOptions `json:"deleteOptions,omitempty"`
+}
+
+type NodeDrainStatus struct {
+	// Phase represents the phase of the NodeDrain.
+	// e.g. Pending/Running/Paused/Succeeded/Failed/Aborted
+	Phase NodeDrainPhase `json:"phase,omitempty"`
+	// Reason represents a brief CamelCase message indicating details about why the NodeDrain is in this state.
+	Reason string `json:"reason,omitempty"`
+	// Message represents a human-readable message indicating details about why the NodeDrain is in this state.
+	Message string `json:"message,omitempty"`
+	// Cordoned indicates the node is cordoned by the NodeDrain.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// TotalPods is the number of pods to be migrated.
+	TotalPods int32 `json:"totalPods,omitempty"`
+	// MigratingPods is the number of pods being migrated.
+	MigratingPods int32 `json:"migratingPods,omitempty"`
+	// SucceededPods is the number of pods migrated successfully.
+	SucceededPods int32 `json:"succeededPods,omitempty"`
+	// FailedPods is the number of pods failed to migrate.
+	FailedPods int32 `json:"failedPods,omitempty"`
+	// Pods records the migration progress of every pod on the node.
+	Pods []NodeDrainPodStatus `json:"pods,omitempty"`
+}
+
+type NodeDrainPodStatus struct {
+	// PodRef represents the Pod to be migrated.
+	PodRef corev1.ObjectReference `json:"podRef"`
+	// Phase represents the migration phase of the Pod.
+	Phase NodeDrainPodPhase `json:"phase,omitempty"`
+	// JobName is the name of the PodMigrationJob migrating the Pod.
+	JobName string `json:"jobName,omitempty"`
+	// NodeName represents the node's name of the migrated Pod.
+	NodeName string `json:"nodeName,omitempty"`
+	// NewPodRef represents the newly created Pod after being migrated.
+	NewPodRef *corev1.ObjectReference `json:"newPodRef,omitempty"`
+	// Message represents a human-readable message indicating details about the migration.
+	Message string `json:"message,omitempty"`
+}
+
+type NodeDrainPhase string
+
+const (
+	// NodeDrainPending represents the initial status
+	NodeDrainPending NodeDrainPhase = "Pending"
+	// NodeDrainRunning represents the node is being drained
+	NodeDrainRunning NodeDrainPhase = "Running"
+	// NodeDrainPaused represents the NodeDrain is paused by the user
+	NodeDrainPaused NodeDrainPhase = "Paused"
+	// NodeDrainSucceeded represents all pods on the node are migrated successfully
+	NodeDrainSucceeded NodeDrainPhase = "Succeeded"
+	// NodeDrainFailed represents some pods on the node failed to migrate
+	NodeDrainFailed NodeDrainPhase = "Failed"
+	// NodeDrainAborted represents the user aborted the NodeDrain
+	NodeDrainAborted NodeDrainPhase = "Aborted"
+)
+
+type NodeDrainPodPhase string
+
+const (
+	// NodeDrainPodWaiting represents the Pod waits for the PodMigrationJob to be created
+	NodeDrainPodWaiting NodeDrainPodPhase = "Waiting"
+	// NodeDrainPodMigrating represents the Pod is being migrated
+	NodeDrainPodMigrating NodeDrainPodPhase = "Migrating"
+	// NodeDrainPodSucceeded represents the Pod is migrated and the new Pod is ready
+	NodeDrainPodSucceeded NodeDrainPodPhase = "Succeeded"
+	// NodeDrainPodFailed represents the Pod failed to migrate
+	NodeDrainPodFailed NodeDrainPodPhase = "Failed"
+	// NodeDrainPodSkipped represents the Pod is not evictable and left on the node
+	NodeDrainPodSkipped NodeDrainPodPhase = "Skipped"
+)
+
+// These are valid reasons of NodeDrain.
+const (
+	NodeDrainReasonMissingNode   = "MissingNode"
+	NodeDrainReasonAborted       = "Aborted"
+	NodeDrainReasonPaused        = "Paused"
+	NodeDrainReasonMigrating     = "Migrating"
+	NodeDrainReasonFailedMigrate = "FailedMigrate"
+	NodeDrainReasonDrained       = "Drained"
+)
+
+// NodeDrain is the Schema for the NodeDrain API.
+// It cordons the node and migrates the pods on it with ReservationFirst PodMigrationJobs.
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster,shortName=nd
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of NodeDrain"
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.totalPods"
+// +kubebuilder:printcolumn:name="Migrating",type="integer",JSONPath=".status.migratingPods"
+// +kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.succeededPods"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedPods"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+type NodeDrain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeDrainSpec   `json:"spec,omitempty"`
+	Status NodeDrainStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeDrainList contains a list of NodeDrain
+type NodeDrainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeDrain `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeDrain{}, &NodeDrainList{})
+}
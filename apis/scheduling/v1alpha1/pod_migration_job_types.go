@@ -165,6 +165,8 @@ const (
 	PodMigrationJobConditionReservationPodBoundReservation PodMigrationJobConditionType = "PodBoundReservation"
 	PodMigrationJobConditionBoundPodReady                  PodMigrationJobConditionType = "BoundPodReady"
 	PodMigrationJobConditionReservationBound               PodMigrationJobConditionType = "ReservationBound"
+	// PodMigrationJobConditionRollback indicates the stuck PodMigrationJob is aborted and its Reservation is released.
+	PodMigrationJobConditionRollback PodMigrationJobConditionType = "Rollback"
 )
 
 // These are valid reasons of PodMigrationJob.
@@ -183,6 +185,10 @@ const (
 	PodMigrationJobReasonEvictComplete             = "EvictComplete"
 	PodMigrationJobReasonWaitForPodBindReservation = "WaitForPodBindReservation"
 	PodMigrationJobReasonWaitForBoundPodReady      = "WaitForBoundPodReady"
+	// PodMigrationJobReasonReservationUnschedulableTimeout means the Reservation stays unschedulable too long.
+	PodMigrationJobReasonReservationUnschedulableTimeout = "ReservationUnschedulableTimeout"
+	// PodMigrationJobReasonBoundPodCrashLooping means the replacement Pod bound to the Reservation keeps crashing.
+	PodMigrationJobReasonBoundPodCrashLooping = "BoundPodCrashLooping"
 )
 
 type PodMigrationJobConditionStatus string
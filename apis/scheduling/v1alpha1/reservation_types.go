@@ -174,6 +174,11 @@ type ReservationOwner struct {
 	Controller *ReservationControllerReference `json:"controller,omitempty" protobuf:"bytes,2,opt,name=controller"`
 	// +optional
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty" protobuf:"bytes,3,opt,name=labelSelector"`
+	// Gang matches the member pods of the gang, which is declared by the coscheduling annotations or the PodGroup label.
+	// It is typically used with the gang reservations, which declare a gang by the coscheduling annotations so that
+	// they are reserved all or nothing, and placed together by the network topology spec of the gang if declared.
+	// +optional
+	Gang *ReservationGangReference `json:"gang,omitempty" protobuf:"bytes,4,opt,name=gang"`
 }
 
 type ReservationControllerReference struct {
@@ -182,6 +187,15 @@ type ReservationControllerReference struct {
 	Namespace             string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
 }
 
+// ReservationGangReference references the gang whose member pods can allocate the reserved resources.
+type ReservationGangReference struct {
+	// Name is the name of the gang.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the gang. It defaults to the namespace of the reservation template if empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+}
+
 type ReservationPhase string
 
 const (
@@ -222,6 +236,7 @@ const (
 	ReasonReservationAvailable = "Available"
 	ReasonReservationSucceeded = "Succeeded"
 	ReasonReservationExpired   = "Expired"
+	ReasonReservationPreempted = "Preempted"
 )
 
 type ReservationCondition struct {
@@ -412,6 +412,138 @@ func (in *NetworkTopologySpec) DeepCopy() *NetworkTopologySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrain) DeepCopyInto(out *NodeDrain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrain.
+func (in *NodeDrain) DeepCopy() *NodeDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeDrain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainList) DeepCopyInto(out *NodeDrainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeDrain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainList.
+func (in *NodeDrainList) DeepCopy() *NodeDrainList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeDrainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainPodStatus) DeepCopyInto(out *NodeDrainPodStatus) {
+	*out = *in
+	out.PodRef = in.PodRef
+	if in.NewPodRef != nil {
+		in, out := &in.NewPodRef, &out.NewPodRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainPodStatus.
+func (in *NodeDrainPodStatus) DeepCopy() *NodeDrainPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainSpec) DeepCopyInto(out *NodeDrainSpec) {
+	*out = *in
+	if in.MaxConcurrentMigrations != nil {
+		in, out := &in.MaxConcurrentMigrations, &out.MaxConcurrentMigrations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JobTTL != nil {
+		in, out := &in.JobTTL, &out.JobTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DeleteOptions != nil {
+		in, out := &in.DeleteOptions, &out.DeleteOptions
+		*out = new(metav1.DeleteOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainSpec.
+func (in *NodeDrainSpec) DeepCopy() *NodeDrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainStatus) DeepCopyInto(out *NodeDrainStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]NodeDrainPodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainStatus.
+func (in *NodeDrainStatus) DeepCopy() *NodeDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFailedDetail) DeepCopyInto(out *NodeFailedDetail) {
 	*out = *in
@@ -835,6 +967,21 @@ func (in *ReservationControllerReference) DeepCopy() *ReservationControllerRefer
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationGangReference) DeepCopyInto(out *ReservationGangReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationGangReference.
+func (in *ReservationGangReference) DeepCopy() *ReservationGangReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationGangReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReservationList) DeepCopyInto(out *ReservationList) {
 	*out = *in
@@ -885,6 +1032,11 @@ func (in *ReservationOwner) DeepCopyInto(out *ReservationOwner) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gang != nil {
+		in, out := &in.Gang, &out.Gang
+		*out = new(ReservationGangReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationOwner.
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -48,6 +49,27 @@ type NodeMetricInfo struct {
 	AggregatedSystemUsages []AggregatedUsage `json:"aggregatedSystemUsages,omitempty"`
 	// NUMAUsages contains the per-NUMA-node resource usage
 	NUMAUsages []NUMAUsage `json:"numaUsages,omitempty"`
+	// Interference is the contention indicators of the pods on the node
+	Interference *InterferenceMetric `json:"interference,omitempty"`
+}
+
+// InterferenceMetric describes how much the workloads are contended, which can differ from the resource usage
+// depending on the co-runners.
+type InterferenceMetric struct {
+	// PSI is the pressure stall information averaged over the recent 10 seconds
+	PSI *PSIMetric `json:"psi,omitempty"`
+	// CPI is the average cycles per instruction of the containers
+	CPI *resource.Quantity `json:"cpi,omitempty"`
+}
+
+// PSIMetric is the percentage of the time some (or all) tasks are stalled on each resource.
+type PSIMetric struct {
+	CPUSome    *resource.Quantity `json:"cpuSome,omitempty"`
+	CPUFull    *resource.Quantity `json:"cpuFull,omitempty"`
+	MemorySome *resource.Quantity `json:"memorySome,omitempty"`
+	MemoryFull *resource.Quantity `json:"memoryFull,omitempty"`
+	IOSome     *resource.Quantity `json:"ioSome,omitempty"`
+	IOFull     *resource.Quantity `json:"ioFull,omitempty"`
 }
 
 // NUMAUsage defines the observed usage of a NUMA node
@@ -79,6 +101,8 @@ type PodMetricInfo struct {
 	QoS apiext.QoSClass `json:"qos,omitempty"`
 	// Third party extensions for PodMetric
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
+	// Interference is the contention indicators of the pod
+	Interference *InterferenceMetric `json:"interference,omitempty"`
 }
 
 type HostApplicationMetricInfo struct {
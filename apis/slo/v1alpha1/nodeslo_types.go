@@ -519,8 +519,48 @@ type NodeSLOSpec struct {
 
 // NodeSLOStatus defines the observed state of NodeSLO
 type NodeSLOStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// ObservedGeneration is the generation of the NodeSLO spec received by the koordlet.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the states of the strategies the koordlet applies on the node.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Conditions []NodeSLOStrategyCondition `json:"conditions,omitempty"`
+}
+
+type NodeSLOStrategyState string
+
+const (
+	// NodeSLOStrategyApplied indicates the strategy is applied on the node.
+	NodeSLOStrategyApplied NodeSLOStrategyState = "Applied"
+	// NodeSLOStrategyUnsupported indicates the strategy cannot work on the node, e.g. the kernel lacks the feature.
+	NodeSLOStrategyUnsupported NodeSLOStrategyState = "Unsupported"
+	// NodeSLOStrategyFailed indicates the strategy failed to apply on the node.
+	NodeSLOStrategyFailed NodeSLOStrategyState = "Failed"
+)
+
+// NodeSLOStrategyCondition describes the state of a strategy applied by the koordlet.
+type NodeSLOStrategyCondition struct {
+	// Name is the name of the strategy, e.g. GroupIdentity, ResctrlReconcile.
+	Name string `json:"name"`
+	// State is the state of the strategy, which is one of Applied, Unsupported and Failed.
+	State NodeSLOStrategyState `json:"state"`
+	// ObservedGeneration is the generation of the NodeSLO spec the state is based on.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Reason is a brief CamelCase reason for the state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details of the state.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastAppliedTime is the last time the observed generation is applied successfully.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	// LastTransitionTime is the last time the state changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // +genclient
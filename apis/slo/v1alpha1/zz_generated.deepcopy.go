@@ -403,6 +403,31 @@ func (in *IOCfg) DeepCopy() *IOCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceMetric) DeepCopyInto(out *InterferenceMetric) {
+	*out = *in
+	if in.PSI != nil {
+		in, out := &in.PSI, &out.PSI
+		*out = new(PSIMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPI != nil {
+		in, out := &in.CPI, &out.CPI
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceMetric.
+func (in *InterferenceMetric) DeepCopy() *InterferenceMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryQOS) DeepCopyInto(out *MemoryQOS) {
 	*out = *in
@@ -665,6 +690,11 @@ func (in *NodeMetricInfo) DeepCopyInto(out *NodeMetricInfo) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Interference != nil {
+		in, out := &in.Interference, &out.Interference
+		*out = new(InterferenceMetric)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMetricInfo.
@@ -791,7 +821,7 @@ func (in *NodeSLO) DeepCopyInto(out *NodeSLO) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLO.
@@ -893,6 +923,13 @@ func (in *NodeSLOSpec) DeepCopy() *NodeSLOSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSLOStatus) DeepCopyInto(out *NodeSLOStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]NodeSLOStrategyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLOStatus.
@@ -905,6 +942,26 @@ func (in *NodeSLOStatus) DeepCopy() *NodeSLOStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSLOStrategyCondition) DeepCopyInto(out *NodeSLOStrategyCondition) {
+	*out = *in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLOStrategyCondition.
+func (in *NodeSLOStrategyCondition) DeepCopy() *NodeSLOStrategyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSLOStrategyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OriginAllocatable) DeepCopyInto(out *OriginAllocatable) {
 	*out = *in
@@ -927,6 +984,51 @@ func (in *OriginAllocatable) DeepCopy() *OriginAllocatable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSIMetric) DeepCopyInto(out *PSIMetric) {
+	*out = *in
+	if in.CPUSome != nil {
+		in, out := &in.CPUSome, &out.CPUSome
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPUFull != nil {
+		in, out := &in.CPUFull, &out.CPUFull
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemorySome != nil {
+		in, out := &in.MemorySome, &out.MemorySome
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryFull != nil {
+		in, out := &in.MemoryFull, &out.MemoryFull
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IOSome != nil {
+		in, out := &in.IOSome, &out.IOSome
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IOFull != nil {
+		in, out := &in.IOFull, &out.IOFull
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSIMetric.
+func (in *PSIMetric) DeepCopy() *PSIMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PSIMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeakMetric) DeepCopyInto(out *PeakMetric) {
 	*out = *in
@@ -967,6 +1069,11 @@ func (in *PodMetricInfo) DeepCopyInto(out *PodMetricInfo) {
 		in, out := &in.Extensions, &out.Extensions
 		*out = (*in).DeepCopy()
 	}
+	if in.Interference != nil {
+		in, out := &in.Interference, &out.Interference
+		*out = new(InterferenceMetric)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricInfo.
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/koordinator-sh/koordinator/pkg/controller/colocationprofile"
+	"github.com/koordinator-sh/koordinator/pkg/quota-controller/federation"
 	"github.com/koordinator-sh/koordinator/pkg/quota-controller/profile"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/nodemetric"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource"
@@ -31,6 +32,7 @@ import (
 var controllerInitFlags = map[string]func(*flag.FlagSet){
 	noderesource.Name:      noderesource.InitFlags,
 	colocationprofile.Name: colocationprofile.InitFlags,
+	federation.Name:        federation.InitFlags,
 }
 
 var controllerAddFuncs = map[string]func(manager.Manager) error{
@@ -39,4 +41,5 @@ var controllerAddFuncs = map[string]func(manager.Manager) error{
 	nodeslo.Name:           nodeslo.Add,
 	profile.Name:           profile.Add,
 	colocationprofile.Name: colocationprofile.Add,
+	federation.Name:        federation.Add,
 }
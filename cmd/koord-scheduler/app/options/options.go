@@ -29,6 +29,7 @@ import (
 	schedulerappconfig "github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/config"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
 )
@@ -56,6 +57,11 @@ func NewOptions() *Options {
 func (o *Options) Validate() []error {
 	errs := o.Options.Validate()
 	errs = append(errs, o.CombinedInsecureServing.Validate()...)
+	if o.ComponentConfig != nil {
+		if err := validation.ValidateQuotaFairQueueing(o.ComponentConfig.Profiles); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errs
 }
 
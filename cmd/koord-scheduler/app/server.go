@@ -135,6 +135,7 @@ for cost reduction and efficiency enhancement.
 		klog.Background().Error(err, "Failed to mark flag filename")
 	}
 
+	cmd.AddCommand(newSimulateCommand(registryOptions...))
 	return cmd
 }
 
@@ -240,82 +241,8 @@ func Run(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, sched *
 		}
 	}
 
-	startInformersAndWaitForSync := func(ctx context.Context) error {
-		// Startup order matters for data-race freedom: some plugins register
-		// AfterPluginInformersSynced hooks (via frameworkexthelper) that rebuild
-		// internal state from an initial-list snapshot of their private informers
-		// (e.g. ElasticQuota's ReplaceQuotas rebuilding groupQuotaManager). Those
-		// hooks must complete before the main informers (pods/nodes/etc.) start
-		// delivering events whose handlers read the same plugin state, so we
-		// sequence the pipeline as: (1) start+sync plugin informer factories,
-		// (2) run AfterPluginInformersSynced hooks, (3) start+sync main informer
-		// factories, (4) run AfterAllInformersSynced hooks.
-
-		// Step 1: start plugin informer factories registered via InformerFactoryProvider.
-		for _, f := range extenderFactory.GetPluginInformerFactories() {
-			f.Start(ctx.Done())
-		}
-		for _, f := range extenderFactory.GetPluginInformerFactories() {
-			f.WaitForCacheSync(ctx.Done())
-		}
-		// Step 2: run plugin-registered AfterPluginInformersSynced hooks. A hook
-		// failure is surfaced as a startup error so the caller can shut down
-		// gracefully (releasing the leader lease, running registered shutdown
-		// hooks) instead of abruptly terminating. A ctx cancellation (normal
-		// shutdown) is not treated as an error.
-		if err := frameworkexthelper.RunAfterPluginInformersSynced(ctx); err != nil {
-			if ctx.Err() != nil {
-				logger.Info("AfterPluginInformersSynced hooks interrupted", "err", err)
-				return nil
-			}
-			return fmt.Errorf("AfterPluginInformersSynced hook failed: %w", err)
-		}
-
-		// Step 3: start the remaining informer factories.
-		cc.InformerFactory.Start(ctx.Done())
-		// DynInformerFactory can be nil in tests.
-		if cc.DynInformerFactory != nil {
-			cc.DynInformerFactory.Start(ctx.Done())
-		}
-		cc.KoordinatorSharedInformerFactory.Start(ctx.Done())
-		cc.NodeResourceTopologyInformerFactory.Start(ctx.Done())
-
-		// Wait for all caches to sync before scheduling.
-		cc.InformerFactory.WaitForCacheSync(ctx.Done())
-		// DynInformerFactory can be nil in tests.
-		if cc.DynInformerFactory != nil {
-			cc.DynInformerFactory.WaitForCacheSync(ctx.Done())
-		}
-		cc.KoordinatorSharedInformerFactory.WaitForCacheSync(ctx.Done())
-		cc.NodeResourceTopologyInformerFactory.WaitForCacheSync(ctx.Done())
-
-		// Wait for all handlers to sync (all items in the initial list delivered) before scheduling.
-		if err := sched.WaitForHandlersSync(ctx); err != nil {
-			logger.Error(err, "waiting for handlers to sync")
-		}
-
-		// Wait for koordinator plugin handlers (registrations collected via
-		// ForceSyncFromInformer) to complete their initial list sync. These are
-		// not visible to sched.WaitForHandlersSync, so we check them separately.
-		if err := frameworkexthelper.WaitForHandlersSync(ctx); err != nil {
-			logger.Error(err, "waiting for koordinator handlers to sync")
-		}
-
-		logger.V(3).Info("Handlers synced")
-
-		// Step 4: run plugin-registered AfterAllInformersSynced hooks. Same
-		// error/shutdown contract as Step 2.
-		if err := frameworkexthelper.RunAfterAllInformersSynced(ctx); err != nil {
-			if ctx.Err() != nil {
-				logger.Info("AfterAllInformersSynced hooks interrupted", "err", err)
-				return nil
-			}
-			return fmt.Errorf("AfterAllInformersSynced hook failed: %w", err)
-		}
-		return nil
-	}
 	if !cc.ComponentConfig.DelayCacheUntilActive || cc.LeaderElection == nil {
-		if err := startInformersAndWaitForSync(ctx); err != nil {
+		if err := startInformersAndWaitForSync(ctx, cc, sched, extenderFactory); err != nil {
 			return err
 		}
 	}
@@ -326,7 +253,7 @@ func Run(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, sched *
 				close(waitingForLeader)
 				if cc.ComponentConfig.DelayCacheUntilActive {
 					logger.Info("Starting informers and waiting for sync...")
-					if err := startInformersAndWaitForSync(ctx); err != nil {
+					if err := startInformersAndWaitForSync(ctx, cc, sched, extenderFactory); err != nil {
 						// Trigger graceful shutdown by canceling the outer context:
 						// the leader elector observes ctx.Done() and invokes
 						// OnStoppedLeading, which runs gracefulShutdownSecureServer
@@ -375,6 +302,84 @@ func Run(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, sched *
 	return fmt.Errorf("finished without leader elect")
 }
 
+// startInformersAndWaitForSync starts all the informer factories in the order the plugins rely on and
+// waits for them and the event handlers to sync.
+func startInformersAndWaitForSync(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, sched *scheduler.Scheduler, extenderFactory *frameworkext.FrameworkExtenderFactory) error {
+	logger := klog.FromContext(ctx)
+	// Startup order matters for data-race freedom: some plugins register
+	// AfterPluginInformersSynced hooks (via frameworkexthelper) that rebuild
+	// internal state from an initial-list snapshot of their private informers
+	// (e.g. ElasticQuota's ReplaceQuotas rebuilding groupQuotaManager). Those
+	// hooks must complete before the main informers (pods/nodes/etc.) start
+	// delivering events whose handlers read the same plugin state, so we
+	// sequence the pipeline as: (1) start+sync plugin informer factories,
+	// (2) run AfterPluginInformersSynced hooks, (3) start+sync main informer
+	// factories, (4) run AfterAllInformersSynced hooks.
+
+	// Step 1: start plugin informer factories registered via InformerFactoryProvider.
+	for _, f := range extenderFactory.GetPluginInformerFactories() {
+		f.Start(ctx.Done())
+	}
+	for _, f := range extenderFactory.GetPluginInformerFactories() {
+		f.WaitForCacheSync(ctx.Done())
+	}
+	// Step 2: run plugin-registered AfterPluginInformersSynced hooks. A hook
+	// failure is surfaced as a startup error so the caller can shut down
+	// gracefully (releasing the leader lease, running registered shutdown
+	// hooks) instead of abruptly terminating. A ctx cancellation (normal
+	// shutdown) is not treated as an error.
+	if err := frameworkexthelper.RunAfterPluginInformersSynced(ctx); err != nil {
+		if ctx.Err() != nil {
+			logger.Info("AfterPluginInformersSynced hooks interrupted", "err", err)
+			return nil
+		}
+		return fmt.Errorf("AfterPluginInformersSynced hook failed: %w", err)
+	}
+
+	// Step 3: start the remaining informer factories.
+	cc.InformerFactory.Start(ctx.Done())
+	// DynInformerFactory can be nil in tests.
+	if cc.DynInformerFactory != nil {
+		cc.DynInformerFactory.Start(ctx.Done())
+	}
+	cc.KoordinatorSharedInformerFactory.Start(ctx.Done())
+	cc.NodeResourceTopologyInformerFactory.Start(ctx.Done())
+
+	// Wait for all caches to sync before scheduling.
+	cc.InformerFactory.WaitForCacheSync(ctx.Done())
+	// DynInformerFactory can be nil in tests.
+	if cc.DynInformerFactory != nil {
+		cc.DynInformerFactory.WaitForCacheSync(ctx.Done())
+	}
+	cc.KoordinatorSharedInformerFactory.WaitForCacheSync(ctx.Done())
+	cc.NodeResourceTopologyInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// Wait for all handlers to sync (all items in the initial list delivered) before scheduling.
+	if err := sched.WaitForHandlersSync(ctx); err != nil {
+		logger.Error(err, "waiting for handlers to sync")
+	}
+
+	// Wait for koordinator plugin handlers (registrations collected via
+	// ForceSyncFromInformer) to complete their initial list sync. These are
+	// not visible to sched.WaitForHandlersSync, so we check them separately.
+	if err := frameworkexthelper.WaitForHandlersSync(ctx); err != nil {
+		logger.Error(err, "waiting for koordinator handlers to sync")
+	}
+
+	logger.V(3).Info("Handlers synced")
+
+	// Step 4: run plugin-registered AfterAllInformersSynced hooks. Same
+	// error/shutdown contract as Step 2.
+	if err := frameworkexthelper.RunAfterAllInformersSynced(ctx); err != nil {
+		if ctx.Err() != nil {
+			logger.Info("AfterAllInformersSynced hooks interrupted", "err", err)
+			return nil
+		}
+		return fmt.Errorf("AfterAllInformersSynced hook failed: %w", err)
+	}
+	return nil
+}
+
 // buildHandlerChain wraps the given handler with the standard filters.
 func buildHandlerChain(handler http.Handler, authn authenticator.Request, authz authorizer.Authorizer) http.Handler {
 	requestInfoResolver := &apirequest.RequestInfoFactory{}
@@ -419,6 +424,7 @@ func newHealthzAndMetricsHandler(config *kubeschedulerconfig.KubeSchedulerConfig
 		debugFlags.Install("v", utilroutes.StringFlagPutHandler(logs.GlogSetter))
 		debugFlags.Install("s", utilroutes.StringFlagPutHandler(frameworkext.DebugScoresSetter))
 		debugFlags.Install("f", utilroutes.StringFlagPutHandler(frameworkext.DebugFiltersSetter))
+		debugFlags.Install("a", utilroutes.StringFlagPutHandler(frameworkext.DebugAttemptsSetter))
 		debugFlags.Install("d", utilroutes.StringFlagPutHandler(frameworkext.DumpDiagnosisSetter))
 		debugFlags.Install("db", utilroutes.StringFlagPutHandler(frameworkext.DumpDiagnosisBlockingSetter))
 	}
@@ -459,7 +465,12 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 
 	// Get the completed config
 	cc := c.Complete()
+	return setupScheduler(ctx, cc, opts.WriteConfigTo, nil, outOfTreeRegistryOptions...)
+}
 
+// setupScheduler creates the scheduler based on the completed config. The extenderOptions are appended to the
+// options of the FrameworkExtenderFactory.
+func setupScheduler(ctx context.Context, cc schedulerserverconfig.CompletedConfig, writeConfigTo string, extenderOptions []frameworkext.Option, outOfTreeRegistryOptions ...Option) (*schedulerserverconfig.CompletedConfig, *scheduler.Scheduler, *frameworkext.FrameworkExtenderFactory, CustomWorkflow, error) {
 	defaultprofile.AppendDefaultPlugins(cc.ComponentConfig.Profiles)
 
 	informer.SetupCustomInformers(cc.InformerFactory)
@@ -478,11 +489,17 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		crossSchedulerNominator = frameworkext.NewCrossSchedulerPodNominator()
 	}
 
-	workloadAuditor := workloadauditor.NewWorkloadAuditor()
+	workloadAuditor := workloadauditor.NewWorkloadAuditor(
+		workloadauditor.WithRecordPersister(workloadauditor.NewRecordPersister(workloadauditor.DefaultWorkloadAuditorConfig(),
+			cc.EventBroadcaster.NewRecorder("koord-scheduler-workload-auditor"), cc.Client, ctx.Done())),
+	)
+	if provider, ok := workloadAuditor.(services.APIServiceProvider); ok && workloadAuditor.Enabled() {
+		cc.ServicesEngine.RegisterDebugService("workloadAuditor", provider)
+	}
 
 	// NOTE(joseph): K8s scheduling framework does not provide extension point for initialization.
 	// Currently, only by copying the initialization code and implementing custom initialization.
-	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(append([]frameworkext.Option{
 		frameworkext.WithServicesEngine(cc.ServicesEngine),
 		frameworkext.WithKoordinatorClientSet(cc.KoordinatorClient),
 		frameworkext.WithKoordinatorSharedInformerFactory(cc.KoordinatorSharedInformerFactory),
@@ -490,7 +507,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		frameworkext.WithNetworkTopologyManager(networkTopologyManager),
 		frameworkext.WithCrossSchedulerPodNominator(crossSchedulerNominator),
 		frameworkext.WithWorkloadAuditor(workloadAuditor),
-	)
+	}, extenderOptions...)...)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -528,7 +545,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
-	if err := scheduleroptions.LogOrWriteConfig(klog.FromContext(ctx), opts.WriteConfigTo, &cc.ComponentConfig, completedProfiles); err != nil {
+	if err := scheduleroptions.LogOrWriteConfig(klog.FromContext(ctx), writeConfigTo, &cc.ComponentConfig, completedProfiles); err != nil {
 		return nil, nil, nil, nil, err
 	}
 
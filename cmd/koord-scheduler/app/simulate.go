@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	nrtclientsetfake "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/fake"
+	nrtscheme "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/scheme"
+	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/logs"
+	"k8s.io/component-base/term"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+	schedulerappconfig "k8s.io/kubernetes/cmd/kube-scheduler/app/config"
+	scheduleroptions "k8s.io/kubernetes/cmd/kube-scheduler/app/options"
+	"k8s.io/kubernetes/pkg/scheduler"
+	kubeschedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/validation"
+
+	schedclientset "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned"
+	schedclientsetfake "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+	schedscheme "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned/scheme"
+	schedulerserverconfig "github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/config"
+	koordclientsetfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordscheme "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/scheme"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/simulator"
+)
+
+type simulateOptions struct {
+	configFile    string
+	snapshotFiles []string
+	workloadFiles []string
+	schedulerName string
+	nodePoolLabel string
+	output        string
+	timeout       time.Duration
+}
+
+// newSimulateCommand creates the simulate subcommand which runs the configured profile with all the registered
+// plugins offline against a cluster snapshot, and reports where the hypothetical workloads would be placed.
+func newSimulateCommand(registryOptions ...Option) *cobra.Command {
+	o := &simulateOptions{
+		output:  simulator.OutputFormatTable,
+		timeout: 5 * time.Minute,
+	}
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate scheduling hypothetical workloads on a cluster snapshot",
+		Long: `Simulate loads a cluster snapshot (nodes, pods, quotas, reservations, devices and other koordinator
+objects, e.g. the output of "kubectl get -A -o yaml") and a list of hypothetical workloads (pods, or
+Deployments, ReplicaSets, StatefulSets and Jobs expanded into their pods), runs the scheduler profile with all
+the koordinator plugins without talking to any cluster, and prints the placement, the unschedulable reasons and
+the resulting utilization per node pool and per ElasticQuota. The pending pods in the snapshot are scheduled
+before the workloads. The workloads are reserved and assumed only, the Permit and binding are not simulated.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runSimulate(context.Background(), cmd.OutOrStdout(), o, registryOptions...); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		},
+		Args: cobra.NoArgs,
+	}
+
+	nfs := cliflag.NamedFlagSets{}
+	fs := nfs.FlagSet("simulate")
+	fs.StringVar(&o.configFile, "config", o.configFile, "The path to the scheduler configuration file. The default configuration is used if not set.")
+	fs.StringSliceVar(&o.snapshotFiles, "snapshot", o.snapshotFiles, "The YAML or JSON files of the cluster snapshot.")
+	fs.StringSliceVar(&o.workloadFiles, "workloads", o.workloadFiles, "The YAML or JSON files of the hypothetical workloads. The objects other than the workloads, e.g. the ElasticQuotas of a new tenant, are added to the snapshot.")
+	fs.StringVar(&o.schedulerName, "scheduler-name", o.schedulerName, "The scheduler profile to simulate, the first profile is used if not set. The workloads without schedulerName are scheduled by it.")
+	fs.StringVar(&o.nodePoolLabel, "node-pool-label", o.nodePoolLabel, "The node label to group the nodes into pools in the utilization report.")
+	fs.StringVarP(&o.output, "output", "o", o.output, "The output format, table or json.")
+	fs.DurationVar(&o.timeout, "timeout", o.timeout, "The timeout of the simulation.")
+	utilfeature.DefaultMutableFeatureGate.AddFlag(nfs.FlagSet("feature gate"))
+	globalflag.AddGlobalFlags(nfs.FlagSet("global"), cmd.Name(), logs.SkipLoggingConfigurationFlags())
+	for _, f := range nfs.FlagSets {
+		cmd.Flags().AddFlagSet(f)
+	}
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, nfs, cols)
+	if err := cmd.MarkFlagRequired("snapshot"); err != nil {
+		klog.Background().Error(err, "Failed to mark flag required")
+	}
+	return cmd
+}
+
+func runSimulate(ctx context.Context, out io.Writer, o *simulateOptions, registryOptions ...Option) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+	logger := klog.FromContext(ctx)
+
+	snapshotObjects, err := simulator.LoadObjectsFromFiles(o.snapshotFiles...)
+	if err != nil {
+		return err
+	}
+	workloadObjects, err := simulator.LoadObjectsFromFiles(o.workloadFiles...)
+	if err != nil {
+		return err
+	}
+	workloadPods, otherObjects := simulator.ExpandWorkloads(workloadObjects)
+	snapshot := simulator.NewSnapshot(snapshotObjects)
+	snapshot.Add(otherObjects...)
+
+	componentConfig, err := loadSimulationConfig(logger, o.configFile)
+	if err != nil {
+		return err
+	}
+	schedulerName := o.schedulerName
+	if schedulerName == "" && len(componentConfig.Profiles) > 0 {
+		schedulerName = componentConfig.Profiles[0].SchedulerName
+	}
+	var podsToSchedule []*corev1.Pod
+	for _, pod := range snapshot.Pods {
+		if pod.Spec.NodeName == "" && pod.Spec.SchedulerName == schedulerName {
+			podsToSchedule = append(podsToSchedule, pod)
+		}
+	}
+	for _, pod := range workloadPods {
+		if pod.Spec.SchedulerName == "" {
+			pod.Spec.SchedulerName = schedulerName
+		}
+		podsToSchedule = append(podsToSchedule, pod)
+	}
+
+	kubeClient := kubefake.NewSimpleClientset()
+	koordClient := koordclientsetfake.NewSimpleClientset()
+	schedClient := schedclientsetfake.NewSimpleClientset()
+	nrtClient := nrtclientsetfake.NewSimpleClientset()
+	trackers := []simulationObjectTracker{
+		{scheme: kubescheme.Scheme, tracker: kubeClient.Tracker()},
+		{scheme: koordscheme.Scheme, tracker: koordClient.Tracker()},
+		{scheme: schedscheme.Scheme, tracker: schedClient.Tracker()},
+		{scheme: nrtscheme.Scheme, tracker: nrtClient.Tracker()},
+	}
+	for _, obj := range snapshot.Objects {
+		if err := addSimulationObject(trackers, obj); err != nil {
+			return err
+		}
+	}
+	// The workloads are visible to the plugins listing the pods, e.g. the gang members of Coscheduling.
+	for _, pod := range workloadPods {
+		if err := addSimulationObject(trackers, pod); err != nil {
+			return err
+		}
+	}
+
+	cc := newSimulationConfig(componentConfig, kubeClient, koordClient, nrtClient)
+	handleWrapper := func(extender frameworkext.FrameworkExtender) fwktype.Handle {
+		return &simulationHandle{FrameworkExtender: extender, Interface: schedClient}
+	}
+	_, sched, extenderFactory, _, err := setupScheduler(ctx, cc, "", []frameworkext.Option{frameworkext.WithPluginHandleWrapper(handleWrapper)}, registryOptions...)
+	if err != nil {
+		return err
+	}
+	if err := startInformersAndWaitForSync(ctx, &cc, sched, extenderFactory); err != nil {
+		return err
+	}
+	fwk, ok := sched.Profiles[schedulerName]
+	if !ok {
+		return fmt.Errorf("scheduler profile %q not found", schedulerName)
+	}
+
+	placements := simulator.New(fwk, sched.SchedulePod, sched.Cache).Schedule(ctx, podsToSchedule)
+	report := simulator.BuildReport(snapshot, placements, o.nodePoolLabel)
+	return report.Print(out, o.output)
+}
+
+func loadSimulationConfig(logger klog.Logger, configFile string) (*kubeschedulerconfig.KubeSchedulerConfiguration, error) {
+	if configFile == "" {
+		return latest.Default()
+	}
+	cfg, err := scheduleroptions.LoadConfigFromFile(logger, configFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateKubeSchedulerConfiguration(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// newSimulationConfig builds the config of the scheduler on the fake clientsets, the leader election and the
+// serving are disabled.
+func newSimulationConfig(componentConfig *kubeschedulerconfig.KubeSchedulerConfiguration, kubeClient *kubefake.Clientset,
+	koordClient *koordclientsetfake.Clientset, nrtClient *nrtclientsetfake.Clientset) schedulerserverconfig.CompletedConfig {
+	componentConfig.LeaderElection.LeaderElect = false
+	// keep the stdout for the report
+	gin.SetMode(gin.ReleaseMode)
+	c := &schedulerserverconfig.Config{
+		Config: &schedulerappconfig.Config{
+			ComponentConfig:  *componentConfig,
+			Client:           kubeClient,
+			KubeConfig:       &rest.Config{},
+			InformerFactory:  frameworkexthelper.NewForceSyncSharedInformerFactory(scheduler.NewInformerFactory(kubeClient, 0)),
+			EventBroadcaster: events.NewEventBroadcasterAdapter(kubeClient),
+		},
+		ServicesEngine:                      services.NewEngine(gin.New()),
+		KoordinatorClient:                   koordClient,
+		KoordinatorSharedInformerFactory:    koordinformers.NewSharedInformerFactoryWithOptions(koordClient, 0),
+		NodeResourceTopologyInformerFactory: nrtinformers.NewSharedInformerFactoryWithOptions(nrtClient, 0),
+	}
+	return c.Complete()
+}
+
+// simulationHandle passes the fake clientset to the plugins building their own clients from the KubeConfig,
+// e.g. ElasticQuota and Coscheduling.
+type simulationHandle struct {
+	frameworkext.FrameworkExtender
+	schedclientset.Interface
+}
+
+type simulationObjectTracker struct {
+	scheme  *runtime.Scheme
+	tracker clienttesting.ObjectTracker
+}
+
+// addSimulationObject adds the object into the fake clientset serving its kind.
+func addSimulationObject(trackers []simulationObjectTracker, obj runtime.Object) error {
+	for _, t := range trackers {
+		if _, _, err := t.scheme.ObjectKinds(obj); err != nil {
+			continue
+		}
+		return t.tracker.Add(obj)
+	}
+	return fmt.Errorf("unsupported object %T", obj)
+}
@@ -36,6 +36,10 @@ func (c *FakeSchedulingV1alpha1) Devices() v1alpha1.DeviceInterface {
 	return newFakeDevices(c)
 }
 
+func (c *FakeSchedulingV1alpha1) NodeDrains() v1alpha1.NodeDrainInterface {
+	return newFakeNodeDrains(c)
+}
+
 func (c *FakeSchedulingV1alpha1) PodMigrationJobs() v1alpha1.PodMigrationJobInterface {
 	return newFakePodMigrationJobs(c)
 }
@@ -22,6 +22,8 @@ type ClusterNetworkTopologyExpansion interface{}
 
 type DeviceExpansion interface{}
 
+type NodeDrainExpansion interface{}
+
 type PodMigrationJobExpansion interface{}
 
 type ReservationExpansion interface{}
@@ -30,6 +30,7 @@ type SchedulingV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterNetworkTopologiesGetter
 	DevicesGetter
+	NodeDrainsGetter
 	PodMigrationJobsGetter
 	ReservationsGetter
 	ScheduleExplanationsGetter
@@ -48,6 +49,10 @@ func (c *SchedulingV1alpha1Client) Devices() DeviceInterface {
 	return newDevices(c)
 }
 
+func (c *SchedulingV1alpha1Client) NodeDrains() NodeDrainInterface {
+	return newNodeDrains(c)
+}
+
 func (c *SchedulingV1alpha1Client) PodMigrationJobs() PodMigrationJobInterface {
 	return newPodMigrationJobs(c)
 }
@@ -28,6 +28,8 @@ type Interface interface {
 	ClusterNetworkTopologies() ClusterNetworkTopologyInformer
 	// Devices returns a DeviceInformer.
 	Devices() DeviceInformer
+	// NodeDrains returns a NodeDrainInformer.
+	NodeDrains() NodeDrainInformer
 	// PodMigrationJobs returns a PodMigrationJobInformer.
 	PodMigrationJobs() PodMigrationJobInformer
 	// Reservations returns a ReservationInformer.
@@ -57,6 +59,11 @@ func (v *version) Devices() DeviceInformer {
 	return &deviceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NodeDrains returns a NodeDrainInformer.
+func (v *version) NodeDrains() NodeDrainInformer {
+	return &nodeDrainInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PodMigrationJobs returns a PodMigrationJobInformer.
 func (v *version) PodMigrationJobs() PodMigrationJobInformer {
 	return &podMigrationJobInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
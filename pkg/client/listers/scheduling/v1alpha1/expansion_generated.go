@@ -26,6 +26,10 @@ type ClusterNetworkTopologyListerExpansion interface{}
 // DeviceLister.
 type DeviceListerExpansion interface{}
 
+// NodeDrainListerExpansion allows custom methods to be added to
+// NodeDrainLister.
+type NodeDrainListerExpansion interface{}
+
 // PodMigrationJobListerExpansion allows custom methods to be added to
 // PodMigrationJobLister.
 type PodMigrationJobListerExpansion interface{}
@@ -19,6 +19,7 @@ package colocationprofile
 import (
 	"context"
 	"flag"
+	"fmt"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -26,11 +27,15 @@ import (
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
 	"github.com/koordinator-sh/koordinator/apis/extension"
@@ -40,6 +45,15 @@ import (
 
 const Name = "colocationprofile"
 
+const (
+	reasonReconciled         = "Reconciled"
+	reasonListPodsFailed     = "ListPodsFailed"
+	reasonInvalidProbability = "InvalidProbability"
+	reasonUpdatePodFailed    = "UpdatePodFailed"
+)
+
+// +kubebuilder:rbac:groups=config.koordinator.sh,resources=clustercolocationprofiles/status,verbs=get;update;patch
+
 var (
 	ReconcileByDefault     = false
 	ReconcileInterval      = 30 * time.Second
@@ -83,42 +97,65 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	// NOTE: The webhook follows the rollout in the status, so the status is updated for every profile.
+	summary := newSummary(profile.Name)
+	rollout := getRolloutStatus(profile, metav1.Now())
+	webhookMutated, err := r.countWebhookMutatedPods(profile)
+	if err != nil {
+		klog.ErrorS(err, "failed to count pods mutated by ClusterColocationProfile", "profile", profile.Name)
+		r.updateProfileStatus(ctx, profile, summary, rollout, reasonListPodsFailed, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	summary.WebhookMutated = webhookMutated
+
 	profileEnabled := extension.ShouldReconcileProfile(profile)
 	klog.V(5).InfoS("reconcile for clusterColocationProfile",
 		"profile", profile.Name, "defaultEnabled", ReconcileByDefault, "profileEnabled", profileEnabled)
 	if !ReconcileByDefault && !profileEnabled {
-		// should not handle the profile
-		return ctrl.Result{}, nil
+		// should not update the pods for the profile
+		r.updateProfileStatus(ctx, profile, summary, rollout, "", nil)
+		return ctrl.Result{RequeueAfter: ReconcileInterval}, nil
 	}
 
 	podList, err := r.listPodsForProfile(profile)
 	if err != nil {
 		klog.ErrorS(err, "failed to list pods for ClusterColocationProfile", "profile", profile.Name)
+		r.updateProfileStatus(ctx, profile, summary, rollout, reasonListPodsFailed, err)
 		return ctrl.Result{Requeue: true}, err
 	}
 	if podList == nil || len(podList.Items) <= 0 {
 		klog.V(6).InfoS("list no pod for ClusterColocationProfile, retry later", "profile", profile.Name)
+		r.updateProfileStatus(ctx, profile, summary, rollout, "", nil)
 		return ctrl.Result{RequeueAfter: ReconcileInterval}, nil
 	}
 	klog.V(5).InfoS("list pods for ClusterColocationProfile", "profile", profile.Name, "pods", len(podList.Items))
 
-	summary := newSummary(profile.Name)
 	for i := range podList.Items {
 		pod := &podList.Items[i]
 		// NOTE: Only handle pending and unscheduled pods.
 		if pod.Spec.NodeName != "" || pod.Status.Phase != corev1.PodPending {
 			continue
 		}
+		summary.Matched++
+		if !extension.IsPodInColocationProfileRollout(rollout, pod) {
+			summary.Skipped++
+			summary.Held++
+			klog.V(5).InfoS("skip update Pod by clusterColocationProfile, held by rollout", "profile", profile.Name, "pod", klog.KObj(pod))
+			continue
+		}
 		skip, err := shouldSkipProfile(profile)
 		if err != nil {
 			klog.ErrorS(err, "failed to check skip profile for pod", "profile", profile.Name, "pod", klog.KObj(pod))
+			r.updateProfileStatus(ctx, profile, summary, rollout, reasonInvalidProbability, err)
 			return ctrl.Result{Requeue: true}, err
 		}
 		if skip {
 			summary.Skipped++
+			summary.Unsampled++
 			klog.V(5).InfoS("skip update Pod by clusterColocationProfile", "profile", profile.Name, "pod", klog.KObj(pod))
 			continue
 		}
+		summary.Sampled++
 		_, exists := r.podUpdateCache.Get(getPodUpdateKey(profile, pod))
 		if exists {
 			summary.Cached++
@@ -134,6 +171,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		isUpdated, err := r.updatePodByClusterColocationProfile(context.TODO(), profile, pod)
 		if err != nil {
+			summary.Failed++
+			summary.LastError = err.Error()
 			klog.ErrorS(err, "failed to patch pod for clusterColocationProfile", "profile", profile.Name, "pod", klog.KObj(pod))
 			continue
 		}
@@ -145,15 +184,68 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 	klog.V(4).InfoS("successfully update pods for clusterColocationProfile",
 		"profile", profile.Name, "allSucceeded", summary.IsAllSucceeded(), "summary", summary)
+	if summary.Failed > 0 {
+		r.updateProfileStatus(ctx, profile, summary, rollout, reasonUpdatePodFailed, fmt.Errorf("failed to update %d pods, last error: %s", summary.Failed, summary.LastError))
+	} else {
+		r.updateProfileStatus(ctx, profile, summary, rollout, "", nil)
+	}
 
 	// TODO: handle reservations
 	return ctrl.Result{RequeueAfter: ReconcileInterval}, nil
 }
 
+// updateProfileStatus updates the profile status with the result of the reconciliation.
+func (r *Reconciler) updateProfileStatus(ctx context.Context, profile *configv1alpha1.ClusterColocationProfile,
+	summary *ReconcileSummary, rollout *configv1alpha1.ColocationProfileRolloutStatus, reason string, reconcileErr error) {
+	newProfile := profile.DeepCopy()
+	status := &newProfile.Status
+	status.ObservedGeneration = profile.Generation
+	status.LastReconcileTime = &metav1.Time{Time: time.Now()}
+	status.MatchedPods = int32(summary.Matched)
+	status.MutatedPods = int32(summary.Changed)
+	status.WebhookMutatedPods = int32(summary.WebhookMutated)
+	status.SkippedPods = int32(summary.Skipped)
+	status.RateLimitedPods = int32(summary.RateLimited)
+	status.FailedPods = int32(summary.Failed)
+	status.Sampling = nil
+	if percent, err := getProbabilityPercent(profile); err == nil {
+		status.Sampling = &configv1alpha1.ColocationProfileSamplingStatus{
+			Percent:       int32(percent),
+			SampledPods:   int32(summary.Sampled),
+			UnsampledPods: int32(summary.Unsampled),
+		}
+	}
+	if rollout != nil {
+		rollout.HeldPods = int32(summary.Held)
+	}
+	status.Rollout = rollout
+
+	condition := metav1.Condition{
+		Type:               configv1alpha1.ColocationProfileConditionReconciled,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: profile.Generation,
+		Reason:             reasonReconciled,
+	}
+	if reconcileErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = reconcileErr.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	if err := r.Client.Status().Patch(ctx, newProfile, client.MergeFrom(profile)); err != nil {
+		klog.ErrorS(err, "failed to update status for ClusterColocationProfile", "profile", profile.Name)
+		return
+	}
+	klog.V(6).InfoS("successfully update status for ClusterColocationProfile", "profile", profile.Name, "status", status)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&configv1alpha1.ClusterColocationProfile{}).
+		// ignore the status updates since the profile is reconciled periodically
+		For(&configv1alpha1.ClusterColocationProfile{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
 		Named(Name).
 		Complete(r)
 }
@@ -19,6 +19,7 @@ package colocationprofile
 import (
 	"context"
 	"flag"
+	"fmt"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -286,6 +288,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
 					return []string{obj.(*corev1.Pod).Spec.NodeName}
 				}).
+				WithIndex(&corev1.Pod{}, indexPodByMutatingColocationProfile, func(obj client.Object) []string {
+					return extension.GetColocationProfilesMutatingPod(obj.(*corev1.Pod))
+				}).
 				Build(),
 			Scheme:         scheme,
 			rateLimiter:    rate.NewLimiter(rate.Limit(1), 5),
@@ -418,7 +423,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, ctrl.Result{RequeueAfter: ReconcileInterval}, result)
 
-		// skip reconcile for profile not managed by controller
+		// skip updating pods for profile not managed by controller
 		gotProfile = &configv1alpha1.ClusterColocationProfile{}
 		err = r.Client.Get(context.TODO(), client.ObjectKey{Name: testProfile.Name}, gotProfile)
 		assert.NoError(t, err)
@@ -436,7 +441,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 			},
 		})
 		assert.NoError(t, err)
-		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, ctrl.Result{RequeueAfter: ReconcileInterval}, result)
 
 		// skip reconcile for deleted profile
 		err = r.Client.Delete(context.TODO(), testProfile3)
@@ -451,6 +456,251 @@ func TestReconciler_Reconcile(t *testing.T) {
 	})
 }
 
+func TestReconciler_ReconcileStatus(t *testing.T) {
+	scheme := getTestScheme()
+	testProfile := &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-profile",
+			Generation: 1,
+			Labels: map[string]string{
+				extension.LabelControllerManaged: "true",
+			},
+		},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"koordinator-colocation-pod": "true",
+				},
+			},
+			QoSClass: string(extension.QoSBE),
+			Rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 100},
+				},
+				Paused: true,
+			},
+		},
+	}
+	var objs []client.Object
+	for i := 0; i < 3; i++ {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("test-pod-%d", i),
+				UID:       types.UID(fmt.Sprintf("test-pod-uid-%d", i)),
+				Labels: map[string]string{
+					"koordinator-colocation-pod": "true",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+			},
+		})
+	}
+	// the pod mutated by the webhook on creation and already scheduled
+	objs = append(objs, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod-webhook",
+			UID:       "test-pod-uid-webhook",
+			Labels: map[string]string{
+				"koordinator-colocation-pod": "true",
+				extension.LabelPodQoS:        string(extension.QoSBE),
+			},
+			Annotations: map[string]string{
+				extension.AnnotationMutatedByColocationProfiles: testProfile.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	})
+	objs = append(objs, testProfile)
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&configv1alpha1.ClusterColocationProfile{}).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			WithIndex(&corev1.Pod{}, indexPodByMutatingColocationProfile, func(obj client.Object) []string {
+				return extension.GetColocationProfilesMutatingPod(obj.(*corev1.Pod))
+			}).
+			Build(),
+		Scheme:         scheme,
+		rateLimiter:    rate.NewLimiter(rate.Limit(1), 5),
+		podUpdateCache: *gocache.New(2*ReconcileInterval, 5*time.Minute),
+	}
+	reconcileAndGet := func() *configv1alpha1.ClusterColocationProfile {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testProfile.Name}})
+		assert.NoError(t, err)
+		got := &configv1alpha1.ClusterColocationProfile{}
+		assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKey{Name: testProfile.Name}, got))
+		return got
+	}
+
+	// the paused rollout holds all pods
+	got := reconcileAndGet()
+	assert.Equal(t, int64(1), got.Status.ObservedGeneration)
+	assert.NotNil(t, got.Status.LastReconcileTime)
+	assert.Equal(t, int32(3), got.Status.MatchedPods)
+	assert.Equal(t, int32(0), got.Status.MutatedPods)
+	assert.Equal(t, int32(1), got.Status.WebhookMutatedPods)
+	assert.Equal(t, int32(3), got.Status.SkippedPods)
+	assert.NotNil(t, got.Status.Rollout)
+	assert.True(t, got.Status.Rollout.Paused)
+	assert.Equal(t, int32(3), got.Status.Rollout.HeldPods)
+	assert.Equal(t, &configv1alpha1.ColocationProfileSamplingStatus{Percent: 100}, got.Status.Sampling)
+	assert.Equal(t, 1, len(got.Status.Conditions))
+	assert.Equal(t, metav1.ConditionTrue, got.Status.Conditions[0].Status)
+	podList := &corev1.PodList{}
+	assert.NoError(t, r.Client.List(context.TODO(), podList))
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" {
+			assert.Empty(t, pod.Labels[extension.LabelPodQoS])
+		}
+	}
+
+	// resume the rollout
+	resumed := got.DeepCopy()
+	resumed.Spec.Rollout.Paused = false
+	assert.NoError(t, r.Client.Update(context.TODO(), resumed))
+	got = reconcileAndGet()
+	assert.Equal(t, int32(3), got.Status.MatchedPods)
+	assert.Equal(t, int32(3), got.Status.MutatedPods)
+	assert.Equal(t, int32(0), got.Status.SkippedPods)
+	assert.False(t, got.Status.Rollout.Paused)
+	assert.Equal(t, int32(100), got.Status.Rollout.Percent)
+	assert.Equal(t, &configv1alpha1.ColocationProfileSamplingStatus{Percent: 100, SampledPods: 3}, got.Status.Sampling)
+	podList = &corev1.PodList{}
+	assert.NoError(t, r.Client.List(context.TODO(), podList))
+	for _, pod := range podList.Items {
+		assert.Equal(t, string(extension.QoSBE), pod.Labels[extension.LabelPodQoS])
+	}
+
+	// the probability misses all pods
+	sampled := got.DeepCopy()
+	sampled.Spec.Rollout = nil
+	sampled.Spec.Probability = ptr.To(intstr.FromString("0%"))
+	assert.NoError(t, r.Client.Update(context.TODO(), sampled))
+	got = reconcileAndGet()
+	assert.Nil(t, got.Status.Rollout)
+	assert.Equal(t, int32(3), got.Status.SkippedPods)
+	assert.Equal(t, &configv1alpha1.ColocationProfileSamplingStatus{Percent: 0, UnsampledPods: 3}, got.Status.Sampling)
+
+	// the invalid probability fails the reconciliation
+	invalid := got.DeepCopy()
+	invalid.Spec.Probability = ptr.To(intstr.FromString("invalid"))
+	assert.NoError(t, r.Client.Update(context.TODO(), invalid))
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testProfile.Name}})
+	assert.Error(t, err)
+	got = &configv1alpha1.ClusterColocationProfile{}
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKey{Name: testProfile.Name}, got))
+	assert.Nil(t, got.Status.Sampling)
+	assert.Equal(t, 1, len(got.Status.Conditions))
+	assert.Equal(t, metav1.ConditionFalse, got.Status.Conditions[0].Status)
+	assert.Equal(t, reasonInvalidProbability, got.Status.Conditions[0].Reason)
+}
+
+func TestReconciler_ReconcileStatusNotManaged(t *testing.T) {
+	scheme := getTestScheme()
+	stepStartTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	testProfile := &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-profile",
+			Generation: 1,
+		},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"koordinator-colocation-pod": "true",
+				},
+			},
+			QoSClass: string(extension.QoSBE),
+			Rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 10, Duration: &metav1.Duration{Duration: time.Minute}},
+					{Percent: 100},
+				},
+			},
+		},
+	}
+	testProfile.Status.Rollout = &configv1alpha1.ColocationProfileRolloutStatus{
+		ProfileHash:   extension.GetColocationProfileMutationHash(testProfile),
+		StepStartTime: &stepStartTime,
+		Percent:       10,
+	}
+	pendingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			UID:       "test-pod-uid",
+			Labels: map[string]string{
+				"koordinator-colocation-pod": "true",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+	}
+	webhookMutatedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod-webhook",
+			UID:       "test-pod-uid-webhook",
+			Labels: map[string]string{
+				"koordinator-colocation-pod": "true",
+				extension.LabelPodQoS:        string(extension.QoSBE),
+			},
+			Annotations: map[string]string{
+				extension.AnnotationMutatedByColocationProfiles: testProfile.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(pendingPod, webhookMutatedPod, testProfile).
+			WithStatusSubresource(&configv1alpha1.ClusterColocationProfile{}).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			WithIndex(&corev1.Pod{}, indexPodByMutatingColocationProfile, func(obj client.Object) []string {
+				return extension.GetColocationProfilesMutatingPod(obj.(*corev1.Pod))
+			}).
+			Build(),
+		Scheme:         scheme,
+		rateLimiter:    rate.NewLimiter(rate.Limit(1), 5),
+		podUpdateCache: *gocache.New(2*ReconcileInterval, 5*time.Minute),
+	}
+
+	// the profile not managed by the controller still gets the status, and its rollout proceeds
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testProfile.Name}})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: ReconcileInterval}, result)
+	got := &configv1alpha1.ClusterColocationProfile{}
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKey{Name: testProfile.Name}, got))
+	assert.NotNil(t, got.Status.LastReconcileTime)
+	assert.Equal(t, int32(1), got.Status.WebhookMutatedPods)
+	assert.Equal(t, int32(0), got.Status.MatchedPods)
+	assert.NotNil(t, got.Status.Rollout)
+	assert.Equal(t, int32(1), got.Status.Rollout.CurrentStep)
+	assert.Equal(t, int32(100), got.Status.Rollout.Percent)
+
+	// the pods are not updated by the controller
+	gotPod := &corev1.Pod{}
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(pendingPod), gotPod))
+	assert.Empty(t, gotPod.Labels[extension.LabelPodQoS])
+}
+
 type fakeManager struct {
 	manager.Manager
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package colocationprofile
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+// getRolloutStatus returns the rollout progress of the profile at the given time.
+// It returns nil if the profile does not stage the rollout.
+func getRolloutStatus(profile *configv1alpha1.ClusterColocationProfile, now metav1.Time) *configv1alpha1.ColocationProfileRolloutStatus {
+	rollout := profile.Spec.Rollout
+	if rollout == nil || len(rollout.Steps) <= 0 {
+		return nil
+	}
+	steps := rollout.Steps
+
+	profileHash := extension.GetColocationProfileMutationHash(profile)
+	status := profile.Status.Rollout.DeepCopy()
+	if status == nil || status.ProfileHash != profileHash || status.StepStartTime == nil {
+		// the mutation changes, restart the rollout
+		status = &configv1alpha1.ColocationProfileRolloutStatus{
+			ProfileHash:   profileHash,
+			StepStartTime: now.DeepCopy(),
+		}
+	}
+	if int(status.CurrentStep) >= len(steps) { // the steps are reduced
+		status.CurrentStep = int32(len(steps) - 1)
+	}
+	status.HeldPods = 0
+
+	if rollout.Paused {
+		status.Paused = true
+	} else {
+		if status.Paused { // resumed, restart the current step
+			status.Paused = false
+			status.StepStartTime = now.DeepCopy()
+		}
+		for int(status.CurrentStep) < len(steps)-1 {
+			duration := steps[status.CurrentStep].Duration
+			if duration == nil {
+				break
+			}
+			stepEndTime := status.StepStartTime.Add(duration.Duration)
+			if now.Time.Before(stepEndTime) {
+				break
+			}
+			status.CurrentStep++
+			status.StepStartTime = &metav1.Time{Time: stepEndTime}
+		}
+	}
+	status.Percent = steps[status.CurrentStep].Percent
+	return status
+}
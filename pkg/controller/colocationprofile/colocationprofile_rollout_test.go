@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package colocationprofile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func Test_getRolloutStatus(t *testing.T) {
+	now := metav1.Now()
+	testProfile := &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-profile",
+		},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			QoSClass: string(extension.QoSBE),
+			Rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 10, Duration: &metav1.Duration{Duration: 10 * time.Minute}},
+					{Percent: 50, Duration: &metav1.Duration{Duration: 10 * time.Minute}},
+					{Percent: 100},
+				},
+			},
+		},
+	}
+	profileHash := extension.GetColocationProfileMutationHash(testProfile)
+	tests := []struct {
+		name        string
+		rollout     *configv1alpha1.ClusterColocationProfileRollout
+		hash        string
+		status      *configv1alpha1.ColocationProfileRolloutStatus
+		wantStep    int32
+		wantPercent int32
+		wantPaused  bool
+		wantStart   metav1.Time
+		wantNil     bool
+	}{
+		{
+			name:    "no rollout",
+			wantNil: true,
+		},
+		{
+			name:        "start the rollout",
+			rollout:     testProfile.Spec.Rollout,
+			hash:        profileHash,
+			wantStep:    0,
+			wantPercent: 10,
+			wantStart:   now,
+		},
+		{
+			name:    "stay at the current step",
+			rollout: testProfile.Spec.Rollout,
+			hash:    profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				StepStartTime: &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			},
+			wantStep:    0,
+			wantPercent: 10,
+			wantStart:   metav1.Time{Time: now.Add(-5 * time.Minute)},
+		},
+		{
+			name:    "move to the next steps",
+			rollout: testProfile.Spec.Rollout,
+			hash:    profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    2,
+			wantPercent: 100,
+			wantStart:   metav1.Time{Time: now.Add(-5 * time.Minute)},
+		},
+		{
+			name:    "restart the rollout since the mutation changes",
+			rollout: testProfile.Spec.Rollout,
+			hash:    profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   "changed",
+				CurrentStep:   2,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    0,
+			wantPercent: 10,
+			wantStart:   now,
+		},
+		{
+			name: "hold at the current step when paused",
+			rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps:  testProfile.Spec.Rollout.Steps,
+				Paused: true,
+			},
+			hash: profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				CurrentStep:   1,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    1,
+			wantPercent: 50,
+			wantPaused:  true,
+			wantStart:   metav1.Time{Time: now.Add(-25 * time.Minute)},
+		},
+		{
+			name:    "restart the current step when resumed",
+			rollout: testProfile.Spec.Rollout,
+			hash:    profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				CurrentStep:   1,
+				Paused:        true,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    1,
+			wantPercent: 50,
+			wantStart:   now,
+		},
+		{
+			name: "stay at the step without duration",
+			rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 10},
+					{Percent: 100},
+				},
+			},
+			hash: profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    0,
+			wantPercent: 10,
+			wantStart:   metav1.Time{Time: now.Add(-25 * time.Minute)},
+		},
+		{
+			name: "steps are reduced",
+			rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 30},
+				},
+			},
+			hash: profileHash,
+			status: &configv1alpha1.ColocationProfileRolloutStatus{
+				ProfileHash:   profileHash,
+				CurrentStep:   2,
+				StepStartTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+			},
+			wantStep:    0,
+			wantPercent: 30,
+			wantStart:   metav1.Time{Time: now.Add(-25 * time.Minute)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := testProfile.DeepCopy()
+			profile.Spec.Rollout = tt.rollout
+			profile.Status.Rollout = tt.status
+			got := getRolloutStatus(profile, now)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.Equal(t, tt.hash, got.ProfileHash)
+			assert.Equal(t, tt.wantStep, got.CurrentStep)
+			assert.Equal(t, tt.wantPercent, got.Percent)
+			assert.Equal(t, tt.wantPaused, got.Paused)
+			assert.True(t, tt.wantStart.Equal(got.StepStartTime), "want %v, got %v", tt.wantStart, got.StepStartTime)
+		})
+	}
+}
@@ -42,6 +42,9 @@ import (
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 )
 
+// indexPodByMutatingColocationProfile is the field index of the pods by the profiles mutating them on creation.
+const indexPodByMutatingColocationProfile = "annotation.mutatedByColocationProfiles"
+
 var (
 	randIntnFn = rand.Intn
 )
@@ -88,6 +91,23 @@ func (r *Reconciler) listPodsForProfile(profile *configv1alpha1.ClusterColocatio
 	return &corev1.PodList{Items: filteredPods}, nil
 }
 
+// countWebhookMutatedPods counts the pods not terminated which the profile mutates on creation.
+func (r *Reconciler) countWebhookMutatedPods(profile *configv1alpha1.ClusterColocationProfile) (int, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), podList, client.MatchingFields{
+		indexPodByMutatingColocationProfile: profile.Name,
+	}, utilclient.DisableDeepCopy); err != nil {
+		return 0, fmt.Errorf("list pods mutated by profile %s failed, err: %w", profile.Name, err)
+	}
+	count := 0
+	for i := range podList.Items {
+		if !util.IsPodTerminated(&podList.Items[i]) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (r *Reconciler) isPodNamespaceMatched(ctx context.Context, pod *corev1.Pod, nsSelector *metav1.LabelSelector) (bool, error) {
 	if nsSelector == nil {
 		return true, nil // no selector means match all
@@ -222,12 +242,20 @@ func (r *Reconciler) doMutateByColocationProfile(pod *corev1.Pod, profile *confi
 type ReconcileSummary struct {
 	Time        string
 	Profile     string
+	Matched     int
 	Desired     int
 	Succeeded   int
 	Changed     int
 	RateLimited int
 	Skipped     int
 	Cached      int
+	Failed      int
+	Sampled     int
+	Unsampled   int
+	Held        int
+	// the pods mutated by the webhook on creation
+	WebhookMutated int
+	LastError      string
 }
 
 func newSummary(profileName string) *ReconcileSummary {
@@ -242,17 +270,22 @@ func (s *ReconcileSummary) IsAllSucceeded() bool {
 }
 
 func shouldSkipProfile(profile *configv1alpha1.ClusterColocationProfile) (bool, error) {
-	percent := 100
-	if profile.Spec.Probability != nil {
-		var err error
-		percent, err = intstr.GetScaledValueFromIntOrPercent(profile.Spec.Probability, 100, false)
-		if err != nil {
-			return false, err
-		}
+	percent, err := getProbabilityPercent(profile)
+	if err != nil {
+		return false, err
 	}
 	return percent == 0 || (percent != 100 && randIntnFn(100) > percent), nil
 }
 
+// getProbabilityPercent returns the effective probability of the profile in percentage.
+func getProbabilityPercent(profile *configv1alpha1.ClusterColocationProfile) (int, error) {
+	if profile.Spec.Probability == nil {
+		return 100, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(profile.Spec.Probability, 100, false)
+}
+
 func getPodUpdateKey(profile *configv1alpha1.ClusterColocationProfile, pod *corev1.Pod) string {
-	return profile.ResourceVersion + "/" + string(pod.UID)
+	// use the generation rather than the resource version since the status updates should not expire the cache
+	return strconv.FormatInt(profile.Generation, 10) + "/" + string(pod.UID)
 }
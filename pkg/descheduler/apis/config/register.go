@@ -50,6 +50,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&LowNodeLoadArgs{},
 		&CustomPriorityArgs{},
 		&FragmentationAwareArgs{},
+		&CPUSetFragmentationArgs{},
+		&GPUFragmentationArgs{},
+		&InterferenceAwareArgs{},
 		&ScaleDownBinPackArgs{},
 	)
 	return nil
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterferenceAwareArgs holds arguments used to configure the InterferenceAware plugin.
+type InterferenceAwareArgs struct {
+	metav1.TypeMeta
+
+	Paused bool
+	DryRun bool
+
+	NodeMetricExpirationSeconds *int64
+
+	NodeSelector        *metav1.LabelSelector
+	EvictableNamespaces *Namespaces
+	PodSelectors        []LowNodeLoadPodSelector
+	NodeFit             bool
+
+	Thresholds           InterferenceThresholds
+	AnomalyCondition     *LoadAnomalyCondition
+	DetectorCacheTimeout *metav1.Duration
+	MaxEvictionsPerNode  int32
+}
+
+// InterferenceThresholds defines the interference thresholds of the node, zero means the metric is not considered.
+type InterferenceThresholds struct {
+	CPUSomePressure    Percentage
+	CPUFullPressure    Percentage
+	MemorySomePressure Percentage
+	MemoryFullPressure Percentage
+	IOSomePressure     Percentage
+	IOFullPressure     Percentage
+	CPI                float64
+}
@@ -159,6 +159,40 @@ type MigrationControllerArgs struct {
 
 	// ArbitrationArgs defines the control parameters of the Arbitration Mechanism.
 	ArbitrationArgs *ArbitrationArgs
+
+	// MaintenanceWindows restricts the execution of PodMigrationJobs to the time windows.
+	// The PodMigrationJobs created outside all windows stay pending until a window opens, and the pending time
+	// does not count towards their TTL.
+	// If empty, the PodMigrationJobs can be executed at any time.
+	MaintenanceWindows []MaintenanceWindow
+
+	// EnableNodeDrain enables the NodeDrain controller, which cordons the node and migrates the pods on it
+	// with ReservationFirst PodMigrationJobs. The NodeDrain CRD must be installed if enabled.
+	EnableNodeDrain bool
+
+	// StuckDetection detects the running PodMigrationJobs stuck before the TTL and rolls them back.
+	// If nil, the stuck PodMigrationJobs are aborted only by the TTL.
+	StuckDetection *MigrationStuckDetectionArgs
+}
+
+// MaintenanceWindow defines a recurring time window in which PodMigrationJobs are allowed to be executed.
+// If multiple windows are active at the same time, the first one takes effect.
+type MaintenanceWindow struct {
+	// Name is the name of the window.
+	Name string
+	// Schedule is the cron expression of the window start time, e.g. "0 22 * * *".
+	Schedule string
+	// Duration indicates how long the window lasts since it started.
+	Duration metav1.Duration
+	// TimeZone is the IANA time zone name the Schedule is interpreted in, e.g. "Asia/Shanghai".
+	// Default is the local time zone of koord-descheduler.
+	TimeZone string
+	// MaxMigratingGlobally overrides the MaxMigratingGlobally of the MigrationControllerArgs within the window.
+	MaxMigratingGlobally *int32
+	// MaxMigratingPerNode overrides the MaxMigratingPerNode of the MigrationControllerArgs within the window.
+	MaxMigratingPerNode *int32
+	// MaxMigratingPerNamespace overrides the MaxMigratingPerNamespace of the MigrationControllerArgs within the window.
+	MaxMigratingPerNamespace *int32
 }
 
 type MigrationLimitObjectType string
@@ -182,6 +216,17 @@ type MigrationObjectLimiter struct {
 	Burst int
 }
 
+// MigrationStuckDetectionArgs holds arguments used to detect the stuck PodMigrationJobs.
+type MigrationStuckDetectionArgs struct {
+	// ReservationUnschedulableTimeout is the maximum duration the Reservation stays unschedulable.
+	// Zero disables the detection.
+	ReservationUnschedulableTimeout metav1.Duration
+
+	// BoundPodMaxRestarts is the maximum restart count of a container in the replacement Pod which is not ready,
+	// beyond which the replacement Pod is regarded as crash looping. Zero disables the detection.
+	BoundPodMaxRestarts int32
+}
+
 // ArbitrationArgs holds arguments used to configure the Arbitration Mechanism.
 type ArbitrationArgs struct {
 	// Enabled defines if Arbitration Mechanism should be enabled.
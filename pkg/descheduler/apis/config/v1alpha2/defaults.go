@@ -47,6 +47,9 @@ const (
 	defaultSchedulerSupportReservation = "koord-scheduler"
 	defaultArbitrationInterval         = 500 * time.Millisecond
 	defaultDetectorCacheTimeout        = 5 * time.Minute
+
+	defaultReservationUnschedulableTimeout = 3 * time.Minute
+	defaultBoundPodMaxRestarts             = 3
 )
 
 var (
@@ -256,6 +259,12 @@ func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
 	if obj.ArbitrationArgs.Interval == nil {
 		obj.ArbitrationArgs.Interval = &metav1.Duration{Duration: defaultArbitrationInterval}
 	}
+	if obj.StuckDetection == nil {
+		obj.StuckDetection = &MigrationStuckDetectionArgs{
+			ReservationUnschedulableTimeout: metav1.Duration{Duration: defaultReservationUnschedulableTimeout},
+			BoundPodMaxRestarts:             defaultBoundPodMaxRestarts,
+		}
+	}
 }
 
 func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
@@ -363,6 +372,76 @@ func SetDefaults_FragmentationAwareArgs(obj *FragmentationAwareArgs) {
 	}
 }
 
+func SetDefaults_CPUSetFragmentationArgs(obj *CPUSetFragmentationArgs) {
+	if obj.Paused == nil {
+		obj.Paused = ptr.To[bool](false)
+	}
+	if obj.DryRun == nil {
+		obj.DryRun = ptr.To[bool](false)
+	}
+	if obj.NodeFit == nil {
+		obj.NodeFit = ptr.To[bool](true)
+	}
+	if obj.ProbeCPUs == nil {
+		obj.ProbeCPUs = ptr.To[int32](8)
+	}
+	if obj.FragmentationThreshold == nil {
+		obj.FragmentationThreshold = ptr.To[float64](0.3)
+	}
+	if obj.MaxEvictionsPerNode == nil {
+		obj.MaxEvictionsPerNode = ptr.To[int32](2)
+	}
+}
+
+func SetDefaults_GPUFragmentationArgs(obj *GPUFragmentationArgs) {
+	if obj.Paused == nil {
+		obj.Paused = ptr.To[bool](false)
+	}
+	if obj.DryRun == nil {
+		obj.DryRun = ptr.To[bool](false)
+	}
+	if obj.NodeFit == nil {
+		obj.NodeFit = ptr.To[bool](true)
+	}
+	if obj.FragmentationThreshold == nil {
+		obj.FragmentationThreshold = ptr.To[float64](0.5)
+	}
+	if obj.MaxEvictionsPerNode == nil {
+		obj.MaxEvictionsPerNode = ptr.To[int32](2)
+	}
+}
+
+func SetDefaults_InterferenceAwareArgs(obj *InterferenceAwareArgs) {
+	if obj.Paused == nil {
+		obj.Paused = ptr.To[bool](false)
+	}
+	if obj.DryRun == nil {
+		obj.DryRun = ptr.To[bool](false)
+	}
+	if obj.NodeFit == nil {
+		obj.NodeFit = ptr.To[bool](true)
+	}
+	if obj.NodeMetricExpirationSeconds == nil {
+		obj.NodeMetricExpirationSeconds = ptr.To[int64](defaultNodeMetricExpirationSeconds)
+	}
+	if obj.AnomalyCondition == nil {
+		obj.AnomalyCondition = defaultLoadAnomalyCondition.DeepCopy()
+	} else {
+		if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+			obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+		}
+		if obj.AnomalyCondition.ConsecutiveNormalities == 0 {
+			obj.AnomalyCondition.ConsecutiveNormalities = defaultLoadAnomalyCondition.ConsecutiveNormalities
+		}
+	}
+	if obj.DetectorCacheTimeout == nil {
+		obj.DetectorCacheTimeout = &metav1.Duration{Duration: defaultDetectorCacheTimeout}
+	}
+	if obj.MaxEvictionsPerNode == nil {
+		obj.MaxEvictionsPerNode = ptr.To[int32](1)
+	}
+}
+
 func SetDefaults_ScaleDownBinPackArgs(obj *ScaleDownBinPackArgs) {
 	if obj.Paused == nil {
 		obj.Paused = ptr.To[bool](false)
@@ -455,6 +455,61 @@ func TestSetDefaults_FragmentationAwareArgs(t *testing.T) {
 	}
 }
 
+func TestSetDefaults_InterferenceAwareArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *InterferenceAwareArgs
+		expected *InterferenceAwareArgs
+	}{
+		{
+			name: "default values",
+			args: &InterferenceAwareArgs{},
+			expected: &InterferenceAwareArgs{
+				Paused:                      ptr.To[bool](false),
+				DryRun:                      ptr.To[bool](false),
+				NodeFit:                     ptr.To[bool](true),
+				NodeMetricExpirationSeconds: ptr.To[int64](defaultNodeMetricExpirationSeconds),
+				AnomalyCondition:            defaultLoadAnomalyCondition,
+				DetectorCacheTimeout:        &metav1.Duration{Duration: defaultDetectorCacheTimeout},
+				MaxEvictionsPerNode:         ptr.To[int32](1),
+			},
+		},
+		{
+			name: "partial anomalyCondition",
+			args: &InterferenceAwareArgs{
+				Thresholds: InterferenceThresholds{
+					CPUSomePressure: 20,
+				},
+				AnomalyCondition: &LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 3,
+				},
+				MaxEvictionsPerNode: ptr.To[int32](2),
+			},
+			expected: &InterferenceAwareArgs{
+				Paused:                      ptr.To[bool](false),
+				DryRun:                      ptr.To[bool](false),
+				NodeFit:                     ptr.To[bool](true),
+				NodeMetricExpirationSeconds: ptr.To[int64](defaultNodeMetricExpirationSeconds),
+				Thresholds: InterferenceThresholds{
+					CPUSomePressure: 20,
+				},
+				AnomalyCondition: &LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 3,
+					ConsecutiveNormalities:   defaultLoadAnomalyCondition.ConsecutiveNormalities,
+				},
+				DetectorCacheTimeout: &metav1.Duration{Duration: defaultDetectorCacheTimeout},
+				MaxEvictionsPerNode:  ptr.To[int32](2),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_InterferenceAwareArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
 func TestSetDefaults_ScaleDownBinPackArgs(t *testing.T) {
 	tests := []struct {
 		name     string
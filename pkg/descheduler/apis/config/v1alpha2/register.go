@@ -58,6 +58,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&LowNodeLoadArgs{},
 		&CustomPriorityArgs{},
 		&FragmentationAwareArgs{},
+		&CPUSetFragmentationArgs{},
+		&GPUFragmentationArgs{},
+		&InterferenceAwareArgs{},
 		&ScaleDownBinPackArgs{},
 	)
 
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CPUSetFragmentationArgs holds arguments used to configure the CPUSetFragmentation plugin.
+type CPUSetFragmentationArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the CPUSetFragmentation plugin is paused.
+	// Default is false.
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun executes the descheduling logic without evicting Pods.
+	// Default is false.
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// NodeSelector selects the nodes that match the labelSelector.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// EvictableNamespaces limits the namespaces of pods that can be evicted.
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// PodSelectors selects the pods that match the labelSelector.
+	PodSelectors []FragmentationAwarePodSelector `json:"podSelectors,omitempty"`
+
+	// NodeFit enables checking whether a candidate Pod can fit on at least one
+	// other node before eviction.
+	// Default is true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// ProbeCPUs is the number of CPUs of the probe pod, which requires the FullPCPUs bind policy
+	// and is bound to a single NUMA node. The free CPUs that cannot be allocated to the probe pods
+	// are regarded as fragments.
+	// Default is 8.
+	ProbeCPUs *int32 `json:"probeCPUs,omitempty"`
+
+	// FragmentationThreshold specifies the minimum ratio of the fragmented CPUs to the free CPUs
+	// required to consider a node for pod migration.
+	// Default is 0.3.
+	FragmentationThreshold *float64 `json:"fragmentationThreshold,omitempty"`
+
+	// MaxEvictionsPerNode specifies the maximum number of pods evicted on a node in one round
+	// to make room for a probe pod. It must be in the range [1, 4].
+	// Default is 2.
+	MaxEvictionsPerNode *int32 `json:"maxEvictionsPerNode,omitempty"`
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterferenceAwareArgs holds arguments used to configure the InterferenceAware plugin.
+type InterferenceAwareArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the InterferenceAware plugin is paused.
+	// Default is false.
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun executes the descheduling logic without evicting Pods.
+	// Default is false.
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// NodeMetricExpirationSeconds indicates the NodeMetric expiration in seconds.
+	// The nodes with expired NodeMetrics are not considered.
+	// Default is 180 seconds.
+	NodeMetricExpirationSeconds *int64 `json:"nodeMetricExpirationSeconds,omitempty"`
+
+	// NodeSelector selects the nodes that match the labelSelector.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// EvictableNamespaces limits the namespaces of pods that can be evicted.
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// PodSelectors selects the pods that match the labelSelector.
+	PodSelectors []LowNodeLoadPodSelector `json:"podSelectors,omitempty"`
+
+	// NodeFit enables checking whether a candidate Pod can fit on at least one
+	// other node before eviction.
+	// Default is true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// Thresholds defines the interference thresholds reported in the NodeMetric,
+	// a node is considered contended when any of the metrics exceeds its threshold.
+	Thresholds InterferenceThresholds `json:"thresholds,omitempty"`
+
+	// AnomalyCondition indicates the node interference anomaly thresholds,
+	// the default is 5 consecutive times exceeding Thresholds,
+	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the interference.
+	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
+
+	// DetectorCacheTimeout indicates the cache expiration time of anomaly detectors, the default is 5 minutes.
+	DetectorCacheTimeout *metav1.Duration `json:"detectorCacheTimeout,omitempty"`
+
+	// MaxEvictionsPerNode specifies the maximum number of pods evicted on a contended node in one round.
+	// Default is 1.
+	MaxEvictionsPerNode *int32 `json:"maxEvictionsPerNode,omitempty"`
+}
+
+// InterferenceThresholds defines the interference thresholds of the node.
+// The pressures are the PSI avg10 percentages, and zero means the metric is not considered.
+type InterferenceThresholds struct {
+	CPUSomePressure    Percentage `json:"cpuSomePressure,omitempty"`
+	CPUFullPressure    Percentage `json:"cpuFullPressure,omitempty"`
+	MemorySomePressure Percentage `json:"memorySomePressure,omitempty"`
+	MemoryFullPressure Percentage `json:"memoryFullPressure,omitempty"`
+	IOSomePressure     Percentage `json:"ioSomePressure,omitempty"`
+	IOFullPressure     Percentage `json:"ioFullPressure,omitempty"`
+	// CPI is the cycles per instruction of the node.
+	CPI float64 `json:"cpi,omitempty"`
+}
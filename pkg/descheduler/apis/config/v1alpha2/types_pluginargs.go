@@ -122,6 +122,40 @@ type MigrationControllerArgs struct {
 
 	// ArbitrationArgs defines the control parameters of the Arbitration Mechanism.
 	ArbitrationArgs *ArbitrationArgs `json:"arbitrationArgs,omitempty"`
+
+	// MaintenanceWindows restricts the execution of PodMigrationJobs to the time windows.
+	// The PodMigrationJobs created outside all windows stay pending until a window opens, and the pending time
+	// does not count towards their TTL.
+	// If empty, the PodMigrationJobs can be executed at any time.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// EnableNodeDrain enables the NodeDrain controller, which cordons the node and migrates the pods on it
+	// with ReservationFirst PodMigrationJobs. The NodeDrain CRD must be installed if enabled.
+	// Default is false
+	EnableNodeDrain bool `json:"enableNodeDrain,omitempty"`
+
+	// StuckDetection detects the running PodMigrationJobs stuck before the TTL and rolls them back.
+	StuckDetection *MigrationStuckDetectionArgs `json:"stuckDetection,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window in which PodMigrationJobs are allowed to be executed.
+// If multiple windows are active at the same time, the first one takes effect.
+type MaintenanceWindow struct {
+	// Name is the name of the window.
+	Name string `json:"name,omitempty"`
+	// Schedule is the cron expression of the window start time, e.g. "0 22 * * *".
+	Schedule string `json:"schedule"`
+	// Duration indicates how long the window lasts since it started.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone name the Schedule is interpreted in, e.g. "Asia/Shanghai".
+	// Default is the local time zone of koord-descheduler.
+	TimeZone string `json:"timeZone,omitempty"`
+	// MaxMigratingGlobally overrides the MaxMigratingGlobally of the MigrationControllerArgs within the window.
+	MaxMigratingGlobally *int32 `json:"maxMigratingGlobally,omitempty"`
+	// MaxMigratingPerNode overrides the MaxMigratingPerNode of the MigrationControllerArgs within the window.
+	MaxMigratingPerNode *int32 `json:"maxMigratingPerNode,omitempty"`
+	// MaxMigratingPerNamespace overrides the MaxMigratingPerNamespace of the MigrationControllerArgs within the window.
+	MaxMigratingPerNamespace *int32 `json:"maxMigratingPerNamespace,omitempty"`
 }
 
 type MigrationLimitObjectType string
@@ -144,6 +178,19 @@ type MigrationObjectLimiter struct {
 	Burst int `json:"burst,omitempty"`
 }
 
+// MigrationStuckDetectionArgs holds arguments used to detect the stuck PodMigrationJobs.
+type MigrationStuckDetectionArgs struct {
+	// ReservationUnschedulableTimeout is the maximum duration the Reservation stays unschedulable.
+	// Zero disables the detection.
+	// Default is 3 minutes
+	ReservationUnschedulableTimeout metav1.Duration `json:"reservationUnschedulableTimeout,omitempty"`
+
+	// BoundPodMaxRestarts is the maximum restart count of a container in the replacement Pod which is not ready,
+	// beyond which the replacement Pod is regarded as crash looping. Zero disables the detection.
+	// Default is 3
+	BoundPodMaxRestarts int32 `json:"boundPodMaxRestarts,omitempty"`
+}
+
 // ArbitrationArgs holds arguments used to configure the Arbitration Mechanism.
 type ArbitrationArgs struct {
 	// Enabled defines if Arbitration Mechanism should be enabled.
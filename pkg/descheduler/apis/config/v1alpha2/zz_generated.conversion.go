@@ -50,6 +50,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CPUSetFragmentationArgs)(nil), (*config.CPUSetFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CPUSetFragmentationArgs_To_config_CPUSetFragmentationArgs(a.(*CPUSetFragmentationArgs), b.(*config.CPUSetFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CPUSetFragmentationArgs)(nil), (*CPUSetFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CPUSetFragmentationArgs_To_v1alpha2_CPUSetFragmentationArgs(a.(*config.CPUSetFragmentationArgs), b.(*CPUSetFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*config.CustomPriorityArgs)(nil), (*CustomPriorityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_config_CustomPriorityArgs_To_v1alpha2_CustomPriorityArgs(a.(*config.CustomPriorityArgs), b.(*CustomPriorityArgs), scope)
 	}); err != nil {
@@ -95,6 +105,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GPUFragmentationArgs)(nil), (*config.GPUFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(a.(*GPUFragmentationArgs), b.(*config.GPUFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.GPUFragmentationArgs)(nil), (*GPUFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(a.(*config.GPUFragmentationArgs), b.(*GPUFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceAwareArgs)(nil), (*config.InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(a.(*InterferenceAwareArgs), b.(*config.InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceAwareArgs)(nil), (*InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(a.(*config.InterferenceAwareArgs), b.(*InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceThresholds)(nil), (*config.InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(a.(*InterferenceThresholds), b.(*config.InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceThresholds)(nil), (*InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(a.(*config.InterferenceThresholds), b.(*InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadAnomalyCondition)(nil), (*config.LoadAnomalyCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(a.(*LoadAnomalyCondition), b.(*config.LoadAnomalyCondition), scope)
 	}); err != nil {
@@ -130,6 +170,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindow)(nil), (*config.MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MaintenanceWindow_To_config_MaintenanceWindow(a.(*MaintenanceWindow), b.(*config.MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MaintenanceWindow)(nil), (*MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(a.(*config.MaintenanceWindow), b.(*MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MigrationControllerArgs)(nil), (*config.MigrationControllerArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(a.(*MigrationControllerArgs), b.(*config.MigrationControllerArgs), scope)
 	}); err != nil {
@@ -150,6 +200,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MigrationStuckDetectionArgs)(nil), (*config.MigrationStuckDetectionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MigrationStuckDetectionArgs_To_config_MigrationStuckDetectionArgs(a.(*MigrationStuckDetectionArgs), b.(*config.MigrationStuckDetectionArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MigrationStuckDetectionArgs)(nil), (*MigrationStuckDetectionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MigrationStuckDetectionArgs_To_v1alpha2_MigrationStuckDetectionArgs(a.(*config.MigrationStuckDetectionArgs), b.(*MigrationStuckDetectionArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Namespaces)(nil), (*config.Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Namespaces_To_config_Namespaces(a.(*Namespaces), b.(*config.Namespaces), scope)
 	}); err != nil {
@@ -285,6 +345,66 @@ func Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.Arbit
 	return autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in, out, s)
 }
 
+func autoConvert_v1alpha2_CPUSetFragmentationArgs_To_config_CPUSetFragmentationArgs(in *CPUSetFragmentationArgs, out *config.CPUSetFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]config.FragmentationAwarePodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.ProbeCPUs, &out.ProbeCPUs, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_float64_To_float64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CPUSetFragmentationArgs_To_config_CPUSetFragmentationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_CPUSetFragmentationArgs_To_config_CPUSetFragmentationArgs(in *CPUSetFragmentationArgs, out *config.CPUSetFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CPUSetFragmentationArgs_To_config_CPUSetFragmentationArgs(in, out, s)
+}
+
+func autoConvert_config_CPUSetFragmentationArgs_To_v1alpha2_CPUSetFragmentationArgs(in *config.CPUSetFragmentationArgs, out *CPUSetFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]FragmentationAwarePodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.ProbeCPUs, &out.ProbeCPUs, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_float64_To_Pointer_float64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_CPUSetFragmentationArgs_To_v1alpha2_CPUSetFragmentationArgs is an autogenerated conversion function.
+func Convert_config_CPUSetFragmentationArgs_To_v1alpha2_CPUSetFragmentationArgs(in *config.CPUSetFragmentationArgs, out *CPUSetFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_config_CPUSetFragmentationArgs_To_v1alpha2_CPUSetFragmentationArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_CustomPriorityArgs_To_config_CustomPriorityArgs(in *CustomPriorityArgs, out *config.CustomPriorityArgs, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
 		return err
@@ -548,6 +668,168 @@ func Convert_config_FragmentationAwarePodSelector_To_v1alpha2_FragmentationAware
 	return autoConvert_config_FragmentationAwarePodSelector_To_v1alpha2_FragmentationAwarePodSelector(in, out, s)
 }
 
+func autoConvert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in *GPUFragmentationArgs, out *config.GPUFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]config.FragmentationAwarePodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_float64_To_float64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in *GPUFragmentationArgs, out *config.GPUFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in, out, s)
+}
+
+func autoConvert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in *config.GPUFragmentationArgs, out *GPUFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]FragmentationAwarePodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_float64_To_Pointer_float64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs is an autogenerated conversion function.
+func Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in *config.GPUFragmentationArgs, out *GPUFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]config.LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(&in.Thresholds, &out.Thresholds, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(config.LoadAnomalyCondition)
+		if err := Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	out.DetectorCacheTimeout = (*v1.Duration)(unsafe.Pointer(in.DetectorCacheTimeout))
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in, out, s)
+}
+
+func autoConvert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.PodSelectors = *(*[]LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(&in.Thresholds, &out.Thresholds, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		if err := Convert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	out.DetectorCacheTimeout = (*v1.Duration)(unsafe.Pointer(in.DetectorCacheTimeout))
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	out.CPUSomePressure = config.Percentage(in.CPUSomePressure)
+	out.CPUFullPressure = config.Percentage(in.CPUFullPressure)
+	out.MemorySomePressure = config.Percentage(in.MemorySomePressure)
+	out.MemoryFullPressure = config.Percentage(in.MemoryFullPressure)
+	out.IOSomePressure = config.Percentage(in.IOSomePressure)
+	out.IOFullPressure = config.Percentage(in.IOFullPressure)
+	out.CPI = in.CPI
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in, out, s)
+}
+
+func autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	out.CPUSomePressure = Percentage(in.CPUSomePressure)
+	out.CPUFullPressure = Percentage(in.CPUFullPressure)
+	out.MemorySomePressure = Percentage(in.MemorySomePressure)
+	out.MemoryFullPressure = Percentage(in.MemoryFullPressure)
+	out.IOSomePressure = Percentage(in.IOSomePressure)
+	out.IOFullPressure = Percentage(in.IOFullPressure)
+	out.CPI = in.CPI
+	return nil
+}
+
+// Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds is an autogenerated conversion function.
+func Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(in *LoadAnomalyCondition, out *config.LoadAnomalyCondition, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Timeout, &out.Timeout, s); err != nil {
 		return err
@@ -753,6 +1035,38 @@ func Convert_config_LowNodeLoadPodSelector_To_v1alpha2_LowNodeLoadPodSelector(in
 	return autoConvert_config_LowNodeLoadPodSelector_To_v1alpha2_LowNodeLoadPodSelector(in, out, s)
 }
 
+func autoConvert_v1alpha2_MaintenanceWindow_To_config_MaintenanceWindow(in *MaintenanceWindow, out *config.MaintenanceWindow, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	out.TimeZone = in.TimeZone
+	out.MaxMigratingGlobally = (*int32)(unsafe.Pointer(in.MaxMigratingGlobally))
+	out.MaxMigratingPerNode = (*int32)(unsafe.Pointer(in.MaxMigratingPerNode))
+	out.MaxMigratingPerNamespace = (*int32)(unsafe.Pointer(in.MaxMigratingPerNamespace))
+	return nil
+}
+
+// Convert_v1alpha2_MaintenanceWindow_To_config_MaintenanceWindow is an autogenerated conversion function.
+func Convert_v1alpha2_MaintenanceWindow_To_config_MaintenanceWindow(in *MaintenanceWindow, out *config.MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MaintenanceWindow_To_config_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_config_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in *config.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	out.TimeZone = in.TimeZone
+	out.MaxMigratingGlobally = (*int32)(unsafe.Pointer(in.MaxMigratingGlobally))
+	out.MaxMigratingPerNode = (*int32)(unsafe.Pointer(in.MaxMigratingPerNode))
+	out.MaxMigratingPerNamespace = (*int32)(unsafe.Pointer(in.MaxMigratingPerNamespace))
+	return nil
+}
+
+// Convert_config_MaintenanceWindow_To_v1alpha2_MaintenanceWindow is an autogenerated conversion function.
+func Convert_config_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in *config.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_config_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in, out, s)
+}
+
 func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(in *MigrationControllerArgs, out *config.MigrationControllerArgs, s conversion.Scope) error {
 	out.DryRun = in.DryRun
 	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles, s); err != nil {
@@ -788,6 +1102,9 @@ func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerA
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
 	out.ArbitrationArgs = (*config.ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.MaintenanceWindows = *(*[]config.MaintenanceWindow)(unsafe.Pointer(&in.MaintenanceWindows))
+	out.EnableNodeDrain = in.EnableNodeDrain
+	out.StuckDetection = (*config.MigrationStuckDetectionArgs)(unsafe.Pointer(in.StuckDetection))
 	return nil
 }
 
@@ -831,6 +1148,9 @@ func autoConvert_config_MigrationControllerArgs_To_v1alpha2_MigrationControllerA
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
 	out.SchedulerNames = *(*[]string)(unsafe.Pointer(&in.SchedulerNames))
 	out.ArbitrationArgs = (*ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.MaintenanceWindows = *(*[]MaintenanceWindow)(unsafe.Pointer(&in.MaintenanceWindows))
+	out.EnableNodeDrain = in.EnableNodeDrain
+	out.StuckDetection = (*MigrationStuckDetectionArgs)(unsafe.Pointer(in.StuckDetection))
 	return nil
 }
 
@@ -863,6 +1183,28 @@ func Convert_config_MigrationObjectLimiter_To_v1alpha2_MigrationObjectLimiter(in
 	return autoConvert_config_MigrationObjectLimiter_To_v1alpha2_MigrationObjectLimiter(in, out, s)
 }
 
+func autoConvert_v1alpha2_MigrationStuckDetectionArgs_To_config_MigrationStuckDetectionArgs(in *MigrationStuckDetectionArgs, out *config.MigrationStuckDetectionArgs, s conversion.Scope) error {
+	out.ReservationUnschedulableTimeout = in.ReservationUnschedulableTimeout
+	out.BoundPodMaxRestarts = in.BoundPodMaxRestarts
+	return nil
+}
+
+// Convert_v1alpha2_MigrationStuckDetectionArgs_To_config_MigrationStuckDetectionArgs is an autogenerated conversion function.
+func Convert_v1alpha2_MigrationStuckDetectionArgs_To_config_MigrationStuckDetectionArgs(in *MigrationStuckDetectionArgs, out *config.MigrationStuckDetectionArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MigrationStuckDetectionArgs_To_config_MigrationStuckDetectionArgs(in, out, s)
+}
+
+func autoConvert_config_MigrationStuckDetectionArgs_To_v1alpha2_MigrationStuckDetectionArgs(in *config.MigrationStuckDetectionArgs, out *MigrationStuckDetectionArgs, s conversion.Scope) error {
+	out.ReservationUnschedulableTimeout = in.ReservationUnschedulableTimeout
+	out.BoundPodMaxRestarts = in.BoundPodMaxRestarts
+	return nil
+}
+
+// Convert_config_MigrationStuckDetectionArgs_To_v1alpha2_MigrationStuckDetectionArgs is an autogenerated conversion function.
+func Convert_config_MigrationStuckDetectionArgs_To_v1alpha2_MigrationStuckDetectionArgs(in *config.MigrationStuckDetectionArgs, out *MigrationStuckDetectionArgs, s conversion.Scope) error {
+	return autoConvert_config_MigrationStuckDetectionArgs_To_v1alpha2_MigrationStuckDetectionArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_Namespaces_To_config_Namespaces(in *Namespaces, out *config.Namespaces, s conversion.Scope) error {
 	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
 	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
@@ -50,6 +50,78 @@ func (in *ArbitrationArgs) DeepCopy() *ArbitrationArgs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUSetFragmentationArgs) DeepCopyInto(out *CPUSetFragmentationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelectors != nil {
+		in, out := &in.PodSelectors, &out.PodSelectors
+		*out = make([]FragmentationAwarePodSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProbeCPUs != nil {
+		in, out := &in.ProbeCPUs, &out.ProbeCPUs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FragmentationThreshold != nil {
+		in, out := &in.FragmentationThreshold, &out.FragmentationThreshold
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxEvictionsPerNode != nil {
+		in, out := &in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUSetFragmentationArgs.
+func (in *CPUSetFragmentationArgs) DeepCopy() *CPUSetFragmentationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUSetFragmentationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CPUSetFragmentationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomPriorityArgs) DeepCopyInto(out *CustomPriorityArgs) {
 	*out = *in
@@ -332,6 +404,167 @@ func (in *FragmentationAwarePodSelector) DeepCopy() *FragmentationAwarePodSelect
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUFragmentationArgs) DeepCopyInto(out *GPUFragmentationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelectors != nil {
+		in, out := &in.PodSelectors, &out.PodSelectors
+		*out = make([]FragmentationAwarePodSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FragmentationThreshold != nil {
+		in, out := &in.FragmentationThreshold, &out.FragmentationThreshold
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxEvictionsPerNode != nil {
+		in, out := &in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFragmentationArgs.
+func (in *GPUFragmentationArgs) DeepCopy() *GPUFragmentationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUFragmentationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUFragmentationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceAwareArgs) DeepCopyInto(out *InterferenceAwareArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeMetricExpirationSeconds != nil {
+		in, out := &in.NodeMetricExpirationSeconds, &out.NodeMetricExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelectors != nil {
+		in, out := &in.PodSelectors, &out.PodSelectors
+		*out = make([]LowNodeLoadPodSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	out.Thresholds = in.Thresholds
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DetectorCacheTimeout != nil {
+		in, out := &in.DetectorCacheTimeout, &out.DetectorCacheTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEvictionsPerNode != nil {
+		in, out := &in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceAwareArgs.
+func (in *InterferenceAwareArgs) DeepCopy() *InterferenceAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceAwareArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterferenceAwareArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceThresholds) DeepCopyInto(out *InterferenceThresholds) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceThresholds.
+func (in *InterferenceThresholds) DeepCopy() *InterferenceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAnomalyCondition) DeepCopyInto(out *LoadAnomalyCondition) {
 	*out = *in
@@ -559,6 +792,38 @@ func (in *LowNodeLoadPodSelector) DeepCopy() *LowNodeLoadPodSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MaxMigratingGlobally != nil {
+		in, out := &in.MaxMigratingGlobally, &out.MaxMigratingGlobally
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMigratingPerNode != nil {
+		in, out := &in.MaxMigratingPerNode, &out.MaxMigratingPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMigratingPerNamespace != nil {
+		in, out := &in.MaxMigratingPerNamespace, &out.MaxMigratingPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 	*out = *in
@@ -655,6 +920,18 @@ func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 		*out = new(ArbitrationArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StuckDetection != nil {
+		in, out := &in.StuckDetection, &out.StuckDetection
+		*out = new(MigrationStuckDetectionArgs)
+		**out = **in
+	}
 	return
 }
 
@@ -698,6 +975,23 @@ func (in *MigrationObjectLimiter) DeepCopy() *MigrationObjectLimiter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStuckDetectionArgs) DeepCopyInto(out *MigrationStuckDetectionArgs) {
+	*out = *in
+	out.ReservationUnschedulableTimeout = in.ReservationUnschedulableTimeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStuckDetectionArgs.
+func (in *MigrationStuckDetectionArgs) DeepCopy() *MigrationStuckDetectionArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStuckDetectionArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Namespaces) DeepCopyInto(out *Namespaces) {
 	*out = *in
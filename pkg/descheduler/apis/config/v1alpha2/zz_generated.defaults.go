@@ -29,15 +29,22 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&CPUSetFragmentationArgs{}, func(obj interface{}) { SetObjectDefaults_CPUSetFragmentationArgs(obj.(*CPUSetFragmentationArgs)) })
 	scheme.AddTypeDefaultingFunc(&CustomPriorityArgs{}, func(obj interface{}) { SetObjectDefaults_CustomPriorityArgs(obj.(*CustomPriorityArgs)) })
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
 	scheme.AddTypeDefaultingFunc(&FragmentationAwareArgs{}, func(obj interface{}) { SetObjectDefaults_FragmentationAwareArgs(obj.(*FragmentationAwareArgs)) })
+	scheme.AddTypeDefaultingFunc(&GPUFragmentationArgs{}, func(obj interface{}) { SetObjectDefaults_GPUFragmentationArgs(obj.(*GPUFragmentationArgs)) })
+	scheme.AddTypeDefaultingFunc(&InterferenceAwareArgs{}, func(obj interface{}) { SetObjectDefaults_InterferenceAwareArgs(obj.(*InterferenceAwareArgs)) })
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
 	scheme.AddTypeDefaultingFunc(&ScaleDownBinPackArgs{}, func(obj interface{}) { SetObjectDefaults_ScaleDownBinPackArgs(obj.(*ScaleDownBinPackArgs)) })
 	return nil
 }
 
+func SetObjectDefaults_CPUSetFragmentationArgs(in *CPUSetFragmentationArgs) {
+	SetDefaults_CPUSetFragmentationArgs(in)
+}
+
 func SetObjectDefaults_CustomPriorityArgs(in *CustomPriorityArgs) {
 	SetDefaults_CustomPriorityArgs(in)
 }
@@ -50,6 +57,14 @@ func SetObjectDefaults_FragmentationAwareArgs(in *FragmentationAwareArgs) {
 	SetDefaults_FragmentationAwareArgs(in)
 }
 
+func SetObjectDefaults_GPUFragmentationArgs(in *GPUFragmentationArgs) {
+	SetDefaults_GPUFragmentationArgs(in)
+}
+
+func SetObjectDefaults_InterferenceAwareArgs(in *InterferenceAwareArgs) {
+	SetDefaults_InterferenceAwareArgs(in)
+}
+
 func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 	SetDefaults_LowNodeLoadArgs(in)
 }
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+// maxCPUSetFragmentationEvictionsPerNode limits the pods migrated on a node in one round,
+// since every migration of a cpuset pod takes the whole cores away from its workload.
+const maxCPUSetFragmentationEvictionsPerNode = 4
+
+func ValidateCPUSetFragmentationArgs(path *field.Path, args *deschedulerconfig.CPUSetFragmentationArgs) error {
+	var allErrs field.ErrorList
+
+	if args == nil {
+		allErrs = append(allErrs, field.Required(path, "CPUSetFragmentationArgs must not be nil"))
+		return allErrs.ToAggregate()
+	}
+
+	if args.ProbeCPUs <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("probeCPUs"), args.ProbeCPUs, "must be greater than 0"))
+	}
+
+	if args.FragmentationThreshold < 0 || args.FragmentationThreshold > 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("fragmentationThreshold"), args.FragmentationThreshold, "must be in the range [0, 1]"))
+	}
+
+	if args.MaxEvictionsPerNode <= 0 || args.MaxEvictionsPerNode > maxCPUSetFragmentationEvictionsPerNode {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxEvictionsPerNode"), args.MaxEvictionsPerNode,
+			fmt.Sprintf("must be in the range [1, %d]", maxCPUSetFragmentationEvictionsPerNode)))
+	}
+
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeSelector"), args.NodeSelector, err.Error()))
+		}
+	}
+
+	for i, v := range args.PodSelectors {
+		if v.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(v.Selector); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("podSelectors").Index(i), v, err.Error()))
+			}
+		}
+	}
+
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
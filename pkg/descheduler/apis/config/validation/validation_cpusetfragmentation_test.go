@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateCPUSetFragmentationArgs(t *testing.T) {
+	validArgs := func() *deschedulerconfig.CPUSetFragmentationArgs {
+		return &deschedulerconfig.CPUSetFragmentationArgs{
+			ProbeCPUs:              8,
+			FragmentationThreshold: 0.3,
+			MaxEvictionsPerNode:    2,
+		}
+	}
+	testCases := []struct {
+		name          string
+		args          func() *deschedulerconfig.CPUSetFragmentationArgs
+		expectedError string
+	}{
+		{
+			name: "valid args",
+			args: validArgs,
+		},
+		{
+			name: "nil args",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				return nil
+			},
+			expectedError: "CPUSetFragmentationArgs must not be nil",
+		},
+		{
+			name: "invalid probeCPUs",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.ProbeCPUs = 0
+				return args
+			},
+			expectedError: "probeCPUs",
+		},
+		{
+			name: "fragmentationThreshold out of range",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.FragmentationThreshold = 1.5
+				return args
+			},
+			expectedError: "must be in the range [0, 1]",
+		},
+		{
+			name: "invalid maxEvictionsPerNode",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.MaxEvictionsPerNode = -1
+				return args
+			},
+			expectedError: "maxEvictionsPerNode",
+		},
+		{
+			name: "too many maxEvictionsPerNode",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.MaxEvictionsPerNode = 5
+				return args
+			},
+			expectedError: "must be in the range [1, 4]",
+		},
+		{
+			name: "invalid node selector",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.NodeSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Operator: "invalid-op",
+						},
+					},
+				}
+				return args
+			},
+			expectedError: "nodeSelector",
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: func() *deschedulerconfig.CPUSetFragmentationArgs {
+				args := validArgs()
+				args.EvictableNamespaces = &deschedulerconfig.Namespaces{
+					Include: []string{"a"},
+					Exclude: []string{"b"},
+				}
+				return args
+			},
+			expectedError: "only one of Include/Exclude namespaces can be set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCPUSetFragmentationArgs(nil, tc.args())
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateInterferenceAwareArgs(path *field.Path, args *deschedulerconfig.InterferenceAwareArgs) error {
+	var allErrs field.ErrorList
+
+	if args == nil {
+		allErrs = append(allErrs, field.Required(path, "InterferenceAwareArgs must not be nil"))
+		return allErrs.ToAggregate()
+	}
+
+	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("nodeMetricExpirationSeconds"), *args.NodeMetricExpirationSeconds, "must be greater than 0"))
+	}
+
+	thresholdsPath := path.Child("thresholds")
+	for _, v := range []struct {
+		name       string
+		percentage deschedulerconfig.Percentage
+	}{
+		{name: "cpuSomePressure", percentage: args.Thresholds.CPUSomePressure},
+		{name: "cpuFullPressure", percentage: args.Thresholds.CPUFullPressure},
+		{name: "memorySomePressure", percentage: args.Thresholds.MemorySomePressure},
+		{name: "memoryFullPressure", percentage: args.Thresholds.MemoryFullPressure},
+		{name: "ioSomePressure", percentage: args.Thresholds.IOSomePressure},
+		{name: "ioFullPressure", percentage: args.Thresholds.IOFullPressure},
+	} {
+		if v.percentage < 0 || v.percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child(v.name), v.percentage, "percentage must be in the range [0, 100]"))
+		}
+	}
+	if args.Thresholds.CPI < 0 {
+		allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("cpi"), args.Thresholds.CPI, "must not be negative"))
+	}
+
+	if args.AnomalyCondition != nil && args.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("anomalyCondition", "consecutiveAbnormalities"), args.AnomalyCondition.ConsecutiveAbnormalities, "must be greater than 0"))
+	}
+
+	if args.MaxEvictionsPerNode <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxEvictionsPerNode"), args.MaxEvictionsPerNode, "must be greater than 0"))
+	}
+
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeSelector"), args.NodeSelector, err.Error()))
+		}
+	}
+
+	for i, v := range args.PodSelectors {
+		if v.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(v.Selector); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("podSelectors").Index(i), v, err.Error()))
+			}
+		}
+	}
+
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateInterferenceAwareArgs(t *testing.T) {
+	validArgs := func() *deschedulerconfig.InterferenceAwareArgs {
+		return &deschedulerconfig.InterferenceAwareArgs{
+			NodeMetricExpirationSeconds: ptr.To[int64](180),
+			Thresholds: deschedulerconfig.InterferenceThresholds{
+				CPUSomePressure: 20,
+				CPI:             2.5,
+			},
+			AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				ConsecutiveAbnormalities: 5,
+			},
+			MaxEvictionsPerNode: 1,
+		}
+	}
+	testCases := []struct {
+		name          string
+		args          func() *deschedulerconfig.InterferenceAwareArgs
+		expectedError string
+	}{
+		{
+			name: "valid args",
+			args: validArgs,
+		},
+		{
+			name: "nil args",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				return nil
+			},
+			expectedError: "InterferenceAwareArgs must not be nil",
+		},
+		{
+			name: "invalid nodeMetricExpirationSeconds",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.NodeMetricExpirationSeconds = ptr.To[int64](0)
+				return args
+			},
+			expectedError: "nodeMetricExpirationSeconds",
+		},
+		{
+			name: "pressure threshold out of range",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.Thresholds.IOFullPressure = 120
+				return args
+			},
+			expectedError: "thresholds.ioFullPressure",
+		},
+		{
+			name: "negative cpi threshold",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.Thresholds.CPI = -1
+				return args
+			},
+			expectedError: "thresholds.cpi",
+		},
+		{
+			name: "invalid anomalyCondition",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.AnomalyCondition.ConsecutiveAbnormalities = 0
+				return args
+			},
+			expectedError: "anomalyCondition.consecutiveAbnormalities",
+		},
+		{
+			name: "invalid maxEvictionsPerNode",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.MaxEvictionsPerNode = 0
+				return args
+			},
+			expectedError: "maxEvictionsPerNode",
+		},
+		{
+			name: "invalid node selector",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.NodeSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Operator: "invalid-op",
+						},
+					},
+				}
+				return args
+			},
+			expectedError: "nodeSelector",
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: func() *deschedulerconfig.InterferenceAwareArgs {
+				args := validArgs()
+				args.EvictableNamespaces = &deschedulerconfig.Namespaces{
+					Include: []string{"a"},
+					Exclude: []string{"b"},
+				}
+				return args
+			},
+			expectedError: "only one of Include/Exclude namespaces can be set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateInterferenceAwareArgs(nil, tc.args())
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
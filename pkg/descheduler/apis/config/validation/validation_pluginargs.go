@@ -18,7 +18,9 @@ package validation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -105,8 +107,50 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobTTL"), args.DefaultJobTTL, "defaultJobTTL should be positive or zero"))
 	}
 
+	for i := range args.MaintenanceWindows {
+		allErrs = append(allErrs, validateMaintenanceWindow(path.Child("maintenanceWindows").Index(i), &args.MaintenanceWindows[i])...)
+	}
+
+	if args.StuckDetection != nil {
+		if args.StuckDetection.ReservationUnschedulableTimeout.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("stuckDetection", "reservationUnschedulableTimeout"), args.StuckDetection.ReservationUnschedulableTimeout, "reservationUnschedulableTimeout should be positive or zero"))
+		}
+		if args.StuckDetection.BoundPodMaxRestarts < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("stuckDetection", "boundPodMaxRestarts"), args.StuckDetection.BoundPodMaxRestarts, "boundPodMaxRestarts should be greater or equal 0"))
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
+
+func validateMaintenanceWindow(path *field.Path, window *deschedulerconfig.MaintenanceWindow) field.ErrorList {
+	var allErrs field.ErrorList
+	spec := window.Schedule
+	if window.TimeZone != "" {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), window.TimeZone, fmt.Sprintf("unknown time zone, err: %v", err)))
+		}
+		spec = fmt.Sprintf("CRON_TZ=%s %s", window.TimeZone, window.Schedule)
+	}
+	if len(allErrs) == 0 {
+		if _, err := cron.ParseStandard(spec); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("schedule"), window.Schedule, fmt.Sprintf("invalid cron schedule, err: %v", err)))
+		}
+	}
+	if window.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), window.Duration, "duration should be positive"))
+	}
+	if window.MaxMigratingGlobally != nil && *window.MaxMigratingGlobally < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxMigratingGlobally"), *window.MaxMigratingGlobally, "maxMigratingGlobally should be greater or equal 0"))
+	}
+	if window.MaxMigratingPerNode != nil && *window.MaxMigratingPerNode < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxMigratingPerNode"), *window.MaxMigratingPerNode, "maxMigratingPerNode should be greater or equal 0"))
+	}
+	if window.MaxMigratingPerNamespace != nil && *window.MaxMigratingPerNamespace < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxMigratingPerNamespace"), *window.MaxMigratingPerNamespace, "maxMigratingPerNamespace should be greater or equal 0"))
+	}
+	return allErrs
+}
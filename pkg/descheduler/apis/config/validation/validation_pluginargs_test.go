@@ -322,3 +322,130 @@ func intstrPtr(val int) *intstr.IntOrString {
 	value := intstr.FromInt(val)
 	return &value
 }
+
+func TestValidateMigrationControllerArgs_MaintenanceWindows(t *testing.T) {
+	testCases := []struct {
+		name     string
+		window   deschedulerconfig.MaintenanceWindow
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name: "valid window",
+			window: deschedulerconfig.MaintenanceWindow{
+				Name:                 "night",
+				Schedule:             "0 22 * * *",
+				Duration:             metav1.Duration{Duration: 8 * time.Hour},
+				TimeZone:             "Asia/Shanghai",
+				MaxMigratingGlobally: int32Ptr(10),
+			},
+		},
+		{
+			name: "invalid schedule",
+			window: deschedulerconfig.MaintenanceWindow{
+				Schedule: "0 25 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			wantErr:  true,
+			errorMsg: "invalid cron schedule",
+		},
+		{
+			name: "unknown time zone",
+			window: deschedulerconfig.MaintenanceWindow{
+				Schedule: "0 22 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+				TimeZone: "Unknown/Zone",
+			},
+			wantErr:  true,
+			errorMsg: "unknown time zone",
+		},
+		{
+			name: "zero duration",
+			window: deschedulerconfig.MaintenanceWindow{
+				Schedule: "0 22 * * *",
+			},
+			wantErr:  true,
+			errorMsg: "duration should be positive",
+		},
+		{
+			name: "negative maxMigratingPerNode",
+			window: deschedulerconfig.MaintenanceWindow{
+				Schedule:            "0 22 * * *",
+				Duration:            metav1.Duration{Duration: time.Hour},
+				MaxMigratingPerNode: int32Ptr(-1),
+			},
+			wantErr:  true,
+			errorMsg: "maxMigratingPerNode should be greater or equal 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.MaintenanceWindows = []deschedulerconfig.MaintenanceWindow{tc.window}
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMigrationControllerArgs_StuckDetection(t *testing.T) {
+	testCases := []struct {
+		name           string
+		stuckDetection *deschedulerconfig.MigrationStuckDetectionArgs
+		wantErr        bool
+		errorMsg       string
+	}{
+		{
+			name: "disabled",
+			stuckDetection: &deschedulerconfig.MigrationStuckDetectionArgs{
+				ReservationUnschedulableTimeout: metav1.Duration{Duration: 0},
+				BoundPodMaxRestarts:             0,
+			},
+		},
+		{
+			name: "negative reservationUnschedulableTimeout",
+			stuckDetection: &deschedulerconfig.MigrationStuckDetectionArgs{
+				ReservationUnschedulableTimeout: metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr:  true,
+			errorMsg: "reservationUnschedulableTimeout should be positive or zero",
+		},
+		{
+			name: "negative boundPodMaxRestarts",
+			stuckDetection: &deschedulerconfig.MigrationStuckDetectionArgs{
+				BoundPodMaxRestarts: -1,
+			},
+			wantErr:  true,
+			errorMsg: "boundPodMaxRestarts should be greater or equal 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			assert.NoError(t, ValidateMigrationControllerArgs(nil, args))
+			args.StuckDetection = tc.stuckDetection
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
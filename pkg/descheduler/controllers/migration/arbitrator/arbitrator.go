@@ -39,6 +39,9 @@ import (
 const (
 	AnnotationPassedArbitration = "descheduler.koordinator.sh/passed-arbitration"
 	AnnotationPodArbitrating    = "descheduler.koordinator.sh/pod-arbitrating"
+	// AnnotationMaintenanceWindowBlocked records the time the PodMigrationJob was held by the closed maintenance
+	// windows before passing the arbitration, which does not count towards the TTL of the job.
+	AnnotationMaintenanceWindowBlocked = "descheduler.koordinator.sh/maintenance-window-blocked"
 )
 
 var enqueueLog = klog.Background().WithName("eventHandler").WithName("arbitratorImpl")
@@ -52,6 +55,8 @@ type Arbitrator interface {
 	MigrationFilter
 	AddPodMigrationJob(job *v1alpha1.PodMigrationJob)
 	DeletePodMigrationJob(job *v1alpha1.PodMigrationJob)
+	// GetMaintenanceWindowBlocked returns the time the PodMigrationJob is held by the closed maintenance windows.
+	GetMaintenanceWindowBlocked(job *v1alpha1.PodMigrationJob) time.Duration
 }
 
 // SortFn stably sorts PodMigrationJobs slice based on a certain strategy. Users
@@ -62,8 +67,9 @@ type arbitratorImpl struct {
 	waitingCollection map[types.UID]*v1alpha1.PodMigrationJob
 	interval          time.Duration
 
-	sorts  []SortFn
-	filter *filter
+	sorts          []SortFn
+	filter         *filter
+	windowBlocking windowBlocking
 
 	client        client.Client
 	eventRecorder events.EventRecorder
@@ -82,7 +88,9 @@ func New(args *config.MigrationControllerArgs, options Options) (Arbitrator, err
 		interval:          args.ArbitrationArgs.Interval.Duration,
 		sorts: []SortFn{
 			SortJobsByCreationTime(),
-			SortJobsByPod(sorter.PodSorter().Sort),
+			SortJobsByPod(func(pods []*corev1.Pod) {
+				sorter.PodSorterWithMigrationCost(newMigrationCostModel(f.controllerFinder)).Sort(pods)
+			}),
 			SortJobsByController(),
 			SortJobsByMigratingNum(options.Client),
 		},
@@ -111,6 +119,23 @@ func (a *arbitratorImpl) AddPodMigrationJob(job *v1alpha1.PodMigrationJob) {
 // It is safe to be called concurrently by multiple goroutines.
 func (a *arbitratorImpl) DeletePodMigrationJob(job *v1alpha1.PodMigrationJob) {
 	a.filter.removeJobPassedArbitration(job.UID)
+	a.mu.Lock()
+	a.windowBlocking.forget(job.UID)
+	a.mu.Unlock()
+}
+
+// GetMaintenanceWindowBlocked returns the time the PodMigrationJob is held by the closed maintenance windows.
+// It is safe to be called concurrently by multiple goroutines.
+func (a *arbitratorImpl) GetMaintenanceWindowBlocked(job *v1alpha1.PodMigrationJob) time.Duration {
+	if s, ok := job.Annotations[AnnotationMaintenanceWindowBlocked]; ok {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, waiting := a.waitingCollection[job.UID]
+	return a.windowBlocking.blockedDuration(job, a.filter.clock.Now(), waiting)
 }
 
 // Start starts the goroutine to arbitrate jobs periodically.
@@ -177,6 +202,13 @@ func (a *arbitratorImpl) updatePassedJob(job *v1alpha1.PodMigrationJob) {
 		job.Annotations = map[string]string{}
 	}
 	job.Annotations[AnnotationPassedArbitration] = "true"
+	// persist the time held by the maintenance windows to exclude it from the TTL
+	a.mu.Lock()
+	blocked := a.windowBlocking.blockedDuration(job, a.filter.clock.Now(), false)
+	a.mu.Unlock()
+	if blocked > 0 {
+		job.Annotations[AnnotationMaintenanceWindowBlocked] = blocked.String()
+	}
 	err := a.client.Update(context.TODO(), job)
 	if err != nil {
 		klog.ErrorS(err, "failed to update job", "job", klog.KObj(job))
@@ -185,6 +217,7 @@ func (a *arbitratorImpl) updatePassedJob(job *v1alpha1.PodMigrationJob) {
 		// remove job from the waitingCollection
 		a.mu.Lock()
 		delete(a.waitingCollection, job.UID)
+		a.windowBlocking.forget(job.UID)
 		a.mu.Unlock()
 	}
 }
@@ -193,6 +226,18 @@ func (a *arbitratorImpl) updatePassedJob(job *v1alpha1.PodMigrationJob) {
 func (a *arbitratorImpl) doOnceArbitrate() {
 	// copy jobs from waitingCollection
 	jobs := a.copyJobs()
+	// hold the jobs pending until a maintenance window opens, and the held time does not count towards the TTL
+	now := a.filter.clock.Now()
+	if !a.filter.maintenanceWindows.isOpen(now) {
+		a.mu.Lock()
+		a.windowBlocking.hold(now)
+		a.mu.Unlock()
+		klog.V(5).InfoS("Arbitration is held since no maintenance window is open", "waitingJobs", len(jobs))
+		return
+	}
+	a.mu.Lock()
+	a.windowBlocking.release(jobs, now)
+	a.mu.Unlock()
 	if len(jobs) == 0 {
 		return
 	}
@@ -240,6 +285,7 @@ func (a *arbitratorImpl) updateFailedJob(job *v1alpha1.PodMigrationJob, pod *cor
 	// delete from waitingCollection
 	a.mu.Lock()
 	delete(a.waitingCollection, job.UID)
+	a.windowBlocking.forget(job.UID)
 	a.mu.Unlock()
 }
 
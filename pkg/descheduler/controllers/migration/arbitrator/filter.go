@@ -56,9 +56,10 @@ type filter struct {
 	retryablePodFilter    framework.FilterFunc
 	defaultFilterPlugin   framework.FilterPlugin
 
-	args              *deschedulerconfig.MigrationControllerArgs
-	controllerFinder  controllerfinder.Interface
-	skipEvictionGates map[deschedulerconfig.EvictionGate]struct{}
+	args               *deschedulerconfig.MigrationControllerArgs
+	controllerFinder   controllerfinder.Interface
+	skipEvictionGates  map[deschedulerconfig.EvictionGate]struct{}
+	maintenanceWindows maintenanceWindows
 
 	arbitratedPodMigrationJobs map[types.UID]bool
 	arbitratedMapLock          sync.Mutex
@@ -88,6 +89,10 @@ func newFilter(args *deschedulerconfig.MigrationControllerArgs, handle framework
 	if err != nil {
 		return nil, err
 	}
+	windows, err := newMaintenanceWindows(args.MaintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
 	f := &filter{
 		client:                     options.Manager.GetClient(),
 		args:                       args,
@@ -95,6 +100,7 @@ func newFilter(args *deschedulerconfig.MigrationControllerArgs, handle framework
 		clock:                      clock.RealClock{},
 		arbitratedPodMigrationJobs: map[types.UID]bool{},
 		skipEvictionGates:          newEvictionGateSet(args.SkipEvictionGates),
+		maintenanceWindows:         windows,
 	}
 	if err := f.initFilters(args, handle); err != nil {
 		return nil, err
@@ -293,11 +299,33 @@ func (f *filter) existingPodMigrationJob(pod *corev1.Pod, expectedPhaseContexts
 	return existing
 }
 
+func (f *filter) getMaxMigratingGlobally() *int32 {
+	if window := f.maintenanceWindows.active(f.clock.Now()); window != nil && window.MaxMigratingGlobally != nil {
+		return window.MaxMigratingGlobally
+	}
+	return f.args.MaxMigratingGlobally
+}
+
+func (f *filter) getMaxMigratingPerNode() *int32 {
+	if window := f.maintenanceWindows.active(f.clock.Now()); window != nil && window.MaxMigratingPerNode != nil {
+		return window.MaxMigratingPerNode
+	}
+	return f.args.MaxMigratingPerNode
+}
+
+func (f *filter) getMaxMigratingPerNamespace() *int32 {
+	if window := f.maintenanceWindows.active(f.clock.Now()); window != nil && window.MaxMigratingPerNamespace != nil {
+		return window.MaxMigratingPerNamespace
+	}
+	return f.args.MaxMigratingPerNamespace
+}
+
 func (f *filter) filterMaxMigratingGlobally(pod *corev1.Pod) bool {
 	if f.isEvictionGateSkipped(deschedulerconfig.EvictionGateMaxMigratingGlobally) {
 		return true
 	}
-	if f.args.MaxMigratingGlobally == nil || *f.args.MaxMigratingGlobally <= 0 {
+	maxMigrating := f.getMaxMigratingGlobally()
+	if maxMigrating == nil || *maxMigrating <= 0 {
 		return true
 	}
 
@@ -318,7 +346,7 @@ func (f *filter) filterMaxMigratingGlobally(pod *corev1.Pod) bool {
 		return true
 	}, expectedPhaseContexts...)
 
-	maxMigratingGlobally := int(*f.args.MaxMigratingGlobally)
+	maxMigratingGlobally := int(*maxMigrating)
 	exceeded := count >= maxMigratingGlobally
 	if exceeded {
 		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod),
@@ -331,7 +359,8 @@ func (f *filter) filterMaxMigratingPerNode(pod *corev1.Pod) bool {
 	if f.isEvictionGateSkipped(deschedulerconfig.EvictionGateMaxMigratingPerNode) {
 		return true
 	}
-	if pod.Spec.NodeName == "" || f.args.MaxMigratingPerNode == nil || *f.args.MaxMigratingPerNode <= 0 {
+	maxMigrating := f.getMaxMigratingPerNode()
+	if pod.Spec.NodeName == "" || maxMigrating == nil || *maxMigrating <= 0 {
 		return true
 	}
 
@@ -363,7 +392,7 @@ func (f *filter) filterMaxMigratingPerNode(pod *corev1.Pod) bool {
 		}
 	}
 
-	maxMigratingPerNode := int(*f.args.MaxMigratingPerNode)
+	maxMigratingPerNode := int(*maxMigrating)
 	exceeded := count >= maxMigratingPerNode
 	if exceeded {
 		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod),
@@ -376,7 +405,8 @@ func (f *filter) filterMaxMigratingPerNamespace(pod *corev1.Pod) bool {
 	if f.isEvictionGateSkipped(deschedulerconfig.EvictionGateMaxMigratingPerNamespace) {
 		return true
 	}
-	if f.args.MaxMigratingPerNamespace == nil || *f.args.MaxMigratingPerNamespace <= 0 {
+	maxMigrating := f.getMaxMigratingPerNamespace()
+	if maxMigrating == nil || *maxMigrating <= 0 {
 		return true
 	}
 
@@ -397,7 +427,7 @@ func (f *filter) filterMaxMigratingPerNamespace(pod *corev1.Pod) bool {
 		return true
 	}, expectedPhaseContexts...)
 
-	maxMigratingPerNamespace := int(*f.args.MaxMigratingPerNamespace)
+	maxMigratingPerNamespace := int(*maxMigrating)
 	exceeded := count >= maxMigratingPerNamespace
 	if exceeded {
 		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod),
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package arbitrator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+type maintenanceWindow struct {
+	*deschedulerconfig.MaintenanceWindow
+	schedule cron.Schedule
+}
+
+// maintenanceWindows determines whether the PodMigrationJobs can be executed at the moment,
+// and which migration limits take effect.
+type maintenanceWindows []maintenanceWindow
+
+func newMaintenanceWindows(windows []deschedulerconfig.MaintenanceWindow) (maintenanceWindows, error) {
+	var result maintenanceWindows
+	for i := range windows {
+		window := &windows[i]
+		schedule, err := parseMaintenanceWindowSchedule(window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q, err: %w", window.Name, err)
+		}
+		result = append(result, maintenanceWindow{MaintenanceWindow: window, schedule: schedule})
+	}
+	return result, nil
+}
+
+// parseMaintenanceWindowSchedule parses the cron schedule of the window in its time zone.
+func parseMaintenanceWindowSchedule(window *deschedulerconfig.MaintenanceWindow) (cron.Schedule, error) {
+	spec := window.Schedule
+	if window.TimeZone != "" {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			return nil, err
+		}
+		spec = fmt.Sprintf("CRON_TZ=%s %s", window.TimeZone, window.Schedule)
+	}
+	return cron.ParseStandard(spec)
+}
+
+// active returns the first window which is open at the given time.
+// It returns nil if no window is open.
+func (w maintenanceWindows) active(now time.Time) *deschedulerconfig.MaintenanceWindow {
+	for _, window := range w {
+		// the window is open if it started within the last duration
+		start := window.schedule.Next(now.Add(-window.Duration.Duration))
+		if !start.IsZero() && !start.After(now) {
+			return window.MaintenanceWindow
+		}
+	}
+	return nil
+}
+
+// isOpen checks if the PodMigrationJobs can be executed at the given time.
+func (w maintenanceWindows) isOpen(now time.Time) bool {
+	return len(w) == 0 || w.active(now) != nil
+}
+
+// windowBlocking accounts the time the waiting PodMigrationJobs are held by the closed maintenance windows,
+// which is excluded from the TTL of the jobs.
+type windowBlocking struct {
+	// closedSince is the time the windows are found closed, zero if the windows are open
+	closedSince time.Time
+	// blocked is the time each waiting job has been held by the previously closed windows
+	blocked map[types.UID]time.Duration
+}
+
+// hold starts accounting the blocked time if the windows just closed.
+func (b *windowBlocking) hold(now time.Time) {
+	if b.closedSince.IsZero() {
+		b.closedSince = now
+	}
+}
+
+// release stops accounting the blocked time for the waiting jobs since the windows open.
+func (b *windowBlocking) release(jobs []*v1alpha1.PodMigrationJob, now time.Time) {
+	if b.closedSince.IsZero() {
+		return
+	}
+	for _, job := range jobs {
+		if d := b.sinceClosed(job, now); d > 0 {
+			if b.blocked == nil {
+				b.blocked = map[types.UID]time.Duration{}
+			}
+			b.blocked[job.UID] += d
+		}
+	}
+	b.closedSince = time.Time{}
+}
+
+// blockedDuration returns the time the job has been held by the closed windows.
+func (b *windowBlocking) blockedDuration(job *v1alpha1.PodMigrationJob, now time.Time, waiting bool) time.Duration {
+	d := b.blocked[job.UID]
+	if waiting && !b.closedSince.IsZero() {
+		d += b.sinceClosed(job, now)
+	}
+	return d
+}
+
+func (b *windowBlocking) sinceClosed(job *v1alpha1.PodMigrationJob, now time.Time) time.Duration {
+	since := b.closedSince
+	if job.CreationTimestamp.Time.After(since) {
+		since = job.CreationTimestamp.Time
+	}
+	return now.Sub(since)
+}
+
+func (b *windowBlocking) forget(uid types.UID) {
+	delete(b.blocked, uid)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package arbitrator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	windows, err := newMaintenanceWindows([]config.MaintenanceWindow{
+		{
+			Name:                 "night",
+			Schedule:             "0 22 * * *",
+			Duration:             metav1.Duration{Duration: 8 * time.Hour},
+			TimeZone:             "Asia/Shanghai",
+			MaxMigratingGlobally: ptr.To[int32](10),
+		},
+		{
+			Name:                 "business-hours",
+			Schedule:             "0 9 * * 1-5",
+			Duration:             metav1.Duration{Duration: 9 * time.Hour},
+			TimeZone:             "Asia/Shanghai",
+			MaxMigratingGlobally: ptr.To[int32](1),
+		},
+	})
+	assert.NoError(t, err)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantWindow string
+	}{
+		{
+			name:       "in the night window",
+			now:        time.Date(2024, 1, 2, 23, 0, 0, 0, loc),
+			wantWindow: "night",
+		},
+		{
+			name:       "in the night window across the day",
+			now:        time.Date(2024, 1, 3, 5, 59, 0, 0, loc),
+			wantWindow: "night",
+		},
+		{
+			name:       "in the business hours",
+			now:        time.Date(2024, 1, 3, 10, 0, 0, 0, loc),
+			wantWindow: "business-hours",
+		},
+		{
+			name: "between the windows",
+			now:  time.Date(2024, 1, 3, 7, 0, 0, 0, loc),
+		},
+		{
+			name: "business hours window is closed at weekends",
+			now:  time.Date(2024, 1, 6, 10, 0, 0, 0, loc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := windows.active(tt.now)
+			if tt.wantWindow == "" {
+				assert.Nil(t, window)
+				assert.False(t, windows.isOpen(tt.now))
+				return
+			}
+			assert.NotNil(t, window)
+			assert.Equal(t, tt.wantWindow, window.Name)
+			assert.True(t, windows.isOpen(tt.now))
+		})
+	}
+
+	var noWindows maintenanceWindows
+	assert.True(t, noWindows.isOpen(time.Now()))
+
+	_, err = newMaintenanceWindows([]config.MaintenanceWindow{{Name: "invalid", Schedule: "invalid"}})
+	assert.Error(t, err)
+}
+
+func TestFilterMaxMigratingInMaintenanceWindow(t *testing.T) {
+	windows, err := newMaintenanceWindows([]config.MaintenanceWindow{
+		{
+			Name:                 "always",
+			Schedule:             "* * * * *",
+			Duration:             metav1.Duration{Duration: time.Hour},
+			MaxMigratingGlobally: ptr.To[int32](1),
+		},
+	})
+	assert.NoError(t, err)
+	f := &filter{
+		args: &config.MigrationControllerArgs{
+			MaxMigratingGlobally:     ptr.To[int32](10),
+			MaxMigratingPerNamespace: ptr.To[int32](5),
+		},
+		maintenanceWindows: windows,
+	}
+	assert.Equal(t, int32(1), *f.getMaxMigratingGlobally())
+	assert.Equal(t, int32(5), *f.getMaxMigratingPerNamespace())
+	assert.Nil(t, f.getMaxMigratingPerNode())
+
+	f.maintenanceWindows = nil
+	assert.Equal(t, int32(10), *f.getMaxMigratingGlobally())
+}
+
+func TestDoOnceArbitrateOutsideMaintenanceWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		schedule   string
+		wantPassed bool
+	}{
+		{
+			name:       "window is open",
+			schedule:   "* * * * *",
+			wantPassed: true,
+		},
+		{
+			name:       "window never opens",
+			schedule:   "0 0 30 2 *",
+			wantPassed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			windows, err := newMaintenanceWindows([]config.MaintenanceWindow{
+				{Schedule: tt.schedule, Duration: metav1.Duration{Duration: time.Hour}},
+			})
+			assert.NoError(t, err)
+			a := &arbitratorImpl{
+				waitingCollection: map[types.UID]*v1alpha1.PodMigrationJob{},
+				filter: &filter{
+					nonRetryablePodFilter: func(pod *corev1.Pod) bool {
+						return true
+					},
+					retryablePodFilter: func(pod *corev1.Pod) bool {
+						return true
+					},
+					arbitratedPodMigrationJobs: map[types.UID]bool{},
+					maintenanceWindows:         windows,
+				},
+				client:        fakeClient,
+				mu:            sync.Mutex{},
+				eventRecorder: &events.FakeRecorder{},
+			}
+
+			pod := makePod("test-pod", 0, extension.QoSNone, corev1.PodQOSBestEffort, time.Now())
+			job := makePodMigrationJob("test-job", time.Now(), pod)
+			assert.Nil(t, fakeClient.Create(context.TODO(), pod))
+			assert.Nil(t, fakeClient.Create(context.TODO(), job))
+			a.AddPodMigrationJob(job)
+
+			a.doOnceArbitrate()
+			assert.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, job))
+			assert.Equal(t, tt.wantPassed, job.Annotations[AnnotationPassedArbitration] == "true")
+			_, waiting := a.waitingCollection[job.UID]
+			assert.Equal(t, !tt.wantPassed, waiting)
+		})
+	}
+}
+
+func TestWindowBlocking(t *testing.T) {
+	now := time.Now()
+	job1 := &v1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{UID: "job-1", CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)}},
+	}
+	job2 := &v1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{UID: "job-2", CreationTimestamp: metav1.Time{Time: now.Add(20 * time.Minute)}},
+	}
+
+	b := &windowBlocking{}
+	b.hold(now)
+	b.hold(now.Add(10 * time.Minute)) // keep the time the windows closed
+	assert.Equal(t, 30*time.Minute, b.blockedDuration(job1, now.Add(30*time.Minute), true))
+	assert.Equal(t, 10*time.Minute, b.blockedDuration(job2, now.Add(30*time.Minute), true))
+	assert.Equal(t, time.Duration(0), b.blockedDuration(job1, now.Add(30*time.Minute), false))
+
+	b.release([]*v1alpha1.PodMigrationJob{job1, job2}, now.Add(time.Hour))
+	assert.Equal(t, time.Hour, b.blockedDuration(job1, now.Add(2*time.Hour), true))
+	assert.Equal(t, 40*time.Minute, b.blockedDuration(job2, now.Add(2*time.Hour), true))
+
+	// the windows close again
+	b.hold(now.Add(2 * time.Hour))
+	assert.Equal(t, 90*time.Minute, b.blockedDuration(job1, now.Add(150*time.Minute), true))
+
+	b.forget(job1.UID)
+	b.release([]*v1alpha1.PodMigrationJob{job2}, now.Add(3*time.Hour))
+	assert.Equal(t, time.Duration(0), b.blockedDuration(job1, now.Add(3*time.Hour), true))
+	assert.Equal(t, 100*time.Minute, b.blockedDuration(job2, now.Add(3*time.Hour), true))
+}
@@ -29,7 +29,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 )
 
@@ -67,6 +69,32 @@ func SortJobsByPod(sorter func(pods []*corev1.Pod)) SortFn {
 	}
 }
 
+// newMigrationCostModel returns a MigrationCostModel considering the replicas of the pod owner besides the default factors.
+// The replicas are cached in the model, so the model should be rebuilt in each round of sorting.
+func newMigrationCostModel(finder controllerfinder.Interface) sorter.MigrationCostModel {
+	factors := sorter.DefaultMigrationCostFactors()
+	if finder != nil {
+		replicasOfOwner := map[types.UID]int32{}
+		factors = append(factors, sorter.OwnerReplicasCostFactor(func(pod *corev1.Pod) (int32, error) {
+			owner := metav1.GetControllerOf(pod)
+			if owner == nil {
+				return 0, nil
+			}
+			if replicas, ok := replicasOfOwner[owner.UID]; ok {
+				return replicas, nil
+			}
+			replicas, err := finder.GetExpectedScaleForPod(pod)
+			if err != nil {
+				klog.V(4).InfoS("failed to get expected scale of pod owner", "pod", klog.KObj(pod), "err", err)
+				return 0, err
+			}
+			replicasOfOwner[owner.UID] = replicas
+			return replicas, nil
+		}))
+	}
+	return sorter.NewMigrationCostModel(factors...)
+}
+
 // SortJobsByCreationTime returns a SortFn that stably sorts PodMigrationJobs by create time.
 func SortJobsByCreationTime() SortFn {
 	return func(jobs []*v1alpha1.PodMigrationJob, podOfJob map[*v1alpha1.PodMigrationJob]*corev1.Pod) []*v1alpha1.PodMigrationJob {
@@ -720,3 +720,39 @@ func (f *fieldIndexFakeClient) RESTMapper() meta.RESTMapper {
 func (f *fieldIndexFakeClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
 	return f.c.Apply(ctx, obj, opts...)
 }
+
+type countingControllerFinder struct {
+	fakeControllerFinder
+	calls int
+}
+
+func (f *countingControllerFinder) GetExpectedScaleForPod(pod *corev1.Pod) (int32, error) {
+	f.calls++
+	return f.fakeControllerFinder.GetExpectedScaleForPod(pod)
+}
+
+func TestNewMigrationCostModel(t *testing.T) {
+	ownerRef := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "test-rs",
+		UID:        "test-rs-uid",
+		Controller: ptr.To[bool](true),
+	}
+	creationTime := time.Now()
+	ownedPod := makePod("test-pod-1", 0, extension.QoSNone, corev1.PodQOSBestEffort, creationTime)
+	ownedPod.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	anotherOwnedPod := makePod("test-pod-2", 0, extension.QoSNone, corev1.PodQOSBestEffort, creationTime)
+	anotherOwnedPod.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	barePod := makePod("test-pod-3", 0, extension.QoSNone, corev1.PodQOSBestEffort, creationTime)
+
+	finder := &countingControllerFinder{fakeControllerFinder: fakeControllerFinder{replicas: 1}}
+	model := newMigrationCostModel(finder)
+	// only the owner replicas are considered expensive, the cost is 2*1 / (3+2+1+1+2) * 100
+	assert.Equal(t, int64(22), model.MigrationCost(ownedPod))
+	assert.Equal(t, int64(22), model.MigrationCost(anotherOwnedPod))
+	assert.Equal(t, int64(0), model.MigrationCost(barePod))
+	assert.Equal(t, 1, finder.calls, "the replicas of the owner should be cached")
+
+	assert.Equal(t, int64(0), newMigrationCostModel(nil).MigrationCost(ownedPod))
+}
@@ -34,6 +34,8 @@ type JobContext struct {
 	Annotations map[string]string
 	Timeout     *time.Duration
 	Mode        sev1alpha1.PodMigrationJobMode
+	// ReservationOptions is used by the ReservationFirst jobs to reserve resources on the target node
+	ReservationOptions *sev1alpha1.PodMigrateReservationOptions
 }
 
 func WithContext(ctx context.Context, jobCtx *JobContext) context.Context {
@@ -73,5 +75,8 @@ func (c *JobContext) ApplyTo(job *sev1alpha1.PodMigrationJob) error {
 	if c.Mode != "" {
 		job.Spec.Mode = c.Mode
 	}
+	if c.ReservationOptions != nil {
+		job.Spec.ReservationOptions = c.ReservationOptions.DeepCopy()
+	}
 	return nil
 }
@@ -38,6 +38,11 @@ func TestJobContext(t *testing.T) {
 		},
 		Mode:    sev1alpha1.PodMigrationJobModeEvictionDirectly,
 		Timeout: &timeout,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			Template: &sev1alpha1.ReservationTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+			},
+		},
 	}
 
 	ctx := WithContext(context.TODO(), expectJobCtx)
@@ -58,6 +63,11 @@ func TestJobContext(t *testing.T) {
 		Spec: sev1alpha1.PodMigrationJobSpec{
 			Mode: sev1alpha1.PodMigrationJobModeEvictionDirectly,
 			TTL:  &metav1.Duration{Duration: timeout},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				Template: &sev1alpha1.ReservationTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+				},
+			},
 		},
 	}
 	assert.Equal(t, expectJob, job)
@@ -141,6 +141,11 @@ func New(ctx context.Context, args runtime.Object, handle framework.Handle) (fra
 	if err = c.Watch(source.Kind[client.Object](options.Manager.GetCache(), r.reservationInterpreter.GetReservationType(), &handler.Funcs{})); err != nil {
 		return nil, err
 	}
+	if controllerArgs.EnableNodeDrain {
+		if err = newNodeDrainController(controllerArgs, r.eventRecorder); err != nil {
+			return nil, err
+		}
+	}
 	r.reconcilerUID = UUIDGenerateFn()
 	return r, nil
 }
@@ -209,7 +214,7 @@ func (r *Reconciler) doScavenge() {
 		if v.Spec.TTL != nil && v.Spec.TTL.Duration > 0 {
 			timeoutDuration = v.Spec.TTL.Duration + 5*time.Minute
 		}
-		if r.clock.Since(v.CreationTimestamp.Time) < timeoutDuration {
+		if r.getJobElapsed(v) < timeoutDuration {
 			continue
 		}
 		if err := r.deleteReservation(context.TODO(), v); err != nil {
@@ -346,6 +351,10 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 	}
 
 	if reservation.IsReservationPending(reservationObj) {
+		if stuck, msg := r.isReservationUnschedulableTimeout(job); stuck {
+			err = r.rollbackStuckJob(ctx, job, stuckPhaseReservationUnschedulable, sev1alpha1.PodMigrationJobReasonReservationUnschedulableTimeout, msg)
+			return reconcile.Result{}, err
+		}
 		klog.V(4).Infof("MigrationJob %s is waiting for Reservation %s scheduled", job.Name, reservationObj)
 		return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
 	}
@@ -541,13 +550,23 @@ func (r *Reconciler) requeueJobIfObjectLimiterFailed(ctx context.Context, job *s
 	return r.checkPodExceedObjectLimiter(pod)
 }
 
+// getJobElapsed returns the time elapsed since the job was created, excluding the time the job is held
+// by the closed maintenance windows.
+func (r *Reconciler) getJobElapsed(job *sev1alpha1.PodMigrationJob) time.Duration {
+	elapsed := r.clock.Since(job.CreationTimestamp.Time)
+	if r.arbitrator != nil {
+		elapsed -= r.arbitrator.GetMaintenanceWindowBlocked(job)
+	}
+	return elapsed
+}
+
 func (r *Reconciler) abortJobIfTimeout(ctx context.Context, job *sev1alpha1.PodMigrationJob) (bool, error) {
 	if job.Spec.TTL == nil || job.Spec.TTL.Duration == 0 {
 		return false, nil
 	}
 
 	timeout := job.Spec.TTL.Duration
-	elapsed := r.clock.Since(job.CreationTimestamp.Time)
+	elapsed := r.getJobElapsed(job)
 	if elapsed < timeout {
 		return false, nil
 	}
@@ -746,6 +765,10 @@ func (r *Reconciler) waitForPodReady(ctx context.Context, job *sev1alpha1.PodMig
 	}
 
 	if isReady := k8spodutil.IsPodReady(podObj); !isReady {
+		if crashLooping, msg := r.isBoundPodCrashLooping(podObj); crashLooping {
+			err := r.rollbackStuckJob(ctx, job, stuckPhaseBoundPodNotReady, sev1alpha1.PodMigrationJobReasonBoundPodCrashLooping, msg)
+			return false, reconcile.Result{}, err
+		}
 		cond = &sev1alpha1.PodMigrationJobCondition{
 			Type:   sev1alpha1.PodMigrationJobConditionBoundPodReady,
 			Status: sev1alpha1.PodMigrationJobConditionStatusFalse,
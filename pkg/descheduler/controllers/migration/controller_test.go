@@ -179,7 +179,16 @@ func TestAbortJobIfTimeout(t *testing.T) {
 	assert.False(t, timeout)
 	assert.Nil(t, err)
 
+	// the time held by the closed maintenance windows does not count towards the TTL
 	reconciler.clock = fakceclock.NewFakeClock(time.Now().Add(60 * time.Minute))
+	reconciler.arbitrator.(*fakeArbitrator).windowBlocked = func(*sev1alpha1.PodMigrationJob) time.Duration {
+		return 45 * time.Minute
+	}
+	timeout, err = reconciler.abortJobIfTimeout(context.TODO(), job)
+	assert.False(t, timeout)
+	assert.Nil(t, err)
+
+	reconciler.arbitrator.(*fakeArbitrator).windowBlocked = nil
 	timeout, err = reconciler.abortJobIfTimeout(context.TODO(), job)
 	assert.True(t, timeout)
 	assert.Nil(t, err)
@@ -2106,6 +2115,7 @@ type fakeArbitrator struct {
 	preEvictionFilter framework.FilterFunc
 	add               func(*sev1alpha1.PodMigrationJob)
 	delete            func(types.UID)
+	windowBlocked     func(*sev1alpha1.PodMigrationJob) time.Duration
 }
 
 func (f *fakeArbitrator) DeletePodMigrationJob(job *sev1alpha1.PodMigrationJob) {
@@ -2123,3 +2133,10 @@ func (f *fakeArbitrator) PreEvictionFilter(pod *corev1.Pod) bool {
 func (f *fakeArbitrator) AddPodMigrationJob(job *sev1alpha1.PodMigrationJob) {
 	f.add(job)
 }
+
+func (f *fakeArbitrator) GetMaintenanceWindowBlocked(job *sev1alpha1.PodMigrationJob) time.Duration {
+	if f.windowBlocked != nil {
+		return f.windowBlocked(job)
+	}
+	return 0
+}
@@ -0,0 +1,511 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
+)
+
+const (
+	// LabelNodeDrain is the label of the PodMigrationJobs created by the NodeDrain, the value is the name of the NodeDrain.
+	LabelNodeDrain = "koordinator.sh/node-drain"
+
+	nodeDrainEvictTrigger = "NodeDrain"
+)
+
+// nodeDrainReconciler drains the node declared by the NodeDrain. It cordons the node, then creates
+// ReservationFirst PodMigrationJobs for the pods on it, so that every pod is deleted only after the
+// replacement is ready on another node. The PodMigrationJobs are still arbitrated by the MigrationController,
+// which respects MaxMigratingPerWorkload, MaxUnavailablePerWorkload and the EvictionPolicy.
+type nodeDrainReconciler struct {
+	client.Client
+	// apiReader reads the jobs missing in the cache to avoid migrating a pod twice
+	apiReader     client.Reader
+	args          *deschedulerconfig.MigrationControllerArgs
+	eventRecorder events.EventRecorder
+}
+
+func newNodeDrainController(args *deschedulerconfig.MigrationControllerArgs, eventRecorder events.EventRecorder) error {
+	manager := options.Manager
+	r := &nodeDrainReconciler{
+		Client:        manager.GetClient(),
+		apiReader:     manager.GetAPIReader(),
+		args:          args,
+		eventRecorder: eventRecorder,
+	}
+	c, err := controller.New(names.NodeDrainController, manager, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+	if err = c.Watch(source.Kind[client.Object](manager.GetCache(), &sev1alpha1.NodeDrain{}, &handler.EnqueueRequestForObject{})); err != nil {
+		return err
+	}
+	return c.Watch(source.Kind[client.Object](manager.GetCache(), &sev1alpha1.PodMigrationJob{},
+		handler.EnqueueRequestForOwner(manager.GetScheme(), manager.GetRESTMapper(), &sev1alpha1.NodeDrain{}, handler.OnlyControllerOwner())))
+}
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=nodedrains,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=nodedrains/status,verbs=get;update;patch
+
+func (r *nodeDrainReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	drain := &sev1alpha1.NodeDrain{}
+	err := r.Client.Get(ctx, request.NamespacedName, drain)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		klog.Errorf("Failed to Get NodeDrain from %v, err: %v", request, err)
+		return reconcile.Result{}, err
+	}
+	if isNodeDrainFinished(drain) {
+		return reconcile.Result{}, nil
+	}
+
+	status := drain.Status.DeepCopy()
+	result, err := r.doDrain(ctx, drain, status)
+	if err != nil {
+		klog.Errorf("Failed to reconcile NodeDrain %v, err: %v", request.NamespacedName, err)
+	}
+	if !equality.Semantic.DeepEqual(&drain.Status, status) {
+		if status.Phase != drain.Status.Phase {
+			eventType := corev1.EventTypeNormal
+			if status.Phase == sev1alpha1.NodeDrainFailed {
+				eventType = corev1.EventTypeWarning
+			}
+			r.eventRecorder.Eventf(drain, nil, eventType, status.Reason, "Draining", status.Message)
+		}
+		drain.Status = *status
+		if updateErr := r.Client.Status().Update(ctx, drain); updateErr != nil {
+			klog.Errorf("Failed to update NodeDrain %s status, err: %v", drain.Name, updateErr)
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+func isNodeDrainFinished(drain *sev1alpha1.NodeDrain) bool {
+	return drain.Status.Phase == sev1alpha1.NodeDrainSucceeded ||
+		drain.Status.Phase == sev1alpha1.NodeDrainFailed ||
+		drain.Status.Phase == sev1alpha1.NodeDrainAborted
+}
+
+func (r *nodeDrainReconciler) doDrain(ctx context.Context, drain *sev1alpha1.NodeDrain, status *sev1alpha1.NodeDrainStatus) (reconcile.Result, error) {
+	node := &corev1.Node{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: drain.Spec.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) {
+			setNodeDrainPhase(status, sev1alpha1.NodeDrainFailed, sev1alpha1.NodeDrainReasonMissingNode,
+				fmt.Sprintf("Node %q is not found", drain.Spec.NodeName))
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	jobs, err := r.listJobs(ctx, drain, status)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	pods, err := r.listPods(ctx, drain.Spec.NodeName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if drain.Spec.Abort {
+		for _, job := range jobs {
+			if err := r.abortPendingJob(ctx, job, drain); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if status.Cordoned {
+			if err := r.setNodeUnschedulable(ctx, node, false); err != nil {
+				return reconcile.Result{}, err
+			}
+			status.Cordoned = false
+		}
+		syncNodeDrainPods(status, jobs, pods)
+		setNodeDrainPhase(status, sev1alpha1.NodeDrainAborted, sev1alpha1.NodeDrainReasonAborted, "NodeDrain is aborted by user")
+		return reconcile.Result{}, nil
+	}
+
+	if !node.Spec.Unschedulable {
+		if err := r.setNodeUnschedulable(ctx, node, true); err != nil {
+			return reconcile.Result{}, err
+		}
+		status.Cordoned = true
+	}
+
+	syncNodeDrainPods(status, jobs, pods)
+
+	for _, job := range jobs {
+		if err := r.pauseJob(ctx, job, drain.Spec.Paused); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if drain.Spec.Paused {
+		setNodeDrainPhase(status, sev1alpha1.NodeDrainPaused, sev1alpha1.NodeDrainReasonPaused, "NodeDrain is paused by user")
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.createJobs(ctx, drain, status, pods); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	switch {
+	case status.MigratingPods > 0 || countNodeDrainPods(status, sev1alpha1.NodeDrainPodWaiting) > 0:
+		setNodeDrainPhase(status, sev1alpha1.NodeDrainRunning, sev1alpha1.NodeDrainReasonMigrating,
+			fmt.Sprintf("%d/%d pods are migrated", status.SucceededPods, status.TotalPods))
+		return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+	case status.FailedPods > 0:
+		// the pods of the rolled back jobs stay on the node, so the node is uncordoned to keep them serving
+		if status.Cordoned && hasRolledBackJob(jobs) {
+			if err := r.setNodeUnschedulable(ctx, node, false); err != nil {
+				return reconcile.Result{}, err
+			}
+			status.Cordoned = false
+		}
+		setNodeDrainPhase(status, sev1alpha1.NodeDrainFailed, sev1alpha1.NodeDrainReasonFailedMigrate,
+			fmt.Sprintf("%d/%d pods failed to migrate", status.FailedPods, status.TotalPods))
+	default:
+		setNodeDrainPhase(status, sev1alpha1.NodeDrainSucceeded, sev1alpha1.NodeDrainReasonDrained,
+			fmt.Sprintf("%d/%d pods are migrated", status.SucceededPods, status.TotalPods))
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *nodeDrainReconciler) listJobs(ctx context.Context, drain *sev1alpha1.NodeDrain, status *sev1alpha1.NodeDrainStatus) ([]*sev1alpha1.PodMigrationJob, error) {
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	if err := r.Client.List(ctx, jobList, client.MatchingLabels{LabelNodeDrain: drain.Name}); err != nil {
+		return nil, err
+	}
+	jobs := make([]*sev1alpha1.PodMigrationJob, 0, len(jobList.Items))
+	jobNames := map[string]struct{}{}
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		// ignore the jobs left by a deleted NodeDrain with the same name
+		if !metav1.IsControlledBy(job, drain) || job.Spec.PodRef == nil {
+			continue
+		}
+		jobs = append(jobs, job)
+		jobNames[job.Name] = struct{}{}
+	}
+	// the jobs just created may not be synced to the cache yet
+	for _, podStatus := range status.Pods {
+		if podStatus.Phase != sev1alpha1.NodeDrainPodMigrating {
+			continue
+		}
+		if _, ok := jobNames[podStatus.JobName]; ok {
+			continue
+		}
+		job := &sev1alpha1.PodMigrationJob{}
+		err := r.apiReader.Get(ctx, types.NamespacedName{Name: podStatus.JobName}, job)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	// the newer job takes precedence if a pod is migrated more than once
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+	return jobs, nil
+}
+
+func (r *nodeDrainReconciler) listPods(ctx context.Context, nodeName string) ([]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, client.MatchingFields{fieldindex.IndexPodByNodeName: nodeName}); err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if k8spodutil.IsPodTerminal(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func (r *nodeDrainReconciler) setNodeUnschedulable(ctx context.Context, node *corev1.Node, unschedulable bool) error {
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+	if err := r.Client.Patch(ctx, node, patch); err != nil {
+		klog.Errorf("Failed to set Node %s unschedulable to %v, err: %v", node.Name, unschedulable, err)
+		return err
+	}
+	return nil
+}
+
+// abortPendingJob aborts the job not started yet. The running jobs are left to finish
+// since interrupting them may leave the pod evicted without the replacement.
+func (r *nodeDrainReconciler) abortPendingJob(ctx context.Context, job *sev1alpha1.PodMigrationJob, drain *sev1alpha1.NodeDrain) error {
+	if job.Status.Phase != "" && job.Status.Phase != sev1alpha1.PodMigrationJobPending {
+		return nil
+	}
+	job.Status.Phase = sev1alpha1.PodMigrationJobAborted
+	job.Status.Reason = sev1alpha1.NodeDrainReasonAborted
+	job.Status.Message = fmt.Sprintf("NodeDrain %s is aborted", drain.Name)
+	return r.Client.Status().Update(ctx, job)
+}
+
+// pauseJob pauses or resumes the job not started yet.
+func (r *nodeDrainReconciler) pauseJob(ctx context.Context, job *sev1alpha1.PodMigrationJob, paused bool) error {
+	if job.Spec.Paused == paused {
+		return nil
+	}
+	if job.Status.Phase != "" && job.Status.Phase != sev1alpha1.PodMigrationJobPending {
+		return nil
+	}
+	job.Spec.Paused = paused
+	return r.Client.Update(ctx, job)
+}
+
+func (r *nodeDrainReconciler) createJobs(ctx context.Context, drain *sev1alpha1.NodeDrain, status *sev1alpha1.NodeDrainStatus, pods []*corev1.Pod) error {
+	waiting := map[types.NamespacedName]*sev1alpha1.NodeDrainPodStatus{}
+	for i := range status.Pods {
+		if status.Pods[i].Phase == sev1alpha1.NodeDrainPodWaiting {
+			waiting[podStatusKey(&status.Pods[i])] = &status.Pods[i]
+		}
+	}
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if _, ok := waiting[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; ok {
+			candidates = append(candidates, pod)
+		}
+	}
+	sorter.PodSorter().Sort(candidates)
+
+	for _, pod := range candidates {
+		if drain.Spec.MaxConcurrentMigrations != nil && status.MigratingPods >= *drain.Spec.MaxConcurrentMigrations {
+			break
+		}
+		job := newNodeDrainJob(drain, pod, r.args)
+		if err := r.Client.Create(ctx, job); err != nil {
+			klog.Errorf("Failed to create PodMigrationJob for Pod %s/%s, NodeDrain: %s, err: %v", pod.Namespace, pod.Name, drain.Name, err)
+			return err
+		}
+		klog.V(4).InfoS("Create PodMigrationJob for NodeDrain", "nodeDrain", drain.Name, "pod", klog.KObj(pod), "job", job.Name)
+		podStatus := waiting[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+		podStatus.Phase = sev1alpha1.NodeDrainPodMigrating
+		podStatus.JobName = job.Name
+		podStatus.Message = ""
+		status.MigratingPods++
+	}
+	return nil
+}
+
+func newNodeDrainJob(drain *sev1alpha1.NodeDrain, pod *corev1.Pod, args *deschedulerconfig.MigrationControllerArgs) *sev1alpha1.PodMigrationJob {
+	ttl := drain.Spec.JobTTL
+	if ttl == nil {
+		ttl = &args.DefaultJobTTL
+	}
+	deleteOptions := drain.Spec.DeleteOptions
+	if deleteOptions == nil {
+		deleteOptions = args.DefaultDeleteOptions
+	}
+	return &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: string(UUIDGenerateFn()),
+			Labels: map[string]string{
+				LabelNodeDrain: drain.Name,
+			},
+			Annotations: map[string]string{
+				evictor.AnnotationEvictReason:  fmt.Sprintf("drain node %s", drain.Spec.NodeName),
+				evictor.AnnotationEvictTrigger: nodeDrainEvictTrigger,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         sev1alpha1.SchemeGroupVersion.String(),
+					Kind:               "NodeDrain",
+					Name:               drain.Name,
+					UID:                drain.UID,
+					Controller:         ptr.To[bool](true),
+					BlockOwnerDeletion: ptr.To[bool](true),
+				},
+			},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+			Mode:          sev1alpha1.PodMigrationJobModeReservationFirst,
+			TTL:           ttl.DeepCopy(),
+			DeleteOptions: deleteOptions.DeepCopy(),
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobPending,
+		},
+	}
+}
+
+// syncNodeDrainPods refreshes the progress of the pods by the jobs and the pods on the node.
+// The pods migrated by the jobs keep their records even if the jobs are scavenged. The pods whose
+// jobs are deleted before finishing are migrated again if they are still on the node.
+func syncNodeDrainPods(status *sev1alpha1.NodeDrainStatus, jobs []*sev1alpha1.PodMigrationJob, pods []*corev1.Pod) {
+	podStatuses := map[types.NamespacedName]*sev1alpha1.NodeDrainPodStatus{}
+	for i := range status.Pods {
+		podStatus := &status.Pods[i]
+		if podStatus.JobName != "" {
+			podStatuses[podStatusKey(podStatus)] = podStatus
+		}
+	}
+	jobNames := map[string]struct{}{}
+	for _, job := range jobs {
+		jobNames[job.Name] = struct{}{}
+		key := types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name}
+		podStatuses[key] = &sev1alpha1.NodeDrainPodStatus{
+			PodRef:    *job.Spec.PodRef,
+			Phase:     nodeDrainPodPhaseOfJob(job),
+			JobName:   job.Name,
+			NodeName:  job.Status.NodeName,
+			NewPodRef: job.Status.PodRef.DeepCopy(),
+			Message:   job.Status.Message,
+		}
+	}
+
+	podsOnNode := map[types.NamespacedName]*corev1.Pod{}
+	for _, pod := range pods {
+		podsOnNode[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
+	}
+	for key, podStatus := range podStatuses {
+		if _, ok := jobNames[podStatus.JobName]; ok || podStatus.Phase == sev1alpha1.NodeDrainPodSucceeded ||
+			podStatus.Phase == sev1alpha1.NodeDrainPodFailed {
+			continue
+		}
+		if _, ok := podsOnNode[key]; ok {
+			delete(podStatuses, key)
+			continue
+		}
+		podStatus.Phase = sev1alpha1.NodeDrainPodFailed
+		podStatus.Message = fmt.Sprintf("PodMigrationJob %s is missing", podStatus.JobName)
+	}
+	for key, pod := range podsOnNode {
+		if _, ok := podStatuses[key]; ok {
+			continue
+		}
+		podStatus := &sev1alpha1.NodeDrainPodStatus{
+			PodRef: corev1.ObjectReference{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID},
+			Phase:  sev1alpha1.NodeDrainPodWaiting,
+		}
+		if reason := nodeDrainSkipReason(pod); reason != "" {
+			podStatus.Phase = sev1alpha1.NodeDrainPodSkipped
+			podStatus.Message = reason
+		} else if utils.IsPodTerminating(pod) {
+			// the pod is leaving the node without the migration
+			continue
+		}
+		podStatuses[key] = podStatus
+	}
+
+	status.Pods = make([]sev1alpha1.NodeDrainPodStatus, 0, len(podStatuses))
+	for _, podStatus := range podStatuses {
+		status.Pods = append(status.Pods, *podStatus)
+	}
+	sort.Slice(status.Pods, func(i, j int) bool {
+		return podStatusKey(&status.Pods[i]).String() < podStatusKey(&status.Pods[j]).String()
+	})
+	status.TotalPods = int32(len(status.Pods)) - countNodeDrainPods(status, sev1alpha1.NodeDrainPodSkipped)
+	status.MigratingPods = countNodeDrainPods(status, sev1alpha1.NodeDrainPodMigrating)
+	status.SucceededPods = countNodeDrainPods(status, sev1alpha1.NodeDrainPodSucceeded)
+	status.FailedPods = countNodeDrainPods(status, sev1alpha1.NodeDrainPodFailed)
+}
+
+// hasRolledBackJob checks whether any job is rolled back and leaves its pod on the node.
+func hasRolledBackJob(jobs []*sev1alpha1.PodMigrationJob) bool {
+	for _, job := range jobs {
+		if isJobRolledBackBeforeEviction(job) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeDrainPodPhaseOfJob(job *sev1alpha1.PodMigrationJob) sev1alpha1.NodeDrainPodPhase {
+	switch job.Status.Phase {
+	case sev1alpha1.PodMigrationJobSucceeded:
+		return sev1alpha1.NodeDrainPodSucceeded
+	case sev1alpha1.PodMigrationJobFailed, sev1alpha1.PodMigrationJobAborted:
+		return sev1alpha1.NodeDrainPodFailed
+	default:
+		return sev1alpha1.NodeDrainPodMigrating
+	}
+}
+
+// nodeDrainSkipReason returns why the pod is not migrated by the NodeDrain, the pods bound to the node
+// are left as kubectl drain does.
+func nodeDrainSkipReason(pod *corev1.Pod) string {
+	switch {
+	case utils.IsDaemonsetPod(pod.OwnerReferences):
+		return "Pod is managed by DaemonSet"
+	case utils.IsMirrorPod(pod) || utils.IsStaticPod(pod):
+		return "Pod is a static pod"
+	}
+	return ""
+}
+
+func countNodeDrainPods(status *sev1alpha1.NodeDrainStatus, phase sev1alpha1.NodeDrainPodPhase) int32 {
+	var count int32
+	for i := range status.Pods {
+		if status.Pods[i].Phase == phase {
+			count++
+		}
+	}
+	return count
+}
+
+func podStatusKey(podStatus *sev1alpha1.NodeDrainPodStatus) types.NamespacedName {
+	return types.NamespacedName{Namespace: podStatus.PodRef.Namespace, Name: podStatus.PodRef.Name}
+}
+
+func setNodeDrainPhase(status *sev1alpha1.NodeDrainStatus, phase sev1alpha1.NodeDrainPhase, reason, message string) {
+	status.Phase = phase
+	status.Reason = reason
+	status.Message = message
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+)
+
+func newTestNodeDrainReconciler(objs ...client.Object) *nodeDrainReconciler {
+	scheme := runtime.NewScheme()
+	_ = sev1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+	runtimeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&sev1alpha1.PodMigrationJob{}, &sev1alpha1.NodeDrain{}).
+		WithIndex(&corev1.Pod{}, fieldindex.IndexPodByNodeName, func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithObjects(objs...).Build()
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: Name})
+	return &nodeDrainReconciler{
+		Client:    runtimeClient,
+		apiReader: runtimeClient,
+		args: &deschedulerconfig.MigrationControllerArgs{
+			DefaultJobTTL: metav1.Duration{Duration: 5 * time.Minute},
+		},
+		eventRecorder: record.NewEventRecorderAdapter(recorder),
+	}
+}
+
+func newTestDrainPod(name string, ownerKind string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: ownerKind, Name: "test", UID: "test", Controller: ptr.To[bool](true)},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+}
+
+func reconcileNodeDrain(t *testing.T, r *nodeDrainReconciler) (*sev1alpha1.NodeDrain, []*sev1alpha1.PodMigrationJob) {
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-drain"}})
+	assert.NoError(t, err)
+	drain := &sev1alpha1.NodeDrain{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-drain"}, drain))
+	jobs, err := r.listJobs(context.TODO(), drain, &drain.Status)
+	assert.NoError(t, err)
+	return drain, jobs
+}
+
+func updateTestJobPhase(t *testing.T, r *nodeDrainReconciler, job *sev1alpha1.PodMigrationJob, phase sev1alpha1.PodMigrationJobPhase) {
+	job.Status.Phase = phase
+	if phase == sev1alpha1.PodMigrationJobSucceeded {
+		job.Status.NodeName = "other-node"
+		job.Status.PodRef = &corev1.ObjectReference{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name + "-new"}
+	}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), job))
+}
+
+func TestNodeDrainReconcile(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	drain := &sev1alpha1.NodeDrain{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drain", UID: "test-drain"},
+		Spec: sev1alpha1.NodeDrainSpec{
+			NodeName:                "test-node",
+			MaxConcurrentMigrations: ptr.To[int32](1),
+		},
+	}
+	r := newTestNodeDrainReconciler(node, drain,
+		newTestDrainPod("pod-1", "ReplicaSet"), newTestDrainPod("pod-2", "ReplicaSet"), newTestDrainPod("pod-ds", "DaemonSet"))
+
+	got, jobs := reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainRunning, got.Status.Phase)
+	assert.True(t, got.Status.Cordoned)
+	assert.Equal(t, int32(2), got.Status.TotalPods)
+	assert.Equal(t, int32(1), got.Status.MigratingPods)
+	assert.Len(t, got.Status.Pods, 3)
+	assert.Equal(t, sev1alpha1.NodeDrainPodSkipped, got.Status.Pods[2].Phase)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, jobs[0].Spec.Mode)
+	assert.Equal(t, &metav1.Duration{Duration: 5 * time.Minute}, jobs[0].Spec.TTL)
+	assert.Equal(t, "test-drain", jobs[0].Labels[LabelNodeDrain])
+	gotNode := &corev1.Node{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-node"}, gotNode))
+	assert.True(t, gotNode.Spec.Unschedulable)
+
+	// no more job is created until the migrating one finished
+	_, jobs = reconcileNodeDrain(t, r)
+	assert.Len(t, jobs, 1)
+
+	migratedPod := jobs[0].Spec.PodRef.Name
+	updateTestJobPhase(t, r, jobs[0], sev1alpha1.PodMigrationJobSucceeded)
+	assert.NoError(t, r.Client.Delete(context.TODO(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: migratedPod}}))
+	got, jobs = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainRunning, got.Status.Phase)
+	assert.Equal(t, int32(1), got.Status.SucceededPods)
+	assert.Equal(t, int32(1), got.Status.MigratingPods)
+	assert.Len(t, jobs, 2)
+	for i := range got.Status.Pods {
+		if got.Status.Pods[i].PodRef.Name == migratedPod {
+			assert.Equal(t, sev1alpha1.NodeDrainPodSucceeded, got.Status.Pods[i].Phase)
+			assert.Equal(t, "other-node", got.Status.Pods[i].NodeName)
+			assert.Equal(t, migratedPod+"-new", got.Status.Pods[i].NewPodRef.Name)
+		}
+	}
+
+	for _, job := range jobs {
+		if job.Status.Phase == sev1alpha1.PodMigrationJobPending {
+			updateTestJobPhase(t, r, job, sev1alpha1.PodMigrationJobFailed)
+		}
+	}
+	got, _ = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainFailed, got.Status.Phase)
+	assert.Equal(t, sev1alpha1.NodeDrainReasonFailedMigrate, got.Status.Reason)
+	assert.Equal(t, int32(1), got.Status.SucceededPods)
+	assert.Equal(t, int32(1), got.Status.FailedPods)
+	assert.Equal(t, int32(0), got.Status.MigratingPods)
+}
+
+func TestNodeDrainSucceeded(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}, Spec: corev1.NodeSpec{Unschedulable: true}}
+	drain := &sev1alpha1.NodeDrain{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drain", UID: "test-drain"},
+		Spec:       sev1alpha1.NodeDrainSpec{NodeName: "test-node"},
+	}
+	r := newTestNodeDrainReconciler(node, drain, newTestDrainPod("pod-1", "ReplicaSet"), newTestDrainPod("pod-2", "ReplicaSet"))
+	got, jobs := reconcileNodeDrain(t, r)
+	assert.False(t, got.Status.Cordoned, "the node is cordoned by others")
+	assert.Equal(t, int32(2), got.Status.MigratingPods)
+	assert.Len(t, jobs, 2)
+	for _, job := range jobs {
+		updateTestJobPhase(t, r, job, sev1alpha1.PodMigrationJobSucceeded)
+	}
+	got, _ = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainSucceeded, got.Status.Phase)
+	assert.Equal(t, int32(2), got.Status.SucceededPods)
+}
+
+func TestNodeDrainFailedWithRolledBackJob(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	drain := &sev1alpha1.NodeDrain{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drain", UID: "test-drain"},
+		Spec:       sev1alpha1.NodeDrainSpec{NodeName: "test-node"},
+	}
+	r := newTestNodeDrainReconciler(node, drain, newTestDrainPod("pod-1", "ReplicaSet"))
+	got, jobs := reconcileNodeDrain(t, r)
+	assert.True(t, got.Status.Cordoned)
+	assert.Len(t, jobs, 1)
+
+	jobs[0].Status.Conditions = []sev1alpha1.PodMigrationJobCondition{
+		{
+			Type:   sev1alpha1.PodMigrationJobConditionRollback,
+			Status: sev1alpha1.PodMigrationJobConditionStatusTrue,
+			Reason: sev1alpha1.PodMigrationJobReasonReservationUnschedulableTimeout,
+		},
+	}
+	updateTestJobPhase(t, r, jobs[0], sev1alpha1.PodMigrationJobFailed)
+	got, _ = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainFailed, got.Status.Phase)
+	assert.False(t, got.Status.Cordoned)
+	gotNode := &corev1.Node{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-node"}, gotNode))
+	assert.False(t, gotNode.Spec.Unschedulable)
+}
+
+func TestNodeDrainPauseAndAbort(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	drain := &sev1alpha1.NodeDrain{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drain", UID: "test-drain"},
+		Spec: sev1alpha1.NodeDrainSpec{
+			NodeName:                "test-node",
+			MaxConcurrentMigrations: ptr.To[int32](1),
+		},
+	}
+	r := newTestNodeDrainReconciler(node, drain, newTestDrainPod("pod-1", "ReplicaSet"), newTestDrainPod("pod-2", "ReplicaSet"))
+	got, jobs := reconcileNodeDrain(t, r)
+	assert.Len(t, jobs, 1)
+
+	got.Spec.Paused = true
+	assert.NoError(t, r.Client.Update(context.TODO(), got))
+	got, jobs = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainPaused, got.Status.Phase)
+	assert.Len(t, jobs, 1)
+	assert.True(t, jobs[0].Spec.Paused)
+
+	got.Spec.Paused = false
+	assert.NoError(t, r.Client.Update(context.TODO(), got))
+	got, jobs = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainRunning, got.Status.Phase)
+	assert.False(t, jobs[0].Spec.Paused)
+
+	got.Spec.Abort = true
+	assert.NoError(t, r.Client.Update(context.TODO(), got))
+	got, jobs = reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainAborted, got.Status.Phase)
+	assert.False(t, got.Status.Cordoned)
+	assert.Equal(t, sev1alpha1.PodMigrationJobAborted, jobs[0].Status.Phase)
+	assert.Equal(t, int32(1), countNodeDrainPods(&got.Status, sev1alpha1.NodeDrainPodWaiting))
+	gotNode := &corev1.Node{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-node"}, gotNode))
+	assert.False(t, gotNode.Spec.Unschedulable)
+}
+
+func TestNodeDrainMissingNode(t *testing.T) {
+	drain := &sev1alpha1.NodeDrain{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drain", UID: "test-drain"},
+		Spec:       sev1alpha1.NodeDrainSpec{NodeName: "test-node"},
+	}
+	r := newTestNodeDrainReconciler(drain)
+	got, _ := reconcileNodeDrain(t, r)
+	assert.Equal(t, sev1alpha1.NodeDrainFailed, got.Status.Phase)
+	assert.Equal(t, sev1alpha1.NodeDrainReasonMissingNode, got.Status.Reason)
+}
+
+func TestSyncNodeDrainPodsWithMissingJob(t *testing.T) {
+	status := &sev1alpha1.NodeDrainStatus{
+		Pods: []sev1alpha1.NodeDrainPodStatus{
+			{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-1"}, Phase: sev1alpha1.NodeDrainPodMigrating, JobName: "job-1"},
+			{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-2"}, Phase: sev1alpha1.NodeDrainPodMigrating, JobName: "job-2"},
+			{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-3"}, Phase: sev1alpha1.NodeDrainPodSucceeded, JobName: "job-3"},
+		},
+	}
+	syncNodeDrainPods(status, nil, []*corev1.Pod{newTestDrainPod("pod-1", "ReplicaSet")})
+	assert.Equal(t, []sev1alpha1.NodeDrainPodStatus{
+		{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-1", UID: "pod-1"}, Phase: sev1alpha1.NodeDrainPodWaiting},
+		{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-2"}, Phase: sev1alpha1.NodeDrainPodFailed, JobName: "job-2", Message: "PodMigrationJob job-2 is missing"},
+		{PodRef: corev1.ObjectReference{Namespace: "default", Name: "pod-3"}, Phase: sev1alpha1.NodeDrainPodSucceeded, JobName: "job-3"},
+	}, status.Pods)
+	assert.Equal(t, int32(3), status.TotalPods)
+	assert.Equal(t, int32(1), status.FailedPods)
+	assert.Equal(t, int32(1), status.SucceededPods)
+}
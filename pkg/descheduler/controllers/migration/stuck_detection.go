@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+)
+
+const (
+	// stuckPhaseReservationUnschedulable is the phase where the job waits for the Reservation scheduled.
+	stuckPhaseReservationUnschedulable = "ReservationUnschedulable"
+	// stuckPhaseBoundPodNotReady is the phase where the job waits for the replacement Pod ready.
+	stuckPhaseBoundPodNotReady = "BoundPodNotReady"
+
+	// reasonMigrationRolledBack is the reason of the event sent to the workload whose Pod is evicted by a rolled back job.
+	reasonMigrationRolledBack = "MigrationRolledBack"
+)
+
+// isReservationUnschedulableTimeout checks whether the Reservation has kept unschedulable longer than the timeout.
+// The unschedulable time is counted from the ReservationScheduled condition synced by syncReservationScheduleFailed.
+func (r *Reconciler) isReservationUnschedulableTimeout(job *sev1alpha1.PodMigrationJob) (bool, string) {
+	if r.args.StuckDetection == nil || r.args.StuckDetection.ReservationUnschedulableTimeout.Duration <= 0 {
+		return false, ""
+	}
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionReservationScheduled)
+	if cond == nil || cond.Status != sev1alpha1.PodMigrationJobConditionStatusFalse ||
+		cond.Reason != sev1alpha1.PodMigrationJobReasonUnschedulable {
+		return false, ""
+	}
+	timeout := r.args.StuckDetection.ReservationUnschedulableTimeout.Duration
+	if r.clock.Since(cond.LastTransitionTime.Time) < timeout {
+		return false, ""
+	}
+	return true, fmt.Sprintf("Reservation keeps unschedulable for more than %v: %s", timeout, cond.Message)
+}
+
+// isBoundPodCrashLooping checks whether any container of the replacement Pod restarts too many times.
+func (r *Reconciler) isBoundPodCrashLooping(pod *corev1.Pod) (bool, string) {
+	if r.args.StuckDetection == nil || r.args.StuckDetection.BoundPodMaxRestarts <= 0 {
+		return false, ""
+	}
+	maxRestarts := r.args.StuckDetection.BoundPodMaxRestarts
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.RestartCount >= maxRestarts {
+				return true, fmt.Sprintf("Container %s of Bound Pod %s/%s restarts %d times",
+					status.Name, pod.Namespace, pod.Name, status.RestartCount)
+			}
+		}
+	}
+	return false, ""
+}
+
+// rollbackStuckJob aborts the stuck job and releases its Reservation. The job fails with the Rollback condition,
+// and the workload of the Pod is notified if the Pod has been evicted, since the Pod is not restored by the rollback.
+func (r *Reconciler) rollbackStuckJob(ctx context.Context, job *sev1alpha1.PodMigrationJob, stuckPhase, reason, message string) error {
+	klog.V(4).Infof("MigrationJob %s is stuck in %s and rolled back, %s", job.Name, stuckPhase, message)
+	if err := r.deleteReservation(ctx, job); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	_, evictionCond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionEviction)
+	evicted := evictionCond != nil
+	if evicted && job.Spec.PodRef != nil {
+		message = fmt.Sprintf("%s, Pod %s/%s has been evicted", message, job.Spec.PodRef.Namespace, job.Spec.PodRef.Name)
+	}
+	util.UpdateCondition(&job.Status, &sev1alpha1.PodMigrationJobCondition{
+		Type:    sev1alpha1.PodMigrationJobConditionRollback,
+		Status:  sev1alpha1.PodMigrationJobConditionStatusTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	job.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	job.Status.Status = string(sev1alpha1.PodMigrationJobConditionRollback)
+	job.Status.Reason = reason
+	job.Status.Message = message
+	if err := r.Client.Status().Update(ctx, job); err != nil {
+		return err
+	}
+
+	metrics.PodMigrationJobsRolledBack.WithLabelValues(stuckPhase, strconv.FormatBool(evicted)).Inc()
+	r.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, reason, "Migrating", "%s", message)
+	if evicted {
+		if owner := r.getWorkloadRef(ctx, job); owner != nil {
+			r.eventRecorder.Eventf(owner, job, corev1.EventTypeWarning, reasonMigrationRolledBack, "Migrating",
+				"PodMigrationJob %s is rolled back after the Pod is evicted: %s", job.Name, message)
+		}
+	}
+	return nil
+}
+
+// getWorkloadRef returns the controller of the migrated Pod, or of the replacement Pod if the migrated one is gone.
+func (r *Reconciler) getWorkloadRef(ctx context.Context, job *sev1alpha1.PodMigrationJob) *corev1.ObjectReference {
+	for _, podRef := range []*corev1.ObjectReference{job.Spec.PodRef, job.Status.PodRef} {
+		if podRef == nil {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}, pod); err != nil {
+			continue
+		}
+		if ownerRef := metav1.GetControllerOf(pod); ownerRef != nil {
+			return &corev1.ObjectReference{
+				APIVersion: ownerRef.APIVersion,
+				Kind:       ownerRef.Kind,
+				Namespace:  pod.Namespace,
+				Name:       ownerRef.Name,
+				UID:        ownerRef.UID,
+			}
+		}
+	}
+	return nil
+}
+
+// isJobRolledBackBeforeEviction checks whether the job is rolled back before the Pod is evicted, i.e. the Pod is left in place.
+func isJobRolledBackBeforeEviction(job *sev1alpha1.PodMigrationJob) bool {
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionRollback)
+	if cond == nil || cond.Status != sev1alpha1.PodMigrationJobConditionStatusTrue {
+		return false
+	}
+	_, evictionCond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionEviction)
+	return evictionCond == nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakceclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+)
+
+func newTestStuckJobAndPod() (*sev1alpha1.PodMigrationJob, *corev1.Pod) {
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Controller: ptr.To[bool](true),
+					Kind:       "StatefulSet",
+					Name:       "test",
+					UID:        "2f96233d-a6b9-4981-b594-7c90c987aed9",
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			SchedulerName: "koord-scheduler",
+			NodeName:      "test-node-0",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	return job, pod
+}
+
+func newTestStuckReservation(status sev1alpha1.ReservationStatus) *sev1alpha1.Reservation {
+	return &sev1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-reservation",
+		},
+		Spec: sev1alpha1.ReservationSpec{
+			Owners: []sev1alpha1.ReservationOwner{
+				{
+					Controller: &sev1alpha1.ReservationControllerReference{
+						Namespace: "default",
+						OwnerReference: metav1.OwnerReference{
+							APIVersion: "apps/v1",
+							Controller: ptr.To[bool](true),
+							Kind:       "StatefulSet",
+							Name:       "test",
+							UID:        "2f96233d-a6b9-4981-b594-7c90c987aed9",
+						},
+					},
+				},
+			},
+		},
+		Status: status,
+	}
+}
+
+func TestMigrateWithReservationUnschedulableTimeout(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.evictorInterpreter = fakeEvictionInterpreter{}
+	job, pod := newTestStuckJobAndPod()
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), pod))
+
+	r := newTestStuckReservation(sev1alpha1.ReservationStatus{
+		Phase: sev1alpha1.ReservationPending,
+		Conditions: []sev1alpha1.ReservationCondition{
+			{
+				Type:    sev1alpha1.ReservationConditionScheduled,
+				Reason:  sev1alpha1.ReasonReservationUnschedulable,
+				Status:  sev1alpha1.ConditionStatusFalse,
+				Message: "0/1 nodes are available",
+			},
+		},
+	})
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), r))
+	reconciler.reservationInterpreter = fakeReservationInterpreter{
+		reservation: r,
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+	for i := 0; i < 3; i++ {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobRunning, job.Status.Phase)
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionReservationScheduled)
+	assert.NotNil(t, cond)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonUnschedulable, cond.Reason)
+
+	reconciler.clock = fakceclock.NewFakeClock(time.Now().Add(5 * time.Minute))
+	_, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonReservationUnschedulableTimeout, job.Status.Reason)
+	_, cond = util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionRollback)
+	assert.NotNil(t, cond)
+	assert.Equal(t, sev1alpha1.PodMigrationJobConditionStatusTrue, cond.Status)
+	assert.True(t, isJobRolledBackBeforeEviction(job))
+}
+
+func TestMigrateWithBoundPodCrashLooping(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.evictorInterpreter = fakeEvictionInterpreter{}
+	job, pod := newTestStuckJobAndPod()
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), pod))
+
+	boundPod := pod.DeepCopy()
+	boundPod.Name = "test-pod-1"
+	boundPod.ResourceVersion = ""
+	boundPod.Spec.NodeName = "test-node-1"
+	boundPod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "main", RestartCount: 3},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), boundPod))
+
+	r := newTestStuckReservation(sev1alpha1.ReservationStatus{
+		Phase: sev1alpha1.ReservationAvailable,
+		Conditions: []sev1alpha1.ReservationCondition{
+			{
+				Type:   sev1alpha1.ReservationConditionScheduled,
+				Reason: sev1alpha1.ReasonReservationScheduled,
+				Status: sev1alpha1.ConditionStatusTrue,
+			},
+		},
+		CurrentOwners: []corev1.ObjectReference{
+			{
+				Namespace: "default",
+				Name:      "test-pod-1",
+			},
+		},
+		NodeName: "test-node-1",
+	})
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), r))
+	reconciler.reservationInterpreter = fakeReservationInterpreter{
+		reservation: r,
+	}
+
+	for i := 0; i < 10; i++ {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}})
+		assert.NoError(t, err)
+		assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+		if job.Status.Phase != "" && job.Status.Phase != sev1alpha1.PodMigrationJobRunning {
+			break
+		}
+		_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionEviction)
+		if cond != nil && cond.Status == sev1alpha1.PodMigrationJobConditionStatusFalse {
+			assert.Nil(t, reconciler.Client.Delete(context.TODO(), pod))
+		}
+	}
+	assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonBoundPodCrashLooping, job.Status.Reason)
+	assert.Contains(t, job.Status.Message, "has been evicted")
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionRollback)
+	assert.NotNil(t, cond)
+	assert.False(t, isJobRolledBackBeforeEviction(job))
+	assert.Equal(t, &corev1.ObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Namespace:  "default",
+		Name:       "test",
+		UID:        "2f96233d-a6b9-4981-b594-7c90c987aed9",
+	}, reconciler.getWorkloadRef(context.TODO(), job))
+}
+
+func TestIsBoundPodCrashLooping(t *testing.T) {
+	reconciler := newTestReconciler()
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", RestartCount: 1}},
+			ContainerStatuses:     []corev1.ContainerStatus{{Name: "main", RestartCount: 2}},
+		},
+	}
+	crashLooping, _ := reconciler.isBoundPodCrashLooping(pod)
+	assert.False(t, crashLooping)
+
+	pod.Status.InitContainerStatuses[0].RestartCount = 3
+	crashLooping, msg := reconciler.isBoundPodCrashLooping(pod)
+	assert.True(t, crashLooping)
+	assert.Contains(t, msg, "init")
+
+	reconciler.args.StuckDetection.BoundPodMaxRestarts = 0
+	crashLooping, _ = reconciler.isBoundPodCrashLooping(pod)
+	assert.False(t, crashLooping)
+}
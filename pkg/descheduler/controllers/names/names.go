@@ -18,4 +18,5 @@ package names
 
 const (
 	MigrationController = "MigrationController"
+	NodeDrainController = "NodeDrainController"
 )
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpusetfragmentation
+
+import (
+	"context"
+	"fmt"
+
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	nrtlisters "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+const (
+	CPUSetFragmentationName = "CPUSetFragmentation"
+)
+
+var _ framework.BalancePlugin = &CPUSetFragmentation{}
+
+// CPUSetFragmentation evicts the cpuset pods which fragment the free CPUs of the NUMA nodes,
+// so that the pods requiring whole physical cores in a single NUMA node can be scheduled.
+type CPUSetFragmentation struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.CPUSetFragmentationArgs
+	nrtLister nrtlisters.NodeResourceTopologyLister
+}
+
+func NewCPUSetFragmentation(ctx context.Context, args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	pluginArgs, ok := args.(*deschedulerconfig.CPUSetFragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type CPUSetFragmentationArgs, got %T", args)
+	}
+
+	if err := validation.ValidateCPUSetFragmentationArgs(nil, pluginArgs); err != nil {
+		return nil, err
+	}
+
+	podSelectorFn, err := utils.NewFragmentationPodSelectorFilter(pluginArgs.PodSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if pluginArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(pluginArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(pluginArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, podSelectorFn)).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nrtClientSet, ok := handle.(nrtclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		nrtClientSet, err = nrtclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nrtSharedInformerFactory := nrtinformers.NewSharedInformerFactory(nrtClientSet, 0)
+	nrtInformer := nrtSharedInformerFactory.Topology().V1alpha1().NodeResourceTopologies()
+	nrtInformer.Informer()
+	nrtSharedInformerFactory.Start(ctx.Done())
+	nrtSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	return &CPUSetFragmentation{
+		handle:    handle,
+		args:      pluginArgs,
+		podFilter: podFilter,
+		nrtLister: nrtInformer.Lister(),
+	}, nil
+}
+
+func (pl *CPUSetFragmentation) Name() string {
+	return CPUSetFragmentationName
+}
+
+func (pl *CPUSetFragmentation) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("CPUSetFragmentation is paused and will do nothing.")
+		return nil
+	}
+
+	ctx = framework.PluginNameWithContext(ctx, pl.Name())
+	candidateNodes, err := utils.FilterNodesByLabelSelector(nodes, pl.args.NodeSelector)
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+
+	for _, node := range candidateNodes {
+		plan := pl.planNode(node, candidateNodes)
+		if plan == nil {
+			continue
+		}
+		evictable := true
+		for _, pod := range plan.pods {
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				evictable = false
+				break
+			}
+		}
+		if !evictable {
+			continue
+		}
+		pl.evictPlan(ctx, node, plan)
+	}
+
+	return nil
+}
+
+func (pl *CPUSetFragmentation) planNode(node *corev1.Node, candidateNodes []*corev1.Node) *defragmentationPlan {
+	probeCPUs := int(pl.args.ProbeCPUs)
+	nrt, err := pl.nrtLister.Get(node.Name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get NodeResourceTopology", "node", node.Name, "err", err)
+		return nil
+	}
+
+	allPods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get pods assigned to node", "node", node.Name)
+		return nil
+	}
+	state := newNodeCPUState(node, nrt, allPods)
+	if state == nil || probeCPUs%state.topologyOptions.CPUTopology.CPUsPerCore() != 0 {
+		return nil
+	}
+
+	frag := state.fragmentation(probeCPUs, nil)
+	if frag.freeCPUs < probeCPUs || frag.ratio() <= pl.args.FragmentationThreshold {
+		return nil
+	}
+	klog.V(4).InfoS("Node cpuset is fragmented", "node", node.Name,
+		"freeCPUs", frag.freeCPUs, "usableCPUs", frag.usableCPUs, "ratio", frag.ratio())
+
+	var candidates []*podCPUAllocation
+	for _, allocation := range state.allocations {
+		if !pl.podFilter(allocation.pod) {
+			continue
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), allocation.pod, candidateNodes) {
+			continue
+		}
+		candidates = append(candidates, allocation)
+	}
+	return state.planDefragmentation(candidates, probeCPUs, int(pl.args.MaxEvictionsPerNode))
+}
+
+func (pl *CPUSetFragmentation) evictPlan(ctx context.Context, node *corev1.Node, plan *defragmentationPlan) {
+	reason := fmt.Sprintf("cpuset defragmentation: make room for %d full physical CPUs in NUMA node %d by migrating %d CPUs",
+		pl.args.ProbeCPUs, plan.numaNode, plan.cost)
+	for _, pod := range plan.pods {
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", node.Name, "reason", reason)
+			continue
+		}
+		// reserve the cpuset on the target node before the pod is evicted, otherwise the evicted pod
+		// may fragment another node or even land on the same NUMA node again. The CPU allocation on
+		// the current node is dropped so that the scheduler allocates the CPUs again.
+		jobCtx := &migration.JobContext{
+			Mode:               sev1alpha1.PodMigrationJobModeReservationFirst,
+			ReservationOptions: utils.BuildMigrateReservationOptions(pod, extension.AnnotationResourceStatus),
+		}
+		pl.handle.Evictor().Evict(migration.WithContext(ctx, jobCtx), pod, framework.EvictOptions{
+			PluginName: pl.Name(),
+			Reason:     reason,
+		})
+	}
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpusetfragmentation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	nrtlisters "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+type fakeEvictor struct {
+	evicted           []*corev1.Pod
+	jobContexts       []*migration.JobContext
+	preEvictionFilter func(pod *corev1.Pod) bool
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	if e.preEvictionFilter != nil {
+		return e.preEvictionFilter(pod)
+	}
+	return true
+}
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, opts framework.EvictOptions) bool {
+	e.evicted = append(e.evicted, pod)
+	e.jobContexts = append(e.jobContexts, migration.FromContext(ctx))
+	return true
+}
+
+type fakeHandle struct {
+	evictor *fakeEvictor
+	pods    []*corev1.Pod
+}
+
+func (h *fakeHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+		var res []*corev1.Pod
+		for _, pod := range h.pods {
+			if pod.Spec.NodeName == nodeName {
+				if filter == nil || filter(pod) {
+					res = append(res, pod)
+				}
+			}
+		}
+		return res, nil
+	}
+}
+
+func (h *fakeHandle) ClientSet() clientset.Interface                         { return nil }
+func (h *fakeHandle) KubeConfig() *restclient.Config                         { return nil }
+func (h *fakeHandle) EventRecorder() events.EventRecorder                    { return nil }
+func (h *fakeHandle) IsDryRun() bool                                         { return false }
+func (h *fakeHandle) SharedInformerFactory() informers.SharedInformerFactory { return nil }
+func (h *fakeHandle) NodeSelector() *metav1.LabelSelector                    { return nil }
+func (h *fakeHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return nil
+}
+func (h *fakeHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return nil
+}
+
+// buildTestNRT builds a node with 2 NUMA nodes, each NUMA node has 4 physical cores with 2 threads,
+// the CPUs 0-7 are in the NUMA node 0 and the CPUs 8-15 are in the NUMA node 1.
+func buildTestNRT(t *testing.T, nodeName string) *nrtv1alpha1.NodeResourceTopology {
+	topology := extension.CPUTopology{}
+	for i := int32(0); i < 16; i++ {
+		topology.Detail = append(topology.Detail, extension.CPUInfo{
+			ID:     i,
+			Core:   i / 2,
+			Socket: i / 8,
+			Node:   i / 8,
+		})
+	}
+	data, err := json.Marshal(topology)
+	assert.NoError(t, err)
+	return &nrtv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Annotations: map[string]string{
+				extension.AnnotationNodeCPUTopology: string(data),
+			},
+		},
+	}
+}
+
+func buildCPUSetPod(t *testing.T, name, nodeName, cpus string) *corev1.Pod {
+	return test.BuildTestPod(name, 1000, 1000, nodeName, func(pod *corev1.Pod) {
+		pod.UID = types.UID(name)
+		assert.NoError(t, extension.SetResourceStatus(pod, &extension.ResourceStatus{CPUSet: cpus}))
+	})
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestNodeCPUStateFragmentation(t *testing.T) {
+	node := test.BuildTestNode("node1", 16000, 16000, 10, nil)
+	nrt := buildTestNRT(t, node.Name)
+	tests := []struct {
+		name       string
+		pods       []*corev1.Pod
+		excluded   sets.Set[types.UID]
+		wantFree   int
+		wantUsable int
+	}{
+		{
+			name:       "idle node",
+			wantFree:   16,
+			wantUsable: 16,
+		},
+		{
+			name: "pods occupy whole cores",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0-3"),
+				buildCPUSetPod(t, "pod-2", node.Name, "8-11"),
+			},
+			wantFree:   8,
+			wantUsable: 8,
+		},
+		{
+			name: "pods occupy a thread of each core",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0,2"),
+				buildCPUSetPod(t, "pod-2", node.Name, "4"),
+				buildCPUSetPod(t, "pod-3", node.Name, "8-15"),
+			},
+			wantFree:   5,
+			wantUsable: 0,
+		},
+		{
+			name: "migrate the pods",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0,2"),
+				buildCPUSetPod(t, "pod-2", node.Name, "4"),
+				buildCPUSetPod(t, "pod-3", node.Name, "8-15"),
+			},
+			excluded:   sets.New[types.UID]("pod-2"),
+			wantFree:   6,
+			wantUsable: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newNodeCPUState(node, nrt, tt.pods)
+			assert.NotNil(t, state)
+			got := state.fragmentation(4, tt.excluded)
+			assert.Equal(t, tt.wantFree, got.freeCPUs)
+			assert.Equal(t, tt.wantUsable, got.usableCPUs)
+		})
+	}
+
+	assert.Nil(t, newNodeCPUState(node, &nrtv1alpha1.NodeResourceTopology{ObjectMeta: metav1.ObjectMeta{Name: node.Name}}, nil))
+}
+
+func TestPlanDefragmentation(t *testing.T) {
+	node := test.BuildTestNode("node1", 16000, 16000, 10, nil)
+	nrt := buildTestNRT(t, node.Name)
+	tests := []struct {
+		name         string
+		pods         []*corev1.Pod
+		maxEvictions int
+		wantPods     []string
+		wantNUMANode int
+	}{
+		{
+			name: "choose the cheapest pod",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0,2"),
+				buildCPUSetPod(t, "pod-2", node.Name, "4"),
+				buildCPUSetPod(t, "pod-3", node.Name, "8-15"),
+			},
+			maxEvictions: 2,
+			wantPods:     []string{"pod-2"},
+		},
+		{
+			name: "migrate multiple pods",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0-7"),
+				buildCPUSetPod(t, "pod-2", node.Name, "8"),
+				buildCPUSetPod(t, "pod-3", node.Name, "10"),
+				buildCPUSetPod(t, "pod-4", node.Name, "12-15"),
+			},
+			maxEvictions: 2,
+			wantPods:     []string{"pod-2", "pod-3"},
+			wantNUMANode: 1,
+		},
+		{
+			name: "exceed the max evictions",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0-7"),
+				buildCPUSetPod(t, "pod-2", node.Name, "8"),
+				buildCPUSetPod(t, "pod-3", node.Name, "10"),
+				buildCPUSetPod(t, "pod-4", node.Name, "12-15"),
+			},
+			maxEvictions: 1,
+		},
+		{
+			name: "migration costs more than the probe pod",
+			pods: []*corev1.Pod{
+				buildCPUSetPod(t, "pod-1", node.Name, "0,2,4,6"),
+				buildCPUSetPod(t, "pod-2", node.Name, "8-15"),
+			},
+			maxEvictions: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newNodeCPUState(node, nrt, tt.pods)
+			assert.NotNil(t, state)
+			plan := state.planDefragmentation(state.allocations, 4, tt.maxEvictions)
+			if tt.wantPods == nil {
+				assert.Nil(t, plan)
+				return
+			}
+			assert.NotNil(t, plan)
+			assert.Equal(t, tt.wantPods, podNames(plan.pods))
+			assert.Equal(t, tt.wantNUMANode, plan.numaNode)
+		})
+	}
+}
+
+func TestCPUSetFragmentationBalance(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 16000, 16000, 10, func(n *corev1.Node) {
+		n.Labels = map[string]string{"pool": "test"}
+	})
+	node2 := test.BuildTestNode("node2", 16000, 16000, 10, nil)
+	fragmentedPods := func(nodeName string) []*corev1.Pod {
+		return []*corev1.Pod{
+			buildCPUSetPod(t, nodeName+"-pod-1", nodeName, "0,2"),
+			buildCPUSetPod(t, nodeName+"-pod-2", nodeName, "4"),
+			buildCPUSetPod(t, nodeName+"-pod-3", nodeName, "8-15"),
+		}
+	}
+	defaultArgs := func() *deschedulerconfig.CPUSetFragmentationArgs {
+		return &deschedulerconfig.CPUSetFragmentationArgs{
+			ProbeCPUs:              4,
+			FragmentationThreshold: 0.3,
+			MaxEvictionsPerNode:    2,
+		}
+	}
+	tests := []struct {
+		name              string
+		args              func(args *deschedulerconfig.CPUSetFragmentationArgs)
+		nodes             []*corev1.Node
+		pods              []*corev1.Pod
+		preEvictionFilter func(pod *corev1.Pod) bool
+		wantEvicted       []string
+	}{
+		{
+			name:        "evict the cheapest pod",
+			nodes:       []*corev1.Node{node1},
+			pods:        fragmentedPods("node1"),
+			wantEvicted: []string{"node1-pod-2"},
+		},
+		{
+			name: "paused",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.Paused = true
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods("node1"),
+		},
+		{
+			name: "dry run",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.DryRun = true
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods("node1"),
+		},
+		{
+			name: "fragmentation below the threshold",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.FragmentationThreshold = 1
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods("node1"),
+		},
+		{
+			name: "probe CPUs are not full physical cores",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.ProbeCPUs = 3
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods("node1"),
+		},
+		{
+			name: "node selector filters nodes",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "test"}}
+			},
+			nodes:       []*corev1.Node{node1, node2},
+			pods:        append(fragmentedPods("node1"), fragmentedPods("node2")...),
+			wantEvicted: []string{"node1-pod-2"},
+		},
+		{
+			name: "pod selector filters pods",
+			args: func(args *deschedulerconfig.CPUSetFragmentationArgs) {
+				args.PodSelectors = []deschedulerconfig.FragmentationAwarePodSelector{
+					{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}},
+				}
+			},
+			nodes: []*corev1.Node{node1},
+			pods: func() []*corev1.Pod {
+				pods := fragmentedPods("node1")
+				pods[0].Labels = map[string]string{"app": "test"}
+				return pods
+			}(),
+			wantEvicted: []string{"node1-pod-1"},
+		},
+		{
+			name:  "skip the node if any pod in the plan fails the pre-eviction filter",
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods("node1"),
+			preEvictionFilter: func(pod *corev1.Pod) bool {
+				return false
+			},
+		},
+		{
+			name:  "skip the node without NodeResourceTopology",
+			nodes: []*corev1.Node{test.BuildTestNode("node3", 16000, 16000, 10, nil)},
+			pods:  fragmentedPods("node3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := defaultArgs()
+			if tt.args != nil {
+				tt.args(args)
+			}
+			evictor := &fakeEvictor{preEvictionFilter: tt.preEvictionFilter}
+			handle := &fakeHandle{evictor: evictor, pods: tt.pods}
+			podFilter, err := utils.NewFragmentationPodSelectorFilter(args.PodSelectors)
+			assert.NoError(t, err)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NoError(t, indexer.Add(buildTestNRT(t, node1.Name)))
+			assert.NoError(t, indexer.Add(buildTestNRT(t, node2.Name)))
+			pl := &CPUSetFragmentation{
+				handle:    handle,
+				args:      args,
+				podFilter: podFilter,
+				nrtLister: nrtlisters.NewNodeResourceTopologyLister(indexer),
+			}
+			status := pl.Balance(context.TODO(), tt.nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, podNames(evictor.evicted))
+			for i, jobCtx := range evictor.jobContexts {
+				assert.NotNil(t, jobCtx)
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, jobCtx.Mode)
+				template := jobCtx.ReservationOptions.Template.Spec.Template
+				assert.NotContains(t, template.Annotations, extension.AnnotationResourceStatus)
+				assert.Equal(t, evictor.evicted[i].Spec, template.Spec)
+			}
+		})
+	}
+}
+
+func TestNewCPUSetFragmentationErrors(t *testing.T) {
+	handle := &fakeHandle{evictor: &fakeEvictor{}}
+	_, err := NewCPUSetFragmentation(context.TODO(), &deschedulerconfig.FragmentationAwareArgs{}, handle)
+	assert.Error(t, err)
+
+	_, err = NewCPUSetFragmentation(context.TODO(), &deschedulerconfig.CPUSetFragmentationArgs{}, handle)
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpusetfragmentation
+
+import (
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/nodenumaresource"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// podCPUAllocation is the cpuset allocated to a pod, as the nodenumaresource plugin records it in the pod annotations.
+type podCPUAllocation struct {
+	pod             *corev1.Pod
+	cpus            cpuset.CPUSet
+	exclusivePolicy schedulingconfig.CPUExclusivePolicy
+}
+
+// nodeCPUState is the cpuset allocation state of a node.
+type nodeCPUState struct {
+	topologyOptions      nodenumaresource.TopologyOptions
+	numaAllocateStrategy schedulingconfig.NUMAAllocateStrategy
+	allocations          []*podCPUAllocation
+}
+
+// fragmentation describes how the free CPUs of a node are fragmented for the probe pods.
+type fragmentation struct {
+	// freeCPUs is the number of CPUs not allocated on the node.
+	freeCPUs int
+	// usableCPUs is the number of free CPUs which can be allocated to the probe pods.
+	usableCPUs int
+}
+
+func (f fragmentation) ratio() float64 {
+	if f.freeCPUs <= 0 {
+		return 0
+	}
+	return float64(f.freeCPUs-f.usableCPUs) / float64(f.freeCPUs)
+}
+
+// defragmentationPlan is a set of pods whose migration makes room for one more probe pod.
+type defragmentationPlan struct {
+	numaNode int
+	pods     []*corev1.Pod
+	// cost is the number of CPUs allocated to the pods
+	cost int
+}
+
+func newNodeCPUState(node *corev1.Node, nrt *nrtv1alpha1.NodeResourceTopology, pods []*corev1.Pod) *nodeCPUState {
+	topologyOptions := nodenumaresource.NewTopologyOptions(nrt)
+	if topologyOptions.CPUTopology == nil || !topologyOptions.CPUTopology.IsValid() {
+		return nil
+	}
+	state := &nodeCPUState{
+		topologyOptions:      topologyOptions,
+		numaAllocateStrategy: nodenumaresource.GetNUMAAllocateStrategy(node, nodenumaresource.GetDefaultNUMAAllocateStrategy(nil)),
+	}
+	for _, pod := range pods {
+		if util.IsPodTerminated(pod) {
+			continue
+		}
+		resourceStatus, err := extension.GetResourceStatus(pod.Annotations)
+		if err != nil || resourceStatus.CPUSet == "" {
+			continue
+		}
+		cpus, err := cpuset.Parse(resourceStatus.CPUSet)
+		if err != nil || cpus.IsEmpty() {
+			continue
+		}
+		resourceSpec, err := extension.GetResourceSpec(pod.Annotations)
+		if err != nil {
+			continue
+		}
+		state.allocations = append(state.allocations, &podCPUAllocation{
+			pod:             pod,
+			cpus:            cpus,
+			exclusivePolicy: resourceSpec.PreferredCPUExclusivePolicy,
+		})
+	}
+	return state
+}
+
+// allocatedCPUs returns the CPUs allocated by the pods except the excluded ones.
+func (s *nodeCPUState) allocatedCPUs(excluded sets.Set[types.UID]) nodenumaresource.CPUDetails {
+	cpuDetails := s.topologyOptions.CPUTopology.CPUDetails
+	allocated := nodenumaresource.NewCPUDetails()
+	for _, allocation := range s.allocations {
+		if excluded.Has(allocation.pod.UID) {
+			continue
+		}
+		for _, cpuID := range allocation.cpus.ToSliceNoSort() {
+			cpuInfo, ok := allocated[cpuID]
+			if !ok {
+				cpuInfo = cpuDetails[cpuID]
+			}
+			cpuInfo.ExclusivePolicy = allocation.exclusivePolicy
+			cpuInfo.RefCount++
+			allocated[cpuID] = cpuInfo
+		}
+	}
+	return allocated
+}
+
+func (s *nodeCPUState) availableCPUs(allocated nodenumaresource.CPUDetails) cpuset.CPUSet {
+	maxRefCount := s.topologyOptions.MaxRefCount
+	used := allocated.CPUs().Filter(func(cpuID int) bool {
+		return allocated[cpuID].RefCount >= maxRefCount
+	})
+	return s.topologyOptions.CPUTopology.CPUDetails.CPUs().Difference(used).Difference(s.topologyOptions.ReservedCPUs)
+}
+
+// probeNUMANode returns the number of CPUs in the NUMA node which can be allocated to the probe pods,
+// the probe pod requires the FullPCPUs bind policy and is bound to a single NUMA node.
+func (s *nodeCPUState) probeNUMANode(numaNode int, available cpuset.CPUSet, allocated nodenumaresource.CPUDetails, probeCPUs int) int {
+	topology := s.topologyOptions.CPUTopology
+	available = available.Intersection(topology.CPUDetails.CPUsInNUMANodes(numaNode))
+	allocated = allocated.Clone()
+	usable := 0
+	for available.Size() >= probeCPUs {
+		cpus, err := nodenumaresource.TakeCPUs(topology, s.topologyOptions.MaxRefCount, available, allocated, probeCPUs,
+			schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUExclusivePolicyNone, s.numaAllocateStrategy)
+		if err != nil || cpus.Size() != probeCPUs || !isFullPCPUs(topology, cpus) {
+			break
+		}
+		usable += probeCPUs
+		available = available.Difference(cpus)
+		for _, cpuID := range cpus.ToSliceNoSort() {
+			cpuInfo := topology.CPUDetails[cpuID]
+			cpuInfo.RefCount++
+			allocated[cpuID] = cpuInfo
+		}
+	}
+	return usable
+}
+
+// isFullPCPUs checks if the CPUs occupy the whole physical cores.
+func isFullPCPUs(topology *nodenumaresource.CPUTopology, cpus cpuset.CPUSet) bool {
+	cores := topology.CPUDetails.KeepOnly(cpus).Cores()
+	return topology.CPUDetails.CPUsInCores(cores.ToSliceNoSort()...).Equals(cpus)
+}
+
+func (s *nodeCPUState) numaNodes() []int {
+	return s.topologyOptions.CPUTopology.CPUDetails.NUMANodes().ToSlice()
+}
+
+// fragmentation calculates the fragmentation of the node if the excluded pods are migrated.
+func (s *nodeCPUState) fragmentation(probeCPUs int, excluded sets.Set[types.UID]) fragmentation {
+	allocated := s.allocatedCPUs(excluded)
+	available := s.availableCPUs(allocated)
+	result := fragmentation{freeCPUs: available.Size()}
+	for _, numaNode := range s.numaNodes() {
+		result.usableCPUs += s.probeNUMANode(numaNode, available, allocated, probeCPUs)
+	}
+	return result
+}
+
+// planDefragmentation finds the cheapest set of the candidate pods whose migration makes room for one more probe pod
+// in a NUMA node. The plan is only worth it if the CPUs of the migrated pods are fewer than the CPUs of the probe pod.
+func (s *nodeCPUState) planDefragmentation(candidates []*podCPUAllocation, probeCPUs, maxEvictions int) *defragmentationPlan {
+	allocated := s.allocatedCPUs(nil)
+	available := s.availableCPUs(allocated)
+	topology := s.topologyOptions.CPUTopology
+
+	var best *defragmentationPlan
+	for _, numaNode := range s.numaNodes() {
+		cpusInNUMANode := topology.CPUDetails.CPUsInNUMANodes(numaNode)
+		var podsInNUMANode []*podCPUAllocation
+		for _, candidate := range candidates {
+			if !candidate.cpus.Intersection(cpusInNUMANode).IsEmpty() {
+				podsInNUMANode = append(podsInNUMANode, candidate)
+			}
+		}
+		if len(podsInNUMANode) == 0 {
+			continue
+		}
+		usableBefore := s.probeNUMANode(numaNode, available, allocated, probeCPUs)
+
+		excluded := sets.New[types.UID]()
+		var selected []*podCPUAllocation
+		var search func(start, cost int)
+		search = func(start, cost int) {
+			if len(selected) > 0 {
+				allocatedAfter := s.allocatedCPUs(excluded)
+				availableAfter := s.availableCPUs(allocatedAfter)
+				if s.probeNUMANode(numaNode, availableAfter, allocatedAfter, probeCPUs) >= usableBefore+probeCPUs {
+					if best == nil || cost < best.cost || (cost == best.cost && len(selected) < len(best.pods)) {
+						best = &defragmentationPlan{numaNode: numaNode, cost: cost}
+						for _, v := range selected {
+							best.pods = append(best.pods, v.pod)
+						}
+					}
+					return
+				}
+			}
+			if len(selected) >= maxEvictions {
+				return
+			}
+			for i := start; i < len(podsInNUMANode); i++ {
+				candidate := podsInNUMANode[i]
+				newCost := cost + candidate.cpus.Size()
+				if newCost >= probeCPUs || (best != nil && newCost > best.cost) {
+					continue
+				}
+				selected = append(selected, candidate)
+				excluded.Insert(candidate.pod.UID)
+				search(i+1, newCost)
+				excluded.Delete(candidate.pod.UID)
+				selected = selected[:len(selected)-1]
+			}
+		}
+		search(0, 0)
+	}
+	return best
+}
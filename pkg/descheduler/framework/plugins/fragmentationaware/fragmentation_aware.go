@@ -21,8 +21,6 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -32,6 +30,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
 )
 
 const (
@@ -56,7 +55,7 @@ func NewFragmentationAware(ctx context.Context, args runtime.Object, handle fram
 		return nil, err
 	}
 
-	podSelectorFn, err := filterPods(pluginArgs.PodSelectors)
+	podSelectorFn, err := utils.NewFragmentationPodSelectorFilter(pluginArgs.PodSelectors)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
 	}
@@ -84,31 +83,6 @@ func NewFragmentationAware(ctx context.Context, args runtime.Object, handle fram
 	}, nil
 }
 
-func filterPods(podSelectors []deschedulerconfig.FragmentationAwarePodSelector) (framework.FilterFunc, error) {
-	var selectors []labels.Selector
-	for _, v := range podSelectors {
-		if v.Selector != nil {
-			selector, err := metav1.LabelSelectorAsSelector(v.Selector)
-			if err != nil {
-				return nil, fmt.Errorf("invalid labelSelector %w", err)
-			}
-			selectors = append(selectors, selector)
-		}
-	}
-
-	return func(pod *corev1.Pod) bool {
-		if len(selectors) == 0 {
-			return true
-		}
-		for _, v := range selectors {
-			if v.Matches(labels.Set(pod.Labels)) {
-				return true
-			}
-		}
-		return false
-	}, nil
-}
-
 func (pl *FragmentationAware) Name() string {
 	return FragmentationAwareName
 }
@@ -120,7 +94,7 @@ func (pl *FragmentationAware) Balance(ctx context.Context, nodes []*corev1.Node)
 	}
 
 	ctx = framework.PluginNameWithContext(ctx, pl.Name())
-	candidateNodes, err := pl.filterNodesByNodeSelector(nodes)
+	candidateNodes, err := utils.FilterNodesByLabelSelector(nodes, pl.args.NodeSelector)
 	if err != nil {
 		return &framework.Status{Err: err}
 	}
@@ -159,23 +133,6 @@ func (pl *FragmentationAware) Balance(ctx context.Context, nodes []*corev1.Node)
 	return nil
 }
 
-func (pl *FragmentationAware) filterNodesByNodeSelector(nodes []*corev1.Node) ([]*corev1.Node, error) {
-	if pl.args.NodeSelector == nil {
-		return nodes, nil
-	}
-	selector, err := metav1.LabelSelectorAsSelector(pl.args.NodeSelector)
-	if err != nil {
-		return nil, err
-	}
-	var filtered []*corev1.Node
-	for _, node := range nodes {
-		if selector.Matches(labels.Set(node.Labels)) {
-			filtered = append(filtered, node)
-		}
-	}
-	return filtered, nil
-}
-
 type evictionCandidate struct {
 	pod       *corev1.Pod
 	gain      float64
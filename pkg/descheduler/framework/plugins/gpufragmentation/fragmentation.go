@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const defaultGPUResourceCapacity = 100
+
+// gpuInfo is the usage of a GPU on the node.
+type gpuInfo struct {
+	minor            int32
+	coreCapacity     int64
+	memRatioCapacity int64
+	usedCore         int64
+	usedMemRatio     int64
+	pods             []*podGPUAllocation
+}
+
+// podGPUAllocation is the GPUs allocated to a pod, as the deviceshare plugin records it in the pod annotations.
+type podGPUAllocation struct {
+	pod         *corev1.Pod
+	allocations []*extension.DeviceAllocation
+	// shared indicates that the pod only uses a part of each allocated GPU
+	shared bool
+}
+
+// nodeGPUState is the GPU allocation state of a node.
+type nodeGPUState struct {
+	gpus        []*gpuInfo
+	allocations []*podGPUAllocation
+}
+
+// fragmentation describes how the free GPU capacity of a node is fragmented, measured in GPUs.
+type fragmentation struct {
+	// freeGPUs is the free capacity of all healthy GPUs.
+	freeGPUs float64
+	// strandedGPUs is the free capacity of the partially used GPUs, which whole-GPU pods cannot use.
+	strandedGPUs float64
+}
+
+func (f fragmentation) ratio() float64 {
+	if f.freeGPUs <= 0 {
+		return 0
+	}
+	return f.strandedGPUs / f.freeGPUs
+}
+
+func (g *gpuInfo) usage() float64 {
+	core := float64(g.usedCore) / float64(g.coreCapacity)
+	memRatio := float64(g.usedMemRatio) / float64(g.memRatioCapacity)
+	if memRatio > core {
+		return memRatio
+	}
+	return core
+}
+
+func newNodeGPUState(device *schedulingv1alpha1.Device, pods []*corev1.Pod) *nodeGPUState {
+	state := &nodeGPUState{}
+	gpus := map[int32]*gpuInfo{}
+	for _, info := range device.Spec.Devices {
+		if info.Type != schedulingv1alpha1.GPU || !info.Health || info.Minor == nil {
+			continue
+		}
+		gpu := &gpuInfo{
+			minor:            *info.Minor,
+			coreCapacity:     defaultGPUResourceCapacity,
+			memRatioCapacity: defaultGPUResourceCapacity,
+		}
+		if quantity, ok := info.Resources[extension.ResourceGPUCore]; ok && quantity.Value() > 0 {
+			gpu.coreCapacity = quantity.Value()
+		}
+		if quantity, ok := info.Resources[extension.ResourceGPUMemoryRatio]; ok && quantity.Value() > 0 {
+			gpu.memRatioCapacity = quantity.Value()
+		}
+		gpus[gpu.minor] = gpu
+		state.gpus = append(state.gpus, gpu)
+	}
+	sort.Slice(state.gpus, func(i, j int) bool {
+		return state.gpus[i].minor < state.gpus[j].minor
+	})
+
+	for _, pod := range pods {
+		if util.IsPodTerminated(pod) {
+			continue
+		}
+		deviceAllocations, err := extension.GetDeviceAllocations(pod.Annotations)
+		if err != nil || len(deviceAllocations[schedulingv1alpha1.GPU]) == 0 {
+			continue
+		}
+		allocation := &podGPUAllocation{pod: pod, shared: true}
+		for _, v := range deviceAllocations[schedulingv1alpha1.GPU] {
+			gpu := gpus[v.Minor]
+			if gpu == nil {
+				continue
+			}
+			core := v.Resources[extension.ResourceGPUCore]
+			memRatio := v.Resources[extension.ResourceGPUMemoryRatio]
+			gpu.usedCore += core.Value()
+			gpu.usedMemRatio += memRatio.Value()
+			gpu.pods = append(gpu.pods, allocation)
+			if core.Value() >= gpu.coreCapacity || memRatio.Value() >= gpu.memRatioCapacity {
+				allocation.shared = false
+			}
+			allocation.allocations = append(allocation.allocations, v)
+		}
+		if len(allocation.allocations) > 0 {
+			state.allocations = append(state.allocations, allocation)
+		}
+	}
+	return state
+}
+
+func (s *nodeGPUState) fragmentation() fragmentation {
+	var result fragmentation
+	for _, gpu := range s.gpus {
+		usage := gpu.usage()
+		if usage >= 1 {
+			continue
+		}
+		result.freeGPUs += 1 - usage
+		if usage > 0 {
+			result.strandedGPUs += 1 - usage
+		}
+	}
+	return result
+}
+
+// planDefragmentation selects the shared-GPU pods to migrate. The partially used GPUs are freed one by one,
+// from the least used one, and a GPU is only freed if all of its pods can be migrated within the limit.
+func (s *nodeGPUState) planDefragmentation(candidates sets.Set[types.UID], maxEvictions int) []*corev1.Pod {
+	partialGPUs := make([]*gpuInfo, 0, len(s.gpus))
+	for _, gpu := range s.gpus {
+		if usage := gpu.usage(); usage > 0 && usage < 1 {
+			partialGPUs = append(partialGPUs, gpu)
+		}
+	}
+	sort.SliceStable(partialGPUs, func(i, j int) bool {
+		return partialGPUs[i].usage() < partialGPUs[j].usage()
+	})
+
+	selected := sets.New[types.UID]()
+	var pods []*corev1.Pod
+	for _, gpu := range partialGPUs {
+		var newPods []*corev1.Pod
+		freeable := true
+		for _, allocation := range gpu.pods {
+			uid := allocation.pod.UID
+			if selected.Has(uid) {
+				continue
+			}
+			if !allocation.shared || !candidates.Has(uid) {
+				freeable = false
+				break
+			}
+			newPods = append(newPods, allocation.pod)
+		}
+		if !freeable || len(pods)+len(newPods) > maxEvictions {
+			continue
+		}
+		for _, pod := range newPods {
+			selected.Insert(pod.UID)
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+const (
+	GPUFragmentationName = "GPUFragmentation"
+)
+
+var _ framework.BalancePlugin = &GPUFragmentation{}
+
+// GPUFragmentation migrates the shared-GPU pods out of the partially used GPUs,
+// so that the pods requiring whole GPUs can be scheduled.
+type GPUFragmentation struct {
+	handle       framework.Handle
+	podFilter    framework.FilterFunc
+	args         *deschedulerconfig.GPUFragmentationArgs
+	deviceLister schedulinglister.DeviceLister
+}
+
+func NewGPUFragmentation(ctx context.Context, args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	pluginArgs, ok := args.(*deschedulerconfig.GPUFragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type GPUFragmentationArgs, got %T", args)
+	}
+
+	if err := validation.ValidateGPUFragmentationArgs(nil, pluginArgs); err != nil {
+		return nil, err
+	}
+
+	podSelectorFn, err := utils.NewFragmentationPodSelectorFilter(pluginArgs.PodSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if pluginArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(pluginArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(pluginArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, podSelectorFn)).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	deviceInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Devices()
+	deviceInformer.Informer()
+	koordSharedInformerFactory.Start(ctx.Done())
+	koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	return &GPUFragmentation{
+		handle:       handle,
+		args:         pluginArgs,
+		podFilter:    podFilter,
+		deviceLister: deviceInformer.Lister(),
+	}, nil
+}
+
+func (pl *GPUFragmentation) Name() string {
+	return GPUFragmentationName
+}
+
+func (pl *GPUFragmentation) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("GPUFragmentation is paused and will do nothing.")
+		return nil
+	}
+
+	ctx = framework.PluginNameWithContext(ctx, pl.Name())
+	candidateNodes, err := utils.FilterNodesByLabelSelector(nodes, pl.args.NodeSelector)
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+
+	for _, node := range candidateNodes {
+		pods := pl.planNode(node, candidateNodes)
+		if len(pods) == 0 {
+			continue
+		}
+		evictable := true
+		for _, pod := range pods {
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				evictable = false
+				break
+			}
+		}
+		if !evictable {
+			continue
+		}
+		for _, pod := range pods {
+			pl.evictPod(ctx, node, pod)
+		}
+	}
+
+	return nil
+}
+
+func (pl *GPUFragmentation) planNode(node *corev1.Node, candidateNodes []*corev1.Node) []*corev1.Pod {
+	device, err := pl.deviceLister.Get(node.Name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get Device", "node", node.Name, "err", err)
+		return nil
+	}
+
+	allPods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get pods assigned to node", "node", node.Name)
+		return nil
+	}
+	state := newNodeGPUState(device, allPods)
+	frag := state.fragmentation()
+	// it is not worth migrating if the stranded capacity cannot make up a whole GPU
+	if frag.strandedGPUs < 1 || frag.ratio() <= pl.args.FragmentationThreshold {
+		return nil
+	}
+	klog.V(4).InfoS("Node GPU is fragmented", "node", node.Name,
+		"freeGPUs", frag.freeGPUs, "strandedGPUs", frag.strandedGPUs, "ratio", frag.ratio())
+
+	candidates := sets.New[types.UID]()
+	for _, allocation := range state.allocations {
+		if !allocation.shared || !pl.podFilter(allocation.pod) {
+			continue
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), allocation.pod, candidateNodes) {
+			continue
+		}
+		candidates.Insert(allocation.pod.UID)
+	}
+	return state.planDefragmentation(candidates, int(pl.args.MaxEvictionsPerNode))
+}
+
+func (pl *GPUFragmentation) evictPod(ctx context.Context, node *corev1.Node, pod *corev1.Pod) {
+	reason := fmt.Sprintf("GPU defragmentation: migrate the shared-GPU pod to free whole GPUs on node %s", node.Name)
+	if pl.args.DryRun {
+		klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", node.Name, "reason", reason)
+		return
+	}
+	// reserve the GPUs on the target node before the pod is evicted. The device allocation on the current node
+	// is dropped so that the scheduler allocates the GPUs again.
+	jobCtx := &migration.JobContext{
+		Mode:               sev1alpha1.PodMigrationJobModeReservationFirst,
+		ReservationOptions: utils.BuildMigrateReservationOptions(pod, extension.AnnotationDeviceAllocated),
+	}
+	pl.handle.Evictor().Evict(migration.WithContext(ctx, jobCtx), pod, framework.EvictOptions{
+		PluginName: pl.Name(),
+		Reason:     reason,
+	})
+}
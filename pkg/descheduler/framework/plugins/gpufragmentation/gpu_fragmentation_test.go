@@ -0,0 +1,347 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	schedulinglister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+type fakeEvictor struct {
+	evicted           []*corev1.Pod
+	jobContexts       []*migration.JobContext
+	preEvictionFilter func(pod *corev1.Pod) bool
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	if e.preEvictionFilter != nil {
+		return e.preEvictionFilter(pod)
+	}
+	return true
+}
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, opts framework.EvictOptions) bool {
+	e.evicted = append(e.evicted, pod)
+	e.jobContexts = append(e.jobContexts, migration.FromContext(ctx))
+	return true
+}
+
+type fakeHandle struct {
+	evictor *fakeEvictor
+	pods    []*corev1.Pod
+}
+
+func (h *fakeHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+		var res []*corev1.Pod
+		for _, pod := range h.pods {
+			if pod.Spec.NodeName == nodeName {
+				if filter == nil || filter(pod) {
+					res = append(res, pod)
+				}
+			}
+		}
+		return res, nil
+	}
+}
+
+func (h *fakeHandle) ClientSet() clientset.Interface                         { return nil }
+func (h *fakeHandle) KubeConfig() *restclient.Config                         { return nil }
+func (h *fakeHandle) EventRecorder() events.EventRecorder                    { return nil }
+func (h *fakeHandle) IsDryRun() bool                                         { return false }
+func (h *fakeHandle) SharedInformerFactory() informers.SharedInformerFactory { return nil }
+func (h *fakeHandle) NodeSelector() *metav1.LabelSelector                    { return nil }
+func (h *fakeHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return nil
+}
+func (h *fakeHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return nil
+}
+
+// buildTestDevice builds a Device with 4 healthy GPUs.
+func buildTestDevice(nodeName string) *sev1alpha1.Device {
+	device := &sev1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	}
+	for i := int32(0); i < 4; i++ {
+		device.Spec.Devices = append(device.Spec.Devices, sev1alpha1.DeviceInfo{
+			Type:   sev1alpha1.GPU,
+			Minor:  ptr.To[int32](i),
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore:        resource.MustParse("100"),
+				extension.ResourceGPUMemoryRatio: resource.MustParse("100"),
+			},
+		})
+	}
+	return device
+}
+
+func buildGPUPod(t *testing.T, name, nodeName string, minor int32, percent int64) *corev1.Pod {
+	return test.BuildTestPod(name, 1000, 1000, nodeName, func(pod *corev1.Pod) {
+		pod.UID = types.UID(name)
+		assert.NoError(t, extension.SetDeviceAllocations(pod, extension.DeviceAllocations{
+			sev1alpha1.GPU: {
+				{
+					Minor: minor,
+					Resources: corev1.ResourceList{
+						extension.ResourceGPUCore:        *resource.NewQuantity(percent, resource.DecimalSI),
+						extension.ResourceGPUMemoryRatio: *resource.NewQuantity(percent, resource.DecimalSI),
+					},
+				},
+			},
+		}))
+	})
+}
+
+// fragmentedPods leaves the GPU 0 and GPU 1 partially used, the GPU 2 fully used and the GPU 3 free.
+func fragmentedPods(t *testing.T, nodeName string) []*corev1.Pod {
+	return []*corev1.Pod{
+		buildGPUPod(t, nodeName+"-pod-1", nodeName, 0, 40),
+		buildGPUPod(t, nodeName+"-pod-2", nodeName, 1, 30),
+		buildGPUPod(t, nodeName+"-pod-3", nodeName, 1, 20),
+		buildGPUPod(t, nodeName+"-pod-4", nodeName, 2, 100),
+	}
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestNodeGPUStateFragmentation(t *testing.T) {
+	device := buildTestDevice("node1")
+	state := newNodeGPUState(device, fragmentedPods(t, "node1"))
+	got := state.fragmentation()
+	assert.InDelta(t, 2.1, got.freeGPUs, 0.001)
+	assert.InDelta(t, 1.1, got.strandedGPUs, 0.001)
+	assert.InDelta(t, 0.524, got.ratio(), 0.001)
+
+	assert.Len(t, state.allocations, 4)
+	assert.True(t, state.allocations[0].shared)
+	assert.False(t, state.allocations[3].shared)
+
+	unhealthy := device.DeepCopy()
+	for i := range unhealthy.Spec.Devices {
+		unhealthy.Spec.Devices[i].Health = false
+	}
+	got = newNodeGPUState(unhealthy, fragmentedPods(t, "node1")).fragmentation()
+	assert.Equal(t, fragmentation{}, got)
+	assert.Equal(t, float64(0), got.ratio())
+}
+
+func TestPlanDefragmentation(t *testing.T) {
+	tests := []struct {
+		name         string
+		candidates   []string
+		maxEvictions int
+		want         []string
+	}{
+		{
+			name:         "free the least used GPU first",
+			candidates:   []string{"pod-1", "pod-2", "pod-3"},
+			maxEvictions: 2,
+			want:         []string{"pod-1"},
+		},
+		{
+			name:         "free multiple GPUs",
+			candidates:   []string{"pod-1", "pod-2", "pod-3"},
+			maxEvictions: 3,
+			want:         []string{"pod-1", "pod-2", "pod-3"},
+		},
+		{
+			name:         "skip the GPU with pods cannot be migrated",
+			candidates:   []string{"pod-2", "pod-3"},
+			maxEvictions: 2,
+			want:         []string{"pod-2", "pod-3"},
+		},
+		{
+			name:         "no GPU can be freed",
+			candidates:   []string{"pod-2"},
+			maxEvictions: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newNodeGPUState(buildTestDevice("node1"), fragmentedPods(t, "node1"))
+			candidates := sets.New[types.UID]()
+			for _, v := range tt.candidates {
+				candidates.Insert(types.UID("node1-" + v))
+			}
+			var want []string
+			for _, v := range tt.want {
+				want = append(want, "node1-"+v)
+			}
+			assert.Equal(t, want, podNames(state.planDefragmentation(candidates, tt.maxEvictions)))
+		})
+	}
+}
+
+func TestGPUFragmentationBalance(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 16000, 16000, 10, func(n *corev1.Node) {
+		n.Labels = map[string]string{"pool": "test"}
+	})
+	node2 := test.BuildTestNode("node2", 16000, 16000, 10, nil)
+	tests := []struct {
+		name              string
+		args              func(args *deschedulerconfig.GPUFragmentationArgs)
+		nodes             []*corev1.Node
+		pods              []*corev1.Pod
+		preEvictionFilter func(pod *corev1.Pod) bool
+		wantEvicted       []string
+	}{
+		{
+			name:        "migrate the shared-GPU pods",
+			nodes:       []*corev1.Node{node1},
+			pods:        fragmentedPods(t, "node1"),
+			wantEvicted: []string{"node1-pod-1"},
+		},
+		{
+			name: "paused",
+			args: func(args *deschedulerconfig.GPUFragmentationArgs) {
+				args.Paused = true
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods(t, "node1"),
+		},
+		{
+			name: "dry run",
+			args: func(args *deschedulerconfig.GPUFragmentationArgs) {
+				args.DryRun = true
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods(t, "node1"),
+		},
+		{
+			name: "fragmentation below the threshold",
+			args: func(args *deschedulerconfig.GPUFragmentationArgs) {
+				args.FragmentationThreshold = 0.6
+			},
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods(t, "node1"),
+		},
+		{
+			name:  "stranded capacity less than a whole GPU",
+			nodes: []*corev1.Node{node1},
+			pods: []*corev1.Pod{
+				buildGPUPod(t, "node1-pod-1", "node1", 0, 40),
+				buildGPUPod(t, "node1-pod-2", "node1", 1, 100),
+				buildGPUPod(t, "node1-pod-3", "node1", 2, 100),
+				buildGPUPod(t, "node1-pod-4", "node1", 3, 100),
+			},
+		},
+		{
+			name: "node selector filters nodes",
+			args: func(args *deschedulerconfig.GPUFragmentationArgs) {
+				args.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "test"}}
+			},
+			nodes:       []*corev1.Node{node1, node2},
+			pods:        append(fragmentedPods(t, "node1"), fragmentedPods(t, "node2")...),
+			wantEvicted: []string{"node1-pod-1"},
+		},
+		{
+			name:  "skip the node if any pod fails the pre-eviction filter",
+			nodes: []*corev1.Node{node1},
+			pods:  fragmentedPods(t, "node1"),
+			preEvictionFilter: func(pod *corev1.Pod) bool {
+				return false
+			},
+		},
+		{
+			name:  "skip the node without Device",
+			nodes: []*corev1.Node{test.BuildTestNode("node3", 16000, 16000, 10, nil)},
+			pods:  fragmentedPods(t, "node3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &deschedulerconfig.GPUFragmentationArgs{
+				FragmentationThreshold: 0.5,
+				MaxEvictionsPerNode:    2,
+			}
+			if tt.args != nil {
+				tt.args(args)
+			}
+			evictor := &fakeEvictor{preEvictionFilter: tt.preEvictionFilter}
+			handle := &fakeHandle{evictor: evictor, pods: tt.pods}
+			podFilter, err := utils.NewFragmentationPodSelectorFilter(args.PodSelectors)
+			assert.NoError(t, err)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NoError(t, indexer.Add(buildTestDevice(node1.Name)))
+			assert.NoError(t, indexer.Add(buildTestDevice(node2.Name)))
+			pl := &GPUFragmentation{
+				handle:       handle,
+				args:         args,
+				podFilter:    podFilter,
+				deviceLister: schedulinglister.NewDeviceLister(indexer),
+			}
+			status := pl.Balance(context.TODO(), tt.nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, podNames(evictor.evicted))
+			for i, jobCtx := range evictor.jobContexts {
+				assert.NotNil(t, jobCtx)
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, jobCtx.Mode)
+				template := jobCtx.ReservationOptions.Template.Spec.Template
+				assert.NotContains(t, template.Annotations, extension.AnnotationDeviceAllocated)
+				assert.Equal(t, evictor.evicted[i].Spec, template.Spec)
+			}
+		})
+	}
+}
+
+func TestNewGPUFragmentationErrors(t *testing.T) {
+	handle := &fakeHandle{evictor: &fakeEvictor{}}
+	_, err := NewGPUFragmentation(context.TODO(), &deschedulerconfig.CPUSetFragmentationArgs{}, handle)
+	assert.Error(t, err)
+
+	_, err = NewGPUFragmentation(context.TODO(), &deschedulerconfig.GPUFragmentationArgs{}, handle)
+	assert.Error(t, err)
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	InterferenceAwareName = "InterferenceAware"
+)
+
+var _ framework.BalancePlugin = &InterferenceAware{}
+
+// InterferenceAware evicts pods from the nodes suffering from interference.
+// Unlike LowNodeLoad, it classifies the nodes with the PSI and CPI reported in the NodeMetric,
+// and picks the pods using most of the contended resources as victims.
+type InterferenceAware struct {
+	handle               framework.Handle
+	podFilter            framework.FilterFunc
+	nodeMetricLister     koordslolisters.NodeMetricLister
+	args                 *deschedulerconfig.InterferenceAwareArgs
+	nodeAnomalyDetectors *gocache.Cache
+}
+
+// NewInterferenceAware builds plugin from its arguments while passing a handle
+func NewInterferenceAware(ctx context.Context, args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	interferenceAwareArgs, ok := args.(*deschedulerconfig.InterferenceAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InterferenceAwareArgs, got %T", args)
+	}
+	if err := validation.ValidateInterferenceAwareArgs(nil, interferenceAwareArgs); err != nil {
+		return nil, err
+	}
+
+	podSelectorFn, err := filterPods(interferenceAwareArgs.PodSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if interferenceAwareArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(interferenceAwareArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(interferenceAwareArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, podSelectorFn)).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+	koordSharedInformerFactory.Start(ctx.Done())
+	koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	nodeAnomalyDetectors := gocache.New(interferenceAwareArgs.DetectorCacheTimeout.Duration, interferenceAwareArgs.DetectorCacheTimeout.Duration)
+
+	return &InterferenceAware{
+		handle:               handle,
+		nodeMetricLister:     nodeMetricInformer.Lister(),
+		args:                 interferenceAwareArgs,
+		podFilter:            podFilter,
+		nodeAnomalyDetectors: nodeAnomalyDetectors,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *InterferenceAware) Name() string {
+	return InterferenceAwareName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *InterferenceAware) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("InterferenceAware is paused and will do nothing.")
+		return nil
+	}
+
+	nodes, err := filterNodes(pl.args.NodeSelector, nodes, sets.NewString())
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+	thresholds := newInterferenceValues(&pl.args.Thresholds)
+	if len(thresholds) == 0 {
+		klog.V(4).InfoS("No interference thresholds are configured, nothing to do here")
+		return nil
+	}
+
+	for _, node := range nodes {
+		nodeMetric, err := pl.nodeMetricLister.Get(node.Name)
+		if err != nil {
+			klog.V(4).InfoS("Failed to get NodeMetric", "node", klog.KObj(node), "err", err)
+			continue
+		}
+		if nodeMetric.Status.NodeMetric == nil || pl.args.NodeMetricExpirationSeconds != nil &&
+			isNodeMetricExpired(nodeMetric.Status.UpdateTime, *pl.args.NodeMetricExpirationSeconds) {
+			klog.V(4).InfoS("NodeMetric has expired, skip the node", "node", klog.KObj(node))
+			continue
+		}
+
+		nodeValues := newInterferenceValuesFromMetric(nodeMetric.Status.NodeMetric.Interference)
+		exceeded := exceededInterferenceKinds(nodeValues, thresholds)
+		if len(exceeded) == 0 {
+			resetNodeAsNormal(node.Name, pl.nodeAnomalyDetectors)
+			continue
+		}
+		if !markNodeAsAbnormal(node.Name, pl.nodeAnomalyDetectors, pl.args.AnomalyCondition) {
+			klog.V(4).InfoS("Node is contended but not yet detected as anomalous", "node", klog.KObj(node))
+			continue
+		}
+
+		reason := interferenceEvictionReason(nodeValues, thresholds, exceeded)
+		klog.V(4).InfoS("Node is detected as contended", "node", klog.KObj(node), "reason", reason)
+		pl.evictPodsFromNode(ctx, node, nodes, nodeMetric, exceeded, reason)
+		tryMarkNodeAsNormal(node.Name, pl.nodeAnomalyDetectors)
+	}
+	return nil
+}
+
+func (pl *InterferenceAware) evictPodsFromNode(ctx context.Context, node *corev1.Node, nodes []*corev1.Node, nodeMetric *slov1alpha1.NodeMetric,
+	exceeded []interferenceKind, reason string) {
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+		return
+	}
+
+	podMetrics := make(map[types.NamespacedName]*slov1alpha1.PodMetricInfo, len(nodeMetric.Status.PodsMetric))
+	totalUsage := corev1.ResourceList{}
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric
+		totalUsage = quotav1.Add(totalUsage, podMetric.PodUsage.ResourceList)
+	}
+	type candidate struct {
+		pod   *corev1.Pod
+		score float64
+	}
+	var candidates []candidate
+	for _, pod := range pods {
+		podMetric := podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+		if podMetric == nil {
+			continue
+		}
+		// the pods without usage of the contended resources are not evicted since they do not cause the interference
+		score := interferenceContribution(podMetric.PodUsage.ResourceList, totalUsage, exceeded)
+		if score <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{pod: pod, score: score})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	evicted := 0
+	for _, c := range candidates {
+		pod := c.pod
+		if evicted >= int(pl.args.MaxEvictionsPerNode) {
+			return
+		}
+		if !pl.podFilter(pod) {
+			klog.V(4).InfoS("Pod aborted eviction because it was filtered by filters", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			continue
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+			klog.V(4).InfoS("Pod aborted eviction because it does not fit any other node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			continue
+		}
+		if !pl.handle.Evictor().PreEvictionFilter(pod) {
+			continue
+		}
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(node), "contribution", c.score, "reason", reason)
+		} else {
+			evictionOptions := framework.EvictOptions{
+				PluginName: pl.Name(),
+				Reason:     reason,
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "contribution", c.score)
+		}
+		evicted++
+	}
+}
+
+type interferenceKind string
+
+const (
+	interferenceCPUSomePressure    interferenceKind = "cpuSomePressure"
+	interferenceCPUFullPressure    interferenceKind = "cpuFullPressure"
+	interferenceMemorySomePressure interferenceKind = "memorySomePressure"
+	interferenceMemoryFullPressure interferenceKind = "memoryFullPressure"
+	interferenceIOSomePressure     interferenceKind = "ioSomePressure"
+	interferenceIOFullPressure     interferenceKind = "ioFullPressure"
+	interferenceCPI                interferenceKind = "cpi"
+)
+
+var interferenceKinds = []interferenceKind{
+	interferenceCPUSomePressure,
+	interferenceCPUFullPressure,
+	interferenceMemorySomePressure,
+	interferenceMemoryFullPressure,
+	interferenceIOSomePressure,
+	interferenceIOFullPressure,
+	interferenceCPI,
+}
+
+// interferenceValues holds the positive values of the interference metrics.
+type interferenceValues map[interferenceKind]float64
+
+func newInterferenceValues(thresholds *deschedulerconfig.InterferenceThresholds) interferenceValues {
+	values := interferenceValues{}
+	values.set(interferenceCPUSomePressure, float64(thresholds.CPUSomePressure))
+	values.set(interferenceCPUFullPressure, float64(thresholds.CPUFullPressure))
+	values.set(interferenceMemorySomePressure, float64(thresholds.MemorySomePressure))
+	values.set(interferenceMemoryFullPressure, float64(thresholds.MemoryFullPressure))
+	values.set(interferenceIOSomePressure, float64(thresholds.IOSomePressure))
+	values.set(interferenceIOFullPressure, float64(thresholds.IOFullPressure))
+	values.set(interferenceCPI, thresholds.CPI)
+	return values
+}
+
+func newInterferenceValuesFromMetric(metric *slov1alpha1.InterferenceMetric) interferenceValues {
+	values := interferenceValues{}
+	if metric == nil {
+		return values
+	}
+	if psi := metric.PSI; psi != nil {
+		values.setQuantity(interferenceCPUSomePressure, psi.CPUSome)
+		values.setQuantity(interferenceCPUFullPressure, psi.CPUFull)
+		values.setQuantity(interferenceMemorySomePressure, psi.MemorySome)
+		values.setQuantity(interferenceMemoryFullPressure, psi.MemoryFull)
+		values.setQuantity(interferenceIOSomePressure, psi.IOSome)
+		values.setQuantity(interferenceIOFullPressure, psi.IOFull)
+	}
+	values.setQuantity(interferenceCPI, metric.CPI)
+	return values
+}
+
+func (v interferenceValues) set(kind interferenceKind, value float64) {
+	if value > 0 {
+		v[kind] = value
+	}
+}
+
+func (v interferenceValues) setQuantity(kind interferenceKind, quantity *resource.Quantity) {
+	if quantity != nil {
+		v.set(kind, float64(quantity.MilliValue())/1000)
+	}
+}
+
+// exceededInterferenceKinds returns the metrics exceeding the thresholds in a stable order.
+func exceededInterferenceKinds(values, thresholds interferenceValues) []interferenceKind {
+	var exceeded []interferenceKind
+	for _, kind := range interferenceKinds {
+		threshold, ok := thresholds[kind]
+		if ok && values[kind] > threshold {
+			exceeded = append(exceeded, kind)
+		}
+	}
+	return exceeded
+}
+
+// interferenceResources maps the interference metrics to the resources whose usage causes the contention.
+// The IO usage of pods is not reported in the NodeMetric, so the IO pressures are not mapped.
+var interferenceResources = map[interferenceKind]corev1.ResourceName{
+	interferenceCPUSomePressure:    corev1.ResourceCPU,
+	interferenceCPUFullPressure:    corev1.ResourceCPU,
+	interferenceMemorySomePressure: corev1.ResourceMemory,
+	interferenceMemoryFullPressure: corev1.ResourceMemory,
+	interferenceCPI:                corev1.ResourceCPU,
+}
+
+// interferenceContribution scores the contribution of the pod to the exceeded metrics of the node
+// by its share of the usage of the contended resources among all the pods on the node.
+// Each contended resource is counted once even if several of its metrics are exceeded.
+func interferenceContribution(podUsage, totalUsage corev1.ResourceList, exceeded []interferenceKind) float64 {
+	var score float64
+	counted := sets.NewString()
+	for _, kind := range exceeded {
+		resourceName, ok := interferenceResources[kind]
+		if !ok || counted.Has(string(resourceName)) {
+			continue
+		}
+		counted.Insert(string(resourceName))
+		total := totalUsage[resourceName]
+		if total.IsZero() {
+			continue
+		}
+		used := podUsage[resourceName]
+		score += float64(used.MilliValue()) / float64(total.MilliValue())
+	}
+	return score
+}
+
+func interferenceEvictionReason(nodeValues, thresholds interferenceValues, exceeded []interferenceKind) string {
+	infos := make([]string, 0, len(exceeded))
+	for _, kind := range exceeded {
+		infos = append(infos, fmt.Sprintf("%s(%.2f)>threshold(%.2f)", kind, nodeValues[kind], thresholds[kind]))
+	}
+	return fmt.Sprintf("node is under interference, %s", strings.Join(infos, ", "))
+}
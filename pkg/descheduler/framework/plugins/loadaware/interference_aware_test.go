@@ -0,0 +1,412 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func newTestInterferenceMetric(cpuSome float64, cpi float64) *slov1alpha1.InterferenceMetric {
+	m := &slov1alpha1.InterferenceMetric{}
+	if cpuSome > 0 {
+		m.PSI = &slov1alpha1.PSIMetric{
+			CPUSome: resource.NewMilliQuantity(int64(cpuSome*1000), resource.DecimalSI),
+		}
+	}
+	if cpi > 0 {
+		m.CPI = resource.NewMilliQuantity(int64(cpi*1000), resource.DecimalSI)
+	}
+	return m
+}
+
+func newTestPodUsage(milliCPU, memoryMB int64) slov1alpha1.ResourceMap {
+	return slov1alpha1.ResourceMap{
+		ResourceList: corev1.ResourceList{
+			corev1.ResourceCPU:    *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+			corev1.ResourceMemory: *resource.NewQuantity(memoryMB*1024*1024, resource.BinarySI),
+		},
+	}
+}
+
+func TestInterferenceAware(t *testing.T) {
+	n1NodeName := "n1"
+	n2NodeName := "n2"
+	nodes := []*corev1.Node{
+		test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+		test.BuildTestNode(n2NodeName, 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 400, 0, n1NodeName, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 400, 0, n1NodeName, test.SetDSOwnerRef),
+		test.BuildTestPod("p5", 400, 0, n2NodeName, test.SetRSOwnerRef),
+	}
+	defaultPodInterference := map[string]*slov1alpha1.InterferenceMetric{
+		"p1": newTestInterferenceMetric(5, 1),
+		"p2": newTestInterferenceMetric(20, 3),
+		// p4 contributes most but cannot be evicted as a DaemonSet pod
+		"p4": newTestInterferenceMetric(40, 8),
+		"p5": newTestInterferenceMetric(1, 1),
+	}
+	defaultPodUsage := map[string]slov1alpha1.ResourceMap{
+		"p1": newTestPodUsage(500, 1024),
+		"p2": newTestPodUsage(1000, 512),
+		// p4 uses most but cannot be evicted as a DaemonSet pod
+		"p4": newTestPodUsage(2000, 256),
+		"p5": newTestPodUsage(100, 100),
+	}
+
+	testCases := []struct {
+		name              string
+		thresholds        deschedulerconfig.InterferenceThresholds
+		nodeInterference  map[string]*slov1alpha1.InterferenceMetric
+		podInterference   map[string]*slov1alpha1.InterferenceMetric
+		podUsage          map[string]slov1alpha1.ResourceMap
+		expired           bool
+		dryRun            bool
+		anomalyCondition  *deschedulerconfig.LoadAnomalyCondition
+		maxEvictions      int32
+		rounds            int
+		expectEvictedPods []string
+	}{
+		{
+			name:       "evict the pod using most cpu under the cpu pressure",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+				n2NodeName: newTestInterferenceMetric(2, 0),
+			},
+			podInterference:   defaultPodInterference,
+			expectEvictedPods: []string{"p2"},
+		},
+		{
+			name:       "evict pods up to maxEvictionsPerNode",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference:   defaultPodInterference,
+			maxEvictions:      3,
+			expectEvictedPods: []string{"p1", "p2"},
+		},
+		{
+			name:       "evict by cpi",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 50, CPI: 2},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 2.5),
+			},
+			podInterference: map[string]*slov1alpha1.InterferenceMetric{
+				"p1": newTestInterferenceMetric(25, 4),
+				"p2": newTestInterferenceMetric(25, 1),
+			},
+			podUsage: map[string]slov1alpha1.ResourceMap{
+				"p1": newTestPodUsage(400, 100),
+				"p2": newTestPodUsage(1200, 100),
+			},
+			expectEvictedPods: []string{"p2"},
+		},
+		{
+			name:       "evict the pod with low pressure but high cpu usage",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference: map[string]*slov1alpha1.InterferenceMetric{
+				"p1": newTestInterferenceMetric(1, 0),
+				"p2": newTestInterferenceMetric(40, 0),
+				"p3": newTestInterferenceMetric(60, 0),
+			},
+			podUsage: map[string]slov1alpha1.ResourceMap{
+				"p1": newTestPodUsage(3000, 100),
+				"p2": newTestPodUsage(200, 100),
+				"p3": newTestPodUsage(100, 100),
+			},
+			expectEvictedPods: []string{"p1"},
+		},
+		{
+			name: "evict the pod using most memory under the memory pressure",
+			thresholds: deschedulerconfig.InterferenceThresholds{
+				CPUSomePressure:    20,
+				MemorySomePressure: 10,
+			},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: {
+					PSI: &slov1alpha1.PSIMetric{
+						CPUSome:    resource.NewMilliQuantity(5000, resource.DecimalSI),
+						MemorySome: resource.NewMilliQuantity(15000, resource.DecimalSI),
+					},
+				},
+			},
+			podInterference:   defaultPodInterference,
+			podUsage:          defaultPodUsage,
+			expectEvictedPods: []string{"p1"},
+		},
+		{
+			name:       "node is not contended",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(10, 5),
+			},
+			podInterference: defaultPodInterference,
+		},
+		{
+			name:       "nodeMetric expired",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference: defaultPodInterference,
+			expired:         true,
+		},
+		{
+			name:       "no thresholds configured",
+			thresholds: deschedulerconfig.InterferenceThresholds{},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 5),
+			},
+			podInterference: defaultPodInterference,
+		},
+		{
+			name:       "dry run",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference: defaultPodInterference,
+			dryRun:          true,
+		},
+		{
+			name:       "node is not anomalous before exceeding consecutive abnormalities",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference: defaultPodInterference,
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				Timeout:                  metav1.Duration{Duration: time.Minute},
+				ConsecutiveAbnormalities: 2,
+				ConsecutiveNormalities:   1,
+			},
+			rounds: 2,
+		},
+		{
+			name:       "node is anomalous after exceeding consecutive abnormalities",
+			thresholds: deschedulerconfig.InterferenceThresholds{CPUSomePressure: 20},
+			nodeInterference: map[string]*slov1alpha1.InterferenceMetric{
+				n1NodeName: newTestInterferenceMetric(30, 0),
+			},
+			podInterference: defaultPodInterference,
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				Timeout:                  metav1.Duration{Duration: time.Minute},
+				ConsecutiveAbnormalities: 2,
+				ConsecutiveNormalities:   1,
+			},
+			rounds:            3,
+			expectEvictedPods: []string{"p2"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				accessor, err := meta.Accessor(action.(coretesting.CreateAction).GetObject())
+				assert.NoError(t, err)
+				evictedPods = append(evictedPods, accessor.GetName())
+				return true, nil, nil
+			})
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			updateTime := metav1.Now()
+			if tt.expired {
+				updateTime = metav1.NewTime(time.Now().Add(-time.Hour))
+			}
+			podUsage := tt.podUsage
+			if podUsage == nil {
+				podUsage = defaultPodUsage
+			}
+			koordClientSet := koordfake.NewSimpleClientset()
+			for _, node := range nodes {
+				nodeMetric := &slov1alpha1.NodeMetric{
+					ObjectMeta: metav1.ObjectMeta{Name: node.Name},
+					Status: slov1alpha1.NodeMetricStatus{
+						UpdateTime: &updateTime,
+						NodeMetric: &slov1alpha1.NodeMetricInfo{
+							Interference: tt.nodeInterference[node.Name],
+						},
+					},
+				}
+				for _, pod := range pods {
+					if pod.Spec.NodeName != node.Name {
+						continue
+					}
+					nodeMetric.Status.PodsMetric = append(nodeMetric.Status.PodsMetric, &slov1alpha1.PodMetricInfo{
+						Namespace:    pod.Namespace,
+						Name:         pod.Name,
+						PodUsage:     podUsage[pod.Name],
+						Interference: tt.podInterference[pod.Name],
+					})
+				}
+				_, err = koordClientSet.SloV1alpha1().NodeMetrics().Create(context.TODO(), nodeMetric, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+
+			anomalyCondition := tt.anomalyCondition
+			if anomalyCondition == nil {
+				anomalyCondition = &deschedulerconfig.LoadAnomalyCondition{ConsecutiveAbnormalities: 1}
+			}
+			maxEvictions := tt.maxEvictions
+			if maxEvictions == 0 {
+				maxEvictions = 1
+			}
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(InterferenceAwareName, func(ctx context.Context, args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewInterferenceAware(ctx, args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: InterferenceAwareName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: InterferenceAwareName,
+							Args: &deschedulerconfig.InterferenceAwareArgs{
+								DryRun:                      tt.dryRun,
+								NodeFit:                     true,
+								NodeMetricExpirationSeconds: ptr.To[int64](180),
+								Thresholds:                  tt.thresholds,
+								AnomalyCondition:            anomalyCondition,
+								DetectorCacheTimeout:        &metav1.Duration{Duration: 5 * time.Minute},
+								MaxEvictionsPerNode:         maxEvictions,
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+				frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			rounds := tt.rounds
+			if rounds == 0 {
+				rounds = 1
+			}
+			for i := 0; i < rounds; i++ {
+				fh.RunBalancePlugins(ctx, nodes)
+			}
+			sort.Strings(evictedPods)
+			assert.Equal(t, tt.expectEvictedPods, evictedPods)
+		})
+	}
+}
+
+func Test_interferenceContribution(t *testing.T) {
+	thresholds := newInterferenceValues(&deschedulerconfig.InterferenceThresholds{
+		CPUSomePressure: 20,
+		IOFullPressure:  10,
+		CPI:             2,
+	})
+	nodeValues := newInterferenceValuesFromMetric(&slov1alpha1.InterferenceMetric{
+		PSI: &slov1alpha1.PSIMetric{
+			CPUSome: resource.NewMilliQuantity(30000, resource.DecimalSI),
+			IOFull:  resource.NewMilliQuantity(5000, resource.DecimalSI),
+			// not configured in thresholds
+			MemorySome: resource.NewMilliQuantity(90000, resource.DecimalSI),
+		},
+		CPI: resource.NewMilliQuantity(3000, resource.DecimalSI),
+	})
+	exceeded := exceededInterferenceKinds(nodeValues, thresholds)
+	assert.Equal(t, []interferenceKind{interferenceCPUSomePressure, interferenceCPI}, exceeded)
+
+	totalUsage := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+	podUsage := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("6Gi"),
+	}
+	// cpu is counted once for both the cpu pressure and the cpi, memory is not contended
+	assert.Equal(t, 0.25, interferenceContribution(podUsage, totalUsage, exceeded))
+	assert.Equal(t, 0.75, interferenceContribution(podUsage, totalUsage, []interferenceKind{interferenceMemoryFullPressure, interferenceIOSomePressure}))
+	assert.Equal(t, 0.0, interferenceContribution(nil, totalUsage, exceeded))
+	assert.Equal(t, 0.0, interferenceContribution(podUsage, nil, exceeded))
+	assert.Equal(t, "node is under interference, cpuSomePressure(30.00)>threshold(20.00), cpi(3.00)>threshold(2.00)",
+		interferenceEvictionReason(nodeValues, thresholds, exceeded))
+}
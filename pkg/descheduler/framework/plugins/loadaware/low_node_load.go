@@ -267,19 +267,27 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 
 func resetNodesAsNormal(lowNodes []NodeInfo, nodeAnomalyDetectors *gocache.Cache) {
 	for _, v := range lowNodes {
-		if obj, ok := nodeAnomalyDetectors.Get(v.node.Name); ok {
-			anomalyDetector := obj.(anomaly.Detector)
-			anomalyDetector.Reset()
-		}
+		resetNodeAsNormal(v.node.Name, nodeAnomalyDetectors)
+	}
+}
+
+func resetNodeAsNormal(nodeName string, nodeAnomalyDetectors *gocache.Cache) {
+	if obj, ok := nodeAnomalyDetectors.Get(nodeName); ok {
+		anomalyDetector := obj.(anomaly.Detector)
+		anomalyDetector.Reset()
 	}
 }
 
 func tryMarkNodesAsNormal(nodes []NodeInfo, nodeAnomalyDetectors *gocache.Cache) {
 	for _, v := range nodes {
-		if obj, ok := nodeAnomalyDetectors.Get(v.node.Name); ok {
-			anomalyDetector := obj.(anomaly.Detector)
-			anomalyDetector.Mark(true)
-		}
+		tryMarkNodeAsNormal(v.node.Name, nodeAnomalyDetectors)
+	}
+}
+
+func tryMarkNodeAsNormal(nodeName string, nodeAnomalyDetectors *gocache.Cache) {
+	if obj, ok := nodeAnomalyDetectors.Get(nodeName); ok {
+		anomalyDetector := obj.(anomaly.Detector)
+		anomalyDetector.Mark(true)
 	}
 }
 
@@ -289,28 +297,37 @@ func filterRealAbnormalNodes(sourceNodes []NodeInfo, nodeAnomalyDetectors *gocac
 	}
 	var abnormalNodes []NodeInfo
 	for _, v := range sourceNodes {
-		obj, ok := nodeAnomalyDetectors.Get(v.node.Name)
-		if !ok {
-			opts := anomaly.Options{
-				Timeout: anomalyCondition.Timeout.Duration,
-				NormalConditionFn: func(counter anomaly.Counter) bool {
-					return counter.ConsecutiveNormalities > anomalyCondition.ConsecutiveNormalities
-				},
-				AnomalyConditionFn: func(counter anomaly.Counter) bool {
-					return counter.ConsecutiveAbnormalities > anomalyCondition.ConsecutiveAbnormalities
-				},
-			}
-			obj = anomaly.NewBasicDetector(v.node.Name, opts)
-		}
-		anomalyDetector := obj.(anomaly.Detector)
-		if state, _ := anomalyDetector.Mark(false); state == anomaly.StateAnomaly {
+		if markNodeAsAbnormal(v.node.Name, nodeAnomalyDetectors, anomalyCondition) {
 			abnormalNodes = append(abnormalNodes, v)
 		}
-		nodeAnomalyDetectors.Set(v.node.Name, anomalyDetector, gocache.DefaultExpiration)
 	}
 	return abnormalNodes
 }
 
+// markNodeAsAbnormal marks the node abnormal once, and returns true if the node is detected as anomalous.
+func markNodeAsAbnormal(nodeName string, nodeAnomalyDetectors *gocache.Cache, anomalyCondition *deschedulerconfig.LoadAnomalyCondition) bool {
+	if anomalyCondition == nil || anomalyCondition.ConsecutiveAbnormalities == 1 {
+		return true
+	}
+	obj, ok := nodeAnomalyDetectors.Get(nodeName)
+	if !ok {
+		opts := anomaly.Options{
+			Timeout: anomalyCondition.Timeout.Duration,
+			NormalConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveNormalities > anomalyCondition.ConsecutiveNormalities
+			},
+			AnomalyConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveAbnormalities > anomalyCondition.ConsecutiveAbnormalities
+			},
+		}
+		obj = anomaly.NewBasicDetector(nodeName, opts)
+	}
+	anomalyDetector := obj.(anomaly.Detector)
+	state, _ := anomalyDetector.Mark(false)
+	nodeAnomalyDetectors.Set(nodeName, anomalyDetector, gocache.DefaultExpiration)
+	return state == anomaly.StateAnomaly
+}
+
 func newThresholds(useDeviationThresholds bool, low, high, lowProd, highProd deschedulerconfig.ResourceThresholds) (thresholds, highThresholds, prodThreshold, highProdThreshold deschedulerconfig.ResourceThresholds) {
 	thresholds = low
 	highThresholds = high
@@ -17,8 +17,10 @@ limitations under the License.
 package plugins
 
 import (
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/cpusetfragmentation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/custompriority"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/fragmentationaware"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/gpufragmentation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/scaledownbinpack"
@@ -27,10 +29,13 @@ import (
 
 func NewInTreeRegistry() runtime.Registry {
 	registry := runtime.Registry{
-		loadaware.LowNodeLoadName:                 loadaware.NewLowNodeLoad,
-		custompriority.PluginCustomPriorityName:   custompriority.NewCustomPriority,
-		fragmentationaware.FragmentationAwareName: fragmentationaware.NewFragmentationAware,
-		scaledownbinpack.ScaleDownBinPackName:     scaledownbinpack.NewScaleDownBinPack,
+		loadaware.LowNodeLoadName:                   loadaware.NewLowNodeLoad,
+		custompriority.PluginCustomPriorityName:     custompriority.NewCustomPriority,
+		fragmentationaware.FragmentationAwareName:   fragmentationaware.NewFragmentationAware,
+		scaledownbinpack.ScaleDownBinPackName:       scaledownbinpack.NewScaleDownBinPack,
+		cpusetfragmentation.CPUSetFragmentationName: cpusetfragmentation.NewCPUSetFragmentation,
+		gpufragmentation.GPUFragmentationName:       gpufragmentation.NewGPUFragmentation,
+		loadaware.InterferenceAwareName:             loadaware.NewInterferenceAware,
 	}
 	kubernetes.SetupK8sDeschedulerPlugins(registry)
 	return registry
@@ -38,8 +38,17 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "namespace", "node"})
 
+	PodMigrationJobsRolledBack = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pod_migration_jobs_rolled_back",
+			Help:           "Number of stuck PodMigrationJobs rolled back, by the phase where the job got stuck, by whether the pod had been evicted",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"phase", "evicted"})
+
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		PodMigrationJobsRolledBack,
 	}
 )
 
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+// NewFragmentationPodSelectorFilter returns a filter matching the pods selected by any of the pod selectors of
+// the fragmentation plugins. All pods are matched if no selector is specified.
+func NewFragmentationPodSelectorFilter(podSelectors []deschedulerconfig.FragmentationAwarePodSelector) (func(pod *corev1.Pod) bool, error) {
+	var selectors []labels.Selector
+	for _, v := range podSelectors {
+		if v.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(v.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid labelSelector %w", err)
+			}
+			selectors = append(selectors, selector)
+		}
+	}
+
+	return func(pod *corev1.Pod) bool {
+		if len(selectors) == 0 {
+			return true
+		}
+		for _, v := range selectors {
+			if v.Matches(labels.Set(pod.Labels)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// FilterNodesByLabelSelector returns the nodes matching the label selector, all nodes if the selector is nil.
+func FilterNodesByLabelSelector(nodes []*corev1.Node, nodeSelector *metav1.LabelSelector) ([]*corev1.Node, error) {
+	if nodeSelector == nil {
+		return nodes, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*corev1.Node
+	for _, node := range nodes {
+		if selector.Matches(labels.Set(node.Labels)) {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered, nil
+}
+
+// BuildMigrateReservationOptions builds the Reservation template carrying the requests of the pod, so that the
+// target node is guaranteed to have the resources before the pod is evicted. The allocation annotations of the
+// current node are dropped so that the scheduler allocates the resources again.
+func BuildMigrateReservationOptions(pod *corev1.Pod, droppedAnnotations ...string) *sev1alpha1.PodMigrateReservationOptions {
+	annotations := map[string]string{}
+	for k, v := range pod.Annotations {
+		annotations[k] = v
+	}
+	for _, k := range droppedAnnotations {
+		delete(annotations, k)
+	}
+	return &sev1alpha1.PodMigrateReservationOptions{
+		Template: &sev1alpha1.ReservationTemplateSpec{
+			Spec: sev1alpha1.ReservationSpec{
+				Template: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   pod.Namespace,
+						Labels:      pod.Labels,
+						Annotations: annotations,
+					},
+					Spec: *pod.Spec.DeepCopy(),
+				},
+			},
+		},
+	}
+}
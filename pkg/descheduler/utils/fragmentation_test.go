@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestNewFragmentationPodSelectorFilter(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "a"}}}
+
+	filter, err := NewFragmentationPodSelectorFilter(nil)
+	assert.NoError(t, err)
+	assert.True(t, filter(pod))
+
+	filter, err = NewFragmentationPodSelectorFilter([]deschedulerconfig.FragmentationAwarePodSelector{
+		{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}}},
+		{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, filter(pod))
+
+	filter, err = NewFragmentationPodSelectorFilter([]deschedulerconfig.FragmentationAwarePodSelector{
+		{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, filter(pod))
+
+	_, err = NewFragmentationPodSelectorFilter([]deschedulerconfig.FragmentationAwarePodSelector{
+		{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "invalid"}}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestFilterNodesByLabelSelector(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "gpu"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	}
+	got, err := FilterNodesByLabelSelector(nodes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, nodes, got)
+
+	got, err = FilterNodesByLabelSelector(nodes, &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}})
+	assert.NoError(t, err)
+	assert.Equal(t, nodes[:1], got)
+}
+
+func TestBuildMigrateReservationOptions(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			Labels:    map[string]string{"app": "a"},
+			Annotations: map[string]string{
+				"foo":                              "bar",
+				extension.AnnotationResourceStatus: `{"cpuset":"0-3"}`,
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1", SchedulerName: "koord-scheduler"},
+	}
+	options := BuildMigrateReservationOptions(pod, extension.AnnotationResourceStatus)
+	template := options.Template.Spec.Template
+	assert.Equal(t, "default", template.Namespace)
+	assert.Equal(t, pod.Labels, template.Labels)
+	assert.Equal(t, map[string]string{"foo": "bar"}, template.Annotations)
+	assert.Equal(t, pod.Spec, template.Spec)
+	assert.Contains(t, pod.Annotations, extension.AnnotationResourceStatus, "the pod must not be modified")
+}
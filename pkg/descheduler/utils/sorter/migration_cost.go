@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sorter
+
+import (
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+const (
+	// startupTimeCostLimit is the startup time regarded as the most expensive.
+	startupTimeCostLimit = 10 * time.Minute
+	// localStorageCostLimit is the local storage size regarded as the most expensive.
+	localStorageCostLimit = 10 * 1024 * 1024 * 1024
+)
+
+// MigrationCostModel estimates how expensive it is to migrate a pod.
+// The cost is in the range [extension.MinMigrationCost, extension.MaxMigrationCost].
+type MigrationCostModel interface {
+	MigrationCost(pod *corev1.Pod) int64
+}
+
+// MigrationCostFactor estimates one aspect of the migration cost.
+// The Estimate returns a value in the range [0, 1], the higher the value the more expensive to migrate.
+type MigrationCostFactor struct {
+	Name     string
+	Weight   int64
+	Estimate func(pod *corev1.Pod) float64
+}
+
+// DefaultMigrationCostModel is used by the PodSorter to order pods by the migration cost.
+var DefaultMigrationCostModel = NewMigrationCostModel(DefaultMigrationCostFactors()...)
+
+type weightedMigrationCostModel struct {
+	factors []MigrationCostFactor
+}
+
+// NewMigrationCostModel returns a MigrationCostModel which calculates the weighted average of the factors.
+// The explicit cost set with the annotation extension.AnnotationMigrationCost takes precedence over the factors.
+func NewMigrationCostModel(factors ...MigrationCostFactor) MigrationCostModel {
+	return &weightedMigrationCostModel{factors: factors}
+}
+
+func (m *weightedMigrationCostModel) MigrationCost(pod *corev1.Pod) int64 {
+	cost, ok, err := extension.GetMigrationCost(pod.Annotations)
+	if err != nil {
+		klog.V(5).InfoS("Failed to get migration cost from annotation", "pod", klog.KObj(pod), "err", err)
+	} else if ok {
+		return cost
+	}
+
+	var score float64
+	var weightSum int64
+	for _, factor := range m.factors {
+		if factor.Weight <= 0 {
+			continue
+		}
+		value := math.Min(math.Max(factor.Estimate(pod), 0), 1)
+		score += value * float64(factor.Weight)
+		weightSum += factor.Weight
+	}
+	if weightSum == 0 {
+		return extension.MinMigrationCost
+	}
+	return int64(math.Round(score / float64(weightSum) * float64(extension.MaxMigrationCost)))
+}
+
+// DefaultMigrationCostFactors returns the factors which can be estimated only from the pod.
+func DefaultMigrationCostFactors() []MigrationCostFactor {
+	return []MigrationCostFactor{
+		{Name: "StartupTime", Weight: 3, Estimate: estimateStartupTime},
+		{Name: "LocalStorage", Weight: 2, Estimate: estimateLocalStorage},
+		{Name: "RestartHistory", Weight: 1, Estimate: estimateRestartHistory},
+		{Name: "ReadinessGates", Weight: 1, Estimate: estimateReadinessGates},
+	}
+}
+
+// OwnerReplicasCostFactor returns a factor that a pod owned by a workload with fewer replicas is more expensive to migrate,
+// since a larger proportion of the workload is unavailable during the migration.
+func OwnerReplicasCostFactor(getReplicas func(pod *corev1.Pod) (int32, error)) MigrationCostFactor {
+	return MigrationCostFactor{
+		Name:   "OwnerReplicas",
+		Weight: 2,
+		Estimate: func(pod *corev1.Pod) float64 {
+			replicas, err := getReplicas(pod)
+			if err != nil || replicas <= 0 {
+				return 0
+			}
+			return 1 / float64(replicas)
+		},
+	}
+}
+
+// estimateStartupTime estimates the time from the pod started to be ready.
+func estimateStartupTime(pod *corev1.Pod) float64 {
+	if pod.Status.StartTime == nil {
+		return 0
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodReady || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		startupTime := condition.LastTransitionTime.Sub(pod.Status.StartTime.Time)
+		if startupTime <= 0 {
+			return 0
+		}
+		return float64(startupTime) / float64(startupTimeCostLimit)
+	}
+	return 0
+}
+
+// estimateLocalStorage estimates the size of the local data lost after migration.
+func estimateLocalStorage(pod *corev1.Pod) float64 {
+	size := resource.NewQuantity(0, resource.BinarySI)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != nil {
+			size.Add(*volume.EmptyDir.SizeLimit)
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[corev1.ResourceEphemeralStorage]; ok {
+			size.Add(request)
+		}
+	}
+	return float64(size.Value()) / localStorageCostLimit
+}
+
+// estimateRestartHistory estimates that the pod restarted frequently is more expensive to migrate,
+// since it may fail to become ready again on the new node, while the pod never restarted costs nothing.
+func estimateRestartHistory(pod *corev1.Pod) float64 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return float64(restarts) / float64(1+restarts)
+}
+
+// estimateReadinessGates estimates that the pod with readiness gates is more expensive to migrate,
+// since the new pod has to wait for the external systems, e.g. the registration of load balancers.
+func estimateReadinessGates(pod *corev1.Pod) float64 {
+	if len(pod.Spec.ReadinessGates) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// MigrationCost compares pods by the migration cost estimated by the model
+func MigrationCost(model MigrationCostModel) CompareFn {
+	return func(p1, p2 *corev1.Pod) int {
+		cost1 := model.MigrationCost(p1)
+		cost2 := model.MigrationCost(p2)
+		if cost1 == cost2 {
+			return 0
+		}
+		if cost1 > cost2 {
+			return 1
+		}
+		return -1
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sorter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func withStartupTime(startupTime time.Duration) podDecoratorFn {
+	return func(pod *corev1.Pod) {
+		startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		pod.Status.StartTime = &startTime
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(startTime.Add(startupTime)),
+		})
+	}
+}
+
+func withRestarts(restarts int32) podDecoratorFn {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:         "main",
+			RestartCount: restarts,
+		})
+	}
+}
+
+func withEmptyDir(sizeLimit string) podDecoratorFn {
+	return func(pod *corev1.Pod) {
+		quantity := resource.MustParse(sizeLimit)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "cache",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &quantity},
+			},
+		})
+	}
+}
+
+func withReadinessGate(pod *corev1.Pod) {
+	pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: "test-gate"})
+}
+
+func TestMigrationCostModel(t *testing.T) {
+	creationTime := time.Now()
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		replicas int32
+		want     int64
+	}{
+		{
+			name: "stateless pod never restarted",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime),
+			want: 0,
+		},
+		{
+			name: "pod takes a long time to start up",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute), withRestarts(0)),
+			want: 33,
+		},
+		{
+			name: "pod takes half of the limit to start up",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(5*time.Minute), withRestarts(0)),
+			want: 17,
+		},
+		{
+			name: "pod restarted frequently",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withRestarts(9)),
+			// only RestartHistory: 1*0.9/(3+2+1+1+2)
+			want: 10,
+		},
+		{
+			name: "pod with large local storage and readiness gates",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withEmptyDir("20Gi"), withReadinessGate),
+			want: 33,
+		},
+		{
+			name:     "pod owned by a single replica workload",
+			pod:      makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime),
+			replicas: 1,
+			want:     22,
+		},
+		{
+			name: "explicit cost takes precedence",
+			pod: makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute),
+				withCost(extension.AnnotationMigrationCost, 5)),
+			want: 5,
+		},
+		{
+			name: "invalid explicit cost is ignored",
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withCost(extension.AnnotationMigrationCost, 200)),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewMigrationCostModel(append(DefaultMigrationCostFactors(), OwnerReplicasCostFactor(func(pod *corev1.Pod) (int32, error) {
+				if tt.replicas == 0 {
+					return 0, fmt.Errorf("no owner")
+				}
+				return tt.replicas, nil
+			}))...)
+			assert.Equal(t, tt.want, model.MigrationCost(tt.pod))
+		})
+	}
+}
+
+func TestSortPodsByMigrationCost(t *testing.T) {
+	creationTime := time.Now()
+	pods := []*corev1.Pod{
+		makePod("slow-start", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute)),
+		makePod("stateless", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime),
+		makePod("explicit", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withCost(extension.AnnotationMigrationCost, 100)),
+		makePod("crash-loop", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withRestarts(20)),
+		makePod("low-priority", -1, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute)),
+	}
+	PodSorter().Sort(pods)
+	var podsOrder []string
+	for _, v := range pods {
+		podsOrder = append(podsOrder, v.Name)
+	}
+	assert.Equal(t, []string{"low-priority", "stateless", "crash-loop", "slow-start", "explicit"}, podsOrder)
+}
+
+func TestSortPodsByMigrationCostAfterCallerComparators(t *testing.T) {
+	creationTime := time.Now()
+	pods := []*corev1.Pod{
+		makePod("slow-start-small", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute)),
+		makePod("stateless-small", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime),
+		makePod("slow-start-large", 0, extension.QoSLS, corev1.PodQOSBurstable, creationTime, withStartupTime(10*time.Minute)),
+	}
+	// the caller prefers the large pods, e.g. ordering by the usage
+	large := func(p1, p2 *corev1.Pod) int {
+		isLarge := func(p *corev1.Pod) int {
+			if strings.HasSuffix(p.Name, "-large") {
+				return 1
+			}
+			return 0
+		}
+		return isLarge(p2) - isLarge(p1)
+	}
+	PodSorter(large).Sort(pods)
+	var podsOrder []string
+	for _, v := range pods {
+		podsOrder = append(podsOrder, v.Name)
+	}
+	assert.Equal(t, []string{"slow-start-large", "stateless-small", "slow-start-small"}, podsOrder)
+}
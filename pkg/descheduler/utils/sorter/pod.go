@@ -161,6 +161,12 @@ func EvictionCost(p1, p2 *corev1.Pod) int {
 }
 
 func PodSorter(cmp ...CompareFn) *MultiSorter {
+	return PodSorterWithMigrationCost(DefaultMigrationCostModel, cmp...)
+}
+
+// PodSorterWithMigrationCost returns a PodSorter ordering the pods by the migration cost estimated by the model
+// only if they are equal in the priority, QoS, the costs set by users and the comparators of the caller.
+func PodSorterWithMigrationCost(model MigrationCostModel, cmp ...CompareFn) *MultiSorter {
 	comparators := []CompareFn{
 		KoordinatorPriorityClass,
 		Priority,
@@ -170,7 +176,7 @@ func PodSorter(cmp ...CompareFn) *MultiSorter {
 		EvictionCost,
 	}
 	comparators = append(comparators, cmp...)
-	comparators = append(comparators, PodCreationTimestamp)
+	comparators = append(comparators, MigrationCost(model), PodCreationTimestamp)
 	return OrderedBy(comparators...)
 }
 
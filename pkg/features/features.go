@@ -94,6 +94,14 @@ const (
 	// EnableQuotaAdmissionOnUpdate enables quota admission on pod update when quota label changes.
 	EnableQuotaAdmissionOnUpdate featuregate.Feature = "EnableQuotaAdmissionOnUpdate"
 
+	// PodResizeAdmission enables the admission of the in-place resize of pods. The cpu resize of the cpuset-bound
+	// pods is rejected, the scale-up of the NUMA-bound pods is checked against the free resources of their NUMA
+	// nodes, and the increment of the requests is evaluated against the quota if quota admission is enabled.
+	PodResizeAdmission featuregate.Feature = "PodResizeAdmission"
+
+	// WorkloadQuotaAdmission enables quota admission for the whole Jobs and PodGroups at creation time.
+	WorkloadQuotaAdmission featuregate.Feature = "WorkloadQuotaAdmission"
+
 	// EnablePodEnhancedValidator enables enhanced validator for pods with configurable rules.
 	EnablePodEnhancedValidator featuregate.Feature = "EnablePodEnhancedValidator"
 
@@ -115,6 +123,10 @@ const (
 	// BindingAdmissionWebhook enables validating webhook for Pod Binding requests
 	// to restrict which schedulers are allowed to bind pods.
 	BindingAdmissionWebhook featuregate.Feature = "BindingAdmissionWebhook"
+
+	// ElasticQuotaFederationController enables the reconciliation for ElasticQuotaFederation, which splits a global
+	// quota into the ElasticQuotas of the member clusters.
+	ElasticQuotaFederationController featuregate.Feature = "ElasticQuotaFederationController"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -138,6 +150,8 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	SupportParentQuotaSubmitPod:             {Default: false, PreRelease: featuregate.Alpha},
 	EnableQuotaAdmission:                    {Default: false, PreRelease: featuregate.Alpha},
 	EnableQuotaAdmissionOnUpdate:            {Default: false, PreRelease: featuregate.Alpha},
+	PodResizeAdmission:                      {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadQuotaAdmission:                  {Default: false, PreRelease: featuregate.Alpha},
 	EnableSyncGPUSharedResource:             {Default: false, PreRelease: featuregate.Alpha},
 	ColocationProfileController:             {Default: false, PreRelease: featuregate.Alpha},
 	ValidatePodDeviceResource:               {Default: false, PreRelease: featuregate.Alpha},
@@ -145,6 +159,7 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	DisableExtendedResourceSpec:             {Default: false, PreRelease: featuregate.Alpha},
 	DisableDeviceResourceSpec:               {Default: false, PreRelease: featuregate.Alpha},
 	BindingAdmissionWebhook:                 {Default: false, PreRelease: featuregate.Alpha},
+	ElasticQuotaFederationController:        {Default: false, PreRelease: featuregate.Alpha},
 }
 
 const (
@@ -63,7 +63,10 @@ const (
 	// owner: @joseph
 	// alpha: v0.1
 	//
-	// ResizePod is used to enable resize pod feature
+	// ResizePod is used to enable resize pod feature.
+	// In koord-scheduler, the quota usage and NUMA allocation follow the resized resources of the Pod, while the
+	// allocated cpuset and devices are kept unchanged.
+	// Only cpu and memory are resizable in place, so the batch and mid resources and the devices never change.
 	ResizePod featuregate.Feature = "ResizePod"
 
 	// owner: @saintube @ZiMengSheng
@@ -153,6 +156,11 @@ const (
 	// reservation restore. It is enabled by default; when disabled, the flow performs no snapshot
 	// writes and the framework's default shared snapshot lister is used instead.
 	EnableBatchScheduleNodeSnapshot featuregate.Feature = "EnableBatchScheduleNodeSnapshot"
+
+	// FilterEquivalenceCache enables memoizing the per-node Filter results of the cacheable plugins for the pods
+	// with the same scheduling-relevant spec. The cached results are invalidated when the node, the pods on it,
+	// the reservations or the devices on it change.
+	FilterEquivalenceCache featuregate.Feature = "FilterEquivalenceCache"
 )
 
 var defaultSchedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -184,6 +192,7 @@ var defaultSchedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSp
 	GangPendingPodsConditionPatch:             {Default: true, PreRelease: featuregate.Beta},
 	EnableInlineBatchSchedule:                 {Default: false, PreRelease: featuregate.Alpha},
 	EnableBatchScheduleNodeSnapshot:           {Default: true, PreRelease: featuregate.Beta},
+	FilterEquivalenceCache:                    {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
@@ -87,6 +87,7 @@ const (
 	ContainerMetricPSICPUFullSupported MetricKind = "container_psi_cpu_full_supported"
 	PodMetricPSI                       MetricKind = "pod_psi"
 	PodMetricPSICPUFullSupported       MetricKind = "pod_psi_cpu_full_supported"
+	NodeMetricPSI                      MetricKind = "node_psi"
 
 	//cold memory metrics
 	NodeMemoryWithHotPageUsage      MetricKind = "node_memory_with_hot_page_usage"
@@ -164,6 +165,7 @@ var MetricPropertiesFunc = struct {
 	ResctrlLLC          func(string, int) map[MetricProperty]string
 	ResctrlMB           func(string, int, string) map[MetricProperty]string
 	PodPSI              func(string, string, string, string) map[MetricProperty]string
+	NodePSI             func(string, string, string) map[MetricProperty]string
 	ContainerPSI        func(string, string, string, string, string) map[MetricProperty]string
 	PodGPU              func(string, string, string) map[MetricProperty]string
 	ContainerGPU        func(string, string, string) map[MetricProperty]string
@@ -204,6 +206,9 @@ var MetricPropertiesFunc = struct {
 	PodPSI: func(podUID, psiResource, psiPrecision, psiDegree string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyPodUID: podUID, MetricPropertyPSIResource: psiResource, MetricPropertyPSIPrecision: psiPrecision, MetricPropertyPSIDegree: psiDegree}
 	},
+	NodePSI: func(psiResource, psiPrecision, psiDegree string) map[MetricProperty]string {
+		return map[MetricProperty]string{MetricPropertyPSIResource: psiResource, MetricPropertyPSIPrecision: psiPrecision, MetricPropertyPSIDegree: psiDegree}
+	},
 	ContainerPSI: func(podUID, containerID, psiResource, psiPrecision, psiDegree string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyPodUID: podUID, MetricPropertyContainerID: containerID, MetricPropertyPSIResource: psiResource, MetricPropertyPSIPrecision: psiPrecision, MetricPropertyPSIDegree: psiDegree}
 	},
@@ -385,6 +385,44 @@ func (p *performanceCollector) collectSinglePodPSI(pod *corev1.Pod, podCgroupDir
 	return psiMetrics
 }
 
+// collectNodePSI collects the PSI of the kubepods cgroup, which indicates how much the pods on the node are contended.
+func (p *performanceCollector) collectNodePSI() {
+	klog.V(6).Infof("start collectNodePSI")
+	kubepodsDir := util.GetPodQoSRelativePath(corev1.PodQOSGuaranteed)
+	nodePSI, err := p.cgroupReader.ReadPSI(kubepodsDir)
+	collectTime := time.Now()
+	if err != nil {
+		klog.Errorf("collect node psi err: %v", err)
+		return
+	}
+
+	psiMetrics := make([]metriccache.MetricSample, 0, 6)
+	for _, v := range []struct {
+		resource metriccache.MetricPropertyValue
+		degree   metriccache.MetricPropertyValue
+		value    float64
+	}{
+		{resource: metriccache.PSIResourceCPU, degree: metriccache.PSIDegreeSome, value: nodePSI.CPU.Some.Avg10},
+		{resource: metriccache.PSIResourceMem, degree: metriccache.PSIDegreeSome, value: nodePSI.Mem.Some.Avg10},
+		{resource: metriccache.PSIResourceIO, degree: metriccache.PSIDegreeSome, value: nodePSI.IO.Some.Avg10},
+		{resource: metriccache.PSIResourceCPU, degree: metriccache.PSIDegreeFull, value: nodePSI.CPU.Full.Avg10},
+		{resource: metriccache.PSIResourceMem, degree: metriccache.PSIDegreeFull, value: nodePSI.Mem.Full.Avg10},
+		{resource: metriccache.PSIResourceIO, degree: metriccache.PSIDegreeFull, value: nodePSI.IO.Full.Avg10},
+	} {
+		sample, err := metriccache.NodePSIMetric.GenerateSample(
+			metriccache.MetricPropertiesFunc.NodePSI(string(v.resource), string(metriccache.PSIPrecision10), string(v.degree)), collectTime, v.value)
+		if err != nil {
+			klog.Warningf("failed to collect node PSI, resource %s, degree %s, err: %s", v.resource, v.degree, err)
+			return
+		}
+		psiMetrics = append(psiMetrics, sample)
+	}
+
+	// save node psi metrics to tsdb
+	p.saveMetric(psiMetrics)
+	klog.V(5).Infof("collectNodePSI finished at %s", collectTime)
+}
+
 func (p *performanceCollector) collectPSI(stopCh <-chan struct{}) {
 	// CgroupV1 psi collector support only on anolis os currently
 	if system.GetCurrentCgroupVersion() == system.CgroupVersionV1 {
@@ -399,6 +437,7 @@ func (p *performanceCollector) collectPSI(stopCh <-chan struct{}) {
 		}
 	}
 	go wait.Until(func() {
+		p.collectNodePSI()
 		p.collectContainerPSI()
 		p.collectPodPSI()
 	}, p.psiCollectInterval, stopCh)
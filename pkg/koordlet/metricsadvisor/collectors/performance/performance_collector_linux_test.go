@@ -343,6 +343,34 @@ func Test_collectPodPSI(t *testing.T) {
 	})
 }
 
+func Test_collectNodePSI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+
+	mockStatesInformer := mockstatesinformer.NewMockStatesInformer(ctrl)
+	mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+	mockAppender := mockmetriccache.NewMockAppender(ctrl)
+	mockMetricCache.EXPECT().Appender().Return(mockAppender).Times(1)
+	mockAppender.EXPECT().Append(gomock.Len(6)).Return(nil).Times(1)
+	mockAppender.EXPECT().Commit().Return(nil).Times(1)
+
+	paths := getPodCgroupCPUAcctPSIPath(util.GetPodQoSRelativePath(corev1.PodQOSGuaranteed))
+	for _, path := range []string{paths.CPU, paths.Mem, paths.IO} {
+		assert.NoError(t, createTestPSIFile(path, FullCorrectPSIContents))
+	}
+
+	collector := New(&framework.Options{
+		Config:         framework.NewDefaultConfig(),
+		StatesInformer: mockStatesInformer,
+		MetricCache:    mockMetricCache,
+		CgroupReader:   resourceexecutor.NewCgroupReader(),
+	})
+	c := collector.(*performanceCollector)
+	c.collectNodePSI()
+}
+
 func createTestPSIFile(filePath, contents string) error {
 	dir, _ := filepath.Split(filePath)
 	if err := os.MkdirAll(dir, 0777); err != nil {
@@ -68,6 +68,9 @@ func (m *mockStatesInformer) GetVolumeName(pvcNamespace, pvcName string) string
 func (m *mockStatesInformer) RegisterCallbacks(objType statesinformer.RegisterType, name, description string, callbackFn statesinformer.UpdateCbFn) {
 }
 
+func (m *mockStatesInformer) ReportNodeSLOStrategy(name string, state slov1alpha1.NodeSLOStrategyState, reason, message string) {
+}
+
 func TestInformer(t *testing.T) {
 	pod1 := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod1"}}
 	pod2 := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod2"}}
@@ -513,16 +513,23 @@ func (r *resctrlReconcile) reconcile() {
 	// skip if host not support resctrl
 	if support, err := system.IsSupportResctrl(); err != nil {
 		klog.Warningf("check support resctrl failed, err: %s", err)
+		r.statesInformer.ReportNodeSLOStrategy(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyFailed,
+			"CheckResctrlFailed", err.Error())
 		return
 	} else if !support {
 		klog.V(5).Infof("resctrlReconcile skipped, cpu not support CAT/MBA")
+		r.statesInformer.ReportNodeSLOStrategy(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyUnsupported,
+			"ResctrlUnsupported", "cpu does not support CAT/MBA or resctrl is not mounted")
 		return
 	}
 
 	if err := initCatResctrl(); err != nil {
 		klog.V(4).Infof("resctrlReconcile failed, cannot initialize cat resctrl group, err: %s", err)
+		r.statesInformer.ReportNodeSLOStrategy(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyFailed,
+			"InitResctrlFailed", err.Error())
 		return
 	}
 	r.reconcileRDTResctrlPolicy(nodeSLO.Spec.ResourceQOSStrategy)
 	r.reconcileResctrlGroups(nodeSLO.Spec.ResourceQOSStrategy)
+	r.statesInformer.ReportNodeSLOStrategy(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyApplied, "", "")
 }
@@ -1419,6 +1419,7 @@ func TestResctrlReconcile_reconcile(t *testing.T) {
 		statesInformer.EXPECT().GetAllPods().Return([]*statesinformer.PodMeta{testingPodMeta}).AnyTimes()
 		statesInformer.EXPECT().GetNodeSLO().Return(testingNodeSLO).AnyTimes()
 		metricCache.EXPECT().Get(metriccache.NodeCPUInfoKey).Return(testingNodeCPUInfo, true).AnyTimes()
+		statesInformer.EXPECT().ReportNodeSLOStrategy(ResctrlReconcileName, gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 		opt := &framework.Options{
 			StatesInformer: statesInformer,
 			MetricCache:    metricCache,
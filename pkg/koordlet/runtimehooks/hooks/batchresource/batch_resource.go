@@ -61,6 +61,9 @@ func (p *plugin) Register(op hooks.Options) {
 		rule.WithUpdateCallback(p.ruleUpdateCbForNodeMeta))
 	hooks.Register(rmconfig.PreRunPodSandbox, name, description+" (pod)", p.SetPodResources)
 	hooks.Register(rmconfig.PreCreateContainer, name, description+" (container)", p.SetContainerResources)
+	// The batch resources are not resizable in place, since the pods/resize only accepts cpu and memory and a BE pod
+	// is BestEffort to the kubelet, whose QoS class cannot change on resize. So the container update from the kubelet
+	// always carries the BestEffort cgroups, which are replaced with the ones of the batch resources in the request.
 	hooks.Register(rmconfig.PreUpdateContainerResources, name, description+" (container)", p.SetContainerResources)
 	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUShares, description+" (pod cpu shares)",
 		p.SetPodCPUShares, reconciler.PodQOSFilter(), podQOSConditions...)
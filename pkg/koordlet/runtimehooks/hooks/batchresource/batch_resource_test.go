@@ -27,6 +27,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
 )
@@ -808,6 +809,56 @@ func Test_plugin_SetContainerResources(t *testing.T) {
 	}
 }
 
+func Test_plugin_SetContainerResourcesOnUpdate(t *testing.T) {
+	testSpecBytes, err := json.Marshal(&apiext.ExtendedResourceSpec{
+		Containers: map[string]apiext.ExtendedResourceContainerSpec{
+			"container-0": {
+				Requests: corev1.ResourceList{
+					apiext.BatchCPU:    resource.MustParse("500"),
+					apiext.BatchMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					apiext.BatchCPU:    resource.MustParse("1000"),
+					apiext.BatchMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	// the kubelet sends the BestEffort cgroups derived from the native cpu and memory in the CRI request
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromProxy(&runtimeapi.ContainerResourceHookRequest{
+		PodMeta: &runtimeapi.PodSandboxMetadata{
+			Name:      "test-pod",
+			Namespace: "test-ns",
+		},
+		ContainerMeta: &runtimeapi.ContainerMetadata{
+			Name: "container-0",
+		},
+		PodLabels: map[string]string{
+			apiext.LabelPodQoS: string(apiext.QoSBE),
+		},
+		PodAnnotations: map[string]string{
+			apiext.AnnotationExtendedResourceSpec: string(testSpecBytes),
+		},
+		ContainerResources: &runtimeapi.LinuxContainerResources{
+			CpuShares:          2,
+			CpuQuota:           -1,
+			MemoryLimitInBytes: 0,
+		},
+	})
+	p := newPlugin()
+	p.rule = &Rule{
+		enableCFSQuota: ptr.To[bool](true),
+	}
+	assert.NoError(t, p.SetContainerResources(containerCtx))
+	assert.Equal(t, protocol.Resources{
+		CPUShares:   ptr.To[int64](512),
+		CFSQuota:    ptr.To[int64](100000),
+		MemoryLimit: ptr.To[int64](2 * 1024 * 1024 * 1024),
+	}, containerCtx.Response.Resources)
+}
+
 func Test_isPodQoSBEByAttr(t *testing.T) {
 	tests := []struct {
 		name string
@@ -26,6 +26,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	ext "github.com/koordinator-sh/koordinator/apis/extension"
+	runtimeapi "github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
@@ -118,6 +119,36 @@ func Test_cpusetPlugin_SetContainerCPUSetAndUnsetCFS(t *testing.T) {
 	})
 }
 
+func Test_cpusetPlugin_SetContainerCPUSetAndUnsetCFSOnUpdate(t *testing.T) {
+	// the kubelet resizes the memory of the cpuset-bound container, and the CRI request carries the cfs quota and the
+	// cpuset of the kubelet, which must not override the ones of koordlet
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromProxy(&runtimeapi.ContainerResourceHookRequest{
+		PodMeta: &runtimeapi.PodSandboxMetadata{
+			Name:      "test-pod",
+			Namespace: "test-ns",
+		},
+		ContainerMeta: &runtimeapi.ContainerMetadata{
+			Name: "test-container",
+		},
+		PodLabels: map[string]string{
+			ext.LabelPodQoS: string(ext.QoSLSR),
+		},
+		PodAnnotations: map[string]string{
+			ext.AnnotationResourceStatus: `{"cpuset":"2-3"}`,
+		},
+		ContainerResources: &runtimeapi.LinuxContainerResources{
+			CpuQuota:           200000,
+			CpusetCpus:         "0-15",
+			MemoryLimitInBytes: 4 * 1024 * 1024 * 1024,
+		},
+	})
+	p := &cpusetPlugin{}
+	assert.NoError(t, p.SetContainerCPUSetAndUnsetCFS(containerCtx))
+	assert.Equal(t, ptr.To[string]("2-3"), containerCtx.Response.Resources.CPUSet)
+	assert.Equal(t, ptr.To[int64](-1), containerCtx.Response.Resources.CFSQuota)
+}
+
 func Test_cpusetPlugin_SetContainerCPUSet(t *testing.T) {
 	type fields struct {
 		rule *cpusetRule
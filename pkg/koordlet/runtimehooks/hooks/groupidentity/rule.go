@@ -29,6 +29,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/rule"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
 	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
@@ -165,6 +166,7 @@ func (b *bvtPlugin) ruleUpdateCb(target *statesinformer.CallbackTarget) error {
 	if isEnabled {
 		if err := b.initSysctl(); err != nil {
 			klog.Warningf("failed to initialize system config for plugin %s, err: %s", name, err)
+			rule.ReportUpdateFailure(name, fmt.Errorf("failed to initialize system config, err: %w", err))
 			return nil
 		}
 	} else {
@@ -192,6 +194,8 @@ func (b *bvtPlugin) ruleUpdateCb(target *statesinformer.CallbackTarget) error {
 		}
 		if _, err = b.executor.Update(true, bvtUpdater); err != nil {
 			klog.Infof("update kube qos %v cpu bvt failed, dir %v, error %v", kubeQOS, kubeQOSCgroupPath, err)
+			// the failures on the kube qos cgroups indicate the rule cannot take effect, e.g. EPERM
+			rule.ReportUpdateFailure(name, fmt.Errorf("update kube qos %v cpu bvt failed, err: %w", kubeQOS, err))
 		}
 		qosCgroupMap[kubeQOSCgroupPath] = struct{}{}
 	}
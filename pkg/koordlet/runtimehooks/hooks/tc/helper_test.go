@@ -20,10 +20,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 )
 
@@ -306,3 +308,200 @@ func Test_getBandwidthVal(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPodBandwidthRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want uint64
+	}{
+		{
+			name: "nil pod",
+			pod:  nil,
+			want: 0,
+		},
+		{
+			name: "pod without bandwidth request",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("1"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "sum bandwidth requests of containers",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									extension.ResourceNetworkBandwidth: resource.MustParse("800M"),
+								},
+							},
+						},
+						{
+							Name: "sidecar",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									extension.ResourceNetworkBandwidth: resource.MustParse("200M"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: 125000000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getPodBandwidthRequest(tt.pod))
+		})
+	}
+}
+
+func Test_getBandwidthRequestFromSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *extension.ExtendedResourceSpec
+		want uint64
+	}{
+		{
+			name: "nil spec",
+			spec: nil,
+			want: 0,
+		},
+		{
+			name: "spec without bandwidth request",
+			spec: &extension.ExtendedResourceSpec{
+				Containers: map[string]extension.ExtendedResourceContainerSpec{
+					"main": {
+						Requests: corev1.ResourceList{
+							extension.BatchCPU: resource.MustParse("1000"),
+						},
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "sum bandwidth requests of containers",
+			spec: &extension.ExtendedResourceSpec{
+				Containers: map[string]extension.ExtendedResourceContainerSpec{
+					"main": {
+						Requests: corev1.ResourceList{
+							extension.ResourceNetworkBandwidth: resource.MustParse("800M"),
+						},
+					},
+					"sidecar": {
+						Requests: corev1.ResourceList{
+							extension.ResourceNetworkBandwidth: resource.MustParse("200M"),
+						},
+					},
+				},
+			},
+			want: 125000000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getBandwidthRequestFromSpec(tt.spec))
+		})
+	}
+}
+
+func Test_getEgressRateAndCeil(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  uint64
+		limit    uint64
+		total    uint64
+		wantRate uint64
+		wantCeil uint64
+	}{
+		{
+			name:     "limit only",
+			request:  0,
+			limit:    1000,
+			wantRate: 1000,
+			wantCeil: 1000,
+		},
+		{
+			name:     "request only, borrow up to the node total",
+			request:  500,
+			limit:    0,
+			total:    2000,
+			wantRate: 500,
+			wantCeil: 2000,
+		},
+		{
+			name:     "request only, node total smaller than request",
+			request:  500,
+			limit:    0,
+			total:    200,
+			wantRate: 500,
+			wantCeil: 500,
+		},
+		{
+			name:     "borrow up to the limit",
+			request:  500,
+			limit:    1000,
+			wantRate: 500,
+			wantCeil: 1000,
+		},
+		{
+			name:     "limit smaller than request",
+			request:  1000,
+			limit:    500,
+			wantRate: 1000,
+			wantCeil: 1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRate, gotCeil := getEgressRateAndCeil(tt.request, tt.limit, tt.total)
+			assert.Equal(t, tt.wantRate, gotRate)
+			assert.Equal(t, tt.wantCeil, gotCeil)
+		})
+	}
+}
+
+func Test_getEgressRateAndCeilForRequestOnlyPod(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							extension.ResourceNetworkBandwidth: resource.MustParse("100M"),
+						},
+					},
+				},
+			},
+		},
+	}
+	ingress, egress, err := getIngressAndEgress(pod.Annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), ingress)
+	assert.Equal(t, uint64(0), egress)
+	request := getPodBandwidthRequest(pod)
+	assert.Equal(t, BitsToBytes(uint64(100000000)), request)
+
+	// the pod without the egress limit borrows up to the total bandwidth of the node
+	nodeSpeed := uint64(1000000000)
+	rate, ceil := getEgressRateAndCeil(request, egress, BitsToBytes(nodeSpeed))
+	assert.Equal(t, request, rate)
+	assert.Equal(t, BitsToBytes(nodeSpeed), ceil)
+}
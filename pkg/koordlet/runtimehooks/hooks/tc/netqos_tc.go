@@ -119,6 +119,59 @@ func getIngressAndEgress(anno map[string]string) (uint64, uint64, error) {
 	return ingress, egress, nil
 }
 
+// getPodBandwidthRequest returns the requested network bandwidth of the pod in bytes.
+// The bandwidth requested by `koordinator.sh/net-bandwidth` is guaranteed as the egress rate of the pod.
+func getPodBandwidthRequest(pod *corev1.Pod) uint64 {
+	if pod == nil {
+		return 0
+	}
+	var request int64
+	for i := range pod.Spec.Containers {
+		if q, ok := pod.Spec.Containers[i].Resources.Requests[extension.ResourceNetworkBandwidth]; ok {
+			request += q.Value()
+		}
+	}
+	if request <= 0 {
+		return 0
+	}
+	return BitsToBytes(uint64(request))
+}
+
+// getBandwidthRequestFromSpec returns the requested network bandwidth in bytes from the extended resource spec,
+// which is used when the original pod spec is unavailable, e.g. in the CRI requests.
+func getBandwidthRequestFromSpec(spec *extension.ExtendedResourceSpec) uint64 {
+	if spec == nil {
+		return 0
+	}
+	var request int64
+	for _, containerSpec := range spec.Containers {
+		if q, ok := containerSpec.Requests[extension.ResourceNetworkBandwidth]; ok {
+			request += q.Value()
+		}
+	}
+	if request <= 0 {
+		return 0
+	}
+	return BitsToBytes(uint64(request))
+}
+
+// getEgressRateAndCeil returns the rate and ceil of the pod-level htb class.
+// The requested bandwidth is guaranteed as the rate, and the pod can borrow up to the egress limit if it is larger.
+// A zero egress limit means no ceiling, so the pod with only the bandwidth request can borrow up to the total
+// bandwidth of the node. Without the bandwidth request, the egress limit is used as both the rate and the ceil.
+func getEgressRateAndCeil(request, limit, total uint64) (uint64, uint64) {
+	if request == 0 {
+		return limit, limit
+	}
+	if limit == 0 {
+		limit = total
+	}
+	if limit < request {
+		return request, request
+	}
+	return request, limit
+}
+
 func BitsToBytes[T uint64 | float64 | int](bits T) T {
 	return bits / 8
 }
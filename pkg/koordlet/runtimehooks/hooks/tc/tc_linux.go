@@ -174,7 +174,8 @@ func (p *tcPlugin) SetPodNetCls(proto protocol.HooksProtocol) error {
 	}
 
 	var handle uint32
-	needLimitInPodLevel := ing != 0 || egress != 0
+	request := getBandwidthRequestFromSpec(podCtx.Request.ExtendedResources)
+	needLimitInPodLevel := ing != 0 || egress != 0 || request != 0
 	if needLimitInPodLevel {
 		if handleId, ok := r.uidToHandle[types.UID(podCtx.Request.PodMeta.UID)]; ok {
 			handle = handleId
@@ -200,10 +201,10 @@ func (p *tcPlugin) SetPodNetCls(proto protocol.HooksProtocol) error {
 	return nil
 }
 
-func (p *tcPlugin) createTcRulesForHostPod(rule *tcRule, pod *v1.Pod, egress uint64) error {
+func (p *tcPlugin) createTcRulesForHostPod(rule *tcRule, pod *v1.Pod, rate, ceil uint64) error {
 	handle, _ := rule.uidToHandle[pod.UID]
 	netqos := GetNetQoSClassByAttrs(pod.Labels, pod.Annotations)
-	cls := newClass(p.interfLink.Attrs().Index, rootClass, handle, egress, egress, GetPrio(netqos))
+	cls := newClass(p.interfLink.Attrs().Index, rootClass, handle, rate, ceil, GetPrio(netqos))
 	err := p.ensureClass(p.interfLink, cls)
 	if err != nil {
 		return err
@@ -326,7 +327,8 @@ func (p *tcPlugin) refreshForAllPods(pods []*statesinformer.PodMeta, rule *tcRul
 		if err != nil {
 			klog.Errorf("failed to get net config from annotation in pod(%s)", format.Pod(pod.Pod))
 		}
-		needLimitAtPodLevel := ing != 0 || egress != 0
+		request := getPodBandwidthRequest(pod.Pod)
+		needLimitAtPodLevel := ing != 0 || egress != 0 || request != 0
 
 		if needLimitAtPodLevel {
 			// create tc rules
@@ -336,11 +338,12 @@ func (p *tcPlugin) refreshForAllPods(pods []*statesinformer.PodMeta, rule *tcRul
 			}
 			p.updateRule(rule)
 
+			rate, ceil := getEgressRateAndCeil(request, egress, BitsToBytes(rule.speed))
 			if pod.Pod.Spec.HostNetwork {
 				// pod in host network namespace, network bandwidth can be limited by net_cls cgroup.
-				err = p.createTcRulesForHostPod(rule, pod.Pod, egress)
+				err = p.createTcRulesForHostPod(rule, pod.Pod, rate, ceil)
 			} else {
-				err = p.createRulesForPod(rule, pod.Pod, netqos, egress)
+				err = p.createRulesForPod(rule, pod.Pod, netqos, rate, ceil)
 			}
 			if err != nil {
 				klog.Errorf("failed to create network rules for pod(uid:%s; ip:%s). err=%v", string(pod.Pod.UID), pod.Pod.Status.PodIP, err)
@@ -409,11 +412,11 @@ func (p *tcPlugin) refreshForAllPods(pods []*statesinformer.PodMeta, rule *tcRul
 	return nil
 }
 
-func (p *tcPlugin) createRulesForPod(rule *tcRule, pod *v1.Pod, netqos NetQoSClass, egress uint64) error {
+func (p *tcPlugin) createRulesForPod(rule *tcRule, pod *v1.Pod, netqos NetQoSClass, rate, ceil uint64) error {
 	klog.V(5).Infof("start to create related rules for pod(uid:%s; ip:%s), anno:%v", pod.UID, pod.Status.PodIP, pod.Annotations)
 
 	handle, _ := rule.uidToHandle[pod.UID]
-	cls := newClass(p.interfLink.Attrs().Index, rootClass, handle, egress, egress, GetPrio(netqos))
+	cls := newClass(p.interfLink.Attrs().Index, rootClass, handle, rate, ceil, GetPrio(netqos))
 	err := p.ensureClass(p.interfLink, cls)
 	if err != nil {
 		klog.Errorf("failed to create class for pod %s, err=%v", string(pod.UID), err)
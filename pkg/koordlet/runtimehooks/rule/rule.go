@@ -17,16 +17,25 @@ limitations under the License.
 package rule
 
 import (
+	"fmt"
 	"reflect"
 	"runtime"
 	"sync"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
+const (
+	reasonSystemUnsupported    = "SystemUnsupported"
+	reasonParseRuleFailed      = "ParseRuleFailed"
+	reasonUpdateCallbackFailed = "UpdateCallbackFailed"
+)
+
 func init() {
 	globalHookRules = map[string]*Rule{}
 }
@@ -38,6 +47,13 @@ type Rule struct {
 	parseRuleFn     ParseRuleFn
 	callbacks       []UpdateCbFn
 	systemSupported bool
+
+	// the last state reported for the NodeSLO
+	nodeSLOState   slov1alpha1.NodeSLOStrategyState
+	nodeSLOReason  string
+	nodeSLOMessage string
+	// the failures reported by the callbacks during the current update
+	updateFailures []error
 }
 
 type ParseRuleFn func(interface{}) (bool, error)
@@ -47,6 +63,14 @@ type SysSupportFn func() bool
 var globalHookRules map[string]*Rule
 var globalRWMutex sync.RWMutex
 
+// nodeSLOReporter reports the states of the rules parsed from the NodeSLO.
+var nodeSLOReporter statesinformer.NodeSLOStrategyReporter
+
+// SetNodeSLOStrategyReporter sets the reporter for the states of the rules parsed from the NodeSLO.
+func SetNodeSLOStrategyReporter(reporter statesinformer.NodeSLOStrategyReporter) {
+	nodeSLOReporter = reporter
+}
+
 func Register(name, description string, injectOpts ...InjectOption) *Rule {
 	r, exist := find(name)
 	if exist {
@@ -60,14 +84,39 @@ func Register(name, description string, injectOpts ...InjectOption) *Rule {
 	return r
 }
 
-func (r *Rule) runUpdateCallbacks(target *statesinformer.CallbackTarget) {
+// ReportUpdateFailure records a failure which makes the rule not take effect during its update callbacks.
+// The callbacks keep running on the failures, and the failures are only reported into the NodeSLO status.
+func ReportUpdateFailure(name string, err error) {
+	globalRWMutex.Lock()
+	defer globalRWMutex.Unlock()
+	if r, exist := globalHookRules[name]; exist && err != nil {
+		r.updateFailures = append(r.updateFailures, err)
+	}
+}
+
+func (r *Rule) runUpdateCallbacks(target *statesinformer.CallbackTarget) error {
 	klog.V(6).Infof("run update callbacks for rules, target %s", target.String())
+	globalRWMutex.Lock()
+	r.updateFailures = nil
+	globalRWMutex.Unlock()
 	for _, callbackFn := range r.callbacks {
 		if err := callbackFn(target); err != nil {
 			cbName := runtime.FuncForPC(reflect.ValueOf(callbackFn).Pointer()).Name()
 			klog.Warningf("executing %s callback function %s failed, error %v", r.name, cbName, err.Error())
+			ReportUpdateFailure(r.name, err)
 		}
 	}
+	globalRWMutex.Lock()
+	defer globalRWMutex.Unlock()
+	return utilerrors.NewAggregate(r.updateFailures)
+}
+
+func (r *Rule) reportNodeSLOState(ruleType statesinformer.RegisterType, state slov1alpha1.NodeSLOStrategyState, reason, message string) {
+	if ruleType != statesinformer.RegisterTypeNodeSLOSpec || nodeSLOReporter == nil {
+		return
+	}
+	r.nodeSLOState, r.nodeSLOReason, r.nodeSLOMessage = state, reason, message
+	nodeSLOReporter.ReportNodeSLOStrategy(r.name, state, reason, message)
 }
 
 func find(name string) (*Rule, bool) {
@@ -90,6 +139,8 @@ func UpdateRules(ruleType statesinformer.RegisterType, ruleObj interface{}, targ
 		}
 		if !r.systemSupported {
 			klog.V(4).Infof("system unsupported for rule %s, do nothing during UpdateRules", r.name)
+			r.reportNodeSLOState(ruleType, slov1alpha1.NodeSLOStrategyUnsupported, reasonSystemUnsupported,
+				fmt.Sprintf("rule %s is not supported by the system", r.name))
 			continue
 		}
 		if r.parseRuleFn == nil {
@@ -98,12 +149,24 @@ func UpdateRules(ruleType statesinformer.RegisterType, ruleObj interface{}, targ
 		updated, err := r.parseRuleFn(ruleObj)
 		if err != nil {
 			klog.Warningf("parse rule %s from nodeSLO failed, error: %v", r.name, err)
+			r.reportNodeSLOState(ruleType, slov1alpha1.NodeSLOStrategyFailed, reasonParseRuleFailed, err.Error())
+			continue
+		}
+		if !updated {
+			// the unchanged rule keeps the result of its last update
+			if r.nodeSLOReason == reasonUpdateCallbackFailed {
+				r.reportNodeSLOState(ruleType, r.nodeSLOState, r.nodeSLOReason, r.nodeSLOMessage)
+			} else {
+				r.reportNodeSLOState(ruleType, slov1alpha1.NodeSLOStrategyApplied, "", "")
+			}
 			continue
 		}
-		if updated {
-			klog.V(3).Infof("rule %s is updated, run update callback for all %v pods and %v host applications",
-				r.name, len(targets.Pods), len(targets.HostApplications))
-			r.runUpdateCallbacks(targets)
+		klog.V(3).Infof("rule %s is updated, run update callback for all %v pods and %v host applications",
+			r.name, len(targets.Pods), len(targets.HostApplications))
+		if err = r.runUpdateCallbacks(targets); err != nil {
+			r.reportNodeSLOState(ruleType, slov1alpha1.NodeSLOStrategyFailed, reasonUpdateCallbackFailed, err.Error())
+		} else {
+			r.reportNodeSLOState(ruleType, slov1alpha1.NodeSLOStrategyApplied, "", "")
 		}
 	}
 }
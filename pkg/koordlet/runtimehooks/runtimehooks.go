@@ -170,6 +170,7 @@ func NewRuntimeHook(si statesinformer.StatesInformer, cfg *Config, schema *apiru
 		executor:          e,
 	}
 	registerPlugins(newPluginOptions)
+	rule.SetNodeSLOStrategyReporter(si)
 	si.RegisterCallbacks(statesinformer.RegisterTypeNodeSLOSpec, "runtime-hooks-rule-node-slo",
 		"Update hooks rule can run callbacks if NodeSLO spec update",
 		rule.UpdateRules)
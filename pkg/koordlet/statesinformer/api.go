@@ -114,6 +114,12 @@ func (t *CallbackTarget) String() string {
 
 type UpdateCbFn func(t RegisterType, obj interface{}, target *CallbackTarget)
 
+// NodeSLOStrategyReporter records the states of the NodeSLO strategies applied on the node, which are reported
+// into the NodeSLO status.
+type NodeSLOStrategyReporter interface {
+	ReportNodeSLOStrategy(name string, state slov1alpha1.NodeSLOStrategyState, reason, message string)
+}
+
 type StatesInformer interface {
 	Run(stopCh <-chan struct{}) error
 	HasSynced() bool
@@ -129,4 +135,6 @@ type StatesInformer interface {
 	GetVolumeName(pvcNamespace, pvcName string) string
 
 	RegisterCallbacks(objType RegisterType, name, description string, callbackFn UpdateCbFn)
+
+	NodeSLOStrategyReporter
 }
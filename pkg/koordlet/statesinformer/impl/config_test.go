@@ -41,6 +41,8 @@ func TestNewDefaultConfig(t *testing.T) {
 				NodeTopologySyncInterval:    3 * time.Second,
 				DisableQueryKubeletConfig:   false,
 				EnableNodeMetricReport:      true,
+				EnableNodeSLOStatusReport:   true,
+				NodeSLOStatusReportInterval: 30 * time.Second,
 				MetricReportInterval:        0,
 				EnablePodTaskIds:            false,
 			},
@@ -65,6 +67,8 @@ func TestConfig_InitFlags(t *testing.T) {
 		"--node-topology-sync-interval=10s",
 		"--disable-query-kubelet-config=true",
 		"--enable-node-metric-report=false",
+		"--enable-node-slo-status-report=false",
+		"--node-slo-status-report-interval=1m",
 		"--enable-pod-taskids=true",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
@@ -78,6 +82,8 @@ func TestConfig_InitFlags(t *testing.T) {
 		NodeTopologySyncInterval    time.Duration
 		DisableQueryKubeletConfig   bool
 		EnableNodeMetricReport      bool
+		EnableNodeSLOStatusReport   bool
+		NodeSLOStatusReportInterval time.Duration
 		EnablePodTaskIds            bool
 	}
 	type args struct {
@@ -99,6 +105,8 @@ func TestConfig_InitFlags(t *testing.T) {
 				NodeTopologySyncInterval:    10 * time.Second,
 				DisableQueryKubeletConfig:   true,
 				EnableNodeMetricReport:      false,
+				EnableNodeSLOStatusReport:   false,
+				NodeSLOStatusReportInterval: time.Minute,
 				EnablePodTaskIds:            true,
 			},
 			args: args{fs: fs},
@@ -115,6 +123,8 @@ func TestConfig_InitFlags(t *testing.T) {
 				NodeTopologySyncInterval:    tt.fields.NodeTopologySyncInterval,
 				DisableQueryKubeletConfig:   tt.fields.DisableQueryKubeletConfig,
 				EnableNodeMetricReport:      tt.fields.EnableNodeMetricReport,
+				EnableNodeSLOStatusReport:   tt.fields.EnableNodeSLOStatusReport,
+				NodeSLOStatusReportInterval: tt.fields.NodeSLOStatusReportInterval,
 				EnablePodTaskIds:            tt.fields.EnablePodTaskIds,
 			}
 			c := NewDefaultConfig()
@@ -216,6 +216,16 @@ func (s *statesInformer) GetNodeSLO() *slov1alpha1.NodeSLO {
 	return nodeSLOInformer.GetNodeSLO()
 }
 
+func (s *statesInformer) ReportNodeSLOStrategy(name string, state slov1alpha1.NodeSLOStrategyState, reason, message string) {
+	nodeSLOInformerIf := s.states.informerPlugins[nodeSLOInformerName]
+	nodeSLOInformer, ok := nodeSLOInformerIf.(*nodeSLOInformer)
+	if !ok {
+		klog.Errorf("node slo informer format error")
+		return
+	}
+	nodeSLOInformer.ReportNodeSLOStrategy(name, state, reason, message)
+}
+
 func (s *statesInformer) GetNodeMetricSpec() *slov1alpha1.NodeMetricSpec {
 	nodeMetricInformerIf := s.states.informerPlugins[nodeMetricInformerName]
 	nodeMetricInformer, ok := nodeMetricInformerIf.(*nodeMetricInformer)
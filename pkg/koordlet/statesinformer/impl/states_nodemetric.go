@@ -375,6 +375,8 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 	}
 	node := r.nodeInformer.GetNode()
 	prodPredictor := r.predictorFactory.New(prediction.ProdReclaimablePredictor, prediction.PredictorContext{Node: node})
+	interferenceEnabled := isInterferenceCollectorEnabled()
+	var nodeCPICounter cpiCounter
 	for _, podMeta := range podsMeta {
 		podMetric, err := r.collectPodMetric(podMeta, queryParam)
 		if err != nil {
@@ -391,8 +393,16 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 		if len(gpus) > 0 {
 			r.fillGPUMetrics(queryParam, podMetric, string(podMeta.Pod.UID), gpus)
 		}
+		if interferenceEnabled {
+			var podCPICounter cpiCounter
+			podMetric.Interference, podCPICounter = r.collectPodInterference(podMeta.Pod, queryParam)
+			nodeCPICounter.add(podCPICounter)
+		}
 		podsMetricInfo = append(podsMetricInfo, podMetric)
 	}
+	if interferenceEnabled {
+		nodeMetricInfo.Interference = r.collectNodeInterference(queryParam, nodeCPICounter)
+	}
 	sort.Slice(podsMetricInfo, func(i, j int) bool {
 		if podsMetricInfo[i].Namespace != podsMetricInfo[j].Namespace {
 			return podsMetricInfo[i].Namespace < podsMetricInfo[j].Namespace
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+)
+
+// psiItems lists the PSI reported in the InterferenceMetric, all of them are averaged over 10 seconds.
+var psiItems = []struct {
+	resource metriccache.MetricPropertyValue
+	degree   metriccache.MetricPropertyValue
+	field    func(psi *slov1alpha1.PSIMetric) **resource.Quantity
+}{
+	{metriccache.PSIResourceCPU, metriccache.PSIDegreeSome, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.CPUSome }},
+	{metriccache.PSIResourceCPU, metriccache.PSIDegreeFull, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.CPUFull }},
+	{metriccache.PSIResourceMem, metriccache.PSIDegreeSome, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.MemorySome }},
+	{metriccache.PSIResourceMem, metriccache.PSIDegreeFull, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.MemoryFull }},
+	{metriccache.PSIResourceIO, metriccache.PSIDegreeSome, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.IOSome }},
+	{metriccache.PSIResourceIO, metriccache.PSIDegreeFull, func(psi *slov1alpha1.PSIMetric) **resource.Quantity { return &psi.IOFull }},
+}
+
+// cpiCounter accumulates the cycles and instructions to calculate the CPI.
+type cpiCounter struct {
+	cycles       float64
+	instructions float64
+}
+
+func (c *cpiCounter) add(o cpiCounter) {
+	c.cycles += o.cycles
+	c.instructions += o.instructions
+}
+
+func (c *cpiCounter) cpi() *resource.Quantity {
+	if c.instructions <= 0 {
+		return nil
+	}
+	return newFloatQuantity(c.cycles / c.instructions)
+}
+
+func newFloatQuantity(v float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(v*1000), resource.DecimalSI)
+}
+
+func newInterferenceMetric(psi *slov1alpha1.PSIMetric, cpi *resource.Quantity) *slov1alpha1.InterferenceMetric {
+	if psi == nil && cpi == nil {
+		return nil
+	}
+	return &slov1alpha1.InterferenceMetric{PSI: psi, CPI: cpi}
+}
+
+// queryPSI queries the PSI with the properties generated by the propertiesFn, it returns nil if no sample found.
+func queryPSI(querier metriccache.Querier, metric metriccache.MetricResource, aggregate metriccache.AggregationType,
+	propertiesFn func(psiResource, psiDegree string) map[metriccache.MetricProperty]string) (*slov1alpha1.PSIMetric, error) {
+	psi := &slov1alpha1.PSIMetric{}
+	found := false
+	for _, item := range psiItems {
+		aggregateResult, err := doQuery(querier, metric, propertiesFn(string(item.resource), string(item.degree)))
+		if err != nil {
+			return nil, err
+		}
+		if aggregateResult.Count() == 0 {
+			continue
+		}
+		value, err := aggregateResult.Value(aggregate)
+		if err != nil {
+			return nil, err
+		}
+		*item.field(psi) = newFloatQuantity(value)
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return psi, nil
+}
+
+// collectPodInterference collects the PSI and the CPI of the pod. The cycles and instructions of the containers
+// are also returned to calculate the CPI of the node.
+func (r *nodeMetricInformer) collectPodInterference(pod *corev1.Pod, queryParam metriccache.QueryParam) (*slov1alpha1.InterferenceMetric, cpiCounter) {
+	var counter cpiCounter
+	querier, err := r.metricCache.Querier(*queryParam.Start, *queryParam.End)
+	if err != nil {
+		klog.V(5).Infof("failed to get querier for pod %s/%s interference, error %v", pod.Namespace, pod.Name, err)
+		return nil, counter
+	}
+	defer querier.Close()
+
+	podUID := string(pod.UID)
+	var psi *slov1alpha1.PSIMetric
+	if features.DefaultKoordletFeatureGate.Enabled(features.PSICollector) {
+		psi, err = queryPSI(querier, metriccache.PodPSIMetric, queryParam.Aggregate, func(psiResource, psiDegree string) map[metriccache.MetricProperty]string {
+			return metriccache.MetricPropertiesFunc.PodPSI(podUID, psiResource, string(metriccache.PSIPrecision10), psiDegree)
+		})
+		if err != nil {
+			klog.V(4).Infof("failed to query pod %s/%s psi, error %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	if features.DefaultKoordletFeatureGate.Enabled(features.CPICollector) {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.ContainerID == "" {
+				continue
+			}
+			containerCounter, err := queryContainerCPI(querier, podUID, containerStatus.ContainerID, queryParam.Aggregate)
+			if err != nil {
+				klog.V(4).Infof("failed to query container %s/%s/%s cpi, error %v", pod.Namespace, pod.Name, containerStatus.Name, err)
+				continue
+			}
+			counter.add(containerCounter)
+		}
+	}
+	return newInterferenceMetric(psi, counter.cpi()), counter
+}
+
+func queryContainerCPI(querier metriccache.Querier, podUID, containerID string, aggregate metriccache.AggregationType) (cpiCounter, error) {
+	var counter cpiCounter
+	for _, v := range []struct {
+		cpiResource metriccache.MetricPropertyValue
+		value       *float64
+	}{
+		{cpiResource: metriccache.CPIResourceCycle, value: &counter.cycles},
+		{cpiResource: metriccache.CPIResourceInstruction, value: &counter.instructions},
+	} {
+		aggregateResult, err := doQuery(querier, metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerID, string(v.cpiResource)))
+		if err != nil {
+			return cpiCounter{}, err
+		}
+		if aggregateResult.Count() == 0 {
+			return cpiCounter{}, nil
+		}
+		if *v.value, err = aggregateResult.Value(aggregate); err != nil {
+			return cpiCounter{}, err
+		}
+	}
+	return counter, nil
+}
+
+// collectNodeInterference collects the PSI of the kubepods cgroup, and the CPI of all containers on the node.
+func (r *nodeMetricInformer) collectNodeInterference(queryParam metriccache.QueryParam, counter cpiCounter) *slov1alpha1.InterferenceMetric {
+	var psi *slov1alpha1.PSIMetric
+	if features.DefaultKoordletFeatureGate.Enabled(features.PSICollector) {
+		querier, err := r.metricCache.Querier(*queryParam.Start, *queryParam.End)
+		if err != nil {
+			klog.V(5).Infof("failed to get querier for node interference, error %v", err)
+		} else {
+			psi, err = queryPSI(querier, metriccache.NodePSIMetric, queryParam.Aggregate, func(psiResource, psiDegree string) map[metriccache.MetricProperty]string {
+				return metriccache.MetricPropertiesFunc.NodePSI(psiResource, string(metriccache.PSIPrecision10), psiDegree)
+			})
+			querier.Close()
+			if err != nil {
+				klog.V(4).Infof("failed to query node psi, error %v", err)
+			}
+		}
+	}
+	return newInterferenceMetric(psi, counter.cpi())
+}
+
+func isInterferenceCollectorEnabled() bool {
+	return features.DefaultKoordletFeatureGate.Enabled(features.PSICollector) ||
+		features.DefaultKoordletFeatureGate.Enabled(features.CPICollector)
+}
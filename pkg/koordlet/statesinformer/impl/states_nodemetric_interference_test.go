@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mockmetriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+func Test_nodeMetricInformer_collectInterference(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-time.Second * 120)
+	queryParam := metriccache.QueryParam{Start: &startTime, End: &now, Aggregate: metriccache.AggregationTypeAVG}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "test-pod",
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "main", ContainerID: "containerd://main"},
+				{Name: "sidecar", ContainerID: "containerd://sidecar"},
+				{Name: "not-started"},
+			},
+		},
+	}
+	// samples of some and full pressure are set to 1.5 and 0.5 for every resource
+	psiSample := func(degree metriccache.MetricPropertyValue) float64 {
+		if degree == metriccache.PSIDegreeSome {
+			return 1.5
+		}
+		return 0.5
+	}
+	wantPSI := &slov1alpha1.PSIMetric{
+		CPUSome:    resource.NewMilliQuantity(1500, resource.DecimalSI),
+		CPUFull:    resource.NewMilliQuantity(500, resource.DecimalSI),
+		MemorySome: resource.NewMilliQuantity(1500, resource.DecimalSI),
+		MemoryFull: resource.NewMilliQuantity(500, resource.DecimalSI),
+		IOSome:     resource.NewMilliQuantity(1500, resource.DecimalSI),
+		IOFull:     resource.NewMilliQuantity(500, resource.DecimalSI),
+	}
+	tests := []struct {
+		name             string
+		psiEnabled       bool
+		cpiEnabled       bool
+		wantPod          *slov1alpha1.InterferenceMetric
+		wantPodCounter   cpiCounter
+		wantNode         *slov1alpha1.InterferenceMetric
+		wantNodeDisabled bool
+	}{
+		{
+			name:             "all collectors disabled",
+			wantNodeDisabled: true,
+		},
+		{
+			name:       "collect psi only",
+			psiEnabled: true,
+			wantPod:    &slov1alpha1.InterferenceMetric{PSI: wantPSI},
+			wantNode:   &slov1alpha1.InterferenceMetric{PSI: wantPSI},
+		},
+		{
+			name:       "collect psi and cpi",
+			psiEnabled: true,
+			cpiEnabled: true,
+			wantPod: &slov1alpha1.InterferenceMetric{
+				PSI: wantPSI,
+				CPI: resource.NewMilliQuantity(2500, resource.DecimalSI),
+			},
+			wantPodCounter: cpiCounter{cycles: 5000, instructions: 2000},
+			wantNode: &slov1alpha1.InterferenceMetric{
+				PSI: wantPSI,
+				CPI: resource.NewMilliQuantity(2500, resource.DecimalSI),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, features.DefaultMutableKoordletFeatureGate, features.PSICollector, tt.psiEnabled)()
+			defer utilfeature.SetFeatureGateDuringTest(t, features.DefaultMutableKoordletFeatureGate, features.CPICollector, tt.cpiEnabled)()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+			mockQuerier.EXPECT().Close().AnyTimes()
+
+			duration := queryParam.End.Sub(*queryParam.Start)
+			for _, item := range psiItems {
+				podQueryMeta, err := metriccache.PodPSIMetric.BuildQueryMeta(metriccache.MetricPropertiesFunc.PodPSI(
+					string(pod.UID), string(item.resource), string(metriccache.PSIPrecision10), string(item.degree)))
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, podQueryMeta, psiSample(item.degree), duration)
+				nodeQueryMeta, err := metriccache.NodePSIMetric.BuildQueryMeta(metriccache.MetricPropertiesFunc.NodePSI(
+					string(item.resource), string(metriccache.PSIPrecision10), string(item.degree)))
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, nodeQueryMeta, psiSample(item.degree), duration)
+			}
+			for _, containerID := range []string{"containerd://main", "containerd://sidecar"} {
+				cycleQueryMeta, err := metriccache.ContainerCPI.BuildQueryMeta(metriccache.MetricPropertiesFunc.ContainerCPI(
+					string(pod.UID), containerID, string(metriccache.CPIResourceCycle)))
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, cycleQueryMeta, 2500, duration)
+				instructionQueryMeta, err := metriccache.ContainerCPI.BuildQueryMeta(metriccache.MetricPropertiesFunc.ContainerCPI(
+					string(pod.UID), containerID, string(metriccache.CPIResourceInstruction)))
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, instructionQueryMeta, 1000, duration)
+			}
+
+			r := &nodeMetricInformer{metricCache: mockMetricCache}
+			assert.Equal(t, !tt.wantNodeDisabled, isInterferenceCollectorEnabled())
+			gotPod, gotCounter := r.collectPodInterference(pod, queryParam)
+			assert.Equal(t, tt.wantPod, gotPod)
+			assert.Equal(t, tt.wantPodCounter, gotCounter)
+			gotNode := r.collectNodeInterference(queryParam, gotCounter)
+			assert.Equal(t, tt.wantNode, gotNode)
+		})
+	}
+}
@@ -20,11 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -45,6 +48,15 @@ type nodeSLOInformer struct {
 	nodeSLORWMutex  sync.RWMutex
 	nodeSLO         *slov1alpha1.NodeSLO
 
+	// strategyConditions are the states of the strategies reported by the koordlet modules
+	strategyRWMutex    sync.RWMutex
+	strategyConditions map[string]*slov1alpha1.NodeSLOStrategyCondition
+
+	nodeName             string
+	koordClient          koordclientset.Interface
+	enableStatusReport   bool
+	statusReportInterval time.Duration
+
 	callbackRunner *callbackRunner
 }
 
@@ -86,11 +98,18 @@ func (s *nodeSLOInformer) Setup(ctx *PluginOption, state *PluginState) {
 		},
 	})
 	s.callbackRunner = state.callbackRunner
+	s.nodeName = ctx.NodeName
+	s.koordClient = ctx.KoordClient
+	s.enableStatusReport = ctx.config.EnableNodeSLOStatusReport
+	s.statusReportInterval = ctx.config.NodeSLOStatusReportInterval
 }
 
 func (s *nodeSLOInformer) Start(stopCh <-chan struct{}) {
 	klog.V(2).Infof("starting node slo informer")
 	go s.nodeSLOInformer.Run(stopCh)
+	if s.enableStatusReport && s.statusReportInterval > 0 {
+		go wait.Until(s.syncNodeSLOStatus, s.statusReportInterval, stopCh)
+	}
 	klog.V(2).Infof("node slo informer started")
 }
 
@@ -117,6 +136,7 @@ func (s *nodeSLOInformer) setNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 	if s.nodeSLO == nil {
 		s.nodeSLO = nodeSLO.DeepCopy()
 	} else {
+		s.nodeSLO.Generation = nodeSLO.Generation
 		s.nodeSLO.Spec = nodeSLO.Spec
 	}
 
@@ -171,6 +191,96 @@ func (s *nodeSLOInformer) mergeNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 
 }
 
+// ReportNodeSLOStrategy records the state of the strategy applying the current NodeSLO spec.
+func (s *nodeSLOInformer) ReportNodeSLOStrategy(name string, state slov1alpha1.NodeSLOStrategyState, reason, message string) {
+	generation := s.getNodeSLOGeneration()
+	// the status is serialized in seconds, so truncate the time to avoid the needless updates
+	now := metav1.Now().Rfc3339Copy()
+
+	s.strategyRWMutex.Lock()
+	defer s.strategyRWMutex.Unlock()
+	if s.strategyConditions == nil {
+		s.strategyConditions = map[string]*slov1alpha1.NodeSLOStrategyCondition{}
+	}
+	condition, exist := s.strategyConditions[name]
+	if !exist {
+		condition = &slov1alpha1.NodeSLOStrategyCondition{Name: name}
+		s.strategyConditions[name] = condition
+	}
+	if condition.State != state {
+		condition.LastTransitionTime = now
+	}
+	// the applied time only refreshes when a new generation is applied, since some strategies are applied periodically
+	if state == slov1alpha1.NodeSLOStrategyApplied &&
+		(condition.State != state || condition.ObservedGeneration != generation || condition.LastAppliedTime == nil) {
+		condition.LastAppliedTime = &now
+	}
+	if condition.State != state || condition.Reason != reason {
+		klog.V(4).Infof("NodeSLO strategy %s turns into %s, generation %v, reason %s, message %s",
+			name, state, generation, reason, message)
+	}
+	condition.State = state
+	condition.ObservedGeneration = generation
+	condition.Reason = reason
+	condition.Message = message
+}
+
+func (s *nodeSLOInformer) getNodeSLOGeneration() int64 {
+	s.nodeSLORWMutex.RLock()
+	defer s.nodeSLORWMutex.RUnlock()
+	if s.nodeSLO == nil {
+		return 0
+	}
+	return s.nodeSLO.Generation
+}
+
+func (s *nodeSLOInformer) getNodeSLOStatus() *slov1alpha1.NodeSLOStatus {
+	status := &slov1alpha1.NodeSLOStatus{
+		ObservedGeneration: s.getNodeSLOGeneration(),
+	}
+
+	s.strategyRWMutex.RLock()
+	defer s.strategyRWMutex.RUnlock()
+	for _, condition := range s.strategyConditions {
+		status.Conditions = append(status.Conditions, *condition.DeepCopy())
+	}
+	sort.Slice(status.Conditions, func(i, j int) bool {
+		return status.Conditions[i].Name < status.Conditions[j].Name
+	})
+	return status
+}
+
+// syncNodeSLOStatus updates the NodeSLO status with the states of the strategies if changed.
+func (s *nodeSLOInformer) syncNodeSLOStatus() {
+	if s.getNodeSLOGeneration() <= 0 {
+		klog.V(5).Infof("skip syncing NodeSLO status since the spec is not received")
+		return
+	}
+	obj, exist, err := s.nodeSLOInformer.GetStore().GetByKey(s.nodeName)
+	if err != nil || !exist {
+		klog.V(4).Infof("failed to get NodeSLO %s from cache, exist %v, err: %v", s.nodeName, exist, err)
+		return
+	}
+	nodeSLO, ok := obj.(*slov1alpha1.NodeSLO)
+	if !ok {
+		klog.Errorf("unable to convert object to *slov1alpha1.NodeSLO, got %T", obj)
+		return
+	}
+
+	newStatus := s.getNodeSLOStatus()
+	if apiequality.Semantic.DeepEqual(&nodeSLO.Status, newStatus) {
+		klog.V(6).Infof("NodeSLO %s status has not changed", s.nodeName)
+		return
+	}
+	newNodeSLO := nodeSLO.DeepCopy()
+	newNodeSLO.Status = *newStatus
+	if _, err = s.koordClient.SloV1alpha1().NodeSLOs().UpdateStatus(context.TODO(), newNodeSLO, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("failed to update NodeSLO %s status, err: %v", s.nodeName, err)
+		return
+	}
+	klog.V(4).Infof("update NodeSLO %s status successfully, %v", s.nodeName, util.DumpJSON(newStatus))
+}
+
 func newNodeSLOInformer(client koordclientset.Interface, nodeName string) cache.SharedIndexInformer {
 	tweakListOptionFunc := func(opt *metav1.ListOptions) {
 		opt.FieldSelector = "metadata.name=" + nodeName
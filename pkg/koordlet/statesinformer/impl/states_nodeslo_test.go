@@ -17,13 +17,17 @@ limitations under the License.
 package impl
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
 )
@@ -253,6 +257,72 @@ func Test_updateNodeSLOSpec(t *testing.T) {
 	assert.Equal(t, testingUpdatedNodeSLO, r.nodeSLO)
 }
 
+func Test_syncNodeSLOStatus(t *testing.T) {
+	testingNodeSLO := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-node",
+			Generation: 2,
+		},
+	}
+	koordClient := koordfake.NewSimpleClientset(testingNodeSLO.DeepCopy())
+	s := &nodeSLOInformer{
+		nodeSLOInformer:    newNodeSLOInformer(koordClient, "test-node"),
+		nodeName:           "test-node",
+		koordClient:        koordClient,
+		enableStatusReport: true,
+		callbackRunner:     NewCallbackRunner(),
+	}
+	assert.NoError(t, s.nodeSLOInformer.GetStore().Add(testingNodeSLO.DeepCopy()))
+
+	// skip when the spec is not received
+	s.ReportNodeSLOStrategy("strategyA", slov1alpha1.NodeSLOStrategyApplied, "", "")
+	s.syncNodeSLOStatus()
+	got, err := koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), "test-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, slov1alpha1.NodeSLOStatus{}, got.Status)
+
+	s.setNodeSLOSpec(testingNodeSLO)
+	s.ReportNodeSLOStrategy("strategyA", slov1alpha1.NodeSLOStrategyApplied, "", "")
+	s.ReportNodeSLOStrategy("strategyB", slov1alpha1.NodeSLOStrategyFailed, "UpdateFailed", "permission denied")
+	s.syncNodeSLOStatus()
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), "test-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got.Status.ObservedGeneration)
+	assert.Equal(t, 2, len(got.Status.Conditions))
+	assert.Equal(t, "strategyA", got.Status.Conditions[0].Name)
+	assert.Equal(t, slov1alpha1.NodeSLOStrategyApplied, got.Status.Conditions[0].State)
+	assert.Equal(t, int64(2), got.Status.Conditions[0].ObservedGeneration)
+	assert.NotNil(t, got.Status.Conditions[0].LastAppliedTime)
+	assert.Equal(t, "strategyB", got.Status.Conditions[1].Name)
+	assert.Equal(t, slov1alpha1.NodeSLOStrategyFailed, got.Status.Conditions[1].State)
+	assert.Equal(t, "UpdateFailed", got.Status.Conditions[1].Reason)
+	assert.Equal(t, "permission denied", got.Status.Conditions[1].Message)
+	assert.Nil(t, got.Status.Conditions[1].LastAppliedTime)
+	assert.NoError(t, s.nodeSLOInformer.GetStore().Update(got))
+
+	// the applied time keeps unchanged when the same generation is applied again
+	lastAppliedTime := got.Status.Conditions[0].LastAppliedTime.DeepCopy()
+	time.Sleep(time.Second)
+	s.ReportNodeSLOStrategy("strategyA", slov1alpha1.NodeSLOStrategyApplied, "", "")
+	s.syncNodeSLOStatus()
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), "test-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, lastAppliedTime, got.Status.Conditions[0].LastAppliedTime)
+
+	// the applied time refreshes when a new generation is applied
+	testingNodeSLO.Generation = 3
+	s.setNodeSLOSpec(testingNodeSLO)
+	s.ReportNodeSLOStrategy("strategyA", slov1alpha1.NodeSLOStrategyApplied, "", "")
+	s.syncNodeSLOStatus()
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), "test-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got.Status.ObservedGeneration)
+	assert.Equal(t, int64(3), got.Status.Conditions[0].ObservedGeneration)
+	assert.True(t, got.Status.Conditions[0].LastAppliedTime.After(lastAppliedTime.Time))
+	// the strategy not reported for the new generation keeps the old generation
+	assert.Equal(t, int64(2), got.Status.Conditions[1].ObservedGeneration)
+}
+
 func Test_mergeSLOSpecResourceUsedThresholdWithBE(t *testing.T) {
 	testingDefaultSpec := sloconfig.DefaultResourceThresholdStrategy()
 	testingNewSpec := &slov1alpha1.ResourceThresholdStrategy{
@@ -36,6 +36,42 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+// MockNodeSLOStrategyReporter is a mock of NodeSLOStrategyReporter interface.
+type MockNodeSLOStrategyReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeSLOStrategyReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockNodeSLOStrategyReporterMockRecorder is the mock recorder for MockNodeSLOStrategyReporter.
+type MockNodeSLOStrategyReporterMockRecorder struct {
+	mock *MockNodeSLOStrategyReporter
+}
+
+// NewMockNodeSLOStrategyReporter creates a new mock instance.
+func NewMockNodeSLOStrategyReporter(ctrl *gomock.Controller) *MockNodeSLOStrategyReporter {
+	mock := &MockNodeSLOStrategyReporter{ctrl: ctrl}
+	mock.recorder = &MockNodeSLOStrategyReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeSLOStrategyReporter) EXPECT() *MockNodeSLOStrategyReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportNodeSLOStrategy mocks base method.
+func (m *MockNodeSLOStrategyReporter) ReportNodeSLOStrategy(name string, state v1alpha10.NodeSLOStrategyState, reason, message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportNodeSLOStrategy", name, state, reason, message)
+}
+
+// ReportNodeSLOStrategy indicates an expected call of ReportNodeSLOStrategy.
+func (mr *MockNodeSLOStrategyReporterMockRecorder) ReportNodeSLOStrategy(name, state, reason, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportNodeSLOStrategy", reflect.TypeOf((*MockNodeSLOStrategyReporter)(nil).ReportNodeSLOStrategy), name, state, reason, message)
+}
+
 // MockStatesInformer is a mock of StatesInformer interface.
 type MockStatesInformer struct {
 	ctrl     *gomock.Controller
@@ -170,6 +206,18 @@ func (mr *MockStatesInformerMockRecorder) RegisterCallbacks(objType, name, descr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCallbacks", reflect.TypeOf((*MockStatesInformer)(nil).RegisterCallbacks), objType, name, description, callbackFn)
 }
 
+// ReportNodeSLOStrategy mocks base method.
+func (m *MockStatesInformer) ReportNodeSLOStrategy(name string, state v1alpha10.NodeSLOStrategyState, reason, message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportNodeSLOStrategy", name, state, reason, message)
+}
+
+// ReportNodeSLOStrategy indicates an expected call of ReportNodeSLOStrategy.
+func (mr *MockStatesInformerMockRecorder) ReportNodeSLOStrategy(name, state, reason, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportNodeSLOStrategy", reflect.TypeOf((*MockStatesInformer)(nil).ReportNodeSLOStrategy), name, state, reason, message)
+}
+
 // Run mocks base method.
 func (m *MockStatesInformer) Run(stopCh <-chan struct{}) error {
 	m.ctrl.T.Helper()
@@ -254,6 +254,19 @@ func getProcessorInfos(lsCPUStr string) ([]ProcessorInfo, error) {
 	return processorInfos, nil
 }
 
+// fillL3CacheIDs overwrites the L3 cache IDs parsed from `lscpu` with the ones in sysfs, since `lscpu` can omit
+// the L3 cache on some platforms. The lscpu values are kept for the cpus whose L3 cache is not exposed in sysfs.
+func fillL3CacheIDs(processorInfos []ProcessorInfo) {
+	for i := range processorInfos {
+		l3, err := system.GetCPUL3CacheID(processorInfos[i].CPUID)
+		if err != nil {
+			klog.V(5).Infof("failed to get L3 cache id of cpu %d from sysfs, err: %v", processorInfos[i].CPUID, err)
+			continue
+		}
+		processorInfos[i].L3 = l3
+	}
+}
+
 func calculateCPUTotalInfo(processorInfos []ProcessorInfo) *CPUTotalInfo {
 	cpuMap := map[int32]struct{}{}
 	coreMap := map[int32][]ProcessorInfo{}
@@ -287,6 +300,7 @@ func GetLocalCPUInfo() (*LocalCPUInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	fillL3CacheIDs(processorInfos)
 	totalInfo := calculateCPUTotalInfo(processorInfos)
 	basicInfo, err := getCPUBasicInfo()
 	if err != nil {
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	cpuCacheIndexPrefix = "index"
+	cpuCacheLevelFile   = "level"
+	cpuCacheIDFile      = "id"
+)
+
+// GetCPUL3CacheID returns the ID of the L3 cache shared by the logical cpu.
+// e.g.
+// $ cat /sys/devices/system/cpu/cpu0/cache/index3/{level,id}
+// 3
+// 0
+// On AMD EPYC processors, each CCX has its own L3 cache, so the cpus in different CCXs of a socket have
+// different L3 cache IDs. It returns an error if the kernel does not expose the L3 cache of the cpu.
+func GetCPUL3CacheID(cpu int32) (int32, error) {
+	cacheDir := GetSysCPUCacheDir(cpu)
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), cpuCacheIndexPrefix) {
+			continue
+		}
+		level, err := readCPUCacheInt(filepath.Join(cacheDir, entry.Name(), cpuCacheLevelFile))
+		if err != nil || level != 3 {
+			continue
+		}
+		id, err := readCPUCacheInt(filepath.Join(cacheDir, entry.Name(), cpuCacheIDFile))
+		if err != nil {
+			return 0, err
+		}
+		return int32(id), nil
+	}
+	return 0, fmt.Errorf("L3 cache of cpu %d not found in %s", cpu, cacheDir)
+}
+
+func readCPUCacheInt(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 32)
+}
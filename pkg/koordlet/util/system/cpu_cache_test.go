@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCPUL3CacheID(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(helper *FileTestUtil)
+		cpu     int32
+		want    int32
+		wantErr bool
+	}{
+		{
+			name:    "cache dir not exist",
+			cpu:     0,
+			wantErr: true,
+		},
+		{
+			name: "L3 cache not exposed",
+			prepare: func(helper *FileTestUtil) {
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index0/level", "1\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index0/id", "0\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index2/level", "2\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index2/id", "0\n")
+			},
+			cpu:     0,
+			wantErr: true,
+		},
+		{
+			name: "get L3 cache id of the second CCX",
+			prepare: func(helper *FileTestUtil) {
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index0/level", "1\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index0/id", "8\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index2/level", "2\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index2/id", "8\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index3/level", "3\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 8)+"/index3/id", "1\n")
+			},
+			cpu:  8,
+			want: 1,
+		},
+		{
+			name: "invalid L3 cache id",
+			prepare: func(helper *FileTestUtil) {
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index3/level", "3\n")
+				helper.WriteFileContents(fmt.Sprintf(SysCPUCacheSubPathFormat, 0)+"/index3/id", "x\n")
+			},
+			cpu:     0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := NewFileTestUtil(t)
+			defer helper.Cleanup()
+			if tt.prepare != nil {
+				tt.prepare(helper)
+			}
+			got, err := GetCPUL3CacheID(tt.cpu)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
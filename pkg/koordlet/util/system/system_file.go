@@ -45,6 +45,7 @@ const (
 
 	SysCPUSMTActiveSubPath       = "devices/system/cpu/smt/active"
 	SysIntelPStateNoTurboSubPath = "devices/system/cpu/intel_pstate/no_turbo"
+	SysCPUCacheSubPathFormat     = "devices/system/cpu/cpu%d/cache"
 )
 
 var (
@@ -109,6 +110,10 @@ func GetSysIntelPStateNoTurboPath() string {
 	return filepath.Join(Conf.SysRootDir, SysIntelPStateNoTurboSubPath)
 }
 
+func GetSysCPUCacheDir(cpu int32) string {
+	return filepath.Join(Conf.SysRootDir, fmt.Sprintf(SysCPUCacheSubPathFormat, cpu))
+}
+
 func GetProcSysFilePath(file string) string {
 	return filepath.Join(Conf.ProcRootDir, SysctlSubDir, file)
 }
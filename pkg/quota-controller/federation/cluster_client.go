@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretKeyKubeConfig is the key of the kubeconfig of the member cluster in the cluster Secret.
+	SecretKeyKubeConfig = "kubeconfig"
+	// SecretKeySchedulerEndpoint is the key of the service endpoint of koord-scheduler in the cluster Secret,
+	// e.g. http://koord-scheduler.koordinator-system:10251.
+	SecretKeySchedulerEndpoint = "schedulerEndpoint"
+
+	quotaSummaryPath = "/apis/v1/plugins/ElasticQuota/quotas/"
+)
+
+// QuotaSummary is the usage of a member quota reported by the scheduler of the member cluster.
+type QuotaSummary struct {
+	Used    corev1.ResourceList `json:"used"`
+	Request corev1.ResourceList `json:"request"`
+}
+
+// ClusterClient accesses a member cluster of the federation.
+type ClusterClient interface {
+	client.Client
+	// GetQuotaSummary returns the summary of the quota in the scheduler of the cluster.
+	// It returns nil if the scheduler does not know the quota yet.
+	GetQuotaSummary(ctx context.Context, quotaName string) (*QuotaSummary, error)
+}
+
+// ClusterClientProvider returns the client of a member cluster by the name.
+type ClusterClientProvider interface {
+	GetClusterClient(ctx context.Context, clusterName string) (ClusterClient, error)
+}
+
+// secretClusterClientProvider builds the member cluster clients from the Secrets named after the clusters.
+type secretClusterClientProvider struct {
+	reader    client.Reader
+	scheme    *runtime.Scheme
+	namespace string
+
+	lock    sync.Mutex
+	clients map[string]*cachedClusterClient
+}
+
+type cachedClusterClient struct {
+	resourceVersion string
+	client          ClusterClient
+}
+
+func newSecretClusterClientProvider(reader client.Reader, scheme *runtime.Scheme, namespace string) *secretClusterClientProvider {
+	return &secretClusterClientProvider{
+		reader:    reader,
+		scheme:    scheme,
+		namespace: namespace,
+		clients:   map[string]*cachedClusterClient{},
+	}
+}
+
+func (p *secretClusterClientProvider) GetClusterClient(ctx context.Context, clusterName string) (ClusterClient, error) {
+	secret := &corev1.Secret{}
+	if err := p.reader.Get(ctx, types.NamespacedName{Namespace: p.namespace, Name: clusterName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret of cluster %s, err: %w", clusterName, err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cached, ok := p.clients[clusterName]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	kubeConfig := secret.Data[SecretKeyKubeConfig]
+	if len(kubeConfig) == 0 {
+		return nil, fmt.Errorf("secret of cluster %s has no %s", clusterName, SecretKeyKubeConfig)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig of cluster %s, err: %w", clusterName, err)
+	}
+	kubeClient, err := client.New(restConfig, client.Options{Scheme: p.scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of cluster %s, err: %w", clusterName, err)
+	}
+	endpoint := strings.TrimSuffix(string(secret.Data[SecretKeySchedulerEndpoint]), "/")
+	if endpoint == "" {
+		return nil, fmt.Errorf("secret of cluster %s has no %s", clusterName, SecretKeySchedulerEndpoint)
+	}
+
+	c := &clusterClient{
+		Client:     kubeClient,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	p.clients[clusterName] = &cachedClusterClient{resourceVersion: secret.ResourceVersion, client: c}
+	return c, nil
+}
+
+type clusterClient struct {
+	client.Client
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c *clusterClient) GetQuotaSummary(ctx context.Context, quotaName string) (*QuotaSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+quotaSummaryPath+url.PathEscape(quotaName), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get summary of quota %s, status: %d, body: %s", quotaName, resp.StatusCode, string(body))
+	}
+	summary := &QuotaSummary{}
+	if err := json.Unmarshal(body, summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal summary of quota %s, err: %w", quotaName, err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ ClusterClient = &FakeClusterClient{}
+
+// FakeClusterClient is a member cluster client backed by an in-memory client and the preset quota summaries.
+type FakeClusterClient struct {
+	client.Client
+
+	lock      sync.RWMutex
+	summaries map[string]*QuotaSummary
+	err       error
+}
+
+func NewFakeClusterClient(c client.Client) *FakeClusterClient {
+	return &FakeClusterClient{
+		Client:    c,
+		summaries: map[string]*QuotaSummary{},
+	}
+}
+
+// SetQuotaSummary sets the summary of the quota returned by GetQuotaSummary.
+func (f *FakeClusterClient) SetQuotaSummary(quotaName string, summary *QuotaSummary) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.summaries[quotaName] = summary
+}
+
+// SetError makes GetQuotaSummary fail with the err, e.g. to mock an unreachable scheduler.
+func (f *FakeClusterClient) SetError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.err = err
+}
+
+func (f *FakeClusterClient) GetQuotaSummary(_ context.Context, quotaName string) (*QuotaSummary, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.summaries[quotaName], nil
+}
+
+var _ ClusterClientProvider = &FakeClusterClientProvider{}
+
+// FakeClusterClientProvider returns the registered FakeClusterClients.
+type FakeClusterClientProvider struct {
+	lock    sync.RWMutex
+	clients map[string]*FakeClusterClient
+}
+
+func NewFakeClusterClientProvider() *FakeClusterClientProvider {
+	return &FakeClusterClientProvider{
+		clients: map[string]*FakeClusterClient{},
+	}
+}
+
+// AddCluster registers the client of the cluster.
+func (p *FakeClusterClientProvider) AddCluster(clusterName string, c *FakeClusterClient) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.clients[clusterName] = c
+}
+
+// RemoveCluster unregisters the cluster to mock a cluster whose access is missing.
+func (p *FakeClusterClientProvider) RemoveCluster(clusterName string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.clients, clusterName)
+}
+
+func (p *FakeClusterClientProvider) GetClusterClient(_ context.Context, clusterName string) (ClusterClient, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	c, ok := p.clients[clusterName]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+	return c, nil
+}
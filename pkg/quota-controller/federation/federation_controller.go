@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/quota/v1alpha1"
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+const Name = "quotafederation"
+
+const (
+	ReasonInvalidSpec        = "InvalidSpec"
+	ReasonClusterUnavailable = "ClusterUnavailable"
+	ReasonRebalanced         = "Rebalanced"
+)
+
+var (
+	RebalanceInterval      = time.Minute
+	ClusterSecretNamespace = "koordinator-system"
+)
+
+func InitFlags(fs *flag.FlagSet) {
+	pflag.DurationVar(&RebalanceInterval, "quota-federation-rebalance-interval", RebalanceInterval, "The interval to rebalance the ElasticQuotaFederation across the member clusters.")
+	pflag.StringVar(&ClusterSecretNamespace, "quota-federation-cluster-secret-namespace", ClusterSecretNamespace, "The namespace of the Secrets holding the kubeconfig and the scheduler endpoint of the member clusters.")
+}
+
+// QuotaFederationReconciler reconciles an ElasticQuotaFederation object
+type QuotaFederationReconciler struct {
+	client.Client
+	Recorder       record.EventRecorder
+	ClientProvider ClusterClientProvider
+}
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=quota.koordinator.sh,resources=elasticquotafederations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=quota.koordinator.sh,resources=elasticquotafederations/status,verbs=get;update;patch
+
+func (r *QuotaFederationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	federation := &v1alpha1.ElasticQuotaFederation{}
+	if err := r.Client.Get(ctx, req.NamespacedName, federation); err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("failed to find federation %v, error: %v", req.NamespacedName, err)
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	status := &v1alpha1.ElasticQuotaFederationStatus{
+		ObservedGeneration: federation.Generation,
+		LastRebalanceTime:  federation.Status.LastRebalanceTime,
+		Clusters:           federation.Status.DeepCopy().Clusters,
+		Conditions:         federation.Status.DeepCopy().Conditions,
+	}
+	if err := validateFederation(federation); err != nil {
+		klog.Errorf("invalid federation %v, error: %v", req.NamespacedName, err)
+		setFederationCondition(federation, status, ReasonInvalidSpec, err)
+		r.updateFederationStatus(federation, status)
+		return ctrl.Result{}, nil
+	}
+
+	previous := map[string]*v1alpha1.ElasticQuotaFederationClusterStatus{}
+	for i := range federation.Status.Clusters {
+		previous[federation.Status.Clusters[i].Name] = &federation.Status.Clusters[i]
+	}
+	members := make([]*memberQuota, 0, len(federation.Spec.Clusters))
+	clients := map[string]ClusterClient{}
+	messages := map[string]string{}
+	for _, cluster := range federation.Spec.Clusters {
+		member := &memberQuota{name: cluster.Name, weight: 1}
+		if cluster.Weight != nil && *cluster.Weight > 1 {
+			member.weight = int64(*cluster.Weight)
+		}
+		if prev := previous[cluster.Name]; prev != nil {
+			member.min, member.max = prev.Min.DeepCopy(), prev.Max.DeepCopy()
+			member.used, member.request = prev.Used.DeepCopy(), prev.Request.DeepCopy()
+		}
+		members = append(members, member)
+
+		clusterClient, err := r.ClientProvider.GetClusterClient(ctx, cluster.Name)
+		if err != nil {
+			messages[cluster.Name] = err.Error()
+			continue
+		}
+		summary, err := clusterClient.GetQuotaSummary(ctx, federation.Spec.QuotaName)
+		if err != nil {
+			messages[cluster.Name] = fmt.Sprintf("failed to get quota summary, err: %v", err)
+			continue
+		}
+		member.reachable = true
+		member.used, member.request = nil, nil
+		if summary != nil {
+			member.used, member.request = summary.Used, summary.Request
+		}
+		clients[cluster.Name] = clusterClient
+	}
+
+	splitGlobalQuota(federation.Spec.Min, federation.Spec.Max, members)
+
+	changed := false
+	status.Clusters = make([]v1alpha1.ElasticQuotaFederationClusterStatus, 0, len(members))
+	for _, member := range members {
+		clusterStatus := v1alpha1.ElasticQuotaFederationClusterStatus{
+			Name:    member.name,
+			Min:     member.min,
+			Max:     member.max,
+			Used:    member.used,
+			Request: member.request,
+			Message: messages[member.name],
+		}
+		if clusterClient := clients[member.name]; clusterClient != nil {
+			updated, err := r.syncMemberQuota(ctx, clusterClient, federation, member)
+			if err != nil {
+				klog.Errorf("failed to sync quota of federation %v in cluster %s, error: %v", req.NamespacedName, member.name, err)
+				r.Recorder.Eventf(federation, corev1.EventTypeWarning, ReasonClusterUnavailable, "failed to sync quota in cluster %s, err: %s", member.name, err)
+				// the member quota keeps the previous min and max
+				clusterStatus.Message = err.Error()
+				clusterStatus.Min, clusterStatus.Max = nil, nil
+				if prev := previous[member.name]; prev != nil {
+					clusterStatus.Min, clusterStatus.Max = prev.Min, prev.Max
+				}
+			}
+			changed = changed || updated
+		}
+		status.Clusters = append(status.Clusters, clusterStatus)
+	}
+
+	var unavailable []string
+	for _, clusterStatus := range status.Clusters {
+		if clusterStatus.Message != "" {
+			unavailable = append(unavailable, fmt.Sprintf("%s: %s", clusterStatus.Name, clusterStatus.Message))
+		}
+	}
+	if len(unavailable) > 0 {
+		setFederationCondition(federation, status, ReasonClusterUnavailable, fmt.Errorf("%s", strings.Join(unavailable, "; ")))
+	} else {
+		setFederationCondition(federation, status, ReasonRebalanced, nil)
+	}
+	if changed || status.LastRebalanceTime == nil {
+		now := metav1.Now()
+		status.LastRebalanceTime = &now
+	}
+	r.updateFederationStatus(federation, status)
+
+	return ctrl.Result{RequeueAfter: RebalanceInterval}, nil
+}
+
+// syncMemberQuota creates or updates the member quota in the cluster, and returns whether the quota is changed.
+func (r *QuotaFederationReconciler) syncMemberQuota(ctx context.Context, clusterClient ClusterClient,
+	federation *v1alpha1.ElasticQuotaFederation, member *memberQuota) (bool, error) {
+	quota := &schedv1alpha1.ElasticQuota{}
+	key := types.NamespacedName{Namespace: federation.Namespace, Name: federation.Spec.QuotaName}
+	quotaExist := true
+	if err := clusterClient.Get(ctx, key, quota); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		quota = &schedv1alpha1.ElasticQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+			},
+		}
+		quotaExist = false
+	}
+	oldQuota := quota.DeepCopy()
+
+	if quota.Labels == nil {
+		quota.Labels = make(map[string]string)
+	}
+	for k, v := range federation.Spec.QuotaLabels {
+		quota.Labels[k] = v
+	}
+	quota.Labels[extension.LabelQuotaFederation] = federation.Name
+	quota.Spec.Min = member.min
+	quota.Spec.Max = member.max
+
+	if !quotaExist {
+		return true, clusterClient.Create(ctx, quota)
+	}
+	if apiequality.Semantic.DeepEqual(quota.Labels, oldQuota.Labels) && apiequality.Semantic.DeepEqual(quota.Spec, oldQuota.Spec) {
+		return false, nil
+	}
+	return true, clusterClient.Update(ctx, quota)
+}
+
+// updateFederationStatus updates the federation status if changed.
+func (r *QuotaFederationReconciler) updateFederationStatus(federation *v1alpha1.ElasticQuotaFederation, status *v1alpha1.ElasticQuotaFederationStatus) {
+	if apiequality.Semantic.DeepEqual(&federation.Status, status) {
+		return
+	}
+	newFederation := federation.DeepCopy()
+	newFederation.Status = *status
+	if err := r.Client.Status().Update(context.TODO(), newFederation); err != nil {
+		klog.Errorf("failed to update status for federation %s/%s, error: %v", federation.Namespace, federation.Name, err)
+		return
+	}
+	klog.V(5).Infof("update status for federation %s/%s successfully", federation.Namespace, federation.Name)
+}
+
+func validateFederation(federation *v1alpha1.ElasticQuotaFederation) error {
+	if federation.Spec.QuotaName == "" {
+		return fmt.Errorf("quotaName is empty")
+	}
+	if len(federation.Spec.Clusters) == 0 {
+		return fmt.Errorf("clusters is empty")
+	}
+	names := map[string]bool{}
+	for _, cluster := range federation.Spec.Clusters {
+		if names[cluster.Name] {
+			return fmt.Errorf("duplicate cluster %s", cluster.Name)
+		}
+		names[cluster.Name] = true
+	}
+	for name, min := range federation.Spec.Min {
+		if max, ok := federation.Spec.Max[name]; ok && min.Cmp(max) > 0 {
+			return fmt.Errorf("min of %s is larger than max", name)
+		}
+	}
+	return nil
+}
+
+func setFederationCondition(federation *v1alpha1.ElasticQuotaFederation, status *v1alpha1.ElasticQuotaFederationStatus, reason string, err error) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ElasticQuotaFederationConditionRebalanced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: federation.Generation,
+		Reason:             reason,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func Add(mgr ctrl.Manager) error {
+	if !utilfeature.DefaultMutableFeatureGate.Enabled(features.ElasticQuotaFederationController) {
+		klog.InfoS("ElasticQuotaFederationController feature is disabled")
+		return nil
+	}
+
+	klog.InfoS("ElasticQuotaFederationController is enabled, add the controller")
+	reconciler := QuotaFederationReconciler{
+		Client:         mgr.GetClient(),
+		Recorder:       mgr.GetEventRecorderFor("quotafederation-controller"),
+		ClientProvider: newSecretClusterClientProvider(mgr.GetAPIReader(), mgr.GetScheme(), ClusterSecretNamespace),
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+func (r *QuotaFederationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ElasticQuotaFederation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named(Name).
+		Complete(r)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	quotav1alpha1 "github.com/koordinator-sh/koordinator/apis/quota/v1alpha1"
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+)
+
+func createResourceList(cpu, mem int64) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(cpu*1000, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(mem, resource.BinarySI),
+	}
+}
+
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	quotav1alpha1.AddToScheme(scheme)
+	schedv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newTestReconciler(federation *quotav1alpha1.ElasticQuotaFederation, clusters ...string) (*QuotaFederationReconciler, map[string]*FakeClusterClient) {
+	scheme := newTestScheme()
+	provider := NewFakeClusterClientProvider()
+	clients := map[string]*FakeClusterClient{}
+	for _, name := range clusters {
+		c := NewFakeClusterClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+		provider.AddCluster(name, c)
+		clients[name] = c
+	}
+	r := &QuotaFederationReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(federation).
+			WithStatusSubresource(&quotav1alpha1.ElasticQuotaFederation{}).Build(),
+		Recorder:       record.NewFakeRecorder(1024),
+		ClientProvider: provider,
+	}
+	return r, clients
+}
+
+func newTestFederation() *quotav1alpha1.ElasticQuotaFederation {
+	return &quotav1alpha1.ElasticQuotaFederation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "federation1",
+		},
+		Spec: quotav1alpha1.ElasticQuotaFederationSpec{
+			QuotaName:   "quota1",
+			QuotaLabels: map[string]string{extension.LabelQuotaParent: "parent1"},
+			Min:         createResourceList(100, 1000),
+			Max:         createResourceList(200, 2000),
+			Clusters: []quotav1alpha1.ElasticQuotaFederationCluster{
+				{Name: "cluster-a"},
+				{Name: "cluster-b", Weight: ptr.To[int32](3)},
+			},
+		},
+	}
+}
+
+func getMemberQuota(t *testing.T, c client.Client) *schedv1alpha1.ElasticQuota {
+	quota := &schedv1alpha1.ElasticQuota{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "quota1"}, quota))
+	return quota
+}
+
+func getFederation(t *testing.T, c client.Client) *quotav1alpha1.ElasticQuotaFederation {
+	federation := &quotav1alpha1.ElasticQuotaFederation{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "federation1"}, federation))
+	return federation
+}
+
+func TestQuotaFederationReconciler_Reconcile(t *testing.T) {
+	federation := newTestFederation()
+	r, clients := newTestReconciler(federation, "cluster-a", "cluster-b")
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "federation1"}}
+
+	// no usage yet, split by the weights
+	result, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, RebalanceInterval, result.RequeueAfter)
+
+	quotaA := getMemberQuota(t, clients["cluster-a"])
+	assert.True(t, quotav1.Equals(createResourceList(25, 250), quotaA.Spec.Min), quotaA.Spec.Min)
+	assert.True(t, quotav1.Equals(createResourceList(50, 500), quotaA.Spec.Max), quotaA.Spec.Max)
+	assert.Equal(t, "federation1", quotaA.Labels[extension.LabelQuotaFederation])
+	assert.Equal(t, "parent1", quotaA.Labels[extension.LabelQuotaParent])
+	quotaB := getMemberQuota(t, clients["cluster-b"])
+	assert.True(t, quotav1.Equals(createResourceList(75, 750), quotaB.Spec.Min), quotaB.Spec.Min)
+	assert.True(t, quotav1.Equals(createResourceList(150, 1500), quotaB.Spec.Max), quotaB.Spec.Max)
+
+	got := getFederation(t, r.Client)
+	assert.Len(t, got.Status.Clusters, 2)
+	assert.NotNil(t, got.Status.LastRebalanceTime)
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, quotav1alpha1.ElasticQuotaFederationConditionRebalanced))
+
+	// cluster-a gets busy, the min moves to cluster-a
+	clients["cluster-a"].SetQuotaSummary("quota1", &QuotaSummary{
+		Used:    createResourceList(60, 600),
+		Request: createResourceList(90, 900),
+	})
+	clients["cluster-b"].SetQuotaSummary("quota1", &QuotaSummary{
+		Used: createResourceList(10, 100),
+	})
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	quotaA = getMemberQuota(t, clients["cluster-a"])
+	assert.True(t, quotav1.Equals(createResourceList(90, 900), quotaA.Spec.Min), quotaA.Spec.Min)
+	assert.True(t, quotav1.Equals(createResourceList(115, 1150), quotaA.Spec.Max), quotaA.Spec.Max)
+	quotaB = getMemberQuota(t, clients["cluster-b"])
+	assert.True(t, quotav1.Equals(createResourceList(10, 100), quotaB.Spec.Min), quotaB.Spec.Min)
+	assert.True(t, quotav1.Equals(createResourceList(85, 850), quotaB.Spec.Max), quotaB.Spec.Max)
+	got = getFederation(t, r.Client)
+	assert.True(t, quotav1.Equals(createResourceList(90, 900), got.Status.Clusters[0].Request))
+
+	// cluster-b becomes unreachable, keeps its quota and cluster-a takes the rest
+	clients["cluster-b"].SetError(fmt.Errorf("connection refused"))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	quotaA = getMemberQuota(t, clients["cluster-a"])
+	assert.True(t, quotav1.Equals(createResourceList(90, 900), quotaA.Spec.Min), quotaA.Spec.Min)
+	assert.True(t, quotav1.Equals(createResourceList(115, 1150), quotaA.Spec.Max), quotaA.Spec.Max)
+	quotaB = getMemberQuota(t, clients["cluster-b"])
+	assert.True(t, quotav1.Equals(createResourceList(10, 100), quotaB.Spec.Min), quotaB.Spec.Min)
+	got = getFederation(t, r.Client)
+	assert.True(t, meta.IsStatusConditionFalse(got.Status.Conditions, quotav1alpha1.ElasticQuotaFederationConditionRebalanced))
+	assert.Contains(t, got.Status.Clusters[1].Message, "connection refused")
+	assert.True(t, quotav1.Equals(createResourceList(10, 100), got.Status.Clusters[1].Min))
+}
+
+func TestQuotaFederationReconciler_InvalidSpec(t *testing.T) {
+	federation := newTestFederation()
+	federation.Spec.Max = createResourceList(50, 2000)
+	r, clients := newTestReconciler(federation, "cluster-a", "cluster-b")
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "federation1"}})
+	assert.NoError(t, err)
+
+	quotaList := &schedv1alpha1.ElasticQuotaList{}
+	assert.NoError(t, clients["cluster-a"].List(context.TODO(), quotaList))
+	assert.Empty(t, quotaList.Items)
+	got := getFederation(t, r.Client)
+	condition := meta.FindStatusCondition(got.Status.Conditions, quotav1alpha1.ElasticQuotaFederationConditionRebalanced)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonInvalidSpec, condition.Reason)
+}
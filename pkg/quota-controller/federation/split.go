@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"math/big"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+)
+
+// memberQuota is a member quota to split the global quota into.
+type memberQuota struct {
+	name   string
+	weight int64
+	// reachable indicates whether the usage of the cluster is known. The min and max of an unreachable
+	// member are kept and excluded from the split.
+	reachable bool
+	used      corev1.ResourceList
+	request   corev1.ResourceList
+
+	min corev1.ResourceList
+	max corev1.ResourceList
+}
+
+// demand returns the resource the member quota needs, which is the larger one of the request and the used.
+func (m *memberQuota) demand() corev1.ResourceList {
+	return quotav1.Max(m.request, m.used)
+}
+
+// splitGlobalQuota splits the global min and max into the reachable members.
+// Each resource is split by the weighted max-min fairness on the demands of the members, and the remaining
+// after all the demands are satisfied is shared by the weights. The max of a member is never less than its min,
+// and the resources only in the global min use the min as the max.
+func splitGlobalQuota(globalMin, globalMax corev1.ResourceList, members []*memberQuota) {
+	var reachable []*memberQuota
+	reservedMin, reservedMax := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, m := range members {
+		if m.reachable {
+			reachable = append(reachable, m)
+			continue
+		}
+		reservedMin = quotav1.Add(reservedMin, m.min)
+		reservedMax = quotav1.Add(reservedMax, m.max)
+	}
+	if len(reachable) == 0 {
+		return
+	}
+
+	weights := make([]int64, len(reachable))
+	demands := make([]corev1.ResourceList, len(reachable))
+	for i, m := range reachable {
+		weights[i] = m.weight
+		demands[i] = m.demand()
+		m.min = corev1.ResourceList{}
+		m.max = corev1.ResourceList{}
+	}
+
+	splitResources := func(global, reserved corev1.ResourceList, assign func(m *memberQuota, name corev1.ResourceName, q resource.Quantity)) {
+		for name, total := range global {
+			available := quantityToInt64(name, total) - quantityToInt64(name, reserved[name])
+			if available < 0 {
+				available = 0
+			}
+			memberDemands := make([]int64, len(reachable))
+			for i := range reachable {
+				memberDemands[i] = quantityToInt64(name, demands[i][name])
+			}
+			for i, v := range waterFill(available, memberDemands, weights) {
+				assign(reachable[i], name, int64ToQuantity(name, v))
+			}
+		}
+	}
+	splitResources(globalMin, reservedMin, func(m *memberQuota, name corev1.ResourceName, q resource.Quantity) {
+		m.min[name] = q
+	})
+	splitResources(globalMax, reservedMax, func(m *memberQuota, name corev1.ResourceName, q resource.Quantity) {
+		if min, ok := m.min[name]; ok && q.Cmp(min) < 0 {
+			q = min.DeepCopy()
+		}
+		m.max[name] = q
+	})
+	for _, m := range reachable {
+		for name, min := range m.min {
+			if _, ok := globalMax[name]; !ok {
+				m.max[name] = min.DeepCopy()
+			}
+		}
+	}
+}
+
+// waterFill splits the total by the weighted max-min fairness on the demands, then shares the remaining by the
+// weights. The indivisible remainders are given to the members in order, so the result is deterministic.
+func waterFill(total int64, demands, weights []int64) []int64 {
+	allocated := make([]int64, len(demands))
+	remaining := total
+
+	active := make([]int, 0, len(demands))
+	for i := range demands {
+		if demands[i] > 0 {
+			active = append(active, i)
+		}
+	}
+	for remaining > 0 && len(active) > 0 {
+		var sumWeight int64
+		for _, i := range active {
+			sumWeight += weights[i]
+		}
+
+		// satisfy the members whose fair share covers the rest demand, then split again among the others
+		var next []int
+		satisfiedDemand := int64(0)
+		for _, i := range active {
+			if rest := demands[i] - allocated[i]; mulDiv(remaining, weights[i], sumWeight) >= rest {
+				satisfiedDemand += rest
+				allocated[i] = demands[i]
+			} else {
+				next = append(next, i)
+			}
+		}
+		if len(next) < len(active) {
+			remaining -= satisfiedDemand
+			active = next
+			continue
+		}
+
+		remaining = share(remaining, active, weights, allocated)
+		active = nil
+	}
+
+	if remaining > 0 {
+		all := make([]int, len(demands))
+		for i := range all {
+			all[i] = i
+		}
+		share(remaining, all, weights, allocated)
+	}
+	return allocated
+}
+
+// share adds the total into the allocated of the members by the weights and returns the undistributed part.
+func share(total int64, members []int, weights, allocated []int64) int64 {
+	var sumWeight int64
+	for _, i := range members {
+		sumWeight += weights[i]
+	}
+	if sumWeight <= 0 {
+		return total
+	}
+	remaining := total
+	for _, i := range members {
+		v := mulDiv(total, weights[i], sumWeight)
+		allocated[i] += v
+		remaining -= v
+	}
+	for _, i := range members {
+		if remaining <= 0 {
+			break
+		}
+		allocated[i]++
+		remaining--
+	}
+	return remaining
+}
+
+// mulDiv returns a*b/c without the overflow of a*b.
+func mulDiv(a, b, c int64) int64 {
+	if c == 0 {
+		return 0
+	}
+	r := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	return r.Quo(r, big.NewInt(c)).Int64()
+}
+
+func quantityToInt64(name corev1.ResourceName, q resource.Quantity) int64 {
+	if name == corev1.ResourceCPU {
+		return q.MilliValue()
+	}
+	return q.Value()
+}
+
+func int64ToQuantity(name corev1.ResourceName, v int64) resource.Quantity {
+	switch name {
+	case corev1.ResourceCPU:
+		return *resource.NewMilliQuantity(v, resource.DecimalSI)
+	case corev1.ResourceMemory:
+		return *resource.NewQuantity(v, resource.BinarySI)
+	default:
+		return *resource.NewQuantity(v, resource.DecimalSI)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+)
+
+func TestWaterFill(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		demands []int64
+		weights []int64
+		want    []int64
+	}{
+		{
+			name:    "all demands satisfied, remaining shared by weights",
+			total:   100,
+			demands: []int64{10, 20},
+			weights: []int64{1, 1},
+			want:    []int64{45, 55},
+		},
+		{
+			name:    "demands exceed the total, max-min fair",
+			total:   100,
+			demands: []int64{10, 80, 80},
+			weights: []int64{1, 1, 1},
+			want:    []int64{10, 45, 45},
+		},
+		{
+			name:    "demands exceed the total, weighted",
+			total:   90,
+			demands: []int64{100, 100},
+			weights: []int64{1, 2},
+			want:    []int64{30, 60},
+		},
+		{
+			name:    "remainder given in order",
+			total:   10,
+			demands: []int64{0, 0, 0},
+			weights: []int64{1, 1, 1},
+			want:    []int64{4, 3, 3},
+		},
+		{
+			name:    "no overflow",
+			total:   math.MaxInt64 / 2,
+			demands: []int64{math.MaxInt64, math.MaxInt64},
+			weights: []int64{3, 1},
+			want:    []int64{3458764513820540928, 1152921504606846975},
+		},
+		{
+			name:    "zero total",
+			total:   0,
+			demands: []int64{10, 10},
+			weights: []int64{1, 1},
+			want:    []int64{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := waterFill(tt.total, tt.demands, tt.weights)
+			assert.Equal(t, tt.want, got)
+			var sum int64
+			for _, v := range got {
+				sum += v
+			}
+			assert.Equal(t, tt.total, sum)
+		})
+	}
+}
+
+func TestSplitGlobalQuota(t *testing.T) {
+	members := []*memberQuota{
+		{
+			name:      "cluster-a",
+			weight:    1,
+			reachable: true,
+			used:      createResourceList(10, 100),
+			request:   createResourceList(30, 50),
+		},
+		{
+			name:      "cluster-b",
+			weight:    1,
+			reachable: true,
+			request:   createResourceList(80, 800),
+		},
+		{
+			name:   "cluster-c",
+			weight: 1,
+			min:    createResourceList(20, 200),
+			max:    createResourceList(40, 400),
+		},
+	}
+	globalMin := createResourceList(100, 1000)
+	globalMax := corev1.ResourceList{
+		corev1.ResourceCPU: createResourceList(200, 0)[corev1.ResourceCPU],
+	}
+	splitGlobalQuota(globalMin, globalMax, members)
+
+	// cluster-c is unreachable and keeps the previous quota
+	assert.True(t, quotav1.Equals(createResourceList(20, 200), members[2].min))
+	assert.True(t, quotav1.Equals(createResourceList(40, 400), members[2].max))
+
+	// cpu: 80 to split, cluster-a needs 30 and cluster-b gets the rest
+	// memory: 800 to split, cluster-a needs 100 and cluster-b needs 800, 700 gets max-min fairly
+	assert.True(t, quotav1.Equals(createResourceList(30, 100), members[0].min), members[0].min)
+	assert.True(t, quotav1.Equals(createResourceList(50, 700), members[1].min), members[1].min)
+	// cpu: 160 to split, both demands satisfied and 50 left shared equally
+	// memory: not in the global max, capped by the min
+	assert.True(t, quotav1.Equals(createResourceList(55, 100), members[0].max), members[0].max)
+	assert.True(t, quotav1.Equals(createResourceList(105, 700), members[1].max), members[1].max)
+}
@@ -23,11 +23,14 @@ import (
 	"hash/fnv"
 	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,8 +54,11 @@ import (
 const Name = "quotaprofile"
 
 const (
-	ReasonCreateQuotaFailed = "CreateQuotaFailed"
-	ReasonUpdateQuotaFailed = "UpdateQuotaFailed"
+	ReasonCreateQuotaFailed   = "CreateQuotaFailed"
+	ReasonUpdateQuotaFailed   = "UpdateQuotaFailed"
+	ReasonQuotaSynced         = "QuotaSynced"
+	ReasonInvalidNodeSelector = "InvalidNodeSelector"
+	ReasonNodeSelectorValid   = "NodeSelectorValid"
 )
 
 var resourceDecorators = []func(profile *v1alpha1.ElasticQuotaProfile, total corev1.ResourceList){
@@ -127,11 +133,22 @@ func (r *QuotaProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 	oldQuota := quota.DeepCopy()
 
+	status := &v1alpha1.ElasticQuotaProfileStatus{
+		ObservedGeneration: profile.Generation,
+		QuotaName:          profile.Spec.QuotaName,
+		QuotaTreeID:        quotaTreeID,
+		ResourceRatio:      strconv.FormatFloat(getResourceRatio(profile), 'f', -1, 64),
+		Conditions:         profile.Status.DeepCopy().Conditions,
+	}
+
 	selector, err := metav1.LabelSelectorAsSelector(profile.Spec.NodeSelector)
 	if err != nil {
 		klog.Errorf("failed to convert profile %v nodeSelector, error: %v", req.NamespacedName, err)
+		setProfileCondition(profile, status, v1alpha1.ElasticQuotaProfileConditionNodeSelectorValid, ReasonInvalidNodeSelector, err)
+		r.updateProfileStatus(profile, status)
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, err
 	}
+	setProfileCondition(profile, status, v1alpha1.ElasticQuotaProfileConditionNodeSelectorValid, ReasonNodeSelectorValid, nil)
 	nodeList := &corev1.NodeList{}
 	if err := r.Client.List(context.TODO(), nodeList, &client.ListOptions{LabelSelector: selector}, utilclient.DisableDeepCopy); err != nil {
 		return ctrl.Result{Requeue: true}, err
@@ -140,15 +157,46 @@ func (r *QuotaProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// TODO: consider node status.
 	totalResource := corev1.ResourceList{}
 	unschedulableResource := corev1.ResourceList{}
+	nodePoolLabelKey := profile.Annotations[extension.AnnotationNodePoolLabelKey]
+	nodePools := map[string]*v1alpha1.ElasticQuotaProfileNodePoolStatus{}
 	for _, node := range nodeList.Items {
-		totalResource = quotav1.Add(totalResource, GetNodeAllocatable(node))
-		if node.Spec.Unschedulable || !nodeutil.IsNodeReady(&node) {
-			unschedulableResource = quotav1.Add(unschedulableResource, GetNodeAllocatable(node))
+		allocatable := GetNodeAllocatable(node)
+		totalResource = quotav1.Add(totalResource, allocatable)
+		unschedulable := node.Spec.Unschedulable || !nodeutil.IsNodeReady(&node)
+		if unschedulable {
+			unschedulableResource = quotav1.Add(unschedulableResource, allocatable)
+			status.UnschedulableNodes++
 		}
+		status.MatchedNodes++
+
+		if nodePoolLabelKey == "" {
+			continue
+		}
+		poolName := node.Labels[nodePoolLabelKey]
+		pool, ok := nodePools[poolName]
+		if !ok {
+			pool = &v1alpha1.ElasticQuotaProfileNodePoolStatus{Name: poolName}
+			nodePools[poolName] = pool
+		}
+		pool.Nodes++
+		if unschedulable {
+			pool.UnschedulableNodes++
+		}
+		pool.RawTotal = quotav1.Add(pool.RawTotal, allocatable)
 	}
+	for _, pool := range nodePools {
+		status.NodePools = append(status.NodePools, *pool)
+	}
+	sort.Slice(status.NodePools, func(i, j int) bool {
+		return status.NodePools[i].Name < status.NodePools[j].Name
+	})
 
+	rawTotalResource := totalResource.DeepCopy()
 	decorateTotalResource(profile, totalResource)
 	decorateTotalResource(profile, unschedulableResource)
+	status.RawTotal = rawTotalResource
+	status.Total = totalResource.DeepCopy()
+	status.Unschedulable = unschedulableResource.DeepCopy()
 
 	resourceKeys := []string{"cpu", "memory"}
 	raw, ok := profile.Annotations[extension.AnnotationResourceKeys]
@@ -170,6 +218,19 @@ func (r *QuotaProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 		min[resourceName] = quantity
 		max[resourceName] = *resource.NewQuantity(math.MaxInt64/2000, resource.DecimalSI)
+
+		resourceStatus := v1alpha1.ElasticQuotaProfileResourceStatus{
+			Name:  resourceName,
+			Raw:   *resource.NewQuantity(0, resource.DecimalSI),
+			Total: quantity.DeepCopy(),
+		}
+		if raw, ok := rawTotalResource[resourceName]; ok {
+			resourceStatus.Raw = raw.DeepCopy()
+		}
+		if unschedulable, ok := unschedulableResource[resourceName]; ok {
+			resourceStatus.Unschedulable = unschedulable.DeepCopy()
+		}
+		status.Resources = append(status.Resources, resourceStatus)
 	}
 
 	// update min and max
@@ -216,6 +277,8 @@ func (r *QuotaProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		if err != nil {
 			r.Recorder.Eventf(profile, corev1.EventTypeWarning, ReasonCreateQuotaFailed, "failed to create quota, err: %s", err)
 			klog.Errorf("failed create quota for profile %v, error: %v", req.NamespacedName, err)
+			setProfileCondition(profile, status, v1alpha1.ElasticQuotaProfileConditionQuotaSynced, ReasonCreateQuotaFailed, err)
+			r.updateProfileStatus(profile, status)
 			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 		}
 	} else {
@@ -224,14 +287,47 @@ func (r *QuotaProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			if err != nil {
 				r.Recorder.Eventf(profile, corev1.EventTypeWarning, ReasonUpdateQuotaFailed, "failed to update quota, err: %s", err)
 				klog.Errorf("failed update quota for profile %v, error: %v", req.NamespacedName, err)
+				setProfileCondition(profile, status, v1alpha1.ElasticQuotaProfileConditionQuotaSynced, ReasonUpdateQuotaFailed, err)
+				r.updateProfileStatus(profile, status)
 				return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 			}
 		}
 	}
+	setProfileCondition(profile, status, v1alpha1.ElasticQuotaProfileConditionQuotaSynced, ReasonQuotaSynced, nil)
+	r.updateProfileStatus(profile, status)
 
 	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 }
 
+// updateProfileStatus updates the profile status if changed.
+func (r *QuotaProfileReconciler) updateProfileStatus(profile *v1alpha1.ElasticQuotaProfile, status *v1alpha1.ElasticQuotaProfileStatus) {
+	if apiequality.Semantic.DeepEqual(&profile.Status, status) {
+		return
+	}
+	newProfile := profile.DeepCopy()
+	newProfile.Status = *status
+	if err := r.Client.Status().Update(context.TODO(), newProfile); err != nil {
+		klog.Errorf("failed to update status for profile %s/%s, error: %v", profile.Namespace, profile.Name, err)
+		return
+	}
+	klog.V(5).Infof("update status for profile %s/%s successfully", profile.Namespace, profile.Name)
+}
+
+func setProfileCondition(profile *v1alpha1.ElasticQuotaProfile, status *v1alpha1.ElasticQuotaProfileStatus,
+	conditionType, reason string, err error) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: profile.Generation,
+		Reason:             reason,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 func Add(mgr ctrl.Manager) error {
 	reconciler := QuotaProfileReconciler{
 		Client:   mgr.GetClient(),
@@ -275,13 +371,20 @@ func DecorateResourceByResourceRatio(profile *v1alpha1.ElasticQuotaProfile, tota
 		return
 	}
 
-	ratio := 1.0
-	val, err := strconv.ParseFloat(*profile.Spec.ResourceRatio, 64)
-	if err == nil && val > 0 && val <= 1.0 {
-		ratio = val
-	}
-
+	ratio := getResourceRatio(profile)
 	for resourceName, quantity := range total {
 		total[resourceName] = MultiplyQuantity(quantity, resourceName, ratio)
 	}
 }
+
+// getResourceRatio returns the effective resource ratio of the profile, the invalid ratio is regarded as 1.
+func getResourceRatio(profile *v1alpha1.ElasticQuotaProfile) float64 {
+	if profile.Spec.ResourceRatio == nil {
+		return 1.0
+	}
+	val, err := strconv.ParseFloat(*profile.Spec.ResourceRatio, 64)
+	if err == nil && val > 0 && val <= 1.0 {
+		return val
+	}
+	return 1.0
+}
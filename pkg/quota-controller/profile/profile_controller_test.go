@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -353,6 +354,123 @@ func TestQuotaProfileReconciler_Reconciler_CreateQuota(t *testing.T) {
 	}
 }
 
+func TestQuotaProfileReconciler_Reconciler_Status(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	quotav1alpha1.AddToScheme(scheme)
+	schedv1alpha1.AddToScheme(scheme)
+
+	nodes := []*corev1.Node{
+		defaultCreateNode("node1", map[string]string{"topology.kubernetes.io/zone": "cn-hangzhou-a", "node-pool": "pool-a"}, createResourceListWithStorage(10, 1000, 1000)),
+		defaultCreateUnreadyNode("node2", map[string]string{"topology.kubernetes.io/zone": "cn-hangzhou-a", "node-pool": "pool-b"}, createResourceListWithStorage(10, 1000, 1000)),
+		defaultCreateNode("node3", map[string]string{"topology.kubernetes.io/zone": "cn-hangzhou-a"}, createResourceListWithStorage(10, 1000, 1000)),
+		defaultCreateNode("node4", map[string]string{"topology.kubernetes.io/zone": "cn-hangzhou-b", "node-pool": "pool-a"}, createResourceListWithStorage(10, 1000, 1000)),
+	}
+	resourceRatio := "0.5"
+
+	tests := []struct {
+		name        string
+		profile     *quotav1alpha1.ElasticQuotaProfile
+		wantErr     bool
+		checkStatus func(t *testing.T, status *quotav1alpha1.ElasticQuotaProfileStatus)
+	}{
+		{
+			name: "computed totals with node pools",
+			profile: &quotav1alpha1.ElasticQuotaProfile{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "profile1",
+					Generation:  2,
+					Annotations: map[string]string{extension.AnnotationNodePoolLabelKey: "node-pool"},
+				},
+				Spec: quotav1alpha1.ElasticQuotaProfileSpec{
+					QuotaName:     "profile1-root",
+					ResourceRatio: &resourceRatio,
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"topology.kubernetes.io/zone": "cn-hangzhou-a"},
+					},
+				},
+			},
+			checkStatus: func(t *testing.T, status *quotav1alpha1.ElasticQuotaProfileStatus) {
+				assert.Equal(t, int64(2), status.ObservedGeneration)
+				assert.Equal(t, "profile1-root", status.QuotaName)
+				assert.Equal(t, hash(fmt.Sprintf("%s/%s", "", "profile1")), status.QuotaTreeID)
+				assert.Equal(t, "0.5", status.ResourceRatio)
+				assert.Equal(t, int32(3), status.MatchedNodes)
+				assert.Equal(t, int32(1), status.UnschedulableNodes)
+				assert.True(t, quotav1.Equals(createResourceListWithStorage(30, 3000, 3000), status.RawTotal))
+				assert.True(t, quotav1.Equals(createResourceListWithStorage(15, 1500, 1500), status.Total))
+				assert.True(t, quotav1.Equals(createResourceListWithStorage(5, 500, 500), status.Unschedulable))
+
+				assert.Len(t, status.Resources, 2)
+				assert.Equal(t, corev1.ResourceCPU, status.Resources[0].Name)
+				assert.Equal(t, int64(30000), status.Resources[0].Raw.MilliValue())
+				assert.Equal(t, int64(15000), status.Resources[0].Total.MilliValue())
+				assert.Equal(t, int64(5000), status.Resources[0].Unschedulable.MilliValue())
+
+				assert.Equal(t, []string{"", "pool-a", "pool-b"}, []string{status.NodePools[0].Name, status.NodePools[1].Name, status.NodePools[2].Name})
+				assert.Equal(t, int32(1), status.NodePools[1].Nodes)
+				assert.Equal(t, int32(0), status.NodePools[1].UnschedulableNodes)
+				assert.Equal(t, int32(1), status.NodePools[2].UnschedulableNodes)
+				assert.True(t, quotav1.Equals(createResourceListWithStorage(10, 1000, 1000), status.NodePools[2].RawTotal))
+
+				assert.True(t, meta.IsStatusConditionTrue(status.Conditions, quotav1alpha1.ElasticQuotaProfileConditionNodeSelectorValid))
+				assert.True(t, meta.IsStatusConditionTrue(status.Conditions, quotav1alpha1.ElasticQuotaProfileConditionQuotaSynced))
+			},
+		},
+		{
+			name: "invalid node selector",
+			profile: &quotav1alpha1.ElasticQuotaProfile{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "profile2",
+				},
+				Spec: quotav1alpha1.ElasticQuotaProfileSpec{
+					QuotaName: "profile2-root",
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "topology.kubernetes.io/zone", Operator: "Unknown"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			checkStatus: func(t *testing.T, status *quotav1alpha1.ElasticQuotaProfileStatus) {
+				assert.Equal(t, "profile2-root", status.QuotaName)
+				assert.Equal(t, "1", status.ResourceRatio)
+				assert.Equal(t, int32(0), status.MatchedNodes)
+				condition := meta.FindStatusCondition(status.Conditions, quotav1alpha1.ElasticQuotaProfileConditionNodeSelectorValid)
+				assert.NotNil(t, condition)
+				assert.Equal(t, metav1.ConditionFalse, condition.Status)
+				assert.Equal(t, ReasonInvalidNodeSelector, condition.Reason)
+				assert.NotEmpty(t, condition.Message)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &QuotaProfileReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&quotav1alpha1.ElasticQuotaProfile{}).Build(),
+				Scheme: scheme,
+			}
+			for _, node := range nodes {
+				err := r.Client.Create(context.TODO(), node.DeepCopy())
+				assert.NoError(t, err)
+			}
+			err := r.Client.Create(context.TODO(), tc.profile)
+			assert.NoError(t, err)
+
+			profileReq := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: tc.profile.Namespace, Name: tc.profile.Name}}
+			_, err = r.Reconcile(context.TODO(), profileReq)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			profile := &quotav1alpha1.ElasticQuotaProfile{}
+			err = r.Client.Get(context.TODO(), profileReq.NamespacedName, profile)
+			assert.NoError(t, err)
+			tc.checkStatus(t, &profile.Status)
+		})
+	}
+}
+
 func TestMultiplyQuantity(t *testing.T) {
 	tests := []struct {
 		name         string
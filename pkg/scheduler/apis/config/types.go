@@ -150,6 +150,8 @@ const (
 	CPUBindPolicySpreadByPCPUs = CPUBindPolicy(extension.CPUBindPolicySpreadByPCPUs)
 	// CPUBindPolicyConstrainedBurst constrains the CPU Shared Pool range of the Burstable Pod
 	CPUBindPolicyConstrainedBurst = CPUBindPolicy(extension.CPUBindPolicyConstrainedBurst)
+	// CPUBindPolicyLLCAligned favor cpuset allocation that pack full physical cores in few last-level cache domains
+	CPUBindPolicyLLCAligned = CPUBindPolicy(extension.CPUBindPolicyLLCAligned)
 )
 
 type CPUExclusivePolicy = extension.CPUExclusivePolicy
@@ -231,6 +233,46 @@ type ReservationArgs struct {
 	// instead of scanning every node that owns reservations.
 	// +optional
 	ReservationSelectorIndex *ReservationSelectorIndexArgs
+	// IdleReclaim configures how the reservation controller reclaims the idle Reservations.
+	// +optional
+	IdleReclaim *ReservationIdleReclaimArgs
+}
+
+// ReservationIdleReclaimPolicy is the action to reclaim an idle Reservation.
+type ReservationIdleReclaimPolicy string
+
+const (
+	// ReservationIdleReclaimPolicyNone keeps the idle Reservation until it expires.
+	ReservationIdleReclaimPolicyNone ReservationIdleReclaimPolicy = "None"
+	// ReservationIdleReclaimPolicyExpire expires the idle Reservation.
+	ReservationIdleReclaimPolicyExpire ReservationIdleReclaimPolicy = "Expire"
+	// ReservationIdleReclaimPolicyShrink shrinks the allocatable of the idle Reservation to the allocated.
+	// The Reservation allocated nothing is expired.
+	ReservationIdleReclaimPolicyShrink ReservationIdleReclaimPolicy = "Shrink"
+	// ReservationIdleReclaimPolicyMigrate creates a replacement of the idle Reservation on another node,
+	// which is expected to be a packed node by the scoring of the scheduler, and expires the idle Reservation
+	// once the replacement is available. The Reservation allocated by any owner is shrunk instead.
+	ReservationIdleReclaimPolicyMigrate ReservationIdleReclaimPolicy = "Migrate"
+)
+
+// ReservationIdleReclaimArgs configures the reclaiming of the idle Reservations, which are Available
+// but allocated little by the owners for a long time.
+type ReservationIdleReclaimArgs struct {
+	// Enabled toggles the idle reclaim on/off. Defaults to false.
+	Enabled bool
+	// Policy is the default action to reclaim the idle Reservations, it can be overridden by the
+	// Reservation annotation `scheduling.koordinator.sh/reservation-idle-reclaim-policy`.
+	// Defaults to Expire.
+	Policy ReservationIdleReclaimPolicy
+	// IdleDurationSeconds is the duration in seconds a Reservation keeps idle before it is reclaimed.
+	// Defaults to 3600 seconds if unspecified.
+	IdleDurationSeconds int64
+	// UtilizationThresholdPercent is the max allocated percentage of every resource of an idle Reservation.
+	// Defaults to 0, which means only the Reservations allocated nothing are idle.
+	UtilizationThresholdPercent int32
+	// CheckIntervalSeconds is the duration in seconds between each turns of idle checking.
+	// Defaults to 60 seconds if unspecified.
+	CheckIntervalSeconds int64
 }
 
 // ReservationSelectorIndexArgs configures the reservationSelector white-list
@@ -321,10 +363,33 @@ type ElasticQuotaArgs struct {
 	// Defaults to 120 seconds if unspecified.
 	QuotaSnapshotUpdateInterval metav1.Duration
 
+	// EnableQuotaFairQueueing if true, the pods with the same priority are sorted in the scheduling queue by
+	// the dominant share of their quotas, the pods of the quota farthest below its share base are popped first.
+	// It takes effect only if ElasticQuota is configured as the QueueSort plugin.
+	EnableQuotaFairQueueing bool
+
+	// QuotaFairQueueingShareBase is the quota resource the used is divided by to calculate the dominant share,
+	// Min or Runtime. Defaults to Min if unspecified.
+	QuotaFairQueueingShareBase QuotaShareBase
+
+	// QuotaFairQueueingUpdateInterval is the interval to refresh the dominant shares of the quotas.
+	// Defaults to 1 second if unspecified.
+	QuotaFairQueueingUpdateInterval metav1.Duration
+
 	// HookPlugins is expected to be configured with enabled hook plugins
 	HookPlugins []HookPluginConf
 }
 
+// QuotaShareBase is the quota resource to calculate the dominant share of a quota.
+type QuotaShareBase string
+
+const (
+	// QuotaShareBaseMin divides the used by the min of the quota.
+	QuotaShareBaseMin QuotaShareBase = "Min"
+	// QuotaShareBaseRuntime divides the used by the runtime of the quota.
+	QuotaShareBaseRuntime QuotaShareBase = "Runtime"
+)
+
 // HookPluginConf define configuration for a single hook plugin
 type HookPluginConf struct {
 	// Key is the key of the hook plugin
@@ -433,3 +498,15 @@ type SchedulingHintArgs struct {
 	// Defaults to 100 if unspecified.
 	MaxHintNodes int32
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadBatchArgs holds arguments used to configure the WorkloadBatch plugin.
+type WorkloadBatchArgs struct {
+	metav1.TypeMeta
+
+	// MaxBatchSize is the maximum number of sibling pods placed in one batch scheduling cycle,
+	// including the triggering pod.
+	// Defaults to 100 if unspecified.
+	MaxBatchSize int32
+}
@@ -80,11 +80,15 @@ var (
 	defaultEnableMinQuotaScale           = ptr.To[bool](true)
 	defaultDisableDefaultQuotaPreemption = ptr.To[bool](true)
 	defaultEnableQueueHint               = ptr.To[bool](false)
+	defaultEnableQuotaFairQueueing       = ptr.To[bool](false)
 
 	defaultTimeout                     = 600 * time.Second
 	defaultControllerWorkers           = 1
 	defaultQuotaSnapshotUpdateInterval = 120 * time.Second
 
+	defaultQuotaFairQueueingShareBase      = QuotaShareBaseMin
+	defaultQuotaFairQueueingUpdateInterval = 1 * time.Second
+
 	defaultGPUSharedResourceTemplatesConfig = &GPUSharedResourceTemplatesConfig{
 		ConfigMapNamespace: "koordinator-system",
 		ConfigMapName:      "gpu-shared-resource-templates",
@@ -94,6 +98,8 @@ var (
 	}
 
 	defaultMaxHintNodes = ptr.To[int32](100)
+
+	defaultWorkloadBatchMaxBatchSize = ptr.To[int32](100)
 )
 
 // SetDefaults_LoadAwareSchedulingArgs sets the default parameters for LoadAwareScheduling plugin.
@@ -229,6 +235,17 @@ func SetDefaults_ElasticQuotaArgs(obj *ElasticQuotaArgs) {
 			Duration: defaultQuotaSnapshotUpdateInterval,
 		}
 	}
+	if obj.EnableQuotaFairQueueing == nil {
+		obj.EnableQuotaFairQueueing = defaultEnableQuotaFairQueueing
+	}
+	if len(obj.QuotaFairQueueingShareBase) == 0 {
+		obj.QuotaFairQueueingShareBase = defaultQuotaFairQueueingShareBase
+	}
+	if obj.QuotaFairQueueingUpdateInterval == nil {
+		obj.QuotaFairQueueingUpdateInterval = &metav1.Duration{
+			Duration: defaultQuotaFairQueueingUpdateInterval,
+		}
+	}
 }
 
 func SetDefaults_CoschedulingArgs(obj *CoschedulingArgs) {
@@ -287,3 +304,10 @@ func SetDefaults_SchedulingHintArgs(obj *SchedulingHintArgs) {
 		obj.MaxHintNodes = defaultMaxHintNodes
 	}
 }
+
+// SetDefaults_WorkloadBatchArgs sets the default parameters for WorkloadBatch plugin.
+func SetDefaults_WorkloadBatchArgs(obj *WorkloadBatchArgs) {
+	if obj.MaxBatchSize == nil {
+		obj.MaxBatchSize = defaultWorkloadBatchMaxBatchSize
+	}
+}
@@ -145,6 +145,8 @@ const (
 	CPUBindPolicySpreadByPCPUs = CPUBindPolicy(extension.CPUBindPolicySpreadByPCPUs)
 	// CPUBindPolicyConstrainedBurst constrains the CPU Shared Pool range of the Burstable Pod
 	CPUBindPolicyConstrainedBurst = CPUBindPolicy(extension.CPUBindPolicyConstrainedBurst)
+	// CPUBindPolicyLLCAligned favor cpuset allocation that pack full physical cores in few last-level cache domains
+	CPUBindPolicyLLCAligned = CPUBindPolicy(extension.CPUBindPolicyLLCAligned)
 )
 
 type CPUExclusivePolicy = extension.CPUExclusivePolicy
@@ -227,6 +229,43 @@ type ReservationArgs struct {
 	// enumerate the candidate nodes that hold any matching reservation.
 	// +optional
 	ReservationSelectorIndex *ReservationSelectorIndexArgs `json:"reservationSelectorIndex,omitempty"`
+	// IdleReclaim configures how the reservation controller reclaims the idle Reservations.
+	// +optional
+	IdleReclaim *ReservationIdleReclaimArgs `json:"idleReclaim,omitempty"`
+}
+
+// ReservationIdleReclaimPolicy is the action to reclaim an idle Reservation.
+type ReservationIdleReclaimPolicy string
+
+const (
+	ReservationIdleReclaimPolicyNone    ReservationIdleReclaimPolicy = "None"
+	ReservationIdleReclaimPolicyExpire  ReservationIdleReclaimPolicy = "Expire"
+	ReservationIdleReclaimPolicyShrink  ReservationIdleReclaimPolicy = "Shrink"
+	ReservationIdleReclaimPolicyMigrate ReservationIdleReclaimPolicy = "Migrate"
+)
+
+// ReservationIdleReclaimArgs configures the reclaiming of the idle Reservations, which are Available
+// but allocated little by the owners for a long time.
+type ReservationIdleReclaimArgs struct {
+	// Enabled toggles the idle reclaim on/off. Defaults to false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Policy is the default action to reclaim the idle Reservations, one of Expire, Shrink and Migrate.
+	// It can be overridden by the Reservation annotation. Defaults to Expire.
+	// +optional
+	Policy ReservationIdleReclaimPolicy `json:"policy,omitempty"`
+	// IdleDurationSeconds is the duration in seconds a Reservation keeps idle before it is reclaimed.
+	// Defaults to 3600 seconds if unspecified.
+	// +optional
+	IdleDurationSeconds int64 `json:"idleDurationSeconds,omitempty"`
+	// UtilizationThresholdPercent is the max allocated percentage of every resource of an idle Reservation.
+	// Defaults to 0, which means only the Reservations allocated nothing are idle.
+	// +optional
+	UtilizationThresholdPercent int32 `json:"utilizationThresholdPercent,omitempty"`
+	// CheckIntervalSeconds is the duration in seconds between each turns of idle checking.
+	// Defaults to 60 seconds if unspecified.
+	// +optional
+	CheckIntervalSeconds int64 `json:"checkIntervalSeconds,omitempty"`
 }
 
 // ReservationSelectorIndexArgs configures the reservationSelector white-list
@@ -314,10 +353,33 @@ type ElasticQuotaArgs struct {
 	// Defaults to 120 seconds if unspecified.
 	QuotaSnapshotUpdateInterval *metav1.Duration `json:"quotaSnapshotUpdateInterval,omitempty"`
 
+	// EnableQuotaFairQueueing if true, the pods with the same priority are sorted in the scheduling queue by
+	// the dominant share of their quotas, the pods of the quota farthest below its share base are popped first.
+	// It takes effect only if ElasticQuota is configured as the QueueSort plugin.
+	EnableQuotaFairQueueing *bool `json:"enableQuotaFairQueueing,omitempty"`
+
+	// QuotaFairQueueingShareBase is the quota resource the used is divided by to calculate the dominant share,
+	// Min or Runtime. Defaults to Min if unspecified.
+	QuotaFairQueueingShareBase QuotaShareBase `json:"quotaFairQueueingShareBase,omitempty"`
+
+	// QuotaFairQueueingUpdateInterval is the interval to refresh the dominant shares of the quotas.
+	// Defaults to 1 second if unspecified.
+	QuotaFairQueueingUpdateInterval *metav1.Duration `json:"quotaFairQueueingUpdateInterval,omitempty"`
+
 	// HookPlugins is expected to be configured with enabled hook plugins
 	HookPlugins []HookPluginConf `json:"hookPlugins,omitempty"`
 }
 
+// QuotaShareBase is the quota resource to calculate the dominant share of a quota.
+type QuotaShareBase string
+
+const (
+	// QuotaShareBaseMin divides the used by the min of the quota.
+	QuotaShareBaseMin QuotaShareBase = "Min"
+	// QuotaShareBaseRuntime divides the used by the runtime of the quota.
+	QuotaShareBaseRuntime QuotaShareBase = "Runtime"
+)
+
 // HookPluginConf define configuration for a single hook plugin
 type HookPluginConf struct {
 	// Key is the key of the hook plugin
@@ -426,3 +488,15 @@ type SchedulingHintArgs struct {
 	// Defaults to 100 if unspecified.
 	MaxHintNodes *int32 `json:"maxHintNodes,omitempty"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadBatchArgs holds arguments used to configure the WorkloadBatch plugin.
+type WorkloadBatchArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// MaxBatchSize is the maximum number of sibling pods placed in one batch scheduling cycle,
+	// including the triggering pod.
+	// Defaults to 100 if unspecified.
+	MaxBatchSize *int32 `json:"maxBatchSize,omitempty"`
+}
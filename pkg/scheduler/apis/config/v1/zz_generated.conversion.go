@@ -151,6 +151,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ReservationIdleReclaimArgs)(nil), (*config.ReservationIdleReclaimArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ReservationIdleReclaimArgs_To_config_ReservationIdleReclaimArgs(a.(*ReservationIdleReclaimArgs), b.(*config.ReservationIdleReclaimArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ReservationIdleReclaimArgs)(nil), (*ReservationIdleReclaimArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ReservationIdleReclaimArgs_To_v1_ReservationIdleReclaimArgs(a.(*config.ReservationIdleReclaimArgs), b.(*ReservationIdleReclaimArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ReservationSelectorIndexArgs)(nil), (*config.ReservationSelectorIndexArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ReservationSelectorIndexArgs_To_config_ReservationSelectorIndexArgs(a.(*ReservationSelectorIndexArgs), b.(*config.ReservationSelectorIndexArgs), scope)
 	}); err != nil {
@@ -201,6 +211,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadBatchArgs)(nil), (*config.WorkloadBatchArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_WorkloadBatchArgs_To_config_WorkloadBatchArgs(a.(*WorkloadBatchArgs), b.(*config.WorkloadBatchArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.WorkloadBatchArgs)(nil), (*WorkloadBatchArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_WorkloadBatchArgs_To_v1_WorkloadBatchArgs(a.(*config.WorkloadBatchArgs), b.(*WorkloadBatchArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*config.NodeNUMAResourceArgs)(nil), (*NodeNUMAResourceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_config_NodeNUMAResourceArgs_To_v1_NodeNUMAResourceArgs(a.(*config.NodeNUMAResourceArgs), b.(*NodeNUMAResourceArgs), scope)
 	}); err != nil {
@@ -319,6 +339,13 @@ func autoConvert_v1_ElasticQuotaArgs_To_config_ElasticQuotaArgs(in *ElasticQuota
 	if err := metav1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.QuotaSnapshotUpdateInterval, &out.QuotaSnapshotUpdateInterval, s); err != nil {
 		return err
 	}
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.EnableQuotaFairQueueing, &out.EnableQuotaFairQueueing, s); err != nil {
+		return err
+	}
+	out.QuotaFairQueueingShareBase = config.QuotaShareBase(in.QuotaFairQueueingShareBase)
+	if err := metav1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.QuotaFairQueueingUpdateInterval, &out.QuotaFairQueueingUpdateInterval, s); err != nil {
+		return err
+	}
 	out.HookPlugins = *(*[]config.HookPluginConf)(unsafe.Pointer(&in.HookPlugins))
 	return nil
 }
@@ -359,6 +386,13 @@ func autoConvert_config_ElasticQuotaArgs_To_v1_ElasticQuotaArgs(in *config.Elast
 	if err := metav1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.QuotaSnapshotUpdateInterval, &out.QuotaSnapshotUpdateInterval, s); err != nil {
 		return err
 	}
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.EnableQuotaFairQueueing, &out.EnableQuotaFairQueueing, s); err != nil {
+		return err
+	}
+	out.QuotaFairQueueingShareBase = QuotaShareBase(in.QuotaFairQueueingShareBase)
+	if err := metav1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.QuotaFairQueueingUpdateInterval, &out.QuotaFairQueueingUpdateInterval, s); err != nil {
+		return err
+	}
 	out.HookPlugins = *(*[]HookPluginConf)(unsafe.Pointer(&in.HookPlugins))
 	return nil
 }
@@ -625,6 +659,7 @@ func autoConvert_v1_ReservationArgs_To_config_ReservationArgs(in *ReservationArg
 	out.IgnoredResources = *(*[]string)(unsafe.Pointer(&in.IgnoredResources))
 	out.IgnoredResourceGroups = *(*[]string)(unsafe.Pointer(&in.IgnoredResourceGroups))
 	out.ReservationSelectorIndex = (*config.ReservationSelectorIndexArgs)(unsafe.Pointer(in.ReservationSelectorIndex))
+	out.IdleReclaim = (*config.ReservationIdleReclaimArgs)(unsafe.Pointer(in.IdleReclaim))
 	return nil
 }
 
@@ -654,6 +689,7 @@ func autoConvert_config_ReservationArgs_To_v1_ReservationArgs(in *config.Reserva
 	out.IgnoredResources = *(*[]string)(unsafe.Pointer(&in.IgnoredResources))
 	out.IgnoredResourceGroups = *(*[]string)(unsafe.Pointer(&in.IgnoredResourceGroups))
 	out.ReservationSelectorIndex = (*ReservationSelectorIndexArgs)(unsafe.Pointer(in.ReservationSelectorIndex))
+	out.IdleReclaim = (*ReservationIdleReclaimArgs)(unsafe.Pointer(in.IdleReclaim))
 	return nil
 }
 
@@ -662,6 +698,34 @@ func Convert_config_ReservationArgs_To_v1_ReservationArgs(in *config.Reservation
 	return autoConvert_config_ReservationArgs_To_v1_ReservationArgs(in, out, s)
 }
 
+func autoConvert_v1_ReservationIdleReclaimArgs_To_config_ReservationIdleReclaimArgs(in *ReservationIdleReclaimArgs, out *config.ReservationIdleReclaimArgs, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Policy = config.ReservationIdleReclaimPolicy(in.Policy)
+	out.IdleDurationSeconds = in.IdleDurationSeconds
+	out.UtilizationThresholdPercent = in.UtilizationThresholdPercent
+	out.CheckIntervalSeconds = in.CheckIntervalSeconds
+	return nil
+}
+
+// Convert_v1_ReservationIdleReclaimArgs_To_config_ReservationIdleReclaimArgs is an autogenerated conversion function.
+func Convert_v1_ReservationIdleReclaimArgs_To_config_ReservationIdleReclaimArgs(in *ReservationIdleReclaimArgs, out *config.ReservationIdleReclaimArgs, s conversion.Scope) error {
+	return autoConvert_v1_ReservationIdleReclaimArgs_To_config_ReservationIdleReclaimArgs(in, out, s)
+}
+
+func autoConvert_config_ReservationIdleReclaimArgs_To_v1_ReservationIdleReclaimArgs(in *config.ReservationIdleReclaimArgs, out *ReservationIdleReclaimArgs, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Policy = ReservationIdleReclaimPolicy(in.Policy)
+	out.IdleDurationSeconds = in.IdleDurationSeconds
+	out.UtilizationThresholdPercent = in.UtilizationThresholdPercent
+	out.CheckIntervalSeconds = in.CheckIntervalSeconds
+	return nil
+}
+
+// Convert_config_ReservationIdleReclaimArgs_To_v1_ReservationIdleReclaimArgs is an autogenerated conversion function.
+func Convert_config_ReservationIdleReclaimArgs_To_v1_ReservationIdleReclaimArgs(in *config.ReservationIdleReclaimArgs, out *ReservationIdleReclaimArgs, s conversion.Scope) error {
+	return autoConvert_config_ReservationIdleReclaimArgs_To_v1_ReservationIdleReclaimArgs(in, out, s)
+}
+
 func autoConvert_v1_ReservationSelectorIndexArgs_To_config_ReservationSelectorIndexArgs(in *ReservationSelectorIndexArgs, out *config.ReservationSelectorIndexArgs, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.KeyPrefixes = *(*[]string)(unsafe.Pointer(&in.KeyPrefixes))
@@ -773,3 +837,27 @@ func autoConvert_config_ScoringStrategy_To_v1_ScoringStrategy(in *config.Scoring
 func Convert_config_ScoringStrategy_To_v1_ScoringStrategy(in *config.ScoringStrategy, out *ScoringStrategy, s conversion.Scope) error {
 	return autoConvert_config_ScoringStrategy_To_v1_ScoringStrategy(in, out, s)
 }
+
+func autoConvert_v1_WorkloadBatchArgs_To_config_WorkloadBatchArgs(in *WorkloadBatchArgs, out *config.WorkloadBatchArgs, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.MaxBatchSize, &out.MaxBatchSize, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_WorkloadBatchArgs_To_config_WorkloadBatchArgs is an autogenerated conversion function.
+func Convert_v1_WorkloadBatchArgs_To_config_WorkloadBatchArgs(in *WorkloadBatchArgs, out *config.WorkloadBatchArgs, s conversion.Scope) error {
+	return autoConvert_v1_WorkloadBatchArgs_To_config_WorkloadBatchArgs(in, out, s)
+}
+
+func autoConvert_config_WorkloadBatchArgs_To_v1_WorkloadBatchArgs(in *config.WorkloadBatchArgs, out *WorkloadBatchArgs, s conversion.Scope) error {
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.MaxBatchSize, &out.MaxBatchSize, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_WorkloadBatchArgs_To_v1_WorkloadBatchArgs is an autogenerated conversion function.
+func Convert_config_WorkloadBatchArgs_To_v1_WorkloadBatchArgs(in *config.WorkloadBatchArgs, out *WorkloadBatchArgs, s conversion.Scope) error {
+	return autoConvert_config_WorkloadBatchArgs_To_v1_WorkloadBatchArgs(in, out, s)
+}
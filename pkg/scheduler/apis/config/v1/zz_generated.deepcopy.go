@@ -186,6 +186,16 @@ func (in *ElasticQuotaArgs) DeepCopyInto(out *ElasticQuotaArgs) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.EnableQuotaFairQueueing != nil {
+		in, out := &in.EnableQuotaFairQueueing, &out.EnableQuotaFairQueueing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QuotaFairQueueingUpdateInterval != nil {
+		in, out := &in.QuotaFairQueueingUpdateInterval, &out.QuotaFairQueueingUpdateInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.HookPlugins != nil {
 		in, out := &in.HookPlugins, &out.HookPlugins
 		*out = make([]HookPluginConf, len(*in))
@@ -523,6 +533,11 @@ func (in *ReservationArgs) DeepCopyInto(out *ReservationArgs) {
 		*out = new(ReservationSelectorIndexArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IdleReclaim != nil {
+		in, out := &in.IdleReclaim, &out.IdleReclaim
+		*out = new(ReservationIdleReclaimArgs)
+		**out = **in
+	}
 	return
 }
 
@@ -544,6 +559,22 @@ func (in *ReservationArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationIdleReclaimArgs) DeepCopyInto(out *ReservationIdleReclaimArgs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationIdleReclaimArgs.
+func (in *ReservationIdleReclaimArgs) DeepCopy() *ReservationIdleReclaimArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationIdleReclaimArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReservationSelectorIndexArgs) DeepCopyInto(out *ReservationSelectorIndexArgs) {
 	*out = *in
@@ -666,3 +697,33 @@ func (in *ScoringStrategy) DeepCopy() *ScoringStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadBatchArgs) DeepCopyInto(out *WorkloadBatchArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.MaxBatchSize != nil {
+		in, out := &in.MaxBatchSize, &out.MaxBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadBatchArgs.
+func (in *WorkloadBatchArgs) DeepCopy() *WorkloadBatchArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadBatchArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadBatchArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&NodeNUMAResourceArgs{}, func(obj interface{}) { SetObjectDefaults_NodeNUMAResourceArgs(obj.(*NodeNUMAResourceArgs)) })
 	scheme.AddTypeDefaultingFunc(&ReservationArgs{}, func(obj interface{}) { SetObjectDefaults_ReservationArgs(obj.(*ReservationArgs)) })
 	scheme.AddTypeDefaultingFunc(&SchedulingHintArgs{}, func(obj interface{}) { SetObjectDefaults_SchedulingHintArgs(obj.(*SchedulingHintArgs)) })
+	scheme.AddTypeDefaultingFunc(&WorkloadBatchArgs{}, func(obj interface{}) { SetObjectDefaults_WorkloadBatchArgs(obj.(*WorkloadBatchArgs)) })
 	return nil
 }
 
@@ -66,3 +67,7 @@ func SetObjectDefaults_ReservationArgs(in *ReservationArgs) {
 func SetObjectDefaults_SchedulingHintArgs(in *SchedulingHintArgs) {
 	SetDefaults_SchedulingHintArgs(in)
 }
+
+func SetObjectDefaults_WorkloadBatchArgs(in *WorkloadBatchArgs) {
+	SetDefaults_WorkloadBatchArgs(in)
+}
@@ -184,6 +184,16 @@ func ValidateElasticQuotaArgs(elasticArgs *config.ElasticQuotaArgs) error {
 		return fmt.Errorf("elasticQuotaArgs error, RevokePodCycle should be a positive value")
 	}
 
+	if elasticArgs.EnableQuotaFairQueueing {
+		if elasticArgs.QuotaFairQueueingShareBase != config.QuotaShareBaseMin && elasticArgs.QuotaFairQueueingShareBase != config.QuotaShareBaseRuntime {
+			return fmt.Errorf("elasticQuotaArgs error, QuotaFairQueueingShareBase should be %v or %v, got %v",
+				config.QuotaShareBaseMin, config.QuotaShareBaseRuntime, elasticArgs.QuotaFairQueueingShareBase)
+		}
+		if elasticArgs.QuotaFairQueueingUpdateInterval.Duration <= 0 {
+			return fmt.Errorf("elasticQuotaArgs error, QuotaFairQueueingUpdateInterval should be a positive value")
+		}
+	}
+
 	return nil
 }
 
@@ -292,18 +302,46 @@ func ValidateReservationArgs(path *field.Path, args *config.ReservationArgs) err
 		}
 	}
 
+	if args.IdleReclaim != nil {
+		allErrs = append(allErrs, validateReservationIdleReclaimArgs(path.Child("idleReclaim"), args.IdleReclaim)...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
 
+func validateReservationIdleReclaimArgs(path *field.Path, args *config.ReservationIdleReclaimArgs) field.ErrorList {
+	var allErrs field.ErrorList
+	switch args.Policy {
+	case "", config.ReservationIdleReclaimPolicyExpire, config.ReservationIdleReclaimPolicyShrink, config.ReservationIdleReclaimPolicyMigrate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("policy"), args.Policy, []string{
+			string(config.ReservationIdleReclaimPolicyExpire),
+			string(config.ReservationIdleReclaimPolicyShrink),
+			string(config.ReservationIdleReclaimPolicyMigrate),
+		}))
+	}
+	if args.IdleDurationSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("idleDurationSeconds"), args.IdleDurationSeconds, "must be non-negative"))
+	}
+	if args.UtilizationThresholdPercent < 0 || args.UtilizationThresholdPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(path.Child("utilizationThresholdPercent"), args.UtilizationThresholdPercent, "must be in the range [0, 100]"))
+	}
+	if args.CheckIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("checkIntervalSeconds"), args.CheckIntervalSeconds, "must be non-negative"))
+	}
+	return allErrs
+}
+
 func ValidateNodeNUMAResourceArgs(path *field.Path, args *config.NodeNUMAResourceArgs) error {
 	var allErrs field.ErrorList
 	if args.DefaultCPUBindPolicy != "" &&
 		args.DefaultCPUBindPolicy != config.CPUBindPolicyFullPCPUs &&
-		args.DefaultCPUBindPolicy != config.CPUBindPolicySpreadByPCPUs {
-		allErrs = append(allErrs, field.Invalid(path.Child("defaultCPUBindPolicy"), args.DefaultCPUBindPolicy, "must specified CPU bind policy FullPCPUs or SpreadByPCPUs"))
+		args.DefaultCPUBindPolicy != config.CPUBindPolicySpreadByPCPUs &&
+		args.DefaultCPUBindPolicy != config.CPUBindPolicyLLCAligned {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultCPUBindPolicy"), args.DefaultCPUBindPolicy, "must specified CPU bind policy FullPCPUs, SpreadByPCPUs or LLCAligned"))
 	}
 
 	if args.ScoringStrategy == nil {
@@ -339,3 +377,19 @@ func ValidateSchedulingHintArgs(path *field.Path, args *config.SchedulingHintArg
 	}
 	return allErrs.ToAggregate()
 }
+
+// ValidateWorkloadBatchArgs validates that WorkloadBatchArgs are correct.
+func ValidateWorkloadBatchArgs(path *field.Path, args *config.WorkloadBatchArgs) error {
+	var allErrs field.ErrorList
+	if args.MaxBatchSize <= 1 {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("maxBatchSize"),
+			args.MaxBatchSize,
+			"must be greater than 1",
+		))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
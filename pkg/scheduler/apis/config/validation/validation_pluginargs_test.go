@@ -273,6 +273,32 @@ func TestValidateElasticQuotaArgs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid quota fair queueing",
+			args: &config.ElasticQuotaArgs{
+				EnableQuotaFairQueueing:         true,
+				QuotaFairQueueingShareBase:      config.QuotaShareBaseRuntime,
+				QuotaFairQueueingUpdateInterval: metav1.Duration{Duration: time.Second},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid quotaFairQueueingShareBase",
+			args: &config.ElasticQuotaArgs{
+				EnableQuotaFairQueueing:         true,
+				QuotaFairQueueingShareBase:      "Max",
+				QuotaFairQueueingUpdateInterval: metav1.Duration{Duration: time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero quotaFairQueueingUpdateInterval",
+			args: &config.ElasticQuotaArgs{
+				EnableQuotaFairQueueing:    true,
+				QuotaFairQueueingShareBase: config.QuotaShareBaseMin,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -451,6 +477,45 @@ func TestValidateReservationArgs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid idle reclaim",
+			args: &config.ReservationArgs{
+				IdleReclaim: &config.ReservationIdleReclaimArgs{
+					Enabled:                     true,
+					Policy:                      config.ReservationIdleReclaimPolicyMigrate,
+					IdleDurationSeconds:         3600,
+					UtilizationThresholdPercent: 10,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "idle reclaim with unsupported policy",
+			args: &config.ReservationArgs{
+				IdleReclaim: &config.ReservationIdleReclaimArgs{
+					Policy: "Unknown",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle reclaim utilizationThresholdPercent exceeds 100",
+			args: &config.ReservationArgs{
+				IdleReclaim: &config.ReservationIdleReclaimArgs{
+					UtilizationThresholdPercent: 101,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle reclaim idleDurationSeconds negative",
+			args: &config.ReservationArgs{
+				IdleReclaim: &config.ReservationIdleReclaimArgs{
+					IdleDurationSeconds: -1,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "gcIntervalSeconds negative",
 			args: &config.ReservationArgs{
@@ -700,3 +765,44 @@ func TestValidateSchedulingHintArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWorkloadBatchArgs(t *testing.T) {
+	path := field.NewPath("workloadBatchArgs")
+	tests := []struct {
+		name    string
+		args    *config.WorkloadBatchArgs
+		wantErr bool
+	}{
+		{
+			name: "valid maxBatchSize",
+			args: &config.WorkloadBatchArgs{
+				MaxBatchSize: 100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "maxBatchSize one",
+			args: &config.WorkloadBatchArgs{
+				MaxBatchSize: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "maxBatchSize zero",
+			args: &config.WorkloadBatchArgs{
+				MaxBatchSize: 0,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWorkloadBatchArgs(path, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
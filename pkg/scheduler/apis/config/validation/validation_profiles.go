@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+const (
+	elasticQuotaPluginName = "ElasticQuota"
+)
+
+// ValidateQuotaFairQueueing validates the profiles enabling the quota fair queueing of ElasticQuota.
+// The quota fair queueing only takes effect if ElasticQuota is the QueueSort plugin.
+// It can be used together with Coscheduling, which doesn't sort the queue by itself: only the representative
+// pod of a gang group waits in the queue and is ordered by the share of its quota, and the other members are
+// fetched by Coscheduling via NextPod once the representative is popped, bypassing the order of the queue.
+func ValidateQuotaFairQueueing(profiles []schedconfig.KubeSchedulerProfile) error {
+	for i := range profiles {
+		profile := &profiles[i]
+		if !isQuotaFairQueueingEnabled(profile) {
+			continue
+		}
+		if profile.Plugins == nil || !isPluginEnabled(profile.Plugins.QueueSort, elasticQuotaPluginName) {
+			return fmt.Errorf("profile %s: EnableQuotaFairQueueing requires %s to be the QueueSort plugin", profile.SchedulerName, elasticQuotaPluginName)
+		}
+	}
+	return nil
+}
+
+func isQuotaFairQueueingEnabled(profile *schedconfig.KubeSchedulerProfile) bool {
+	for _, pluginConfig := range profile.PluginConfig {
+		if pluginConfig.Name != elasticQuotaPluginName {
+			continue
+		}
+		args, ok := pluginConfig.Args.(*config.ElasticQuotaArgs)
+		return ok && args.EnableQuotaFairQueueing
+	}
+	return false
+}
+
+func isPluginEnabled(pluginSet schedconfig.PluginSet, name string) bool {
+	for _, plugin := range pluginSet.Disabled {
+		if plugin.Name == name {
+			return false
+		}
+	}
+	for _, plugin := range pluginSet.Enabled {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func TestValidateQuotaFairQueueing(t *testing.T) {
+	newProfile := func(enable bool, queueSort string, coscheduling bool) schedconfig.KubeSchedulerProfile {
+		profile := schedconfig.KubeSchedulerProfile{
+			SchedulerName: "koord-scheduler",
+			Plugins: &schedconfig.Plugins{
+				QueueSort: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: queueSort}}},
+				PreFilter: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: elasticQuotaPluginName}}},
+			},
+			PluginConfig: []schedconfig.PluginConfig{
+				{Name: elasticQuotaPluginName, Args: &config.ElasticQuotaArgs{EnableQuotaFairQueueing: enable}},
+			},
+		}
+		if coscheduling {
+			profile.Plugins.PreFilter.Enabled = append(profile.Plugins.PreFilter.Enabled, schedconfig.Plugin{Name: "Coscheduling"})
+		}
+		return profile
+	}
+	tests := []struct {
+		name    string
+		profile schedconfig.KubeSchedulerProfile
+		wantErr bool
+	}{
+		{
+			name:    "fair queueing disabled",
+			profile: newProfile(false, "PrioritySort", true),
+		},
+		{
+			name:    "fair queueing with ElasticQuota QueueSort",
+			profile: newProfile(true, elasticQuotaPluginName, false),
+		},
+		{
+			name:    "fair queueing without ElasticQuota QueueSort",
+			profile: newProfile(true, "PrioritySort", false),
+			wantErr: true,
+		},
+		{
+			name:    "fair queueing with Coscheduling",
+			profile: newProfile(true, elasticQuotaPluginName, true),
+		},
+		{
+			name:    "fair queueing with Coscheduling but without ElasticQuota QueueSort",
+			profile: newProfile(true, "PrioritySort", true),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuotaFairQueueing([]schedconfig.KubeSchedulerProfile{tt.profile})
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
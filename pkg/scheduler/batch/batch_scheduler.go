@@ -25,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 	fwktype "k8s.io/kube-scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler"
@@ -32,6 +33,7 @@ import (
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler/metrics"
 
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/batch/framework"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 )
@@ -122,7 +124,18 @@ func (bs *BatchScheduler) BatchSchedule(
 		// Build the compact failure message before cleanup: cleanup overwrites every assumed pod's
 		// per-pod status with the cleanup status, which would otherwise corrupt the first-failed-pod detection.
 		failMsg := jobResult.ExampleMessage(podRequestsByNode, assumedPods)
+		// The pods of an independent plan do not depend on each other, so the failed ones are dropped and
+		// the scheduled ones are retried. Collect them before cleanup for the same reason as above.
+		var retryPlan *frameworkext.BatchScheduleResult
+		if plan.Independent {
+			retryPlan = buildScheduledPlan(triggerPod, plan, jobResult)
+		}
 		bs.cleanup(ctx, logger, parallelizer, ext, jobRequest, jobResult, assumedPods, nodeSnapshot, jobResult.Status, "batch_schedule_failure")
+		removePlannedPodsFromSnapshot(logger, ext, plan, jobResult)
+		if retryPlan != nil {
+			logger.V(4).Info("Retry to batch schedule the independent pods without the failed ones", "job", jobRequest.String(), "pods", len(retryPlan.Pods), "droppedPods", len(plan.Pods)-len(retryPlan.Pods))
+			return bs.BatchSchedule(ctx, ext, cycleState, triggerPod, retryPlan)
+		}
 		return fwktype.NewStatus(jobResult.Status.Code(), failMsg)
 	}
 
@@ -137,6 +150,7 @@ func (bs *BatchScheduler) BatchSchedule(
 	if !permitStatus.IsSuccess() {
 		logger.V(4).Info("Failed to permit the batch scheduled job, cleaning up assumed pods", "job", jobRequest.String(), "status", permitStatus.Message())
 		bs.cleanup(ctx, logger, parallelizer, ext, jobRequest, jobResult, assumedPods, nodeSnapshot, permitStatus, "batch_permit_failure")
+		removePlannedPodsFromSnapshot(logger, ext, plan, jobResult)
 		return permitStatus
 	}
 
@@ -452,6 +466,54 @@ func (bs *BatchScheduler) skipPod(logger klog.Logger, pod *corev1.Pod) bool {
 	return false
 }
 
+// buildScheduledPlan builds the plan of the pods passed the scheduling cycle, without the failed ones.
+// It returns nil if the trigger pod failed or no pod failed, since there is nothing to retry then.
+func buildScheduledPlan(triggerPod *corev1.Pod, plan *frameworkext.BatchScheduleResult, jobResult *framework.JobResult) *frameworkext.BatchScheduleResult {
+	// a nil status means the pod was never processed, which is not a success
+	if status := jobResult.PodStatus(framework.GetPodKey(triggerPod)); status == nil || !status.IsSuccess() {
+		return nil
+	}
+	scheduled := &frameworkext.BatchScheduleResult{
+		PodToNodeName: map[string]string{},
+		Independent:   true,
+	}
+	for _, pod := range plan.Pods {
+		key := framework.GetPodKey(pod)
+		if status := jobResult.PodStatus(key); status != nil && status.IsSuccess() {
+			scheduled.Pods = append(scheduled.Pods, pod)
+			scheduled.PodToNodeName[key] = plan.PodToNodeName[key]
+		}
+	}
+	if len(scheduled.Pods) == len(plan.Pods) {
+		return nil
+	}
+	return scheduled
+}
+
+// removePlannedPodsFromSnapshot removes the pods of a failed plan from the shared snapshot, where the
+// scheduling cycle added them in place, so that the rest of the current scheduling cycle, e.g. the
+// fallback or the retry, does not see the nodes occupied by the pods already forgotten.
+func removePlannedPodsFromSnapshot(logger klog.Logger, ext frameworkext.FrameworkExtender, plan *frameworkext.BatchScheduleResult, jobResult *framework.JobResult) {
+	if k8sfeature.DefaultFeatureGate.Enabled(features.EnableBatchScheduleNodeSnapshot) {
+		// each node uses an isolated snapshot, the shared one is never touched
+		return
+	}
+	for _, pod := range plan.Pods {
+		// the skipped pods are already scheduled, which must be kept in the snapshot
+		if status := jobResult.PodStatus(framework.GetPodKey(pod)); status == nil || status.IsSkip() {
+			continue
+		}
+		nodeInfo, err := ext.SnapshotSharedLister().NodeInfos().Get(plan.PodToNodeName[framework.GetPodKey(pod)])
+		if err != nil {
+			continue
+		}
+		// the pods never added to the node, e.g. the ones after a failed pod, are not found
+		if err = nodeInfo.RemovePod(logger, pod); err != nil {
+			logger.V(5).Info("Planned pod is not in the snapshot", "pod", klog.KObj(pod), "node", nodeInfo.Node().Name, "err", err)
+		}
+	}
+}
+
 // buildJobRequest builds a JobRequest by grouping the plan's pods by their planned node.
 func buildJobRequest(triggerPod *corev1.Pod, plan *frameworkext.BatchScheduleResult) *framework.JobRequest {
 	podsByNode := map[string]map[string]framework.PodRequest{}
@@ -27,12 +27,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 	fwktype "k8s.io/kube-scheduler/framework"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/batch/framework"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 func newExtWithScheduler(nodeNames ...string) *fakeExtender {
@@ -104,6 +108,85 @@ func TestBatchSchedulePermitFail(t *testing.T) {
 	assert.Equal(t, int32(1), ext.unreserveCalled.Load())
 }
 
+// newPodWithUID returns a pod with the UID, which the snapshot requires to remove the pod from the node.
+func newPodWithUID(namespace, name string) *corev1.Pod {
+	pod := newPod(namespace, name)
+	pod.UID = types.UID(name)
+	return pod
+}
+
+func TestBatchScheduleIndependentDropsFailedPods(t *testing.T) {
+	// the pods are scheduled on the shared snapshot, which must be restored on the failure
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.EnableBatchScheduleNodeSnapshot, false)()
+	ext := newExtWithScheduler("node-a", "node-b")
+	// the Reserve of a sibling is rejected, e.g. by the quota
+	ext.reserveStatusPerPod = map[string]*fwktype.Status{
+		"ns/p2": fwktype.NewStatus(fwktype.Unschedulable, "quota exceeded"),
+	}
+	c := newFakeCache()
+	bs := NewBatchScheduler(c, nil)
+	triggerPod := newPodWithUID("ns", "p1")
+	plan := &frameworkext.BatchScheduleResult{
+		Pods:          []*corev1.Pod{triggerPod, newPodWithUID("ns", "p2"), newPodWithUID("ns", "p3")},
+		PodToNodeName: map[string]string{"ns/p1": "node-a", "ns/p2": "node-b", "ns/p3": "node-a"},
+		Independent:   true,
+	}
+	status := bs.BatchSchedule(context.Background(), ext, schedulerframework.NewCycleState(), triggerPod, plan)
+	assert.Nil(t, status)
+	// the failed sibling is dropped and the rest are scheduled in the retry
+	eventuallyEqual(t, int32(2), func() int32 { return ext.postBindCalled.Load() })
+	assert.Equal(t, int32(3), ext.unreserveCalled.Load())
+	c.mu.RLock()
+	assert.Len(t, c.assumedPods, 2)
+	assert.NotContains(t, c.assumedPods, "ns/p2")
+	c.mu.RUnlock()
+	// the snapshot only holds the pods scheduled in the retry
+	nodeA, _ := ext.snapshotLister.NodeInfos().Get("node-a")
+	assert.Len(t, nodeA.GetPods(), 2)
+	nodeB, _ := ext.snapshotLister.NodeInfos().Get("node-b")
+	assert.Len(t, nodeB.GetPods(), 0)
+}
+
+func TestBatchScheduleIndependentTriggerPodFails(t *testing.T) {
+	// the pods are scheduled on the shared snapshot, which must be restored on the failure
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.EnableBatchScheduleNodeSnapshot, false)()
+	ext := newExtWithScheduler("node-a", "node-b")
+	ext.reserveStatusPerPod = map[string]*fwktype.Status{
+		"ns/p1": fwktype.NewStatus(fwktype.Unschedulable, "quota exceeded"),
+	}
+	bs := NewBatchScheduler(newFakeCache(), nil)
+	triggerPod := newPodWithUID("ns", "p1")
+	plan := &frameworkext.BatchScheduleResult{
+		Pods:          []*corev1.Pod{triggerPod, newPodWithUID("ns", "p2")},
+		PodToNodeName: map[string]string{"ns/p1": "node-a", "ns/p2": "node-b"},
+		Independent:   true,
+	}
+	status := bs.BatchSchedule(context.Background(), ext, schedulerframework.NewCycleState(), triggerPod, plan)
+	assert.False(t, status.IsSuccess())
+	assert.Equal(t, int32(0), ext.postBindCalled.Load())
+	for _, nodeName := range []string{"node-a", "node-b"} {
+		nodeInfo, _ := ext.snapshotLister.NodeInfos().Get(nodeName)
+		assert.Len(t, nodeInfo.GetPods(), 0)
+	}
+}
+
+func TestBatchScheduleGangFailsWithFailedPod(t *testing.T) {
+	ext := newExtWithScheduler("node-a", "node-b")
+	ext.reserveStatusPerPod = map[string]*fwktype.Status{
+		"ns/p2": fwktype.NewStatus(fwktype.Unschedulable, "quota exceeded"),
+	}
+	bs := NewBatchScheduler(newFakeCache(), nil)
+	triggerPod := newPodWithUID("ns", "p1")
+	plan := &frameworkext.BatchScheduleResult{
+		Pods:          []*corev1.Pod{triggerPod, newPodWithUID("ns", "p2")},
+		PodToNodeName: map[string]string{"ns/p1": "node-a", "ns/p2": "node-b"},
+	}
+	status := bs.BatchSchedule(context.Background(), ext, schedulerframework.NewCycleState(), triggerPod, plan)
+	assert.False(t, status.IsSuccess())
+	assert.Equal(t, int32(2), ext.unreserveCalled.Load())
+	assert.Equal(t, int32(0), ext.postBindCalled.Load())
+}
+
 // ---- runPermit ----
 
 func newBS() *BatchScheduler {
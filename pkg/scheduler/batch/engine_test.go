@@ -65,8 +65,9 @@ type fakeExtender struct {
 	waitPermitState *fwktype.Status
 
 	// per-pod overrides keyed by pod key "ns/name"
-	permitStatusPerPod map[string]*fwktype.Status
-	bindStatusPerPod   map[string]*fwktype.Status
+	reserveStatusPerPod map[string]*fwktype.Status
+	permitStatusPerPod  map[string]*fwktype.Status
+	bindStatusPerPod    map[string]*fwktype.Status
 
 	forgetErr error
 
@@ -107,6 +108,11 @@ func (f *fakeExtender) RunFilterPluginsWithNominatedPods(ctx context.Context, st
 
 func (f *fakeExtender) RunReservePluginsReserve(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod, nodeName string) *fwktype.Status {
 	f.reserveCalled.Add(1)
+	if f.reserveStatusPerPod != nil {
+		if s, ok := f.reserveStatusPerPod[podKey(pod)]; ok {
+			return s
+		}
+	}
 	return f.reserveStatus
 }
 
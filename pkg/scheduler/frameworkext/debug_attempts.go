@@ -0,0 +1,450 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fwktype "k8s.io/kube-scheduler/framework"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/schedulingphase"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+	"github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+var (
+	// debugAttemptsPerPod is the number of the latest scheduling attempts recorded for each pod. 0 disables recording.
+	debugAttemptsPerPod = 0
+	// debugAttemptsMaxPods is the max number of pods whose scheduling attempts are kept in memory.
+	debugAttemptsMaxPods = 1000
+)
+
+const (
+	debugAttemptStateKey = extension.SchedulingDomainPrefix + "/debug-attempt"
+)
+
+// DebugAttemptsSetter updates debugAttemptsPerPod to specified value
+func DebugAttemptsSetter(val string) (string, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("failed set debugAttemptsPerPod %s: %v", val, err)
+	}
+	debugAttemptsPerPod = n
+	if n == 0 {
+		defaultAttemptRecorder.reset()
+	}
+	return fmt.Sprintf("successfully set debugAttemptsPerPod to %s", val), nil
+}
+
+// SchedulingAttempt is the structured breakdown of one scheduling cycle of a pod.
+type SchedulingAttempt struct {
+	Pod       string      `json:"pod"`
+	UID       string      `json:"uid,omitempty"`
+	Profile   string      `json:"profile,omitempty"`
+	StartTime metav1.Time `json:"startTime"`
+	EndTime   metav1.Time `json:"endTime,omitempty"`
+	// FilterResults records the Filter status of each evaluated node.
+	FilterResults map[string]*AttemptFilterResult `json:"filterResults,omitempty"`
+	// NodeScores records the per-plugin scores of the feasible nodes, sorted by total score.
+	NodeScores []AttemptNodeScore `json:"nodeScores,omitempty"`
+	// ReservationCandidates records the scored reservations on each node.
+	ReservationCandidates map[string][]AttemptReservationScore `json:"reservationCandidates,omitempty"`
+	Result                *AttemptResult                       `json:"result,omitempty"`
+
+	weights map[string]int32
+	lock    sync.Mutex
+}
+
+type AttemptFilterResult struct {
+	Code    string   `json:"code"`
+	Plugin  string   `json:"plugin,omitempty"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type AttemptNodeScore struct {
+	Node       string               `json:"node"`
+	TotalScore int64                `json:"totalScore"`
+	Plugins    []AttemptPluginScore `json:"plugins,omitempty"`
+}
+
+// AttemptPluginScore carries the weighted score returned by the framework and the normalized score
+// before the plugin weight was applied.
+type AttemptPluginScore struct {
+	Name            string `json:"name"`
+	Weight          int32  `json:"weight,omitempty"`
+	NormalizedScore int64  `json:"normalizedScore"`
+	Score           int64  `json:"score"`
+}
+
+type AttemptReservationScore struct {
+	Name   string           `json:"name"`
+	UID    string           `json:"uid,omitempty"`
+	Scores map[string]int64 `json:"scores,omitempty"`
+}
+
+type AttemptResult struct {
+	SuggestedHost  string `json:"suggestedHost,omitempty"`
+	EvaluatedNodes int    `json:"evaluatedNodes,omitempty"`
+	FeasibleNodes  int    `json:"feasibleNodes,omitempty"`
+	Error          string `json:"error,omitempty"`
+	// BatchScheduled indicates the pod is placed by the inline batch schedule of its workload.
+	BatchScheduled bool `json:"batchScheduled,omitempty"`
+}
+
+// Clone deep copies the attempt, so the records made on the cloned CycleState, e.g. the preemption dry runs,
+// do not leak into the attempt of the scheduling cycle.
+func (a *SchedulingAttempt) Clone() fwktype.StateData {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	clone := &SchedulingAttempt{
+		Pod:       a.Pod,
+		UID:       a.UID,
+		Profile:   a.Profile,
+		StartTime: a.StartTime,
+		EndTime:   a.EndTime,
+		// weights is never modified after the attempt is created
+		weights: a.weights,
+	}
+	if a.FilterResults != nil {
+		clone.FilterResults = make(map[string]*AttemptFilterResult, len(a.FilterResults))
+		for node, result := range a.FilterResults {
+			r := *result
+			r.Reasons = append([]string(nil), result.Reasons...)
+			clone.FilterResults[node] = &r
+		}
+	}
+	if a.NodeScores != nil {
+		clone.NodeScores = make([]AttemptNodeScore, len(a.NodeScores))
+		for i := range a.NodeScores {
+			clone.NodeScores[i] = a.NodeScores[i]
+			clone.NodeScores[i].Plugins = append([]AttemptPluginScore(nil), a.NodeScores[i].Plugins...)
+		}
+	}
+	if a.ReservationCandidates != nil {
+		clone.ReservationCandidates = make(map[string][]AttemptReservationScore, len(a.ReservationCandidates))
+		for node, candidates := range a.ReservationCandidates {
+			cloned := make([]AttemptReservationScore, len(candidates))
+			for i := range candidates {
+				cloned[i] = candidates[i]
+				if candidates[i].Scores != nil {
+					cloned[i].Scores = make(map[string]int64, len(candidates[i].Scores))
+					for k, v := range candidates[i].Scores {
+						cloned[i].Scores[k] = v
+					}
+				}
+			}
+			clone.ReservationCandidates[node] = cloned
+		}
+	}
+	if a.Result != nil {
+		result := *a.Result
+		clone.Result = &result
+	}
+	return clone
+}
+
+func newSchedulingAttempt(pod *corev1.Pod, profileName string, plugins *schedconfig.Plugins) *SchedulingAttempt {
+	podKey := framework.GetNamespacedName(pod.Namespace, pod.Name)
+	if reservation.IsReservePod(pod) {
+		podKey = reservation.GetReservationNameFromReservePod(pod)
+	}
+	attempt := &SchedulingAttempt{
+		Pod:       podKey,
+		UID:       string(pod.UID),
+		Profile:   profileName,
+		StartTime: nowFunc(),
+	}
+	if plugins != nil {
+		attempt.weights = make(map[string]int32, len(plugins.Score.Enabled))
+		for _, pl := range plugins.Score.Enabled {
+			attempt.weights[pl.Name] = pl.Weight
+		}
+	}
+	return attempt
+}
+
+func getSchedulingAttempt(cycleState fwktype.CycleState) *SchedulingAttempt {
+	if cycleState == nil {
+		return nil
+	}
+	s, err := cycleState.Read(debugAttemptStateKey)
+	if err != nil || s == nil {
+		return nil
+	}
+	attempt, _ := s.(*SchedulingAttempt)
+	return attempt
+}
+
+// isRecordingAttempt returns the attempt of the cycle if it is recorded and the cycle is not in the PostFilter,
+// since preemption evaluates the nodes on cloned states with victims removed.
+func isRecordingAttempt(cycleState fwktype.CycleState) *SchedulingAttempt {
+	attempt := getSchedulingAttempt(cycleState)
+	if attempt == nil || schedulingphase.GetExtensionPointBeingExecuted(cycleState) == schedulingphase.PostFilter {
+		return nil
+	}
+	return attempt
+}
+
+func recordAttemptFilter(cycleState fwktype.CycleState, nodeName string, status *fwktype.Status) {
+	attempt := isRecordingAttempt(cycleState)
+	if attempt == nil {
+		return
+	}
+	result := &AttemptFilterResult{
+		Code: status.Code().String(),
+	}
+	if status != nil {
+		result.Plugin = status.Plugin()
+		result.Reasons = status.Reasons()
+	}
+	attempt.lock.Lock()
+	defer attempt.lock.Unlock()
+	if attempt.FilterResults == nil {
+		attempt.FilterResults = map[string]*AttemptFilterResult{}
+	}
+	attempt.FilterResults[nodeName] = result
+}
+
+func recordAttemptScores(cycleState fwktype.CycleState, allNodePluginScores []fwktype.NodePluginScores) {
+	attempt := isRecordingAttempt(cycleState)
+	if attempt == nil {
+		return
+	}
+	nodeScores := make([]AttemptNodeScore, 0, len(allNodePluginScores))
+	for _, nodeScore := range allNodePluginScores {
+		s := AttemptNodeScore{
+			Node:       nodeScore.Name,
+			TotalScore: nodeScore.TotalScore,
+			Plugins:    make([]AttemptPluginScore, 0, len(nodeScore.Scores)),
+		}
+		for _, pluginScore := range nodeScore.Scores {
+			weight := attempt.weights[pluginScore.Name]
+			normalized := pluginScore.Score
+			if weight > 0 {
+				normalized = pluginScore.Score / int64(weight)
+			}
+			s.Plugins = append(s.Plugins, AttemptPluginScore{
+				Name:            pluginScore.Name,
+				Weight:          weight,
+				NormalizedScore: normalized,
+				Score:           pluginScore.Score,
+			})
+		}
+		nodeScores = append(nodeScores, s)
+	}
+	sort.SliceStable(nodeScores, func(i, j int) bool {
+		return nodeScores[i].TotalScore > nodeScores[j].TotalScore
+	})
+	attempt.lock.Lock()
+	defer attempt.lock.Unlock()
+	attempt.NodeScores = nodeScores
+}
+
+func recordAttemptReservationScores(cycleState fwktype.CycleState, nodeName string, reservationInfos []*ReservationInfo, pluginToReservationScores PluginToReservationScores) {
+	attempt := isRecordingAttempt(cycleState)
+	if attempt == nil || len(reservationInfos) == 0 {
+		return
+	}
+	candidates := make([]AttemptReservationScore, 0, len(reservationInfos))
+	for i, rInfo := range reservationInfos {
+		candidate := AttemptReservationScore{
+			Name: rInfo.GetName(),
+			UID:  string(rInfo.UID()),
+		}
+		for pluginName, scores := range pluginToReservationScores {
+			if i >= len(scores) {
+				continue
+			}
+			if candidate.Scores == nil {
+				candidate.Scores = map[string]int64{}
+			}
+			candidate.Scores[pluginName] = scores[i].Score
+		}
+		candidates = append(candidates, candidate)
+	}
+	attempt.lock.Lock()
+	defer attempt.lock.Unlock()
+	if attempt.ReservationCandidates == nil {
+		attempt.ReservationCandidates = map[string][]AttemptReservationScore{}
+	}
+	attempt.ReservationCandidates[nodeName] = candidates
+}
+
+// attemptRecorder keeps the latest attempts of the most recently scheduled pods.
+type attemptRecorder struct {
+	lock     sync.RWMutex
+	attempts map[string]*list.Element
+	// lru orders the pods by their last attempt, the front is the latest.
+	lru *list.List
+}
+
+type podAttempts struct {
+	podKey   string
+	attempts []*SchedulingAttempt
+}
+
+var defaultAttemptRecorder = newAttemptRecorder()
+
+func newAttemptRecorder() *attemptRecorder {
+	return &attemptRecorder{
+		attempts: map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+func (r *attemptRecorder) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.attempts = map[string]*list.Element{}
+	r.lru.Init()
+}
+
+func (r *attemptRecorder) add(attempt *SchedulingAttempt, maxAttempts, maxPods int) {
+	if maxAttempts <= 0 {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	elem := r.attempts[attempt.Pod]
+	if elem == nil {
+		elem = r.lru.PushFront(&podAttempts{podKey: attempt.Pod})
+		r.attempts[attempt.Pod] = elem
+	} else {
+		r.lru.MoveToFront(elem)
+	}
+	pa := elem.Value.(*podAttempts)
+	pa.attempts = append(pa.attempts, attempt)
+	if len(pa.attempts) > maxAttempts {
+		pa.attempts = pa.attempts[len(pa.attempts)-maxAttempts:]
+	}
+	for maxPods > 0 && r.lru.Len() > maxPods {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.attempts, oldest.Value.(*podAttempts).podKey)
+	}
+}
+
+// get returns the latest n attempts of the pod, the latest attempt comes first.
+func (r *attemptRecorder) get(podKey string, n int) []*SchedulingAttempt {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	elem := r.attempts[podKey]
+	if elem == nil {
+		return nil
+	}
+	attempts := elem.Value.(*podAttempts).attempts
+	if n <= 0 || n > len(attempts) {
+		n = len(attempts)
+	}
+	result := make([]*SchedulingAttempt, 0, n)
+	for i := len(attempts) - 1; i >= len(attempts)-n; i-- {
+		result = append(result, attempts[i])
+	}
+	return result
+}
+
+// startSchedulingAttempt starts recording the scheduling attempt of the pod if enabled.
+func startSchedulingAttempt(cycleState fwktype.CycleState, pod *corev1.Pod, fwk framework.Framework) {
+	if debugAttemptsPerPod <= 0 {
+		return
+	}
+	var plugins *schedconfig.Plugins
+	if extender, ok := fwk.(*frameworkExtenderImpl); ok {
+		plugins = extender.configuredPlugins
+	}
+	cycleState.Write(debugAttemptStateKey, newSchedulingAttempt(pod, fwk.ProfileName(), plugins))
+}
+
+// finishSchedulingAttempt records the final decision of the scheduling attempt.
+func finishSchedulingAttempt(cycleState fwktype.CycleState, suggestedHost string, evaluatedNodes, feasibleNodes int, err error) {
+	result := &AttemptResult{
+		SuggestedHost:  suggestedHost,
+		EvaluatedNodes: evaluatedNodes,
+		FeasibleNodes:  feasibleNodes,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	recordAttemptResult(cycleState, result)
+}
+
+// finishBatchScheduledAttempt records the attempt of the pod placed by the inline batch schedule.
+func finishBatchScheduledAttempt(cycleState fwktype.CycleState, nodeName string) {
+	recordAttemptResult(cycleState, &AttemptResult{
+		SuggestedHost:  nodeName,
+		BatchScheduled: true,
+	})
+}
+
+func recordAttemptResult(cycleState fwktype.CycleState, result *AttemptResult) {
+	attempt := getSchedulingAttempt(cycleState)
+	if attempt == nil {
+		return
+	}
+	attempt.lock.Lock()
+	attempt.Result = result
+	attempt.EndTime = nowFunc()
+	attempt.lock.Unlock()
+	defaultAttemptRecorder.add(attempt, debugAttemptsPerPod, debugAttemptsMaxPods)
+}
+
+var _ services.APIServiceProvider = &attemptRecorder{}
+
+// RegisterEndpoints serves the recorded attempts by GET /pods/:namespace/:name?limit=N and
+// GET /reservations/:name?limit=N.
+func (r *attemptRecorder) RegisterEndpoints(group *gin.RouterGroup) {
+	group.GET("/pods/:namespace/:name", func(c *gin.Context) {
+		r.serveAttempts(c, framework.GetNamespacedName(c.Param("namespace"), c.Param("name")))
+	})
+	group.GET("/reservations/:name", func(c *gin.Context) {
+		r.serveAttempts(c, c.Param("name"))
+	})
+}
+
+func (r *attemptRecorder) serveAttempts(c *gin.Context, key string) {
+	if debugAttemptsPerPod <= 0 {
+		services.ResponseErrorMessage(c, http.StatusNotFound, "scheduling attempts recording is disabled")
+		return
+	}
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			services.ResponseErrorMessage(c, http.StatusBadRequest, "invalid limit %s: %v", v, err)
+			return
+		}
+		limit = n
+	}
+	// the attempts are immutable once they are added into the recorder
+	attempts := r.get(key, limit)
+	if len(attempts) == 0 {
+		services.ResponseErrorMessage(c, http.StatusNotFound, "cannot find scheduling attempts of %s", key)
+		return
+	}
+	c.JSON(http.StatusOK, attempts)
+}
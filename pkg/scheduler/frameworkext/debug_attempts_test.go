@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fwktype "k8s.io/kube-scheduler/framework"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/schedulingphase"
+)
+
+func TestDebugAttemptsSetter(t *testing.T) {
+	defer func() { debugAttemptsPerPod = 0 }()
+	_, err := DebugAttemptsSetter("3")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, debugAttemptsPerPod)
+	_, err = DebugAttemptsSetter("-1")
+	assert.Error(t, err)
+	_, err = DebugAttemptsSetter("abc")
+	assert.Error(t, err)
+	assert.Equal(t, 3, debugAttemptsPerPod)
+}
+
+func TestRecordSchedulingAttempt(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			UID:       "123",
+		},
+	}
+	plugins := &schedconfig.Plugins{
+		Score: schedconfig.PluginSet{
+			Enabled: []schedconfig.Plugin{
+				{Name: "PluginA", Weight: 2},
+				{Name: "PluginB", Weight: 1},
+			},
+		},
+	}
+	cycleState := framework.NewCycleState()
+	cycleState.Write(debugAttemptStateKey, newSchedulingAttempt(pod, "koord-scheduler", plugins))
+
+	recordAttemptFilter(cycleState, "node-1", nil)
+	recordAttemptFilter(cycleState, "node-2", fwktype.NewStatus(fwktype.Unschedulable, "Insufficient cpu").WithPlugin("NodeResourcesFit"))
+	recordAttemptScores(cycleState, []fwktype.NodePluginScores{
+		{
+			Name:       "node-1",
+			TotalScore: 130,
+			Scores: []fwktype.PluginScore{
+				{Name: "PluginA", Score: 100},
+				{Name: "PluginB", Score: 30},
+			},
+		},
+	})
+	recordAttemptReservationScores(cycleState, "node-1", []*ReservationInfo{
+		NewReservationInfoFromPod(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "r-1", UID: "r-uid"}}),
+	}, PluginToReservationScores{
+		"Reservation": {{Name: "r-1", Score: 80}},
+	})
+
+	// filters during PostFilter are ignored
+	schedulingphase.RecordPhase(cycleState, schedulingphase.PostFilter)
+	recordAttemptFilter(cycleState, "node-3", nil)
+	schedulingphase.RecordPhase(cycleState, "")
+
+	recorder := defaultAttemptRecorder
+	defer recorder.reset()
+	debugAttemptsPerPod = 2
+	defer func() { debugAttemptsPerPod = 0 }()
+	finishSchedulingAttempt(cycleState, "node-1", 3, 1, nil)
+
+	attempts := recorder.get("default/test-pod", 0)
+	assert.Len(t, attempts, 1)
+	attempt := attempts[0]
+	assert.Equal(t, "koord-scheduler", attempt.Profile)
+	assert.Equal(t, map[string]*AttemptFilterResult{
+		"node-1": {Code: fwktype.Success.String()},
+		"node-2": {Code: fwktype.Unschedulable.String(), Plugin: "NodeResourcesFit", Reasons: []string{"Insufficient cpu"}},
+	}, attempt.FilterResults)
+	assert.Equal(t, []AttemptNodeScore{
+		{
+			Node:       "node-1",
+			TotalScore: 130,
+			Plugins: []AttemptPluginScore{
+				{Name: "PluginA", Weight: 2, NormalizedScore: 50, Score: 100},
+				{Name: "PluginB", Weight: 1, NormalizedScore: 30, Score: 30},
+			},
+		},
+	}, attempt.NodeScores)
+	assert.Equal(t, map[string][]AttemptReservationScore{
+		"node-1": {{Name: "r-1", UID: "r-uid", Scores: map[string]int64{"Reservation": 80}}},
+	}, attempt.ReservationCandidates)
+	assert.Equal(t, &AttemptResult{SuggestedHost: "node-1", EvaluatedNodes: 3, FeasibleNodes: 1}, attempt.Result)
+
+	for i := 0; i < 3; i++ {
+		cycleState := framework.NewCycleState()
+		cycleState.Write(debugAttemptStateKey, newSchedulingAttempt(pod, "koord-scheduler", plugins))
+		finishSchedulingAttempt(cycleState, "", 3, 0, errors.New("0/3 nodes are available"))
+	}
+	attempts = recorder.get("default/test-pod", 0)
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, "0/3 nodes are available", attempts[0].Result.Error)
+	assert.Len(t, recorder.get("default/test-pod", 1), 1)
+}
+
+func TestSchedulingAttemptClone(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"}}
+	cycleState := framework.NewCycleState()
+	cycleState.Write(debugAttemptStateKey, newSchedulingAttempt(pod, "koord-scheduler", nil))
+	recordAttemptFilter(cycleState, "node-1", fwktype.NewStatus(fwktype.Unschedulable, "Insufficient cpu").WithPlugin("NodeResourcesFit"))
+
+	clonedState := cycleState.Clone()
+	recordAttemptFilter(clonedState, "node-2", nil)
+	getSchedulingAttempt(clonedState).FilterResults["node-1"].Reasons[0] = "modified"
+
+	attempt := getSchedulingAttempt(cycleState)
+	assert.Equal(t, map[string]*AttemptFilterResult{
+		"node-1": {Code: fwktype.Unschedulable.String(), Plugin: "NodeResourcesFit", Reasons: []string{"Insufficient cpu"}},
+	}, attempt.FilterResults)
+	assert.Len(t, getSchedulingAttempt(clonedState).FilterResults, 2)
+}
+
+func TestFinishBatchScheduledAttempt(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"}}
+	cycleState := framework.NewCycleState()
+	cycleState.Write(debugAttemptStateKey, newSchedulingAttempt(pod, "koord-scheduler", nil))
+
+	recorder := defaultAttemptRecorder
+	defer recorder.reset()
+	debugAttemptsPerPod = 1
+	defer func() { debugAttemptsPerPod = 0 }()
+	finishBatchScheduledAttempt(cycleState, "node-1")
+
+	attempts := recorder.get("default/test-pod", 0)
+	assert.Len(t, attempts, 1)
+	assert.Equal(t, &AttemptResult{SuggestedHost: "node-1", BatchScheduled: true}, attempts[0].Result)
+}
+
+func TestAttemptRecorderEvictsOldestPods(t *testing.T) {
+	recorder := newAttemptRecorder()
+	for _, name := range []string{"a", "b", "c"} {
+		recorder.add(&SchedulingAttempt{Pod: "default/" + name}, 1, 2)
+	}
+	assert.Nil(t, recorder.get("default/a", 0))
+	assert.Len(t, recorder.get("default/b", 0), 1)
+	assert.Len(t, recorder.get("default/c", 0), 1)
+}
+
+func TestAttemptRecorderEndpoints(t *testing.T) {
+	recorder := newAttemptRecorder()
+	recorder.add(&SchedulingAttempt{Pod: "default/test-pod", Result: &AttemptResult{SuggestedHost: "node-1"}}, 1, 10)
+	engine := gin.New()
+	recorder.RegisterEndpoints(engine.Group("/"))
+
+	debugAttemptsPerPod = 0
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pods/default/test-pod", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	debugAttemptsPerPod = 1
+	defer func() { debugAttemptsPerPod = 0 }()
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pods/default/test-pod?limit=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got []*SchedulingAttempt
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Len(t, got, 1)
+	assert.Equal(t, "node-1", got[0].Result.SuggestedHost)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pods/default/not-found", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
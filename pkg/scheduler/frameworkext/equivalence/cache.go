@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package equivalence
+
+import (
+	"sync"
+
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	fwktype "k8s.io/kube-scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+)
+
+// Cache memoizes the per-node Filter results of the equivalent pods.
+//
+// A cached result is valid only if both of the following remain unchanged since it was computed:
+//   - the generation of the NodeInfo, which changes when the node or any pod on it changes in the scheduler cache;
+//   - the epoch of the node, which is bumped by InvalidateNode when a state outside the NodeInfo changes,
+//     e.g. a reservation, a Device or a NodeResourceTopology of the node.
+type Cache struct {
+	lock  sync.RWMutex
+	nodes map[string]*nodeCache
+}
+
+type nodeCache struct {
+	lock       sync.RWMutex
+	epoch      int64
+	generation int64
+	results    map[resultKey]*result
+}
+
+type resultKey struct {
+	plugin  string
+	podHash string
+}
+
+type result struct {
+	code    fwktype.Code
+	reasons []string
+}
+
+var defaultCache = NewCache()
+
+// NewCache creates an empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		nodes: map[string]*nodeCache{},
+	}
+}
+
+// DefaultCache returns the Cache shared by all the scheduling profiles. The pod hash covers the scheduler name,
+// so the profiles never share the results.
+func DefaultCache() *Cache {
+	return defaultCache
+}
+
+// InvalidateNode invalidates the cached results of the node in the default Cache. It is a no-op when the
+// FilterEquivalenceCache feature is disabled, where nothing is cached and the deleted nodes are never cleaned up.
+func InvalidateNode(nodeName string) {
+	if !k8sfeature.DefaultFeatureGate.Enabled(features.FilterEquivalenceCache) {
+		return
+	}
+	defaultCache.InvalidateNode(nodeName)
+}
+
+func (c *Cache) getNode(nodeName string, create bool) *nodeCache {
+	c.lock.RLock()
+	n := c.nodes[nodeName]
+	c.lock.RUnlock()
+	if n != nil || !create {
+		return n
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	n = c.nodes[nodeName]
+	if n == nil {
+		n = &nodeCache{}
+		c.nodes[nodeName] = n
+	}
+	return n
+}
+
+// Epoch returns the current epoch of the node. It must be read before running the Filter and passed to Store,
+// so that the result racing with an invalidation is dropped.
+func (c *Cache) Epoch(nodeName string) int64 {
+	n := c.getNode(nodeName, false)
+	if n == nil {
+		return 0
+	}
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.epoch
+}
+
+// Lookup returns the cached Filter result of the plugin for the pods with the hash on the node.
+// The returned status is a new object which can be modified by the caller.
+func (c *Cache) Lookup(nodeName string, generation int64, plugin, podHash string) (*fwktype.Status, bool) {
+	n := c.getNode(nodeName, false)
+	if n == nil {
+		return nil, false
+	}
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if n.generation != generation {
+		return nil, false
+	}
+	r, ok := n.results[resultKey{plugin: plugin, podHash: podHash}]
+	if !ok {
+		return nil, false
+	}
+	if r.code == fwktype.Success {
+		return nil, true
+	}
+	return fwktype.NewStatus(r.code, r.reasons...), true
+}
+
+// Store caches the Filter result of the plugin for the pods with the hash on the node. The result is dropped if
+// the node is invalidated after the epoch was read. Only the success and unschedulable results are cached.
+func (c *Cache) Store(nodeName string, generation, epoch int64, plugin, podHash string, status *fwktype.Status) {
+	code := status.Code()
+	if code != fwktype.Success && code != fwktype.Unschedulable && code != fwktype.UnschedulableAndUnresolvable {
+		return
+	}
+
+	n := c.getNode(nodeName, true)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.epoch != epoch {
+		return
+	}
+	if n.generation != generation || n.results == nil {
+		n.generation = generation
+		n.results = map[resultKey]*result{}
+	}
+	r := &result{code: code}
+	if code != fwktype.Success {
+		r.reasons = append([]string(nil), status.Reasons()...)
+	}
+	n.results[resultKey{plugin: plugin, podHash: podHash}] = r
+}
+
+// InvalidateNode drops all the cached results of the node and bumps its epoch.
+func (c *Cache) InvalidateNode(nodeName string) {
+	if nodeName == "" {
+		return
+	}
+	n := c.getNode(nodeName, true)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.epoch++
+	n.results = nil
+}
+
+// DeleteNode removes all the cached results of the deleted node.
+func (c *Cache) DeleteNode(nodeName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.nodes, nodeName)
+}
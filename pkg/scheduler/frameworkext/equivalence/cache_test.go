@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package equivalence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	fwktype "k8s.io/kube-scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+func TestCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *fwktype.Status
+		operate    func(c *Cache)
+		generation int64
+		wantFound  bool
+		wantStatus *fwktype.Status
+	}{
+		{
+			name:       "cache success",
+			status:     nil,
+			generation: 1,
+			wantFound:  true,
+			wantStatus: nil,
+		},
+		{
+			name:       "cache unschedulable",
+			status:     fwktype.NewStatus(fwktype.Unschedulable, "Insufficient cpu"),
+			generation: 1,
+			wantFound:  true,
+			wantStatus: fwktype.NewStatus(fwktype.Unschedulable, "Insufficient cpu"),
+		},
+		{
+			name:       "cache unschedulable and unresolvable",
+			status:     fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, "invalid cpu topology"),
+			generation: 1,
+			wantFound:  true,
+			wantStatus: fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, "invalid cpu topology"),
+		},
+		{
+			name:       "not cache error",
+			status:     fwktype.AsStatus(assert.AnError),
+			generation: 1,
+			wantFound:  false,
+		},
+		{
+			name:       "generation changed",
+			status:     nil,
+			generation: 2,
+			wantFound:  false,
+		},
+		{
+			name:   "node invalidated",
+			status: nil,
+			operate: func(c *Cache) {
+				c.InvalidateNode("node-1")
+			},
+			generation: 1,
+			wantFound:  false,
+		},
+		{
+			name:   "node deleted",
+			status: nil,
+			operate: func(c *Cache) {
+				c.DeleteNode("node-1")
+			},
+			generation: 1,
+			wantFound:  false,
+		},
+		{
+			name:   "other node invalidated",
+			status: nil,
+			operate: func(c *Cache) {
+				c.InvalidateNode("node-2")
+			},
+			generation: 1,
+			wantFound:  true,
+			wantStatus: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache()
+			c.Store("node-1", 1, c.Epoch("node-1"), "plugin", "hash", tt.status)
+			if tt.operate != nil {
+				tt.operate(c)
+			}
+			status, found := c.Lookup("node-1", tt.generation, "plugin", "hash")
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantStatus, status)
+
+			_, found = c.Lookup("node-1", tt.generation, "other-plugin", "hash")
+			assert.False(t, found)
+			_, found = c.Lookup("node-1", tt.generation, "plugin", "other-hash")
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestCacheStoreAfterInvalidation(t *testing.T) {
+	c := NewCache()
+	epoch := c.Epoch("node-1")
+	// the node is invalidated while the Filter is running
+	c.InvalidateNode("node-1")
+	c.Store("node-1", 1, epoch, "plugin", "hash", nil)
+	_, found := c.Lookup("node-1", 1, "plugin", "hash")
+	assert.False(t, found)
+
+	c.Store("node-1", 1, c.Epoch("node-1"), "plugin", "hash", nil)
+	_, found = c.Lookup("node-1", 1, "plugin", "hash")
+	assert.True(t, found)
+}
+
+func TestCacheLookupReturnsNewStatus(t *testing.T) {
+	c := NewCache()
+	c.Store("node-1", 1, c.Epoch("node-1"), "plugin", "hash", fwktype.NewStatus(fwktype.Unschedulable, "Insufficient cpu"))
+	status, found := c.Lookup("node-1", 1, "plugin", "hash")
+	assert.True(t, found)
+	status.WithPlugin("plugin")
+	status, found = c.Lookup("node-1", 1, "plugin", "hash")
+	assert.True(t, found)
+	assert.Equal(t, "", status.Plugin())
+}
+
+func TestInvalidateNode(t *testing.T) {
+	defer func() {
+		defaultCache.DeleteNode("node-1")
+	}()
+	// nothing is cached when the feature is disabled, and the deleted nodes are never cleaned up
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, false)()
+	InvalidateNode("node-1")
+	assert.Nil(t, defaultCache.getNode("node-1", false))
+
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, true)()
+	InvalidateNode("node-1")
+	assert.Equal(t, int64(1), defaultCache.Epoch("node-1"))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package equivalence
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	corev1 "k8s.io/api/core/v1"
+	hashutil "k8s.io/kubernetes/pkg/util/hash"
+)
+
+// containerTemplate is the scheduling-relevant part of a container.
+type containerTemplate struct {
+	Resources     corev1.ResourceRequirements
+	Ports         []corev1.ContainerPort
+	RestartPolicy *corev1.ContainerRestartPolicy
+}
+
+// podTemplate is the scheduling-relevant part of a pod. Pods of the same workload differ in their names,
+// generated volumes (e.g. the projected service account token) and other per-pod fields, so the hash
+// only covers the fields which are read by the scheduling plugins.
+type podTemplate struct {
+	Namespace                 string
+	Labels                    map[string]string
+	Annotations               map[string]string
+	SchedulerName             string
+	PriorityClassName         string
+	Priority                  *int32
+	PreemptionPolicy          *corev1.PreemptionPolicy
+	NodeSelector              map[string]string
+	Affinity                  *corev1.Affinity
+	Tolerations               []corev1.Toleration
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	RuntimeClassName          *string
+	Overhead                  corev1.ResourceList
+	Resources                 *corev1.ResourceRequirements
+	HostNetwork               bool
+	InitContainers            []containerTemplate
+	Containers                []containerTemplate
+}
+
+// GetPodHash returns the hash of the scheduling-relevant fields of the pod. The pods with the same hash are
+// equivalent to the scheduling plugins, e.g. the replicas created from the same workload template.
+func GetPodHash(pod *corev1.Pod) string {
+	template := &podTemplate{
+		Namespace:                 pod.Namespace,
+		Labels:                    pod.Labels,
+		Annotations:               pod.Annotations,
+		SchedulerName:             pod.Spec.SchedulerName,
+		PriorityClassName:         pod.Spec.PriorityClassName,
+		Priority:                  pod.Spec.Priority,
+		PreemptionPolicy:          pod.Spec.PreemptionPolicy,
+		NodeSelector:              pod.Spec.NodeSelector,
+		Affinity:                  pod.Spec.Affinity,
+		Tolerations:               pod.Spec.Tolerations,
+		TopologySpreadConstraints: pod.Spec.TopologySpreadConstraints,
+		RuntimeClassName:          pod.Spec.RuntimeClassName,
+		Overhead:                  pod.Spec.Overhead,
+		Resources:                 pod.Spec.Resources,
+		HostNetwork:               pod.Spec.HostNetwork,
+		InitContainers:            make([]containerTemplate, 0, len(pod.Spec.InitContainers)),
+		Containers:                make([]containerTemplate, 0, len(pod.Spec.Containers)),
+	}
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		template.InitContainers = append(template.InitContainers, containerTemplate{
+			Resources:     container.Resources,
+			Ports:         container.Ports,
+			RestartPolicy: container.RestartPolicy,
+		})
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		template.Containers = append(template.Containers, containerTemplate{
+			Resources: container.Resources,
+			Ports:     container.Ports,
+		})
+	}
+	// The pods with the same hash share the Filter results, so a collision would place a pod on an infeasible node.
+	// Use a cryptographic hash rather than a short FNV one to make collisions practically impossible.
+	hasher := sha256.New()
+	hashutil.DeepHashObject(hasher, template)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
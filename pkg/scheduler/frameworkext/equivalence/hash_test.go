@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package equivalence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{"app": "test"},
+		},
+		Spec: corev1.PodSpec{
+			SchedulerName: "koord-scheduler",
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetPodHash(t *testing.T) {
+	pod := newTestPod("pod-1")
+	sibling := newTestPod("pod-2")
+	sibling.Spec.Volumes = []corev1.Volume{{Name: "kube-api-access-abcde"}}
+	assert.Equal(t, GetPodHash(pod), GetPodHash(sibling))
+
+	sibling.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+	assert.NotEqual(t, GetPodHash(pod), GetPodHash(sibling))
+
+	sibling = newTestPod("pod-2")
+	sibling.Spec.NodeSelector = map[string]string{"zone": "a"}
+	assert.NotEqual(t, GetPodHash(pod), GetPodHash(sibling))
+
+	sibling = newTestPod("pod-2")
+	sibling.Namespace = "other"
+	assert.NotEqual(t, GetPodHash(pod), GetPodHash(sibling))
+
+	sibling = newTestPod("pod-2")
+	sibling.Spec.SchedulerName = "other-scheduler"
+	assert.NotEqual(t, GetPodHash(pod), GetPodHash(sibling))
+}
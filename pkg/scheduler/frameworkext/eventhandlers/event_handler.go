@@ -28,12 +28,14 @@ import (
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 )
 
 // AddScheduleEventHandler adds extra event handlers for the scheduler:
 // - Reservation event handlers for the scheduler just like pods'. One special case is that reservations have expiration, which the scheduler should clean up expired ones from the
 // cache and queue.
 // - Pod and reservation event handlers for multi-scheduler clean up.
+// - Node event handlers for the Filter equivalence cache clean up.
 func AddScheduleEventHandler(sched *scheduler.Scheduler, schedAdapter frameworkext.Scheduler, informerFactory informers.SharedInformerFactory, koordSharedInformerFactory koordinatorinformers.SharedInformerFactory, crossSchedulerNominator *frameworkext.CrossSchedulerPodNominator) {
 	podInformer := informerFactory.Core().V1().Pods().Informer()
 	if k8sfeature.DefaultFeatureGate.Enabled(features.DynamicSchedulerCheck) {
@@ -51,6 +53,22 @@ func AddScheduleEventHandler(sched *scheduler.Scheduler, schedAdapter frameworke
 		klog.Fatalf("failed to add reservation handler, err: %s", err)
 	}
 
+	if k8sfeature.DefaultFeatureGate.Enabled(features.FilterEquivalenceCache) {
+		// The node updates change the NodeInfo generation which invalidates the cached Filter results,
+		// only the deleted nodes need to be cleaned up.
+		nodeInformer := informerFactory.Core().V1().Nodes().Informer()
+		_, err := nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: func(obj interface{}) {
+				if node := toNode(obj); node != nil {
+					equivalence.DefaultCache().DeleteNode(node.Name)
+				}
+			},
+		})
+		if err != nil {
+			klog.Fatalf("failed to add node handler for FilterEquivalenceCache, err: %s", err)
+		}
+	}
+
 	// Register cross-scheduler pod nominator event handler when feature is enabled.
 	if k8sfeature.DefaultFeatureGate.Enabled(features.CrossSchedulerNomination) && crossSchedulerNominator != nil {
 		_, err := podInformer.AddEventHandler(cache.FilteringResourceEventHandler{
@@ -102,6 +120,17 @@ func toPod(obj interface{}) *corev1.Pod {
 	return pod
 }
 
+func toNode(obj interface{}) *corev1.Node {
+	var node *corev1.Node
+	switch t := obj.(type) {
+	case *corev1.Node:
+		node = t
+	case cache.DeletedFinalStateUnknown:
+		node, _ = t.Obj.(*corev1.Node)
+	}
+	return node
+}
+
 func isResponsibleForPod(profiles profile.Map, pod *corev1.Pod) bool {
 	return profiles.HandlesSchedulerName(pod.Spec.SchedulerName)
 }
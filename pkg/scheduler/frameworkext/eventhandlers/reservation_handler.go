@@ -45,6 +45,7 @@ import (
 	schedulingv1alpha1lister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
@@ -535,6 +536,7 @@ func addReservationToSchedulerCache(sched frameworkext.Scheduler, r *schedulingv
 	} else {
 		klog.V(4).InfoS("Successfully add reservation into SchedulerCache", "reservation", klog.KObj(r))
 	}
+	equivalence.InvalidateNode(reservePod.Spec.NodeName)
 	sched.GetSchedulingQueue().AssignedPodAdded(klog.Background(), reservePod)
 }
 
@@ -570,6 +572,7 @@ func updateReservationInSchedulerCache(sched frameworkext.Scheduler, oldR, newR
 	} else {
 		klog.V(4).InfoS("Successfully update reservation into SchedulerCache", "reservation", klog.KObj(newR))
 	}
+	equivalence.InvalidateNode(newNodeName)
 	sched.GetSchedulingQueue().AssignedPodUpdated(klog.Background(), oldReservePod, newReservePod, fwktype.ClusterEvent{})
 }
 
@@ -622,6 +625,7 @@ func deleteReservationFromSchedulerCache(sched frameworkext.Scheduler, r *schedu
 	} else {
 		klog.V(4).InfoS("Successfully delete reservation from SchedulerCache", "reservation", klog.KObj(r), "reservationUID", r.UID)
 	}
+	equivalence.InvalidateNode(reservePod.Spec.NodeName)
 
 	// The deleted reserve pod must be carried as the oldObj of the delete event.
 	// This event may be recorded into the scheduler's inFlightEvents and later replayed
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	fwktype "k8s.io/kube-scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/schedulingphase"
+)
+
+const (
+	podEquivalenceHashStateKey = extension.SchedulingDomainPrefix + "/pod-equivalence-hash"
+)
+
+type podEquivalenceHashState struct {
+	hash string
+}
+
+func (s *podEquivalenceHashState) Clone() fwktype.StateData {
+	return s
+}
+
+// SetPodEquivalenceHash computes the equivalence hash of the pod and writes it into the CycleState.
+// It is called before PreFilter when the FilterEquivalenceCache feature is enabled.
+func SetPodEquivalenceHash(cycleState fwktype.CycleState, pod *corev1.Pod) {
+	cycleState.Write(podEquivalenceHashStateKey, &podEquivalenceHashState{
+		hash: equivalence.GetPodHash(pod),
+	})
+}
+
+// GetPodEquivalenceHash returns the equivalence hash of the pod written by SetPodEquivalenceHash.
+func GetPodEquivalenceHash(cycleState fwktype.CycleState) string {
+	s, err := cycleState.Read(podEquivalenceHashStateKey)
+	if err != nil || s == nil {
+		return ""
+	}
+	return s.(*podEquivalenceHashState).hash
+}
+
+// RunFilterWithEquivalenceCache returns the cached Filter result of the plugin if an equivalent pod has been
+// filtered on the same state of the node, otherwise it runs the filter function and caches the result.
+// The cache is bypassed in the PostFilter phase since the dry-run preemption filters the pod on the nodes with
+// the victims removed.
+func RunFilterWithEquivalenceCache(pl CacheableFilterPlugin, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo,
+	filter func() *fwktype.Status) *fwktype.Status {
+	if !k8sfeature.DefaultFeatureGate.Enabled(features.FilterEquivalenceCache) {
+		return filter()
+	}
+	podHash := GetPodEquivalenceHash(cycleState)
+	node := nodeInfo.Node()
+	if podHash == "" || node == nil || schedulingphase.GetExtensionPointBeingExecuted(cycleState) == schedulingphase.PostFilter ||
+		!pl.IsFilterCacheable(cycleState, pod, nodeInfo) {
+		return filter()
+	}
+
+	cache := equivalence.DefaultCache()
+	generation := nodeInfo.GetGeneration()
+	if status, ok := cache.Lookup(node.Name, generation, pl.Name(), podHash); ok {
+		return status
+	}
+	epoch := cache.Epoch(node.Name)
+	status := filter()
+	cache.Store(node.Name, generation, epoch, pl.Name(), podHash, status)
+	return status
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/schedulingphase"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+type fakeCacheableFilterPlugin struct {
+	cacheable bool
+	calls     int
+	status    *fwktype.Status
+}
+
+func (p *fakeCacheableFilterPlugin) Name() string { return "fakeCacheableFilterPlugin" }
+
+func (p *fakeCacheableFilterPlugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	return RunFilterWithEquivalenceCache(p, cycleState, pod, nodeInfo, func() *fwktype.Status {
+		p.calls++
+		return p.status
+	})
+}
+
+func (p *fakeCacheableFilterPlugin) IsFilterCacheable(cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) bool {
+	return p.cacheable
+}
+
+func TestRunFilterWithEquivalenceCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		disabled     bool
+		notCacheable bool
+		noPodHash    bool
+		postFilter   bool
+		invalidate   bool
+		addPod       bool
+		status       *fwktype.Status
+		wantCalls    int
+	}{
+		{
+			name:      "cache success",
+			wantCalls: 1,
+		},
+		{
+			name:      "cache unschedulable",
+			status:    fwktype.NewStatus(fwktype.Unschedulable, "Insufficient devices"),
+			wantCalls: 1,
+		},
+		{
+			name:      "not cache error",
+			status:    fwktype.AsStatus(assert.AnError),
+			wantCalls: 2,
+		},
+		{
+			name:      "feature disabled",
+			disabled:  true,
+			wantCalls: 2,
+		},
+		{
+			name:         "plugin not cacheable",
+			notCacheable: true,
+			wantCalls:    2,
+		},
+		{
+			name:      "missing pod hash",
+			noPodHash: true,
+			wantCalls: 2,
+		},
+		{
+			name:       "bypass in PostFilter",
+			postFilter: true,
+			wantCalls:  2,
+		},
+		{
+			name:       "node invalidated",
+			invalidate: true,
+			wantCalls:  2,
+		},
+		{
+			name:      "pod added to node",
+			addPod:    true,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, !tt.disabled)()
+
+			nodeName := "test-equivalence-node-" + tt.name
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1"}}
+
+			pl := &fakeCacheableFilterPlugin{cacheable: !tt.notCacheable, status: tt.status}
+			for i := 0; i < 2; i++ {
+				cycleState := framework.NewCycleState()
+				if !tt.noPodHash {
+					SetPodEquivalenceHash(cycleState, pod)
+				}
+				if tt.postFilter {
+					schedulingphase.RecordPhase(cycleState, schedulingphase.PostFilter)
+				}
+				status := pl.Filter(context.TODO(), cycleState, pod, nodeInfo)
+				assert.Equal(t, tt.status.Code(), status.Code())
+				assert.Equal(t, tt.status.AsError(), status.AsError())
+
+				if tt.invalidate {
+					equivalence.InvalidateNode(nodeName)
+				}
+				if tt.addPod {
+					nodeInfo.AddPod(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "assigned-pod"}})
+				}
+			}
+			assert.Equal(t, tt.wantCalls, pl.calls)
+		})
+	}
+}
@@ -321,6 +321,10 @@ func (ext *frameworkExtenderImpl) RunPreFilterPlugins(ctx context.Context, cycle
 		}
 	}
 
+	if k8sfeature.DefaultFeatureGate.Enabled(features.FilterEquivalenceCache) {
+		SetPodEquivalenceHash(cycleState, pod)
+	}
+
 	result, status, rejectors := ext.Framework.RunPreFilterPlugins(ctx, cycleState, pod)
 	if !status.IsSuccess() {
 		return result, status, rejectors
@@ -381,7 +385,7 @@ func (ext *frameworkExtenderImpl) RunPreFilterPlugins(ctx context.Context, cycle
 		batchStart := time.Now()
 		bStatus := ext.batchScheduler.BatchSchedule(ctx, ext, cycleState, pod, plan)
 		batchDuration := time.Since(batchStart)
-		setBatchScheduleState(cycleState, &batchScheduleStateData{handled: true, success: bStatus.IsSuccess()})
+		setBatchScheduleState(cycleState, &batchScheduleStateData{handled: true, success: bStatus.IsSuccess(), nodeName: nodeName})
 		batchResult := "success"
 		if !bStatus.IsSuccess() {
 			batchResult = "failure"
@@ -393,18 +397,23 @@ func (ext *frameworkExtenderImpl) RunPreFilterPlugins(ctx context.Context, cycle
 			// the batch scheduler; their binding cycles proceed asynchronously (one goroutine per pod).
 			return nil, fwktype.NewStatus(fwktype.Unschedulable, BatchScheduledReason), nil
 		}
-		// Inline batch scheduling failed. We never want a batch failure to trigger preemption for the
-		// trigger pod, so upgrade a plain Unschedulable status to UnschedulableAndUnresolvable: the
-		// scheduler stamps the PreFilter status onto all nodes, and preemption only considers nodes
-		// coded Unschedulable, so UnschedulableAndUnresolvable makes preemption find no candidate node.
-		// Build a fresh status to avoid mutating the shared JobStatus* package vars returned by the engine.
-		failCode := bStatus.Code()
-		if failCode == fwktype.Unschedulable {
-			failCode = fwktype.UnschedulableAndUnresolvable
-		}
-		failStatus := fwktype.NewStatus(failCode, bStatus.Message()).WithPlugin(ext.findOneNodePlugin.Name())
-		klog.ErrorS(bStatus.AsError(), "Failed to run inline batch schedule", "pod", klog.KObj(pod), "plugin", ext.findOneNodePlugin.Name(), "pods", len(plan.Pods), "duration", batchDuration)
-		return nil, failStatus, nil
+		if !plan.Independent {
+			// Inline batch scheduling failed. We never want a batch failure to trigger preemption for the
+			// trigger pod, so upgrade a plain Unschedulable status to UnschedulableAndUnresolvable: the
+			// scheduler stamps the PreFilter status onto all nodes, and preemption only considers nodes
+			// coded Unschedulable, so UnschedulableAndUnresolvable makes preemption find no candidate node.
+			// Build a fresh status to avoid mutating the shared JobStatus* package vars returned by the engine.
+			failCode := bStatus.Code()
+			if failCode == fwktype.Unschedulable {
+				failCode = fwktype.UnschedulableAndUnresolvable
+			}
+			failStatus := fwktype.NewStatus(failCode, bStatus.Message()).WithPlugin(ext.findOneNodePlugin.Name())
+			klog.ErrorS(bStatus.AsError(), "Failed to run inline batch schedule", "pod", klog.KObj(pod), "plugin", ext.findOneNodePlugin.Name(), "pods", len(plan.Pods), "duration", batchDuration)
+			return nil, failStatus, nil
+		}
+		// The pods of an independent plan can be scheduled one by one as well, so the triggering pod
+		// falls back to the standard flow as if the FindOneNodePlugin skipped it.
+		klog.V(4).InfoS("Inline batch schedule failed, fallback to the standard flow", "pod", klog.KObj(pod), "plugin", ext.findOneNodePlugin.Name(), "pods", len(plan.Pods), "status", bStatus.Message(), "duration", batchDuration)
 	} else if !status.IsSkip() {
 		status = status.WithPlugin(ext.findOneNodePlugin.Name())
 		klog.ErrorS(status.AsError(), "Failed to run FindOneNodePlugin", "pod", klog.KObj(pod), "plugin", ext.findOneNodePlugin.Name())
@@ -446,10 +455,12 @@ const batchScheduleStateKey = "koord-batch-schedule-state"
 type batchScheduleStateData struct {
 	handled bool
 	success bool
+	// nodeName is the node assigned to the pod of the scheduling cycle by the batch plan
+	nodeName string
 }
 
 func (s *batchScheduleStateData) Clone() fwktype.StateData {
-	return &batchScheduleStateData{handled: s.handled, success: s.success}
+	return &batchScheduleStateData{handled: s.handled, success: s.success, nodeName: s.nodeName}
 }
 
 func setBatchScheduleState(cycleState fwktype.CycleState, data *batchScheduleStateData) {
@@ -519,7 +530,11 @@ func (ext *frameworkExtenderImpl) RunFilterPluginsWithNominatedPods(ctx context.
 		}
 	}
 
-	return ext.runFilterPluginsWithNominatedPods(ctx, cycleState, pod, nodeInfo)
+	status := ext.runFilterPluginsWithNominatedPods(ctx, cycleState, pod, nodeInfo)
+	if debugAttemptsPerPod > 0 && nodeInfo.Node() != nil {
+		recordAttemptFilter(cycleState, nodeInfo.Node().Name, status)
+	}
+	return status
 }
 
 func (ext *frameworkExtenderImpl) RunScorePlugins(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod, nodeInfos []fwktype.NodeInfo) ([]fwktype.NodePluginScores, *fwktype.Status) {
@@ -541,6 +556,9 @@ func (ext *frameworkExtenderImpl) RunScorePlugins(ctx context.Context, state fwk
 	if status.IsSuccess() && debugTopNScores > 0 {
 		debugScores(debugTopNScores, pod, pluginToNodeScores, nodeInfos)
 	}
+	if status.IsSuccess() && debugAttemptsPerPod > 0 {
+		recordAttemptScores(state, pluginToNodeScores)
+	}
 	return pluginToNodeScores, status
 }
 
@@ -823,6 +841,9 @@ func (ext *frameworkExtenderImpl) RunReservationScorePlugins(ctx context.Context
 		}
 	}
 
+	if debugAttemptsPerPod > 0 {
+		recordAttemptReservationScores(cycleState, nodeName, reservationInfos, pluginToReservationScores)
+	}
 	return pluginToReservationScores, nil
 }
 
@@ -61,6 +61,8 @@ var errBatchScheduled = fmt.Errorf("pod handled by inline batch schedule: %s", B
 func AddFlags(fs *pflag.FlagSet) {
 	fs.IntVarP(&debugTopNScores, "debug-scores", "s", debugTopNScores, "logging topN nodes score and scores for each plugin after running the score extension, disable if set to 0")
 	fs.BoolVarP(&debugFilterFailure, "debug-filters", "f", debugFilterFailure, "logging filter failures")
+	fs.IntVarP(&debugAttemptsPerPod, "debug-attempts", "", debugAttemptsPerPod, "recording the last N scheduling attempts of each pod with filter and score breakdown, disable if set to 0")
+	fs.IntVarP(&debugAttemptsMaxPods, "debug-attempts-max-pods", "", debugAttemptsMaxPods, "the max number of pods whose scheduling attempts are recorded")
 	fs.BoolVarP(&dumpDiagnosis, "debug-diagnosis", "d", dumpDiagnosis, "logging scheduling diagnosis info for debugging")
 	fs.BoolVarP(&dumpDiagnosisBlocking, "debug-diagnosis-blocking", "", dumpDiagnosisBlocking, "logging diagnosis info for debugging, including blocking next scheduling cycle")
 	fs.IntVarP(&diagnosisQueueSize, "debug-diagnosis-queue-size", "", diagnosisQueueSize, "queue size for diagnosis info")
@@ -82,8 +84,13 @@ type extendedHandleOptions struct {
 	networkTopologyManager              networktopology.TreeManager
 	crossSchedulerNominator             *CrossSchedulerPodNominator
 	workloadAuditor                     workloadauditor.WorkloadAuditor
+	pluginHandleWrapper                 PluginHandleWrapper
 }
 
+// PluginHandleWrapper wraps the FrameworkExtender passed to the plugin factories, e.g. the scheduler simulator
+// wraps it with the fake clientsets so that the plugins building their own clients do not talk to a real cluster.
+type PluginHandleWrapper func(extender FrameworkExtender) fwktype.Handle
+
 type Option func(*extendedHandleOptions)
 
 func WithServicesEngine(engine *services.Engine) Option {
@@ -140,6 +147,12 @@ func WithWorkloadAuditor(auditor workloadauditor.WorkloadAuditor) Option {
 	}
 }
 
+func WithPluginHandleWrapper(wrapper PluginHandleWrapper) Option {
+	return func(options *extendedHandleOptions) {
+		options.pluginHandleWrapper = wrapper
+	}
+}
+
 // FrameworkExtenderFactory is a factory for creating a FrameworkExtender.
 // NOTE: DO NOT put framework-level data here.
 type FrameworkExtenderFactory struct {
@@ -162,6 +175,8 @@ type FrameworkExtenderFactory struct {
 
 	workloadAuditor workloadauditor.WorkloadAuditor
 
+	pluginHandleWrapper PluginHandleWrapper
+
 	pluginInformerFactories []SharedInformerFactory
 
 	metricsRecorder *metrics.MetricAsyncRecorder
@@ -176,6 +191,9 @@ func NewFrameworkExtenderFactory(options ...Option) (*FrameworkExtenderFactory,
 	if err := indexer.AddIndexers(handleOptions.koordinatorSharedInformerFactory); err != nil {
 		return nil, err
 	}
+	if handleOptions.servicesEngine != nil {
+		handleOptions.servicesEngine.RegisterDebugService("schedulingAttempts", defaultAttemptRecorder)
+	}
 
 	return &FrameworkExtenderFactory{
 		controllerMaps:                      NewControllersMap(),
@@ -191,6 +209,7 @@ func NewFrameworkExtenderFactory(options ...Option) (*FrameworkExtenderFactory,
 		networkTopologyTreeManager:          handleOptions.networkTopologyManager,
 		crossSchedulerNominator:             handleOptions.crossSchedulerNominator,
 		workloadAuditor:                     handleOptions.workloadAuditor,
+		pluginHandleWrapper:                 handleOptions.pluginHandleWrapper,
 		metricsRecorder:                     metrics.NewMetricsAsyncRecorder(1000, time.Second, wait.NeverStop),
 	}, nil
 }
@@ -378,23 +397,29 @@ func PodScheduleAttemptInfo(pod *corev1.Pod) (attempts int, initialAttemptTimest
 	return podInfo.Attempts, podInfo.InitialAttemptTimestamp, true
 }
 
-func (f *FrameworkExtenderFactory) scheduleOne(ctx context.Context, fwk framework.Framework, cycleState fwktype.CycleState, pod *corev1.Pod) (scheduler.ScheduleResult, error) {
+func (f *FrameworkExtenderFactory) scheduleOne(ctx context.Context, fwk framework.Framework, cycleState fwktype.CycleState, pod *corev1.Pod) (scheduleResult scheduler.ScheduleResult, err error) {
 	InitDiagnosis(cycleState, pod)
+	startSchedulingAttempt(cycleState, pod, fwk)
 	f.monitor.StartMonitoring(pod)
 	if f.workloadAuditor != nil {
 		f.workloadAuditor.RecordAttemptPod(pod)
 	}
-	scheduleResult, err := f.schedulePod(ctx, fwk, cycleState, pod)
+	scheduleResult, err = f.schedulePod(ctx, fwk, cycleState, pod)
 	if err != nil {
 		if st := getBatchScheduleState(cycleState); st != nil && st.handled && st.success {
 			// The whole job (including this pod) has already been assumed and bound by the inline
 			// batch scheduler. Return a sentinel error to skip PostFilter/preemption; the registered
 			// error handler filter suppresses the default failure handling.
+			finishBatchScheduledAttempt(cycleState, st.nodeName)
 			return scheduleResult, errBatchScheduled
 		}
 		recordScheduleDiagnosis(cycleState, err)
+		finishSchedulingAttempt(cycleState, "", scheduleResult.EvaluatedNodes, scheduleResult.FeasibleNodes, err)
 		return scheduleResult, err
 	}
+	defer func() {
+		finishSchedulingAttempt(cycleState, scheduleResult.SuggestedHost, scheduleResult.EvaluatedNodes, scheduleResult.FeasibleNodes, err)
+	}()
 
 	extender, ok := fwk.(*frameworkExtenderImpl)
 	if ok {
@@ -509,7 +534,11 @@ func PluginFactoryProxy(extenderFactory *FrameworkExtenderFactory, factoryFn fra
 	return func(ctx context.Context, args runtime.Object, handle fwktype.Handle) (fwktype.Plugin, error) {
 		fw := handle.(framework.Framework)
 		frameworkExtender := extenderFactory.NewFrameworkExtender(fw)
-		plugin, err := factoryFn(ctx, args, frameworkExtender)
+		var pluginHandle fwktype.Handle = frameworkExtender
+		if extenderFactory.pluginHandleWrapper != nil {
+			pluginHandle = extenderFactory.pluginHandleWrapper(frameworkExtender)
+		}
+		plugin, err := factoryFn(ctx, args, pluginHandle)
 		if err != nil {
 			return nil, err
 		}
@@ -92,6 +92,49 @@ func TestExtenderFactory(t *testing.T) {
 	assert.Len(t, impl.postFilterTransformers, 1)
 }
 
+type wrappedHandle struct {
+	FrameworkExtender
+}
+
+func TestPluginFactoryProxyWithHandleWrapper(t *testing.T) {
+	koordClientSet := koordfake.NewSimpleClientset()
+	factory, err := NewFrameworkExtenderFactory(
+		WithKoordinatorClientSet(koordClientSet),
+		WithKoordinatorSharedInformerFactory(koordinformers.NewSharedInformerFactory(koordClientSet, 0)),
+		WithPluginHandleWrapper(func(extender FrameworkExtender) fwktype.Handle {
+			return &wrappedHandle{FrameworkExtender: extender}
+		}),
+	)
+	assert.NoError(t, err)
+
+	var pluginHandle fwktype.Handle
+	proxyNew := PluginFactoryProxy(factory, func(ctx context.Context, args runtime.Object, f fwktype.Handle) (fwktype.Plugin, error) {
+		pluginHandle = f
+		return &TestTransformer{index: 1}, nil
+	})
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+	}
+	fakeClient := kubefake.NewSimpleClientset()
+	fh, err := schedulertesting.NewFramework(
+		context.TODO(),
+		registeredPlugins,
+		"koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(fakeNodeInfoLister{nodeInfoLister: nodeInfoLister{}}),
+		frameworkruntime.WithClientSet(fakeClient),
+		frameworkruntime.WithInformerFactory(informers.NewSharedInformerFactory(fakeClient, 0)),
+	)
+	assert.NoError(t, err)
+	_, err = proxyNew(context.TODO(), nil, fh)
+	assert.NoError(t, err)
+	wrapped, ok := pluginHandle.(*wrappedHandle)
+	assert.True(t, ok)
+	assert.Equal(t, factory.GetExtender("koord-scheduler"), wrapped.FrameworkExtender)
+	// the plugin is still registered to the extender
+	assert.Len(t, wrapped.FrameworkExtender.(*frameworkExtenderImpl).preFilterTransformers, 1)
+}
+
 func TestCopyQueueInfoToPod(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -133,6 +133,11 @@ type BatchScheduleResult struct {
 	Pods []*corev1.Pod
 	// PodToNodeName maps a pod key (namespace/name) to its planned node name.
 	PodToNodeName map[string]string
+	// Independent indicates the pods do not depend on each other, e.g. the replicas of a workload rather than
+	// the members of a gang. The pods failed in the batch schedule of an independent plan are dropped while
+	// the rest are still scheduled, and the triggering pod falls back to the standard scheduling flow if it
+	// fails itself.
+	Independent bool
 }
 
 // BatchScheduler batch-schedules all pods of a job according to a BatchScheduleResult computed by a FindOneNodePlugin.
@@ -161,6 +166,18 @@ type FilterTransformer interface {
 	BeforeFilter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) (*corev1.Pod, fwktype.NodeInfo, bool, *fwktype.Status)
 }
 
+// CacheableFilterPlugin is a FilterPlugin whose Filter result only depends on the scheduling-relevant spec of the pod
+// (see equivalence.GetPodHash) and the state of the node, so the equivalent pods can share the result on the same node.
+// The plugin calls RunFilterWithEquivalenceCache in its Filter, and must invalidate the cached results of the node
+// through equivalence.InvalidateNode when a state it reads outside the NodeInfo changes.
+type CacheableFilterPlugin interface {
+	fwktype.FilterPlugin
+	// IsFilterCacheable returns whether the Filter result of the pod on the node can be cached and reused.
+	// It must return false when the result depends on the per-pod state, e.g. the reservations matched by the pod,
+	// or when the Filter writes the state used by the later extension points.
+	IsFilterCacheable(cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) bool
+}
+
 // ScoreTransformer is executed before Score.
 type ScoreTransformer interface {
 	SchedulingTransformer
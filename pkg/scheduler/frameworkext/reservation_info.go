@@ -112,6 +112,7 @@ func NewReservationInfo(r *schedulingv1alpha1.Reservation) *ReservationInfo {
 		parseErrors = append(parseErrors, err)
 		klog.ErrorS(err, "Failed to parse reservation owner matchers", "reservation", klog.KObj(r))
 	}
+	reservationutil.DefaultReservationOwnerGangNamespace(ownerMatchers, reservedPod.Namespace)
 
 	var parseError error
 	if len(parseErrors) > 0 {
@@ -158,6 +159,7 @@ func NewReservationInfoFromPod(pod *corev1.Pod) *ReservationInfo {
 			parseErrors = append(parseErrors, err)
 			klog.ErrorS(err, "Failed to parse reservation owner matchers of pod", "pod", klog.KObj(pod))
 		}
+		reservationutil.DefaultReservationOwnerGangNamespace(ownerMatchers, pod.Namespace)
 	}
 
 	var parseError error
@@ -432,6 +434,7 @@ func (ri *ReservationInfo) UpdateReservation(r *schedulingv1alpha1.Reservation)
 		klog.ErrorS(err, "Failed to parse reservation owner matchers", "reservation", klog.KObj(r))
 		parseErrors = append(parseErrors, err)
 	}
+	reservationutil.DefaultReservationOwnerGangNamespace(ownerMatchers, ri.Pod.Namespace)
 	ri.OwnerMatchers = ownerMatchers
 
 	var parseError error
@@ -477,6 +480,7 @@ func (ri *ReservationInfo) UpdatePod(pod *corev1.Pod) {
 			klog.ErrorS(err, "Failed to parse reservation owner matchers of pod", "pod", klog.KObj(pod))
 			parseErrors = append(parseErrors, err)
 		}
+		reservationutil.DefaultReservationOwnerGangNamespace(ownerMatchers, pod.Namespace)
 	}
 	ri.OwnerMatchers = ownerMatchers
 
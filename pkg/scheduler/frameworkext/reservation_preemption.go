@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	eventReasonReservationPreempted = "Preempted"
+	eventActionPreempting           = "Preempting"
+)
+
+// IsReservePodPreemptible checks if the Reservation of the reserve pod can be selected as a preemption victim.
+// Only the available Reservation which is not allocated by any owner can be preempted, since preempting an allocated
+// Reservation would disrupt its owner pods.
+func IsReservePodPreemptible(handle ExtendedHandle, reservePod *corev1.Pod) bool {
+	rName := reservationutil.GetReservationNameFromReservePod(reservePod)
+	if rName == "" {
+		return false
+	}
+	r, err := handle.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().Reservations().Lister().Get(rName)
+	if err != nil {
+		klog.V(5).InfoS("failed to get reservation of the reserve pod", "reservePod", klog.KObj(reservePod), "err", err)
+		return false
+	}
+	return reservationutil.IsReservationPreemptible(r)
+}
+
+// PreemptReservation marks the Reservation of the victim reserve pod as failed with the Preempted reason, so that
+// the reserved resources are released for the preemptor. The Reservation and its owners are notified via events.
+func PreemptReservation(ctx context.Context, handle ExtendedHandle, preemptor, reservePod *corev1.Pod) error {
+	logger := klog.FromContext(ctx)
+	rName := reservationutil.GetReservationNameFromReservePod(reservePod)
+	if rName == "" {
+		return fmt.Errorf("missing reservation name of the reserve pod %s", klog.KObj(reservePod))
+	}
+	reservationLister := handle.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().Reservations().Lister()
+	message := fmt.Sprintf("preempted by pod %s on node %s", klog.KObj(preemptor), reservePod.Spec.NodeName)
+
+	var preempted *schedulingv1alpha1.Reservation
+	err := util.RetryOnConflictOrTooManyRequests(func() error {
+		r, err := reservationLister.Get(rName)
+		if err != nil {
+			return err
+		}
+		if reservationutil.IsReservationPreempted(r) {
+			return nil
+		}
+		// the reservation may be allocated after the victims are selected
+		if !reservationutil.IsReservationPreemptible(r) {
+			return fmt.Errorf("reservation %s is no longer preemptible, phase %s, owners %d",
+				rName, r.Status.Phase, len(r.Status.CurrentOwners))
+		}
+
+		r = r.DeepCopy()
+		reservationutil.SetReservationPreempted(r, message)
+		preempted, err = handle.KoordinatorClientSet().SchedulingV1alpha1().Reservations().UpdateStatus(context.TODO(), r, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "Failed to preempt reservation", "reservation", rName, "preemptor", klog.KObj(preemptor))
+		return err
+	}
+	if preempted == nil {
+		return nil
+	}
+	logger.V(2).Info("Preemptor Pod preempted victim Reservation", "preemptor", klog.KObj(preemptor), "reservation", rName, "node", reservePod.Spec.NodeName)
+
+	recorder := handle.EventRecorder()
+	if recorder == nil {
+		return nil
+	}
+	recorder.Eventf(preempted, preemptor, corev1.EventTypeNormal, eventReasonReservationPreempted, eventActionPreempting,
+		"Reservation is %s", message)
+	for _, owner := range reservationutil.GetReservationOwnerReferences(preempted) {
+		recorder.Eventf(owner, preemptor, corev1.EventTypeNormal, eventReasonReservationPreempted, eventActionPreempting,
+			"Reservation %s is %s", rName, message)
+	}
+	return nil
+}
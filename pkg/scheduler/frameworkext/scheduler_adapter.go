@@ -79,6 +79,8 @@ type SchedulingQueue interface {
 	MoveAllToActiveOrBackoffQueue(logger klog.Logger, event fwktype.ClusterEvent, oldObj, newObj interface{}, preCheck PreEnqueueCheck)
 	Activate(logger klog.Logger, pods map[string]*corev1.Pod)
 	Done(types.UID)
+	// PendingPods returns the pods waiting in the activeQ, backoffQ and unschedulable pods pool.
+	PendingPods() []*corev1.Pod
 }
 
 var _ Scheduler = &SchedulerAdapter{}
@@ -206,6 +208,11 @@ func (q *queueAdapter) Done(pod types.UID) {
 	q.scheduler.SchedulingQueue.Done(pod)
 }
 
+func (q *queueAdapter) PendingPods() []*corev1.Pod {
+	pods, _ := q.scheduler.SchedulingQueue.PendingPods()
+	return pods
+}
+
 var _ Scheduler = &FakeScheduler{}
 var _ SchedulingQueue = &FakeQueue{}
 
@@ -365,3 +372,14 @@ func (f *FakeQueue) Activate(logger klog.Logger, pods map[string]*corev1.Pod) {
 }
 
 func (f *FakeQueue) Done(pod types.UID) {}
+
+func (f *FakeQueue) PendingPods() []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, len(f.Pods)+len(f.UnschedulablePods))
+	for _, pod := range f.Pods {
+		pods = append(pods, pod)
+	}
+	for _, pod := range f.UnschedulablePods {
+		pods = append(pods, pod)
+	}
+	return pods
+}
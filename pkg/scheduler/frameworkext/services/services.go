@@ -31,6 +31,7 @@ import (
 const (
 	servicesBaseRelativePath       = "/apis/v1/"
 	pluginServicesBaseRelativePath = servicesBaseRelativePath + "plugins"
+	debugServicesBaseRelativePath  = servicesBaseRelativePath + "__debug__"
 )
 
 var once sync.Once
@@ -100,6 +101,19 @@ func (e *Engine) RegisterPluginService(plugin fwktype.Plugin, profileName string
 	}
 }
 
+// RegisterDebugService registers the endpoints of the debug service provider under /apis/v1/__debug__/<name>.
+func (e *Engine) RegisterDebugService(name string, provider APIServiceProvider) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	key := "__debug__/" + name
+	if _, exists := e.registeredProviders[key]; exists {
+		return
+	}
+	provider.RegisterEndpoints(e.Engine.Group(debugServicesBaseRelativePath).Group(name))
+	e.registeredProviders[key] = struct{}{}
+	klog.V(4).InfoS("debug service provider successfully registered", "provider", name)
+}
+
 func listRegisteredServices(e *gin.Engine) gin.HandlerFunc {
 	return func(context *gin.Context) {
 		routes := e.Routes()
@@ -73,6 +73,7 @@ package workloadauditor
 //   (scheduled / deleted / gangScheduled / gangDeleted).
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -122,7 +123,7 @@ func handlePreemptNominated(config *WorkloadAuditorConfig, wr *WorkloadRecord, n
 	nomCount := wr.recordTypeCounts[RecordTypePreemptNominated]
 	if nomCount > 1 {
 		RepeatedPreemptionTotal.WithLabelValues(wr.labelValues...).Inc()
-		klog.Infof("workloadauditor: ALERT: repeated preemption for workload %s %s, count=%d, trigger=%s, message=%q",
+		alert(wr, AnomalyRepeatedPreemption, "repeated preemption for workload %s %s, count=%d, trigger=%s, message=%q",
 			wr.WorkloadKey, wr.labelDetail, nomCount, triggerType, triggerMsg)
 	}
 
@@ -130,7 +131,7 @@ func handlePreemptNominated(config *WorkloadAuditorConfig, wr *WorkloadRecord, n
 	if nomCount >= 2 && !wr.lastPreemptNominatedInvalidated {
 		wr.lastPreemptNominatedInvalidated = true
 		PreemptionInvalidationsTotal.WithLabelValues(wr.labelValues...).Inc()
-		klog.Infof("workloadauditor: ALERT: preemption invalidated by new preemption for workload %s %s, trigger=%s, message=%q",
+		alert(wr, AnomalyPreemptionInvalidated, "preemption invalidated by new preemption for workload %s %s, trigger=%s, message=%q",
 			wr.WorkloadKey, wr.labelDetail, triggerType, triggerMsg)
 	}
 
@@ -151,7 +152,7 @@ func handlePreemptionInvalidation(wr *WorkloadRecord, recordType RecordType, mes
 	}
 	wr.lastPreemptNominatedInvalidated = true
 	PreemptionInvalidationsTotal.WithLabelValues(wr.labelValues...).Inc()
-	klog.Infof("workloadauditor: ALERT: preemption invalidated by %s for workload %s %s, message=%q",
+	alert(wr, AnomalyPreemptionInvalidated, "preemption invalidated by %s for workload %s %s, message=%q",
 		recordType, wr.WorkloadKey, wr.labelDetail, message)
 }
 
@@ -164,7 +165,7 @@ func handleVictimRescheduleCheck(config *WorkloadAuditorConfig, wr *WorkloadReco
 	duration := now.Sub(*wr.lastVictimAllDeletedTime)
 	VictimRescheduleDurationSeconds.WithLabelValues(wr.labelValues...).Observe(duration.Seconds())
 	if duration > config.VictimRescheduleDuration {
-		klog.Infof("workloadauditor: ALERT: slow reschedule after victim deletion for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
+		alert(wr, AnomalySlowVictimReschedule, "slow reschedule after victim deletion for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
 			wr.WorkloadKey, wr.labelDetail, duration, config.VictimRescheduleDuration, triggerType, triggerMsg)
 	}
 	wr.lastVictimAllDeletedTime = nil
@@ -208,17 +209,17 @@ func finalizePreemptionCycle(config *WorkloadAuditorConfig, wr *WorkloadRecord,
 	PreemptionVictimDeletingRetries.WithLabelValues(wr.labelValues...).Observe(float64(retries))
 
 	if retries > config.VictimDeletingRetries {
-		klog.Infof("workloadauditor: ALERT: excessive victim deleting retries for workload %s %s, retries=%d (threshold %d), trigger=%s, message=%q",
+		alert(wr, AnomalyExcessiveVictimDeletingRetries, "excessive victim deleting retries for workload %s %s, retries=%d (threshold %d), trigger=%s, message=%q",
 			wr.WorkloadKey, wr.labelDetail, retries, config.VictimDeletingRetries, triggerType, triggerMsg)
 	}
 	if victimDeleted {
 		if duration > config.VictimDeletionDuration {
-			klog.Infof("workloadauditor: ALERT: slow victim deletion for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
+			alert(wr, AnomalySlowVictimDeletion, "slow victim deletion for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
 				wr.WorkloadKey, wr.labelDetail, duration, config.VictimDeletionDuration, triggerType, triggerMsg)
 		}
 	} else {
 		if duration > config.VictimDeletionDuration {
-			klog.Infof("workloadauditor: ALERT: preemption cycle interrupted (victim not fully deleted) for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
+			alert(wr, AnomalyPreemptionCycleInterrupted, "preemption cycle interrupted (victim not fully deleted) for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
 				wr.WorkloadKey, wr.labelDetail, duration, config.VictimDeletionDuration, triggerType, triggerMsg)
 		}
 	}
@@ -259,13 +260,20 @@ func trackSchedulingEventInterval(config *WorkloadAuditorConfig, wr *WorkloadRec
 		duration := now.Sub(*wr.lastSchedulingEventTime)
 		SchedulingEventIntervalSeconds.WithLabelValues(wr.labelValues...).Observe(duration.Seconds())
 		if duration > config.SchedulingEventInterval {
-			klog.Infof("workloadauditor: ALERT: long scheduling event interval for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
+			alert(wr, AnomalyLongSchedulingEventInterval, "long scheduling event interval for workload %s %s, duration=%v (threshold %v), trigger=%s, message=%q",
 				wr.WorkloadKey, wr.labelDetail, duration, config.SchedulingEventInterval, recordType, message)
 		}
 	}
 	wr.lastSchedulingEventTime = &now
 }
 
+// alert emits an ALERT log for the workload and keeps the anomaly in its timeline.
+func alert(wr *WorkloadRecord, anomalyType AnomalyType, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	klog.Infof("workloadauditor: ALERT: %s", message)
+	wr.addAnomaly(anomalyType, message, time.Now())
+}
+
 // finalizeWorkloadRecord is called just before a workload record is removed from
 // the map. It flushes any remaining preemption cycle and observes event-count histograms.
 func finalizeWorkloadRecord(wr *WorkloadRecord, outcome string) {
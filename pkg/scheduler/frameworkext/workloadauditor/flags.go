@@ -33,6 +33,17 @@ var (
 	VictimDeletionDuration   = 30 * time.Second
 	VictimDeletingRetries    = 3
 	SchedulingEventInterval  = 5 * time.Minute
+
+	// Record retention and persistence
+	WorkloadAuditorPersistRecords           = false
+	WorkloadAuditorPersistMinAttempts       = 2
+	WorkloadAuditorPersistBackend           = PersistBackendConfigMap
+	WorkloadAuditorPersistNamespace         = "koordinator-system"
+	WorkloadAuditorPersistedRecordRetention = 7 * 24 * time.Hour
+	WorkloadAuditorMaxPersistedRecords      = 500
+	WorkloadAuditorRecordRetention          = time.Hour
+	WorkloadAuditorMaxRetainedRecords       = 1000
+	WorkloadAuditorMaxTimelineEntries       = 50
 )
 
 // AddFlags registers the workloadAuditorImpl command-line flags.
@@ -43,6 +54,15 @@ func AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&VictimDeletionDuration, "workload-auditor-victim-deletion-duration", VictimDeletionDuration, "max time from preemptNominated to victimAllDeleted before ALERT")
 	fs.IntVar(&VictimDeletingRetries, "workload-auditor-victim-deleting-retries", VictimDeletingRetries, "max preemptVictimDeleting count per preemption cycle before ALERT")
 	fs.DurationVar(&SchedulingEventInterval, "workload-auditor-scheduling-event-interval", SchedulingEventInterval, "max time between consecutive scheduling events within a dequeue round before ALERT")
+	fs.BoolVar(&WorkloadAuditorPersistRecords, "workload-auditor-persist-records", WorkloadAuditorPersistRecords, "persist finalized workload records into the backend set by --workload-auditor-persist-backend")
+	fs.IntVar(&WorkloadAuditorPersistMinAttempts, "workload-auditor-persist-min-attempts", WorkloadAuditorPersistMinAttempts, "min scheduling attempts of a finalized workload record to be persisted, records with anomalies are always persisted")
+	fs.StringVar(&WorkloadAuditorPersistBackend, "workload-auditor-persist-backend", WorkloadAuditorPersistBackend, "backend of the persisted workload records, ConfigMap or Event. Event records expire with the event TTL of the kube-apiserver (1h by default)")
+	fs.StringVar(&WorkloadAuditorPersistNamespace, "workload-auditor-persist-namespace", WorkloadAuditorPersistNamespace, "namespace of the ConfigMap persisting the workload records")
+	fs.DurationVar(&WorkloadAuditorPersistedRecordRetention, "workload-auditor-persisted-record-retention", WorkloadAuditorPersistedRecordRetention, "how long the workload records persisted into the ConfigMap are kept, keep forever if set to 0")
+	fs.IntVar(&WorkloadAuditorMaxPersistedRecords, "workload-auditor-max-persisted-records", WorkloadAuditorMaxPersistedRecords, "max number of workload records persisted into the ConfigMap, the earliest ones are dropped first")
+	fs.DurationVar(&WorkloadAuditorRecordRetention, "workload-auditor-record-retention", WorkloadAuditorRecordRetention, "how long finalized workload records are retained in memory for querying, disable if set to 0")
+	fs.IntVar(&WorkloadAuditorMaxRetainedRecords, "workload-auditor-max-retained-records", WorkloadAuditorMaxRetainedRecords, "max number of finalized workload records retained in memory for querying")
+	fs.IntVar(&WorkloadAuditorMaxTimelineEntries, "workload-auditor-max-timeline-entries", WorkloadAuditorMaxTimelineEntries, "max number of compacted timeline entries kept per workload record")
 }
 
 type WorkloadAuditorConfig struct {
@@ -53,6 +73,15 @@ type WorkloadAuditorConfig struct {
 	SchedulingEventInterval  time.Duration
 	MetricLabelNames         []string // ["priority", "gpu", "quota_name", ...]
 	PodLabelKeys             []string // parallel to MetricLabelNames[1:]
+	PersistRecords           bool
+	PersistMinAttempts       int
+	PersistBackend           string
+	PersistNamespace         string
+	PersistedRecordRetention time.Duration
+	MaxPersistedRecords      int
+	RecordRetention          time.Duration
+	MaxRetainedRecords       int
+	MaxTimelineEntries       int
 }
 
 // parseMetricLabels parses the comma-separated metricLabel=podLabelKey pairs.
@@ -92,5 +121,14 @@ func DefaultWorkloadAuditorConfig() WorkloadAuditorConfig {
 		SchedulingEventInterval:  SchedulingEventInterval,
 		MetricLabelNames:         names,
 		PodLabelKeys:             podKeys,
+		PersistRecords:           WorkloadAuditorPersistRecords,
+		PersistMinAttempts:       WorkloadAuditorPersistMinAttempts,
+		PersistBackend:           WorkloadAuditorPersistBackend,
+		PersistNamespace:         WorkloadAuditorPersistNamespace,
+		PersistedRecordRetention: WorkloadAuditorPersistedRecordRetention,
+		MaxPersistedRecords:      WorkloadAuditorMaxPersistedRecords,
+		RecordRetention:          WorkloadAuditorRecordRetention,
+		MaxRetainedRecords:       WorkloadAuditorMaxRetainedRecords,
+		MaxTimelineEntries:       WorkloadAuditorMaxTimelineEntries,
 	}
 }
@@ -0,0 +1,506 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadauditor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+)
+
+const (
+	// EventReasonWorkloadScheduleAudit is the reason of the Events persisting the finalized workload records.
+	EventReasonWorkloadScheduleAudit = "WorkloadScheduleAudit"
+	eventActionFinalize              = "Finalize"
+	// eventNoteLengthLimit is the max length of the note of events.k8s.io/v1 Events.
+	eventNoteLengthLimit = 1024
+
+	// PersistBackendEvent persists the records as Events, which expire with the event TTL of the kube-apiserver.
+	PersistBackendEvent = "Event"
+	// PersistBackendConfigMap persists the records into a ConfigMap with the configured retention.
+	PersistBackendConfigMap = "ConfigMap"
+	// RecordConfigMapName is the name of the ConfigMap persisting the finalized workload records.
+	RecordConfigMapName = "koord-scheduler-workload-records"
+	// recordFlushInterval is the interval of flushing the pending records into the ConfigMap.
+	recordFlushInterval = 5 * time.Second
+	// maxRecordDataKeyPrefixLength keeps the data keys within the 253 characters limit, leaving the room
+	// for the separator and the 19 digits of the finalize time.
+	maxRecordDataKeyPrefixLength = 253 - 1 - 19
+)
+
+// RecordPersister persists the finalized workload records so that they survive the leader changes.
+type RecordPersister interface {
+	Persist(summary *WorkloadRecordSummary)
+	// List returns the persisted records regarding the object with the namespace and name.
+	List(ctx context.Context, namespace, name string) ([]*WorkloadRecordSummary, error)
+}
+
+// NewRecordPersister builds the RecordPersister of the configured backend.
+func NewRecordPersister(config WorkloadAuditorConfig, recorder events.EventRecorder, client kubernetes.Interface, stopCh <-chan struct{}) RecordPersister {
+	if config.PersistBackend == PersistBackendEvent {
+		return NewEventRecordPersister(recorder, client)
+	}
+	persister := NewConfigMapRecordPersister(client, config.PersistNamespace, config.PersistedRecordRetention, config.MaxPersistedRecords)
+	if config.Enabled && config.PersistRecords {
+		go wait.Until(persister.flush, recordFlushInterval, stopCh)
+	}
+	return persister
+}
+
+// eventRecordPersister persists the records as compacted Events regarding the pod of the workload.
+// The retention of the persisted records follows the event TTL of the kube-apiserver (--event-ttl,
+// 1h by default), which is not controlled by the scheduler. Use the ConfigMap backend if the records
+// are expected to be kept longer.
+type eventRecordPersister struct {
+	recorder events.EventRecorder
+	client   kubernetes.Interface
+}
+
+func NewEventRecordPersister(recorder events.EventRecorder, client kubernetes.Interface) RecordPersister {
+	return &eventRecordPersister{
+		recorder: recorder,
+		client:   client,
+	}
+}
+
+func (p *eventRecordPersister) Persist(summary *WorkloadRecordSummary) {
+	if summary.Regarding == nil {
+		return
+	}
+	note, err := compactSummary(summary, eventNoteLengthLimit)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compact workload record", "workloadKey", summary.WorkloadKey)
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if len(summary.Anomalies) > 0 {
+		eventType = corev1.EventTypeWarning
+	}
+	p.recorder.Eventf(summary.Regarding, nil, eventType, EventReasonWorkloadScheduleAudit, eventActionFinalize, "%s", note)
+}
+
+func (p *eventRecordPersister) List(ctx context.Context, namespace, name string) ([]*WorkloadRecordSummary, error) {
+	if p.client == nil {
+		return nil, nil
+	}
+	selector := fields.Set{
+		"reason":         EventReasonWorkloadScheduleAudit,
+		"regarding.name": name,
+	}.AsSelector().String()
+	eventList, err := p.client.EventsV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	var summaries []*WorkloadRecordSummary
+	for i := range eventList.Items {
+		summary := &WorkloadRecordSummary{}
+		if err := json.Unmarshal([]byte(eventList.Items[i].Note), summary); err != nil {
+			klog.V(5).InfoS("Failed to decode persisted workload record", "event", klog.KObj(&eventList.Items[i]), "err", err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// configMapRecordPersister persists the compacted records into a single ConfigMap, keyed by the
+// workload key and the finalize time. The records are kept for the retention and at most maxRecords
+// of them are kept, so that the ConfigMap stays far below the size limit of the objects.
+type configMapRecordPersister struct {
+	client     kubernetes.Interface
+	namespace  string
+	retention  time.Duration
+	maxRecords int
+
+	lock    sync.Mutex
+	pending map[string]string
+}
+
+func NewConfigMapRecordPersister(client kubernetes.Interface, namespace string, retention time.Duration, maxRecords int) *configMapRecordPersister {
+	return &configMapRecordPersister{
+		client:     client,
+		namespace:  namespace,
+		retention:  retention,
+		maxRecords: maxRecords,
+		pending:    map[string]string{},
+	}
+}
+
+// Persist queues the record, the queued records are written by flush asynchronously.
+func (p *configMapRecordPersister) Persist(summary *WorkloadRecordSummary) {
+	note, err := compactSummary(summary, eventNoteLengthLimit)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compact workload record", "workloadKey", summary.WorkloadKey)
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pending[recordDataKey(summary.WorkloadKey, summary.FinalizeTime)] = note
+}
+
+func (p *configMapRecordPersister) flush() {
+	p.lock.Lock()
+	pending := p.pending
+	p.pending = map[string]string{}
+	p.lock.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return p.write(context.TODO(), pending, time.Now())
+	})
+	if err == nil {
+		return
+	}
+	// only the conflicting and the racing creation are retried in the next flush, the other errors, e.g. the
+	// invalid records, are not expected to be resolved by retrying, so the records are dropped.
+	if !errors.IsConflict(err) && !errors.IsNotFound(err) && !errors.IsAlreadyExists(err) {
+		klog.ErrorS(err, "Failed to persist workload records, drop them", "configMap", klog.KRef(p.namespace, RecordConfigMapName), "records", len(pending))
+		return
+	}
+	klog.ErrorS(err, "Failed to persist workload records, retry later", "configMap", klog.KRef(p.namespace, RecordConfigMapName), "records", len(pending))
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for key, note := range pending {
+		if _, ok := p.pending[key]; !ok {
+			p.pending[key] = note
+		}
+	}
+	// the requeued records are capped as the persisted ones
+	p.pending = p.gcRecords(p.pending, time.Now())
+}
+
+func (p *configMapRecordPersister) write(ctx context.Context, pending map[string]string, now time.Time) error {
+	configMap, err := p.client.CoreV1().ConfigMaps(p.namespace).Get(ctx, RecordConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: p.namespace, Name: RecordConfigMapName},
+			Data:       p.gcRecords(pending, now),
+		}
+		_, err = p.client.CoreV1().ConfigMaps(p.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	data := make(map[string]string, len(configMap.Data)+len(pending))
+	for key, note := range configMap.Data {
+		data[key] = note
+	}
+	for key, note := range pending {
+		data[key] = note
+	}
+	configMap = configMap.DeepCopy()
+	configMap.Data = p.gcRecords(data, now)
+	_, err = p.client.CoreV1().ConfigMaps(p.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// gcRecords drops the records out of the retention and the earliest ones exceeding maxRecords.
+func (p *configMapRecordPersister) gcRecords(data map[string]string, now time.Time) map[string]string {
+	type record struct {
+		key          string
+		finalizeTime int64
+	}
+	records := make([]record, 0, len(data))
+	for key := range data {
+		finalizeTime, ok := parseRecordFinalizeTime(key)
+		if !ok || (p.retention > 0 && now.Sub(time.Unix(0, finalizeTime)) > p.retention) {
+			continue
+		}
+		records = append(records, record{key: key, finalizeTime: finalizeTime})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].finalizeTime > records[j].finalizeTime
+	})
+	if p.maxRecords > 0 && len(records) > p.maxRecords {
+		records = records[:p.maxRecords]
+	}
+	result := make(map[string]string, len(records))
+	for _, r := range records {
+		result[r.key] = data[r.key]
+	}
+	return result
+}
+
+func (p *configMapRecordPersister) List(ctx context.Context, namespace, name string) ([]*WorkloadRecordSummary, error) {
+	configMap, err := p.client.CoreV1().ConfigMaps(p.namespace).Get(ctx, RecordConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := recordDataKeyPrefix(namespace + "/" + name)
+	var summaries []*WorkloadRecordSummary
+	for key, note := range configMap.Data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		summary := &WorkloadRecordSummary{}
+		if err := json.Unmarshal([]byte(note), summary); err != nil {
+			klog.V(5).InfoS("Failed to decode persisted workload record", "key", key, "err", err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].FinalizeTime.After(summaries[j].FinalizeTime)
+	})
+	return summaries, nil
+}
+
+// recordDataKey returns the ConfigMap data key of the record, e.g. default_pod-1_uid-1_1700000000000000000.
+// The characters of the workload key not allowed in the keys are replaced, e.g. the slashes and the commas
+// of the gang group keys, and the overlong workload keys are truncated with a hash suffix.
+func recordDataKey(workloadKey string, finalizeTime time.Time) string {
+	return recordDataKeyPrefix(workloadKey) + strconv.FormatInt(finalizeTime.UnixNano(), 10)
+}
+
+func recordDataKeyPrefix(workloadKey string) string {
+	escaped := []byte(workloadKey)
+	for i, c := range escaped {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.') {
+			escaped[i] = '_'
+		}
+	}
+	if len(escaped) > maxRecordDataKeyPrefixLength {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(workloadKey))
+		escaped = append(escaped[:maxRecordDataKeyPrefixLength-17], fmt.Sprintf("-%016x", hash.Sum64())...)
+	}
+	return string(escaped) + "_"
+}
+
+func parseRecordFinalizeTime(key string) (int64, bool) {
+	idx := strings.LastIndex(key, "_")
+	if idx < 0 {
+		return 0, false
+	}
+	finalizeTime, err := strconv.ParseInt(key[idx+1:], 10, 64)
+	return finalizeTime, err == nil
+}
+
+// compactSummary encodes the summary into at most limit bytes by dropping the messages and the
+// earliest timeline entries when necessary.
+func compactSummary(summary *WorkloadRecordSummary, limit int) (string, error) {
+	data, err := json.Marshal(summary)
+	if err != nil || len(data) <= limit {
+		return string(data), err
+	}
+	compacted := *summary
+	compacted.Labels = nil
+	compacted.Timeline = dropMessages(summary.Timeline)
+	compacted.Anomalies = dropMessages(summary.Anomalies)
+	for {
+		data, err = json.Marshal(&compacted)
+		if err != nil || len(data) <= limit {
+			return string(data), err
+		}
+		switch {
+		case len(compacted.Timeline) > 0:
+			compacted.Timeline = compacted.Timeline[1:]
+			compacted.TruncatedTimelineEntries++
+		case len(compacted.Anomalies) > 0:
+			compacted.Anomalies = compacted.Anomalies[1:]
+		case compacted.RecordTypeCounts != nil:
+			compacted.RecordTypeCounts = nil
+		default:
+			return "", fmt.Errorf("workload record %s exceeds %d bytes", summary.WorkloadKey, limit)
+		}
+	}
+}
+
+func dropMessages(entries []TimelineEntry) []TimelineEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+	result := make([]TimelineEntry, len(entries))
+	for i := range entries {
+		result[i] = entries[i]
+		result[i].Message = ""
+	}
+	return result
+}
+
+// recordStore retains the finalized records in memory for the query endpoint.
+type recordStore struct {
+	lock       sync.Mutex
+	retention  time.Duration
+	maxRecords int
+	// summaries is ordered by the finalize time.
+	summaries []*WorkloadRecordSummary
+}
+
+func newRecordStore(retention time.Duration, maxRecords int) *recordStore {
+	return &recordStore{
+		retention:  retention,
+		maxRecords: maxRecords,
+	}
+}
+
+func (s *recordStore) add(summary *WorkloadRecordSummary) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.summaries = append(s.summaries, summary)
+	s.gcLocked(summary.FinalizeTime)
+}
+
+func (s *recordStore) gcLocked(now time.Time) {
+	expired := 0
+	for _, summary := range s.summaries {
+		if s.retention <= 0 || now.Sub(summary.FinalizeTime) <= s.retention {
+			break
+		}
+		expired++
+	}
+	if s.maxRecords > 0 && len(s.summaries)-expired > s.maxRecords {
+		expired = len(s.summaries) - s.maxRecords
+	}
+	if expired > 0 {
+		s.summaries = append(s.summaries[:0:0], s.summaries[expired:]...)
+	}
+}
+
+// list returns the retained records of the workload, the latest comes first. The pod workloads are
+// keyed by namespace/name/uid, so the key matches the records of all the pods with the same name.
+func (s *recordStore) list(key string, now time.Time) []*WorkloadRecordSummary {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.gcLocked(now)
+	var result []*WorkloadRecordSummary
+	for i := len(s.summaries) - 1; i >= 0; i-- {
+		workloadKey := s.summaries[i].WorkloadKey
+		if workloadKey == key || strings.HasPrefix(workloadKey, key+"/") {
+			result = append(result, s.summaries[i])
+		}
+	}
+	return result
+}
+
+var _ services.APIServiceProvider = &workloadAuditorImpl{}
+
+// RegisterEndpoints serves the finalized records by GET /records/:namespace/:name. The records
+// retained in memory are returned first, then the persisted ones if a RecordPersister is set.
+func (w *workloadAuditorImpl) RegisterEndpoints(group *gin.RouterGroup) {
+	group.GET("/records/:namespace/:name", func(c *gin.Context) {
+		namespace, name := c.Param("namespace"), c.Param("name")
+		var summaries []*WorkloadRecordSummary
+		if w.store != nil {
+			summaries = w.store.list(namespace+"/"+name, time.Now())
+		}
+		if w.persister != nil && c.Query("persisted") != "false" {
+			persisted, err := w.persister.List(c.Request.Context(), namespace, name)
+			if err != nil {
+				services.ResponseErrorMessage(c, http.StatusInternalServerError, "failed to list persisted records, err: %v", err)
+				return
+			}
+			summaries = mergeSummaries(summaries, persisted)
+		}
+		if len(summaries) == 0 {
+			services.ResponseErrorMessage(c, http.StatusNotFound, "cannot find workload records of %s/%s", namespace, name)
+			return
+		}
+		c.JSON(http.StatusOK, summaries)
+	})
+}
+
+// mergeSummaries appends the persisted records which are not retained, ordered by the finalize time desc.
+func mergeSummaries(retained, persisted []*WorkloadRecordSummary) []*WorkloadRecordSummary {
+	type key struct {
+		workloadKey  string
+		finalizeTime int64
+	}
+	seen := make(map[key]struct{}, len(retained))
+	for _, summary := range retained {
+		seen[key{summary.WorkloadKey, summary.FinalizeTime.Unix()}] = struct{}{}
+	}
+	result := retained
+	for _, summary := range persisted {
+		if _, ok := seen[key{summary.WorkloadKey, summary.FinalizeTime.Unix()}]; ok {
+			continue
+		}
+		result = append(result, summary)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FinalizeTime.After(result[j].FinalizeTime)
+	})
+	return result
+}
+
+// summarizeWorkloadRecord builds the WorkloadRecordSummary from the record. The caller must hold the record lock.
+func (w *workloadAuditorImpl) summarizeWorkloadRecord(wr *WorkloadRecord, outcome string, now time.Time) *WorkloadRecordSummary {
+	summary := &WorkloadRecordSummary{
+		WorkloadKey:              wr.WorkloadKey,
+		Regarding:                wr.regarding,
+		Outcome:                  outcome,
+		Attempts:                 wr.Attempts,
+		GangMinMember:            wr.gangMinMember,
+		CreateTime:               wr.createTime,
+		FinalizeTime:             now,
+		RecordTypeCounts:         make(map[RecordType]int, len(wr.recordTypeCounts)),
+		Timeline:                 append([]TimelineEntry(nil), wr.timeline...),
+		TruncatedTimelineEntries: wr.truncatedTimeline,
+		Anomalies:                append([]TimelineEntry(nil), wr.anomalies...),
+	}
+	for recordType, count := range wr.recordTypeCounts {
+		summary.RecordTypeCounts[recordType] = count
+	}
+	if wr.labelsExtracted {
+		summary.Labels = make(map[string]string, len(w.Config.MetricLabelNames))
+		for i, name := range w.Config.MetricLabelNames {
+			if i < len(wr.labelValues) && wr.labelValues[i] != "" {
+				summary.Labels[name] = wr.labelValues[i]
+			}
+		}
+	}
+	return summary
+}
+
+// persistWorkloadRecord retains and persists the finalized record. The caller must hold the record lock.
+func (w *workloadAuditorImpl) persistWorkloadRecord(wr *WorkloadRecord, outcome string) {
+	if w.store == nil && w.persister == nil {
+		return
+	}
+	summary := w.summarizeWorkloadRecord(wr, outcome, time.Now())
+	if w.store != nil {
+		w.store.add(summary)
+	}
+	if w.persister != nil && (len(summary.Anomalies) > 0 || summary.Attempts >= w.Config.PersistMinAttempts) {
+		w.persister.Persist(summary)
+	}
+}
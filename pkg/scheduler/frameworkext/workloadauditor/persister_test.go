@@ -0,0 +1,282 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadauditor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type fakeEventRecorder struct {
+	regarding []runtime.Object
+	eventType []string
+	notes     []string
+}
+
+func (f *fakeEventRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	f.regarding = append(f.regarding, regarding)
+	f.eventType = append(f.eventType, eventtype)
+	f.notes = append(f.notes, fmt.Sprintf(note, args...))
+}
+
+type fakeRecordPersister struct {
+	persisted []*WorkloadRecordSummary
+}
+
+func (f *fakeRecordPersister) Persist(summary *WorkloadRecordSummary) {
+	f.persisted = append(f.persisted, summary)
+}
+
+func (f *fakeRecordPersister) List(ctx context.Context, namespace, name string) ([]*WorkloadRecordSummary, error) {
+	return f.persisted, nil
+}
+
+func TestWorkloadRecordTimeline(t *testing.T) {
+	wr := &WorkloadRecord{maxTimelineEntries: 3}
+	now := time.Now()
+	wr.addTimeline(RecordTypeCreate, "", now)
+	wr.addTimeline(RecordTypeScheduleFailure, "failure 1", now.Add(time.Second))
+	wr.addTimeline(RecordTypeScheduleFailure, "failure 2", now.Add(2*time.Second))
+	assert.Len(t, wr.timeline, 2)
+	assert.Equal(t, TimelineEntry{
+		Type:      string(RecordTypeScheduleFailure),
+		FirstTime: now.Add(time.Second),
+		LastTime:  now.Add(2 * time.Second),
+		Count:     2,
+		Message:   "failure 2",
+	}, wr.timeline[1])
+
+	wr.addTimeline(RecordTypePreemptNominated, "", now.Add(3*time.Second))
+	wr.addTimeline(RecordTypeScheduled, "", now.Add(4*time.Second))
+	assert.Len(t, wr.timeline, 3)
+	assert.Equal(t, 1, wr.truncatedTimeline)
+	assert.Equal(t, string(RecordTypeScheduleFailure), wr.timeline[0].Type)
+}
+
+func TestPersistWorkloadRecord(t *testing.T) {
+	w := newTestAuditor()
+	w.Config.PersistMinAttempts = 2
+	w.Config.MaxTimelineEntries = 10
+	w.store = newRecordStore(time.Hour, 10)
+	persister := &fakeRecordPersister{}
+	w.persister = persister
+
+	pod := newPod("default", "pod-1", "uid-1")
+	w.AddPod(pod)
+	w.RecordAttemptPod(pod)
+	w.RecordPodScheduleResult(pod, RecordTypeScheduled, "node-1")
+	// scheduled at the first attempt is only retained
+	assert.Empty(t, persister.persisted)
+
+	pod2 := newPod("default", "pod-2", "uid-2")
+	w.AddPod(pod2)
+	for i := 0; i < 2; i++ {
+		w.RecordAttemptPod(pod2)
+		w.RecordPodScheduleResult(pod2, RecordTypeScheduleFailure, "insufficient cpu")
+	}
+	w.DeletePod(pod2)
+	assert.Len(t, persister.persisted, 1)
+	summary := persister.persisted[0]
+	assert.Equal(t, "default/pod-2/uid-2", summary.WorkloadKey)
+	assert.Equal(t, outcomeDeleted, summary.Outcome)
+	assert.Equal(t, 2, summary.Attempts)
+	assert.Equal(t, "pod-2", summary.Regarding.Name)
+	assert.Len(t, summary.Timeline, 2)
+	assert.Equal(t, 2, summary.Timeline[1].Count)
+	assert.Equal(t, 2, summary.RecordTypeCounts[RecordTypeScheduleFailure])
+
+	retained := w.store.list("default/pod-1", time.Now())
+	assert.Len(t, retained, 1)
+	assert.Equal(t, outcomeScheduled, retained[0].Outcome)
+	assert.Empty(t, w.store.list("default/pod", time.Now()))
+}
+
+func TestRecordStoreRetention(t *testing.T) {
+	store := newRecordStore(time.Minute, 2)
+	now := time.Now()
+	store.add(&WorkloadRecordSummary{WorkloadKey: "default/a", FinalizeTime: now.Add(-2 * time.Minute)})
+	store.add(&WorkloadRecordSummary{WorkloadKey: "default/b", FinalizeTime: now})
+	assert.Empty(t, store.list("default/a", now))
+	store.add(&WorkloadRecordSummary{WorkloadKey: "default/c", FinalizeTime: now})
+	store.add(&WorkloadRecordSummary{WorkloadKey: "default/d", FinalizeTime: now})
+	assert.Empty(t, store.list("default/b", now))
+	assert.Len(t, store.list("default/c", now), 1)
+	assert.Len(t, store.list("default/d", now), 1)
+}
+
+func TestCompactSummary(t *testing.T) {
+	now := time.Now()
+	summary := &WorkloadRecordSummary{
+		WorkloadKey: "default/gang-a",
+		Outcome:     outcomeGangScheduled,
+		Attempts:    100,
+		Labels:      map[string]string{"priority": "koord-prod"},
+	}
+	for i := 0; i < 30; i++ {
+		summary.Timeline = append(summary.Timeline, TimelineEntry{
+			Type:      string(RecordTypeScheduleFailure),
+			FirstTime: now,
+			Count:     1,
+			Message:   strings.Repeat("x", 100),
+		})
+	}
+	note, err := compactSummary(summary, eventNoteLengthLimit)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(note), eventNoteLengthLimit)
+	decoded := &WorkloadRecordSummary{}
+	assert.NoError(t, json.Unmarshal([]byte(note), decoded))
+	assert.Equal(t, summary.WorkloadKey, decoded.WorkloadKey)
+	assert.Equal(t, 100, decoded.Attempts)
+	assert.Equal(t, len(summary.Timeline), len(decoded.Timeline)+decoded.TruncatedTimelineEntries)
+	assert.Empty(t, decoded.Timeline[0].Message)
+	assert.Len(t, summary.Timeline[0].Message, 100, "the original summary must not be modified")
+}
+
+func TestEventRecordPersister(t *testing.T) {
+	recorder := &fakeEventRecorder{}
+	client := fake.NewSimpleClientset()
+	persister := NewEventRecordPersister(recorder, client)
+	summary := &WorkloadRecordSummary{
+		WorkloadKey: "default/pod-1/uid-1",
+		Regarding:   &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "pod-1"},
+		Outcome:     outcomeScheduled,
+		Attempts:    3,
+		Anomalies:   []TimelineEntry{{Type: string(AnomalyRepeatedPreemption), Count: 1}},
+	}
+	persister.Persist(summary)
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/gang-a"})
+	assert.Len(t, recorder.notes, 1)
+	assert.Equal(t, corev1.EventTypeWarning, recorder.eventType[0])
+
+	_, err := client.EventsV1().Events("default").Create(context.TODO(), &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1.audit"},
+		Reason:     EventReasonWorkloadScheduleAudit,
+		Regarding:  *summary.Regarding,
+		Note:       recorder.notes[0],
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	summaries, err := persister.List(context.TODO(), "default", "pod-1")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, summary.WorkloadKey, summaries[0].WorkloadKey)
+	assert.Equal(t, 3, summaries[0].Attempts)
+}
+
+func TestConfigMapRecordPersister(t *testing.T) {
+	now := time.Now()
+	client := fake.NewSimpleClientset()
+	persister := NewConfigMapRecordPersister(client, "koordinator-system", 24*time.Hour, 2)
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/pod-1/uid-1", Attempts: 2, FinalizeTime: now.Add(-48 * time.Hour)})
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/pod-1/uid-2", Attempts: 3, FinalizeTime: now.Add(-time.Hour)})
+	persister.flush()
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/pod-10/uid-3", Attempts: 4, FinalizeTime: now.Add(-time.Minute)})
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/pod-1/uid-4", Attempts: 5, FinalizeTime: now})
+	persister.flush()
+
+	configMap, err := client.CoreV1().ConfigMaps("koordinator-system").Get(context.TODO(), RecordConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, configMap.Data, 2, "the expired and the earliest records exceeding the max records must be dropped")
+	assert.Empty(t, persister.pending)
+
+	summaries, err := persister.List(context.TODO(), "default", "pod-1")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "default/pod-1/uid-4", summaries[0].WorkloadKey)
+	assert.Equal(t, 5, summaries[0].Attempts)
+	summaries, err = persister.List(context.TODO(), "default", "pod-10")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	summaries, err = persister.List(context.TODO(), "default", "pod-2")
+	assert.NoError(t, err)
+	assert.Empty(t, summaries)
+}
+
+func TestConfigMapRecordPersisterGangGroupKey(t *testing.T) {
+	now := time.Now()
+	client := fake.NewSimpleClientset()
+	persister := NewConfigMapRecordPersister(client, "koordinator-system", 24*time.Hour, 10)
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/gang-a,default/gang-b", Attempts: 2, FinalizeTime: now})
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/" + strings.Repeat("gang-c", 60), Attempts: 2, FinalizeTime: now})
+	persister.flush()
+	assert.Empty(t, persister.pending)
+
+	configMap, err := client.CoreV1().ConfigMaps("koordinator-system").Get(context.TODO(), RecordConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, configMap.Data, 2)
+	for key := range configMap.Data {
+		assert.Empty(t, validation.IsConfigMapKey(key), key)
+	}
+	summaries, err := persister.List(context.TODO(), "default", "gang-a")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "default/gang-a,default/gang-b", summaries[0].WorkloadKey)
+}
+
+func TestConfigMapRecordPersisterDropInvalidRecords(t *testing.T) {
+	now := time.Now()
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("ConfigMap").GroupKind(), RecordConfigMapName, nil)
+	})
+	persister := NewConfigMapRecordPersister(client, "koordinator-system", 24*time.Hour, 10)
+	persister.Persist(&WorkloadRecordSummary{WorkloadKey: "default/pod-1/uid-1", Attempts: 2, FinalizeTime: now})
+	persister.flush()
+	assert.Empty(t, persister.pending, "the records failed to be validated must not be requeued")
+}
+
+func TestWorkloadRecordsEndpoint(t *testing.T) {
+	now := time.Now()
+	w := newTestAuditor()
+	w.store = newRecordStore(time.Hour, 10)
+	w.store.add(&WorkloadRecordSummary{WorkloadKey: "default/pod-1/uid-2", FinalizeTime: now})
+	w.persister = &fakeRecordPersister{persisted: []*WorkloadRecordSummary{
+		{WorkloadKey: "default/pod-1/uid-1", FinalizeTime: now.Add(-time.Hour)},
+		{WorkloadKey: "default/pod-1/uid-2", FinalizeTime: now},
+	}}
+	engine := gin.New()
+	w.RegisterEndpoints(engine.Group("/"))
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/records/default/pod-1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var got []*WorkloadRecordSummary
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Len(t, got, 2)
+	assert.Equal(t, "default/pod-1/uid-2", got[0].WorkloadKey)
+	assert.Equal(t, "default/pod-1/uid-1", got[1].WorkloadKey)
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/records/default/pod-2?persisted=false", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
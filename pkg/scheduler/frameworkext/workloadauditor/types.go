@@ -42,6 +42,46 @@ const (
 	RecordTypeGangMinMemberSatisfied RecordType = "gangMinMemberSatisfied"
 )
 
+type AnomalyType string
+
+const (
+	AnomalyRepeatedPreemption             AnomalyType = "repeatedPreemption"
+	AnomalyPreemptionInvalidated          AnomalyType = "preemptionInvalidated"
+	AnomalySlowVictimReschedule           AnomalyType = "slowVictimReschedule"
+	AnomalyExcessiveVictimDeletingRetries AnomalyType = "excessiveVictimDeletingRetries"
+	AnomalySlowVictimDeletion             AnomalyType = "slowVictimDeletion"
+	AnomalyPreemptionCycleInterrupted     AnomalyType = "preemptionCycleInterrupted"
+	AnomalyLongSchedulingEventInterval    AnomalyType = "longSchedulingEventInterval"
+)
+
+// TimelineEntry is a compacted timeline item of a workload. Consecutive records of the same type
+// are merged into one entry.
+type TimelineEntry struct {
+	Type      string    `json:"type"`
+	FirstTime time.Time `json:"firstTime"`
+	LastTime  time.Time `json:"lastTime,omitempty"`
+	Count     int       `json:"count,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// WorkloadRecordSummary is the finalized WorkloadRecord which can be persisted and queried after
+// the workload left the scheduler.
+type WorkloadRecordSummary struct {
+	WorkloadKey      string                  `json:"workloadKey"`
+	Regarding        *corev1.ObjectReference `json:"regarding,omitempty"`
+	Outcome          string                  `json:"outcome"`
+	Attempts         int                     `json:"attempts"`
+	GangMinMember    int                     `json:"gangMinMember,omitempty"`
+	Labels           map[string]string       `json:"labels,omitempty"`
+	CreateTime       time.Time               `json:"createTime"`
+	FinalizeTime     time.Time               `json:"finalizeTime"`
+	RecordTypeCounts map[RecordType]int      `json:"recordTypeCounts,omitempty"`
+	Timeline         []TimelineEntry         `json:"timeline,omitempty"`
+	// TruncatedTimelineEntries is the number of the earliest timeline entries dropped due to the limit.
+	TruncatedTimelineEntries int             `json:"truncatedTimelineEntries,omitempty"`
+	Anomalies                []TimelineEntry `json:"anomalies,omitempty"`
+}
+
 // WorkloadRecord tracks the full lifecycle of a workload in the scheduler.
 type WorkloadRecord struct {
 	mu          sync.Mutex // protects all mutable fields below
@@ -78,6 +118,48 @@ type WorkloadRecord struct {
 	labelValues []string
 	// labelDetail is a pre-formatted compact string of labels for ALERT logs.
 	labelDetail string
+
+	// createTime, regarding, timeline and anomalies are kept for the WorkloadRecordSummary.
+	createTime         time.Time
+	regarding          *corev1.ObjectReference
+	timeline           []TimelineEntry
+	truncatedTimeline  int
+	maxTimelineEntries int
+	anomalies          []TimelineEntry
+}
+
+// addTimeline appends the record into the compacted timeline.
+func (wr *WorkloadRecord) addTimeline(recordType RecordType, message string, now time.Time) {
+	if n := len(wr.timeline); n > 0 && wr.timeline[n-1].Type == string(recordType) {
+		last := &wr.timeline[n-1]
+		last.Count++
+		last.LastTime = now
+		last.Message = message
+		return
+	}
+	wr.timeline = append(wr.timeline, TimelineEntry{Type: string(recordType), FirstTime: now, Count: 1, Message: message})
+	if wr.maxTimelineEntries > 0 && len(wr.timeline) > wr.maxTimelineEntries {
+		dropped := len(wr.timeline) - wr.maxTimelineEntries
+		wr.timeline = append(wr.timeline[:0:0], wr.timeline[dropped:]...)
+		wr.truncatedTimeline += dropped
+	}
+}
+
+func (wr *WorkloadRecord) addAnomaly(anomalyType AnomalyType, message string, now time.Time) {
+	if wr.maxTimelineEntries > 0 && len(wr.anomalies) >= wr.maxTimelineEntries {
+		return
+	}
+	wr.anomalies = append(wr.anomalies, TimelineEntry{Type: string(anomalyType), FirstTime: now, Count: 1, Message: message})
+}
+
+func getObjectReference(pod *corev1.Pod) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Kind:       "Pod",
+		APIVersion: "v1",
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		UID:        pod.UID,
+	}
 }
 
 func GetPodKey(pod *corev1.Pod) string {
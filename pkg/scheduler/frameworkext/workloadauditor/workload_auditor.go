@@ -60,6 +60,11 @@ type WorkloadAuditor interface {
 type workloadAuditorImpl struct {
 	records sync.Map // workloadKey -> *WorkloadRecord
 	Config  WorkloadAuditorConfig
+
+	// store retains the finalized records for querying, nil if the retention is disabled.
+	store *recordStore
+	// persister persists the finalized records, nil if the persistence is disabled.
+	persister RecordPersister
 }
 
 func (w *workloadAuditorImpl) Enabled() bool {
@@ -90,7 +95,10 @@ func (w *workloadAuditorImpl) AddGangGroup(gangGroupID string, minMember int) {
 		recordTypeCounts:    map[RecordType]int{RecordTypeCreate: 1},
 		labelValues:         make([]string, len(w.Config.MetricLabelNames)),
 		labelDetail:         formatLabelDetail(w.Config.MetricLabelNames, make([]string, len(w.Config.MetricLabelNames))),
+		createTime:          start,
+		maxTimelineEntries:  w.Config.MaxTimelineEntries,
 	}
+	record.addTimeline(RecordTypeCreate, "", start)
 	w.records.LoadOrStore(gangGroupID, record)
 }
 
@@ -112,6 +120,7 @@ func (w *workloadAuditorImpl) DeleteGangGroup(gangGroupID string) {
 	klog.V(4).Infof("WorkloadAuditor delete(gangDeleted): workloadKey=%s %s, gangMinMember=%d(%s), attempts=%d",
 		record.WorkloadKey, record.labelDetail, record.gangMinMember, record.gangMinMemberBucket, record.Attempts)
 	finalizeWorkloadRecord(record, outcomeGangDeleted)
+	w.persistWorkloadRecord(record, outcomeGangDeleted)
 }
 
 func (w *workloadAuditorImpl) RecordGangGroup(gangGroupID string, pod *corev1.Pod, recordType RecordType, message string) {
@@ -171,13 +180,17 @@ func (w *workloadAuditorImpl) AddPod(pod *corev1.Pod) {
 	gated := PodIsGated(pod)
 
 	record := &WorkloadRecord{
-		WorkloadKey:      workloadKey,
-		recordTypeCounts: map[RecordType]int{RecordTypeCreate: 1},
-		gated:            gated,
-		labelsExtracted:  true,
-		labelValues:      labelValues,
-		labelDetail:      formatLabelDetail(w.Config.MetricLabelNames, labelValues),
-	}
+		WorkloadKey:        workloadKey,
+		recordTypeCounts:   map[RecordType]int{RecordTypeCreate: 1},
+		gated:              gated,
+		labelsExtracted:    true,
+		labelValues:        labelValues,
+		labelDetail:        formatLabelDetail(w.Config.MetricLabelNames, labelValues),
+		createTime:         start,
+		regarding:          getObjectReference(pod),
+		maxTimelineEntries: w.Config.MaxTimelineEntries,
+	}
+	record.addTimeline(RecordTypeCreate, "", start)
 	if _, loaded := w.records.LoadOrStore(workloadKey, record); loaded {
 		return
 	}
@@ -285,6 +298,7 @@ func (w *workloadAuditorImpl) DeletePod(pod *corev1.Pod) {
 	klog.V(4).Infof("WorkloadAuditor delete(podDeleted): workloadKey=%s %s, attempts=%d",
 		record.WorkloadKey, record.labelDetail, record.Attempts)
 	finalizeWorkloadRecord(record, outcomeDeleted)
+	w.persistWorkloadRecord(record, outcomeDeleted)
 }
 
 func (w *workloadAuditorImpl) RecordAttemptPod(pod *corev1.Pod) {
@@ -337,6 +351,7 @@ func (w *workloadAuditorImpl) RecordPodScheduleResult(pod *corev1.Pod, recordTyp
 		klog.V(4).Infof("WorkloadAuditor delete(podScheduled): workloadKey=%s %s, attempts=%d",
 			record.WorkloadKey, record.labelDetail, record.Attempts)
 		finalizeWorkloadRecord(record, outcomeScheduled)
+		w.persistWorkloadRecord(record, outcomeScheduled)
 		record.mu.Unlock()
 		w.records.Delete(workloadKey)
 		return
@@ -363,6 +378,7 @@ func (w *workloadAuditorImpl) RecordGangScheduleResult(gangKey string, recordTyp
 		klog.V(4).Infof("WorkloadAuditor delete(gangScheduled): workloadKey=%s %s, gangMinMember=%d(%s), attempts=%d",
 			record.WorkloadKey, record.labelDetail, record.gangMinMember, record.gangMinMemberBucket, record.Attempts)
 		finalizeWorkloadRecord(record, outcomeGangScheduled)
+		w.persistWorkloadRecord(record, outcomeGangScheduled)
 		record.mu.Unlock()
 		w.records.Delete(gangKey)
 		return
@@ -373,6 +389,7 @@ func (w *workloadAuditorImpl) RecordGangScheduleResult(gangKey string, recordTyp
 // appendRecord increments the record-type count, logs the event, and runs anomaly detection.
 func (w *workloadAuditorImpl) appendRecord(wr *WorkloadRecord, recordType RecordType, message string) {
 	wr.recordTypeCounts[recordType]++
+	wr.addTimeline(recordType, message, time.Now())
 	if wr.gangMinMember > 0 {
 		klog.V(4).Infof("WorkloadAuditor record: workloadKey=%s %s, gangMinMember=%d(%s), type=%s, message=%s, attempts=%d",
 			wr.WorkloadKey, wr.labelDetail, wr.gangMinMember, wr.gangMinMemberBucket, recordType, message, wr.Attempts)
@@ -383,13 +400,31 @@ func (w *workloadAuditorImpl) appendRecord(wr *WorkloadRecord, recordType Record
 	checkRecordAnomaly(&w.Config, wr, recordType, message)
 }
 
+type Option func(w *workloadAuditorImpl)
+
+// WithRecordPersister persists the finalized records if the persistence is enabled.
+func WithRecordPersister(persister RecordPersister) Option {
+	return func(w *workloadAuditorImpl) {
+		if w.Config.PersistRecords {
+			w.persister = persister
+		}
+	}
+}
+
 // NewWorkloadAuditor creates a new workloadAuditorImpl reading config from package-level vars.
-func NewWorkloadAuditor() WorkloadAuditor {
+func NewWorkloadAuditor(opts ...Option) WorkloadAuditor {
 	config := DefaultWorkloadAuditorConfig()
 	InitMetrics(config.MetricLabelNames)
-	return &workloadAuditorImpl{
+	w := &workloadAuditorImpl{
 		Config: config,
 	}
+	if config.RecordRetention > 0 && config.MaxRetainedRecords > 0 {
+		w.store = newRecordStore(config.RecordRetention, config.MaxRetainedRecords)
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 // tryExtractLabels populates the WorkloadRecord's label values from the given pod,
@@ -399,6 +434,9 @@ func (w *workloadAuditorImpl) tryExtractLabels(wr *WorkloadRecord, pod *corev1.P
 		return
 	}
 	wr.labelsExtracted = true
+	if wr.regarding == nil {
+		wr.regarding = getObjectReference(pod)
+	}
 	wr.labelValues = w.extractLabels(pod)
 	wr.labelDetail = formatLabelDetail(w.Config.MetricLabelNames, wr.labelValues)
 }
@@ -55,6 +55,13 @@ var (
 			Help:      "Resource metrics for a reservation, including allocatable, allocated, and utilization with unit.",
 		},
 		[]string{"type", "name", "resource", "unit"})
+	ReservationIdleResource = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem: schedulermetrics.SchedulerSubsystem,
+			Name:      "reservation_idle_resource",
+			Help:      "The reserved but unallocated resources of the idle reservations in each namespace with unit.",
+		},
+		[]string{"namespace", "resource", "unit"})
 
 	PodSchedulingEvaluatedNodes = metrics.NewHistogram(
 		&metrics.HistogramOpts{
@@ -172,6 +179,7 @@ var (
 		SchedulingTimeout,
 		ReservationStatusPhase,
 		ReservationResource,
+		ReservationIdleResource,
 		PodSchedulingEvaluatedNodes,
 		PodSchedulingFeasibleNodes,
 		ElasticQuotaProcessLatency,
@@ -192,6 +200,7 @@ var (
 
 const (
 	reservationNameKey         = "name"
+	reservationNamespaceKey    = "namespace"
 	reservationPhaseKey        = "phase"
 	reservationResourceKey     = "resource"
 	reservationResourceTypeKey = "type"
@@ -266,6 +275,20 @@ func RecordReservationResourceByTypeWithUnit(name, resource, typ, unit string, v
 	ReservationResource.With(labels).Set(value)
 }
 
+func ResetReservationIdleResource() {
+	ReservationIdleResource.Reset()
+}
+
+// RecordReservationIdleResource records the idle reserved resource of the reservations in a namespace as a metric.
+func RecordReservationIdleResource(namespace, resource, unit string, value float64) {
+	labels := prometheus.Labels{
+		reservationNamespaceKey:    namespace,
+		reservationResourceKey:     resource,
+		reservationResourceUnitKey: unit,
+	}
+	ReservationIdleResource.With(labels).Set(value)
+}
+
 func RecordElasticQuotaProcessLatency(operation string, latency time.Duration) {
 	ElasticQuotaProcessLatency.WithLabelValues(operation).Observe(latency.Seconds())
 }
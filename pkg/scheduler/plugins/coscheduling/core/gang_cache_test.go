@@ -25,17 +25,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 	fakepgclientset "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned/fake"
 	pgformers "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/informers/externalversions"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	v1 "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
 
 var fakeTimeNowFn = func() time.Time {
@@ -1891,3 +1894,63 @@ func TestGangCache_onPodGroupDelete_Tombstone(t *testing.T) {
 		assert.Equal(t, 1, len(gangCache.gangItems))
 	})
 }
+
+func TestGangCache_OnGangReservationAdd(t *testing.T) {
+	pgClient := fakepgclientset.NewSimpleClientset()
+	pgInformerFactory := pgformers.NewSharedInformerFactory(pgClient, 0)
+	pglister := pgInformerFactory.Scheduling().V1alpha1().PodGroups().Lister()
+	gangCache := NewGangCache(getTestDefaultCoschedulingArgs(t), nil, pglister, pgClient, nil)
+	handler := reservationutil.NewReservationToPodEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    gangCache.onPodAdd,
+		UpdateFunc: gangCache.onPodUpdate,
+		DeleteFunc: gangCache.onPodDelete,
+	})
+
+	// the reservations declare the gang by the coscheduling annotations, which are inherited by the reserve pods
+	newGangReservation := func(name string) *schedulingv1alpha1.Reservation {
+		return &schedulingv1alpha1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				UID:  types.UID(name),
+				Annotations: map[string]string{
+					extension.AnnotationGangName:   "reserve-job-a",
+					extension.AnnotationGangMinNum: "2",
+				},
+			},
+			Spec: schedulingv1alpha1.ReservationSpec{
+				Template: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+					},
+				},
+				Owners: []schedulingv1alpha1.ReservationOwner{
+					{
+						Gang: &schedulingv1alpha1.ReservationGangReference{
+							Namespace: "default",
+							Name:      "job-a",
+						},
+					},
+				},
+			},
+		}
+	}
+	r0 := newGangReservation("reservation-0")
+	r1 := newGangReservation("reservation-1")
+	handler.OnAdd(r0, true)
+	handler.OnAdd(r1, true)
+
+	gang := gangCache.getGangFromCacheByGangId("default/reserve-job-a", false)
+	assert.NotNil(t, gang)
+	assert.Equal(t, 2, gang.getGangMinNum())
+	assert.Equal(t, 2, gang.getChildrenNum())
+	assert.Equal(t, 2, gang.getPendingChildrenNum())
+
+	// the scheduled reservation is counted as the bound member
+	scheduledR0 := r0.DeepCopy()
+	scheduledR0.Status.NodeName = "node-1"
+	handler.OnUpdate(r0, scheduledR0)
+	assert.Equal(t, int32(1), gang.getBoundPodNum())
+
+	handler.OnDelete(scheduledR0)
+	assert.Equal(t, 1, gang.getChildrenNum())
+}
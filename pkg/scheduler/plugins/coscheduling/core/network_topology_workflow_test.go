@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	fwktype "k8s.io/kube-scheduler/framework"
@@ -22,6 +23,7 @@ import (
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/networktopology"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
 
 func TestPodGroupManager_PreFilter(t *testing.T) {
@@ -2380,3 +2382,136 @@ func TestPodGroupManager_PreScore(t *testing.T) {
 		})
 	}
 }
+
+func TestPodGroupManager_NetworkTopologyGangReservations(t *testing.T) {
+	newNode := func(name, spine, block string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					networktopology.FakeSpineLabel: spine,
+					networktopology.FakeBlockLabel: block,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:  resource.MustParse("16"),
+					corev1.ResourcePods: resource.MustParse("110"),
+				},
+			},
+		}
+	}
+	nodes := []*corev1.Node{
+		newNode("node-8", "s1", "b1"),
+		newNode("node-1", "s1", "b1"),
+		newNode("node-7", "s1", "b2"),
+		newNode("node-2", "s1", "b2"),
+		newNode("node-6", "s2", "b3"),
+		newNode("node-3", "s2", "b3"),
+		newNode("node-5", "s2", "b4"),
+		newNode("node-4", "s2", "b4"),
+	}
+	// only the block b4 has two free nodes
+	var existingPods []*corev1.Pod
+	for _, nodeName := range []string{"node-8", "node-7", "node-6"} {
+		existingPods = append(existingPods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing-pod-on-" + nodeName,
+				Namespace: "default",
+				UID:       types.UID("existing-pod-on-" + nodeName),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("16"),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// the gang reservations declare the gang and its network topology by the coscheduling annotations
+	networkTopologySpec := `{"gatherStrategy":[{"layer":"BlockLayer","strategy":"MustGather"}]}`
+	newGangReservation := func(name string) *schedulingv1alpha1.Reservation {
+		return &schedulingv1alpha1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				UID:  types.UID(name),
+				Annotations: map[string]string{
+					extension.AnnotationGangName:                "reserve-job-a",
+					extension.AnnotationGangMinNum:              "2",
+					extension.AnnotationGangNetworkTopologySpec: networkTopologySpec,
+				},
+			},
+			Spec: schedulingv1alpha1.ReservationSpec{
+				Template: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("16"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Owners: []schedulingv1alpha1.ReservationOwner{
+					{
+						Gang: &schedulingv1alpha1.ReservationGangReference{
+							Name: "job-a",
+						},
+					},
+				},
+			},
+		}
+	}
+	reservePods := []*corev1.Pod{
+		reservationutil.NewReservePod(newGangReservation("reservation-0")),
+		reservationutil.NewReservePod(newGangReservation("reservation-1")),
+	}
+
+	extendedFramework := NewFakeExtendedFramework(t, nodes, existingPods, nil, nil, networktopology.FakeClusterNetworkTopology)
+	pgMgr := &PodGroupManager{handle: extendedFramework, networkTopologySolver: NewNetworkTopologySolver(extendedFramework)}
+	pgMgr.cache = NewGangCache(getTestDefaultCoschedulingArgs(t), nil, nil, nil, nil)
+	for _, pod := range reservePods {
+		pgMgr.cache.onPodAdd(pod)
+	}
+	gang := pgMgr.GetGangByPod(reservePods[0])
+	assert.NotNil(t, gang)
+	assert.NotNil(t, gang.NetworkTopologySpec)
+	pgMgr.holder = GangSchedulingContextHolder{gangSchedulingContext: &GangSchedulingContext{
+		firstPod:                reservePods[0],
+		triggerPod:              reservePods[0],
+		gangGroup:               sets.New[string](gang.GangGroup...),
+		gangGroupID:             gang.GangGroupId,
+		networkTopologySpec:     gang.NetworkTopologySpec,
+		networkTopologySnapshot: extendedFramework.GetNetworkTopologyTreeManager().GetSnapshot(),
+	}}
+
+	cycleState := framework.NewCycleState()
+	frameworkext.InitDiagnosis(cycleState, reservePods[0])
+	plan, status := pgMgr.FindOneNode(context.Background(), cycleState, reservePods[0], nil)
+	assert.True(t, status.IsSuccess(), status.Message())
+	assert.Len(t, plan.Pods, 2)
+	// the reserve pods of the gang are planned together into the only block which can hold all of them
+	assert.Equal(t, map[string]string{
+		"default/" + reservePods[0].Name: "node-4",
+		"default/" + reservePods[1].Name: "node-5",
+	}, plan.PodToNodeName)
+
+	// the following reserve pod of the gang is restricted to its planned node
+	pgMgr.holder.gangSchedulingContext.alreadyAttemptedPods = sets.New[string](reservePods[0].Name, reservePods[1].Name)
+	result, status := pgMgr.PreFilter(context.Background(), cycleState, reservePods[1], nil)
+	assert.True(t, status.IsSuccess())
+	assert.Equal(t, sets.New[string]("node-5"), result.NodeNames)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/workloadauditor"
 	schedulermetrics "github.com/koordinator-sh/koordinator/pkg/scheduler/metrics"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
 
 const (
@@ -82,11 +83,16 @@ func NewPreemptionEvaluator(handle fwktype.Handle, gangCache *GangCache, gangCon
 	if handle == nil {
 		return nil
 	}
+	extendedHandle := handle.(frameworkext.ExtendedHandle)
 	return &preemptionEvaluatorImpl{
 		IsEligiblePod: func(nodeInfo fwktype.NodeInfo, victim fwktype.PodInfo, preemptor *corev1.Pod) bool {
-			return extension.IsPodPreemptible(victim.GetPod()) && !extension.IsPodNonPreemptible(victim.GetPod())
+			if !extension.IsPodPreemptible(victim.GetPod()) || extension.IsPodNonPreemptible(victim.GetPod()) {
+				return false
+			}
+			// only the unallocated reservation can be preempted
+			return !reservationutil.IsReservePod(victim.GetPod()) || frameworkext.IsReservePodPreemptible(extendedHandle, victim.GetPod())
 		},
-		handle:                handle.(frameworkext.ExtendedHandle),
+		handle:                extendedHandle,
 		gangCache:             gangCache,
 		gangContextHolder:     gangContextHolder,
 		networkTopologySolver: networkTopologySolver,
@@ -887,6 +893,11 @@ func (ev *preemptionEvaluatorImpl) preemptPod(ctx context.Context, preemptor, vi
 	logger := klog.FromContext(ctx)
 	preemptionState := preemptionStateFromContext(ctx)
 
+	// If the victim is a reserve pod, fail its Reservation since there is no real pod to delete.
+	if reservationutil.IsReservePod(victim) {
+		return frameworkext.PreemptReservation(ctx, ev.handle, preemptor, victim)
+	}
+
 	// If the victim is a WaitingPod, send a reject message to the PermitPlugin.
 	// Otherwise, we should delete the victim.
 	if waitingPod := ev.handle.GetWaitingPod(victim.UID); waitingPod != nil {
@@ -39,6 +39,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/networktopology"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
 
 type FakeFitPlugin struct {
@@ -1800,6 +1801,75 @@ func Test_preemptionEvaluatorImpl_preempt(t *testing.T) {
 	}
 }
 
+func Test_preemptionEvaluatorImpl_preemptReservation(t *testing.T) {
+	highPriority := int32(1000)
+	lowPriority := int32(1)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+	}
+	preemptor := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "preemptor",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Priority: ptr.To[int32](highPriority),
+		},
+	}
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "unallocated-reservation",
+			UID:  "123456",
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Priority: ptr.To[int32](lowPriority),
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: node.Name,
+		},
+	}
+	allocatedReservation := reservation.DeepCopy()
+	allocatedReservation.Name = "allocated-reservation"
+	allocatedReservation.UID = "654321"
+	allocatedReservation.Status.CurrentOwners = []corev1.ObjectReference{
+		{
+			Namespace: "default",
+			Name:      "owner-pod",
+		},
+	}
+
+	extendedFramework := NewFakeExtendedFramework(t, []*corev1.Node{node}, nil, nil, nil, nil)
+	reservationStore := extendedFramework.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().Reservations().Informer().GetStore()
+	for _, r := range []*schedulingv1alpha1.Reservation{reservation, allocatedReservation} {
+		_, err := extendedFramework.KoordinatorClientSet().SchedulingV1alpha1().Reservations().Create(context.TODO(), r, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, reservationStore.Add(r))
+	}
+	ev := NewPreemptionEvaluator(extendedFramework, NewGangCache(nil, nil, nil, nil, nil), &GangSchedulingContextHolder{}, nil).(*preemptionEvaluatorImpl)
+	nodeInfo, _ := extendedFramework.SnapshotSharedLister().NodeInfos().Get(node.Name)
+
+	reservePod := reservationutil.NewReservePod(reservation)
+	allocatedReservePod := reservationutil.NewReservePod(allocatedReservation)
+	reservePodInfo, _ := framework.NewPodInfo(reservePod)
+	allocatedReservePodInfo, _ := framework.NewPodInfo(allocatedReservePod)
+	assert.True(t, ev.isPreemptionAllowed(nodeInfo, reservePodInfo, preemptor))
+	assert.False(t, ev.isPreemptionAllowed(nodeInfo, allocatedReservePodInfo, preemptor))
+
+	ctx := contextWithJobPreemptionState(context.Background(), &JobPreemptionState{})
+	assert.NoError(t, ev.preemptPod(ctx, preemptor, reservePod, frameworkext.JobRejectPlugin))
+	got, err := extendedFramework.KoordinatorClientSet().SchedulingV1alpha1().Reservations().Get(context.TODO(), reservation.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, reservationutil.IsReservationPreempted(got))
+	assert.Error(t, ev.preemptPod(ctx, preemptor, allocatedReservePod, frameworkext.JobRejectPlugin))
+}
+
 func TestJobPreemptionState_addMoreDetailForStateToMarshal(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -24,6 +24,7 @@ import (
 
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 )
 
@@ -45,6 +46,7 @@ func (n *nodeDeviceCache) onDeviceAdd(obj interface{}) {
 		return
 	}
 	n.updateNodeDevice(device.Name, device)
+	equivalence.InvalidateNode(device.Name)
 	klog.V(4).InfoS("device cache added", "Device", klog.KObj(device))
 }
 
@@ -56,6 +58,7 @@ func (n *nodeDeviceCache) onDeviceUpdate(oldObj, newObj interface{}) {
 		return
 	}
 	n.updateNodeDevice(newD.Name, newD)
+	equivalence.InvalidateNode(newD.Name)
 	klog.V(4).InfoS("device cache updated", "Device", klog.KObj(newD))
 }
 
@@ -81,5 +84,6 @@ func (n *nodeDeviceCache) onDeviceDelete(obj interface{}) {
 	// otherwise the GPU may be repeatedly allocated to different Pods.
 	//
 	n.invalidateNodeDevice(device)
+	equivalence.InvalidateNode(device.Name)
 	klog.V(4).InfoS("device invalided", "Device", klog.KObj(device))
 }
@@ -26,6 +26,7 @@ import (
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
@@ -123,6 +124,7 @@ func (n *nodeDeviceCache) updatePod(oldPod *corev1.Pod, pod *corev1.Pod) {
 	info := n.getNodeDevice(pod.Spec.NodeName, true)
 	info.lock.Lock()
 	defer info.lock.Unlock()
+	defer equivalence.InvalidateNode(pod.Spec.NodeName)
 	if oldPod != nil && oldPod.Spec.NodeName != "" && len(oldAllocations) > 0 { // avoid leaking for an unassigned pod
 		info.updateCacheUsed(oldAllocations, oldPod, false)
 		klog.V(4).InfoS("remove old pod from nodeDevice cache on node", "pod", klog.KObj(pod), "node", oldPod.Spec.NodeName)
@@ -155,6 +157,7 @@ func (n *nodeDeviceCache) deletePod(pod *corev1.Pod) {
 
 	info.lock.Lock()
 	defer info.lock.Unlock()
+	defer equivalence.InvalidateNode(pod.Spec.NodeName)
 
 	info.updateCacheUsed(devicesAllocation, pod, false)
 	klog.V(5).InfoS("pod has been deleted so remove pod from nodeDevice cache on node", "pod", klog.KObj(pod), "node", pod.Spec.NodeName)
@@ -73,6 +73,7 @@ var (
 	_ frameworkext.ReservationScorePlugin                = &Plugin{}
 	_ frameworkext.ReservationScoreExtensions            = &Plugin{}
 	_ frameworkext.ReservationPreBindPlugin              = &Plugin{}
+	_ frameworkext.CacheableFilterPlugin                 = &Plugin{}
 )
 
 type Plugin struct {
@@ -343,6 +344,30 @@ func (p *Plugin) RemovePod(ctx context.Context, cycleState fwktype.CycleState, p
 }
 
 func (p *Plugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	return frameworkext.RunFilterWithEquivalenceCache(p, cycleState, pod, nodeInfo, func() *fwktype.Status {
+		return p.filter(ctx, cycleState, pod, nodeInfo)
+	})
+}
+
+// IsFilterCacheable returns false when the pod is allocated with the designated devices, the NUMA affinity of the
+// pod on the node has been admitted, any reservation is on the node, or the preemption returns devices on the node.
+func (p *Plugin) IsFilterCacheable(cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) bool {
+	state, status := getPreFilterState(cycleState)
+	if !status.IsSuccess() || state.skip || state.designatedAllocation != nil {
+		return false
+	}
+	node := nodeInfo.Node()
+	if _, ok := topologymanager.GetStore(cycleState).GetAffinity(node.Name); ok {
+		return false
+	}
+	if len(state.preemptibleDevices[node.Name]) > 0 {
+		return false
+	}
+	restoreState := getReservationRestoreState(cycleState).getNodeState(node.Name)
+	return len(restoreState.matched) == 0 && len(restoreState.unmatched) == 0 && restoreState.preAllocationRInfo == nil
+}
+
+func (p *Plugin) filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
 		return status
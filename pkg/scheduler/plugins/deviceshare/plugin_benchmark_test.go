@@ -24,11 +24,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/utils/ptr"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 // BenchmarkPreFilter measures the cost of PreFilter for a pod requesting a whole GPU.
@@ -139,6 +143,56 @@ func BenchmarkFilter_GPUShare(b *testing.B) {
 	}
 }
 
+// BenchmarkFilter_GPUShare_EquivalenceCache measures the cost of filtering a pod requesting a shared GPU slice
+// across a 256-node cluster, with and without the Filter equivalence cache. Each iteration filters an equivalent
+// pod in a new cycle, so all iterations but the first hit the cache when it is enabled.
+func BenchmarkFilter_GPUShare_EquivalenceCache(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("FilterEquivalenceCache=%v", enabled), func(b *testing.B) {
+			defer utilfeature.SetFeatureGateDuringTest(b, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, enabled)()
+
+			const numNodes = 256
+			nodes := makeGPUNodes(numNodes, 8)
+			suit := newPluginTestSuit(b, nodes)
+			ctx, cancel := context.WithCancel(context.Background())
+			b.Cleanup(cancel)
+			p, err := suit.proxyNew(ctx, getDefaultArgs(), suit.Framework)
+			if err != nil {
+				b.Fatalf("failed to create plugin: %v", err)
+			}
+			pl := p.(*Plugin)
+
+			for i := 0; i < numNodes; i++ {
+				pl.nodeDeviceCache.updateNodeDevice(fmt.Sprintf("node-%d", i), makeGPUDevice(fmt.Sprintf("node-%d", i), 8))
+			}
+
+			pod := makeGPUSharePod(50, 50)
+			nodeInfos, err := suit.Framework.SnapshotSharedLister().NodeInfos().List()
+			if err != nil {
+				b.Fatalf("failed to list node infos: %v", err)
+			}
+
+			baseState, status := preparePod(pod, pl.gpuSharedResourceTemplatesCache, pl.gpuSharedResourceTemplatesMatchedResources)
+			if !status.IsSuccess() {
+				b.Fatalf("preparePod failed: %v", status)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cycleState := framework.NewCycleState()
+				cycleState.Write(stateKey, baseState.Clone())
+				frameworkext.SetPodEquivalenceHash(cycleState, pod)
+				for _, nodeInfo := range nodeInfos {
+					filterStatus := pl.Filter(ctx, cycleState, pod, nodeInfo)
+					if !filterStatus.IsSuccess() {
+						b.Fatalf("Filter failed: %v", filterStatus)
+					}
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkFilter_LargeCluster benchmarks Filter across a large cluster (1024 nodes, 8 GPUs each).
 func BenchmarkFilter_LargeCluster(b *testing.B) {
 	const numNodes = 1024
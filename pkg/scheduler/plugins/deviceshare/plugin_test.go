@@ -3220,6 +3220,36 @@ func Test_Plugin_Filter(t *testing.T) {
 	}
 }
 
+func Test_Plugin_FilterWithEquivalenceCache(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, koordfeatures.FilterEquivalenceCache, true)()
+
+	nodes := makeGPUNodes(1, 1)
+	nodes[0].Name = "test-equivalence-node"
+	suit := newPluginTestSuit(t, nodes)
+	p, err := suit.proxyNew(context.TODO(), getDefaultArgs(), suit.Framework)
+	assert.NoError(t, err)
+	pl := p.(*Plugin)
+	device := makeGPUDevice(nodes[0].Name, 1)
+	pl.nodeDeviceCache.onDeviceAdd(device)
+
+	runFilter := func() *fwktype.Status {
+		pod := makeGPUSharePod(50, 50)
+		cycleState := framework.NewCycleState()
+		frameworkext.SetPodEquivalenceHash(cycleState, pod)
+		_, status := pl.PreFilter(context.TODO(), cycleState, pod, nil)
+		assert.True(t, status.IsSuccess())
+		nodeInfo, err := suit.Framework.SnapshotSharedLister().NodeInfos().Get(nodes[0].Name)
+		assert.NoError(t, err)
+		return pl.Filter(context.TODO(), cycleState, pod, nodeInfo)
+	}
+
+	assert.True(t, runFilter().IsSuccess())
+	assert.True(t, runFilter().IsSuccess())
+	// the unhealthy devices invalidate the cached result through the device event handler
+	pl.nodeDeviceCache.onDeviceDelete(device)
+	assert.False(t, runFilter().IsSuccess())
+}
+
 func Test_Plugin_FilterNominateReservation(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
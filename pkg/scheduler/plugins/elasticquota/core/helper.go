@@ -25,10 +25,11 @@ import (
 )
 
 func PodRequests(pod *corev1.Pod) (reqs corev1.ResourceList) {
-	if k8sfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaIgnorePodOverhead) {
-		return apiresource.PodRequests(pod, apiresource.PodResourcesOptions{
-			ExcludeOverhead: true,
-		})
+	opts := apiresource.PodResourcesOptions{
+		ExcludeOverhead: k8sfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaIgnorePodOverhead),
+		// when the pod is resized in place, count the larger one of the desired and the actual resources
+		// until the resize is actuated, so the quota usage follows the resized pod
+		UseStatusResources: k8sfeature.DefaultFeatureGate.Enabled(features.ResizePod),
 	}
-	return apiresource.PodRequests(pod, apiresource.PodResourcesOptions{})
+	return apiresource.PodRequests(pod, opts)
 }
@@ -88,3 +88,81 @@ func TestPodRequestsAndLimits(t *testing.T) {
 	}
 
 }
+
+func TestPodRequestsWithResizePod(t *testing.T) {
+	tests := []struct {
+		name       string
+		enable     bool
+		specCPU    int64
+		conditions []corev1.PodCondition
+		wantCPU    int64
+	}{
+		{
+			name:    "ResizePod=false, count the desired resources",
+			enable:  false,
+			specCPU: 2000,
+			wantCPU: 2000,
+		},
+		{
+			name:    "ResizePod=true, count the actual resources before the shrink is actuated",
+			enable:  true,
+			specCPU: 2000,
+			wantCPU: 4000,
+		},
+		{
+			name:    "ResizePod=true, count the desired resources for the expanding",
+			enable:  true,
+			specCPU: 8000,
+			wantCPU: 8000,
+		},
+		{
+			name:    "ResizePod=true, count the actual resources if the resize is infeasible",
+			enable:  true,
+			specCPU: 8000,
+			conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodResizePending,
+					Status: corev1.ConditionTrue,
+					Reason: corev1.PodReasonInfeasible,
+				},
+			},
+			wantCPU: 4000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, koordfeatures.ResizePod, tt.enable)()
+			actual := corev1.ResourceList{
+				corev1.ResourceCPU: *resource.NewMilliQuantity(4000, resource.DecimalSI),
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: *resource.NewMilliQuantity(tt.specCPU, resource.DecimalSI),
+								},
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: tt.conditions,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:               "main",
+							AllocatedResources: actual,
+							Resources: &corev1.ResourceRequirements{
+								Requests: actual,
+							},
+						},
+					},
+				},
+			}
+			reqs := PodRequests(pod)
+			assert.Equal(t, tt.wantCPU, reqs.Cpu().MilliValue())
+		})
+	}
+}
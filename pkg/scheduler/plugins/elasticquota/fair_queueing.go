@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
+)
+
+// Less sorts the pods by priority first. If EnableQuotaFairQueueing, the pods with the same priority are sorted
+// by the dominant share of their quotas, so that a quota with lots of pending pods can't starve the quotas far
+// below their share base. The pods are sorted by the timestamp at last, the same as the PrioritySort plugin.
+//
+// The dominant shares are refreshed periodically, but the share of a queued pod is snapshotted until it's
+// popped again, so the refreshes never reorder the pods under the heap of the scheduling queue. The comparisons
+// only read the snapshots without locks.
+func (g *Plugin) Less(podInfo1, podInfo2 fwktype.QueuedPodInfo) bool {
+	pod1, pod2 := podInfo1.GetPodInfo().GetPod(), podInfo2.GetPodInfo().GetPod()
+	p1, p2 := corev1helpers.PodPriority(pod1), corev1helpers.PodPriority(pod2)
+	if p1 != p2 {
+		return p1 > p2
+	}
+	if g.pluginArgs.EnableQuotaFairQueueing {
+		share1, share2 := g.getQueuedPodShare(podInfo1), g.getQueuedPodShare(podInfo2)
+		if share1 != share2 {
+			return share1 < share2
+		}
+	}
+	return podInfo1.GetTimestamp().Before(podInfo2.GetTimestamp())
+}
+
+// queuedPodShare is the dominant share snapshotted for the pod in the given scheduling attempt.
+type queuedPodShare struct {
+	attempts int
+	share    float64
+}
+
+// getQueuedPodShare returns the share snapshotted for the pod. The attempts of a pod only increase when it's
+// popped from the queue, so the share is refreshed once per pop and keeps stable while the pod is queued.
+func (g *Plugin) getQueuedPodShare(podInfo fwktype.QueuedPodInfo) float64 {
+	pod := podInfo.GetPodInfo().GetPod()
+	if value, ok := g.queuedPodShares.Load(pod.UID); ok {
+		if snapshot := value.(queuedPodShare); snapshot.attempts == podInfo.GetAttempts() {
+			return snapshot.share
+		}
+	}
+	share := g.getQuotaShare(g.GetQuotaName(pod))
+	g.queuedPodShares.Store(pod.UID, queuedPodShare{attempts: podInfo.GetAttempts(), share: share})
+	return share
+}
+
+// forgetQueuedPodShare drops the share snapshotted for the pod which leaves the scheduling queue.
+func (g *Plugin) forgetQueuedPodShare(pod *corev1.Pod) {
+	if !g.pluginArgs.EnableQuotaFairQueueing {
+		return
+	}
+	g.queuedPodShares.Delete(pod.UID)
+}
+
+// getQuotaShare returns the dominant share of the quota, the quotas unknown yet are served last.
+func (g *Plugin) getQuotaShare(quotaName string) float64 {
+	shares := g.quotaShares.Load()
+	if shares == nil {
+		return math.Inf(1)
+	}
+	share, ok := (*shares)[quotaName]
+	if !ok {
+		return math.Inf(1)
+	}
+	return share
+}
+
+// updateQuotaShares recalculates the dominant shares of all quotas and records the pods of each quota waiting
+// in the scheduling queue.
+func (g *Plugin) updateQuotaShares() {
+	g.quotaManagerLock.RLock()
+	managers := make([]*core.GroupQuotaManager, 0, len(g.groupQuotaManagersForQuotaTree)+1)
+	for _, mgr := range g.groupQuotaManagersForQuotaTree {
+		if mgr != nil {
+			managers = append(managers, mgr)
+		}
+	}
+	if g.groupQuotaManager != nil {
+		managers = append(managers, g.groupQuotaManager)
+	}
+	g.quotaManagerLock.RUnlock()
+
+	pending := g.countQueuedPods()
+	shares := make(map[string]float64)
+	metricTrees := make(map[string]string)
+	for _, mgr := range managers {
+		for quotaName, summary := range mgr.GetQuotaSummaries(false) {
+			base := summary.Min
+			if g.pluginArgs.QuotaFairQueueingShareBase == config.QuotaShareBaseRuntime {
+				base = summary.Runtime
+			}
+			shares[quotaName] = dominantShare(summary.Used, base)
+			if summary.IsParent {
+				continue
+			}
+
+			ElasticQuotaPendingPods.WithLabelValues(quotaName, summary.Tree).Set(float64(pending[quotaName]))
+			ElasticQuotaDominantShare.WithLabelValues(quotaName, summary.Tree).Set(shares[quotaName])
+			metricTrees[quotaName] = summary.Tree
+		}
+	}
+	g.quotaShares.Store(&shares)
+
+	for quotaName, treeID := range g.quotaSharesMetricTrees {
+		if newTreeID, ok := metricTrees[quotaName]; !ok || newTreeID != treeID {
+			ElasticQuotaPendingPods.DeleteLabelValues(quotaName, treeID)
+			ElasticQuotaDominantShare.DeleteLabelValues(quotaName, treeID)
+		}
+	}
+	g.quotaSharesMetricTrees = metricTrees
+	klog.V(5).Infof("updated the dominant shares of %d quotas", len(shares))
+}
+
+// countQueuedPods counts the pods waiting in the scheduling queue by their quotas.
+func (g *Plugin) countQueuedPods() map[string]int {
+	pending := map[string]int{}
+	extendedHandle, ok := g.handle.(frameworkext.ExtendedHandle)
+	if !ok || extendedHandle.Scheduler() == nil || extendedHandle.Scheduler().GetSchedulingQueue() == nil {
+		return pending
+	}
+	for _, pod := range extendedHandle.Scheduler().GetSchedulingQueue().PendingPods() {
+		pending[g.GetQuotaName(pod)]++
+	}
+	return pending
+}
+
+// dominantShare is the max ratio of used to base across the resources with a positive base. The quotas without
+// any positive base, e.g. the quotas without min, are only served after the others.
+func dominantShare(used, base corev1.ResourceList) float64 {
+	share, hasBase := 0.0, false
+	for resourceName, quantity := range base {
+		total := quantity.AsApproximateFloat64()
+		if total <= 0 {
+			continue
+		}
+		hasBase = true
+		usedQuantity := used[resourceName]
+		if ratio := usedQuantity.AsApproximateFloat64() / total; ratio > share {
+			share = ratio
+		}
+	}
+	if !hasBase {
+		return math.Inf(1)
+	}
+	return share
+}
+
+// recordCreationToReserveTime observes the time since the pod creation until reserved into the quota, which
+// includes both the time waiting in the queue and the time of the failed scheduling attempts.
+func (g *Plugin) recordCreationToReserveTime(quotaName string, pod *corev1.Pod) {
+	if !g.pluginArgs.EnableQuotaFairQueueing || pod.CreationTimestamp.IsZero() {
+		return
+	}
+	ElasticQuotaPodCreationToReserveDuration.WithLabelValues(quotaName).Observe(time.Since(pod.CreationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/component-base/metrics/testutil"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+type fakeSchedulerHandle struct {
+	frameworkext.ExtendedHandle
+	scheduler frameworkext.Scheduler
+}
+
+func (h *fakeSchedulerHandle) Scheduler() frameworkext.Scheduler {
+	return h.scheduler
+}
+
+func newQueuedPodInfo(t *testing.T, pod *corev1.Pod, timestamp time.Time) *framework.QueuedPodInfo {
+	podInfo, err := framework.NewPodInfo(pod)
+	assert.NoError(t, err)
+	return &framework.QueuedPodInfo{PodInfo: podInfo, Timestamp: timestamp}
+}
+
+func TestPlugin_Less(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name          string
+		enable        bool
+		shareBase     config.QuotaShareBase
+		wantIdleFirst bool
+	}{
+		{
+			name:          "fair queueing disabled, sorted by timestamp",
+			enable:        false,
+			shareBase:     config.QuotaShareBaseMin,
+			wantIdleFirst: false,
+		},
+		{
+			name:          "fair queueing enabled, the quota below min first",
+			enable:        true,
+			shareBase:     config.QuotaShareBaseMin,
+			wantIdleFirst: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil, func(args *config.ElasticQuotaArgs) {
+				args.EnableQuotaFairQueueing = tt.enable
+				args.QuotaFairQueueingShareBase = tt.shareBase
+			})
+			gp := suit.createPlugin(t).(*Plugin)
+			gp.addQuota("busy", extension.RootQuotaName, 100, 1000, 10, 100, 100, 1000, false, "", "")
+			gp.addQuota("idle", extension.RootQuotaName, 100, 1000, 10, 100, 100, 1000, false, "", "")
+			// busy has used 8 of 10 cpu min and idle has used 1 of 10
+			gp.OnPodAdd(defaultCreatePodWithQuotaName("busy-running", "busy", 0, 8, 10))
+			gp.OnPodAdd(defaultCreatePodWithQuotaName("idle-running", "idle", 0, 1, 10))
+			busyPending := defaultCreatePodWithQuotaAndNonPreemptible("busy-pending", "busy", 0, 1, 1, false)
+			busyPending.Spec.NodeName = ""
+			gp.OnPodAdd(busyPending)
+			gp.updateQuotaShares()
+
+			busyPod := newQueuedPodInfo(t, busyPending, now)
+			idlePod := newQueuedPodInfo(t, defaultCreatePodWithQuotaAndNonPreemptible("idle-pending", "idle", 0, 1, 1, false), now.Add(time.Second))
+			assert.Equal(t, tt.wantIdleFirst, gp.Less(idlePod, busyPod))
+			assert.Equal(t, !tt.wantIdleFirst, gp.Less(busyPod, idlePod))
+
+			// the priority always comes first
+			highPod := newQueuedPodInfo(t, defaultCreatePodWithQuotaAndNonPreemptible("busy-high", "busy", 100, 1, 1, false), now.Add(2*time.Second))
+			assert.True(t, gp.Less(highPod, idlePod))
+			assert.False(t, gp.Less(idlePod, highPod))
+		})
+	}
+}
+
+func TestPlugin_LessSnapshotsSharesPerPop(t *testing.T) {
+	now := time.Now()
+	suit := newPluginTestSuit(t, nil, func(args *config.ElasticQuotaArgs) {
+		args.EnableQuotaFairQueueing = true
+		args.QuotaFairQueueingShareBase = config.QuotaShareBaseMin
+	})
+	gp := suit.createPlugin(t).(*Plugin)
+	gp.addQuota("quota-a", extension.RootQuotaName, 100, 1000, 10, 100, 100, 1000, false, "", "")
+	gp.addQuota("quota-b", extension.RootQuotaName, 100, 1000, 10, 100, 100, 1000, false, "", "")
+	gp.OnPodAdd(defaultCreatePodWithQuotaName("a-running", "quota-a", 0, 1, 10))
+	gp.OnPodAdd(defaultCreatePodWithQuotaName("b-running", "quota-b", 0, 8, 10))
+	gp.updateQuotaShares()
+
+	podA := newQueuedPodInfo(t, defaultCreatePodWithQuotaAndNonPreemptible("a-pending", "quota-a", 0, 1, 1, false), now.Add(time.Second))
+	podB := newQueuedPodInfo(t, defaultCreatePodWithQuotaAndNonPreemptible("b-pending", "quota-b", 0, 1, 1, false), now)
+	assert.True(t, gp.Less(podA, podB))
+
+	// the refreshed shares must not reorder the pods still in the queue
+	gp.OnPodAdd(defaultCreatePodWithQuotaName("a-running-2", "quota-a", 0, 9, 10))
+	gp.updateQuotaShares()
+	assert.True(t, gp.Less(podA, podB))
+	assert.False(t, gp.Less(podB, podA))
+
+	// the shares are refreshed once the pods are popped
+	podA.Attempts++
+	podB.Attempts++
+	assert.False(t, gp.Less(podA, podB))
+	assert.True(t, gp.Less(podB, podA))
+
+	gp.handlePodDelete(podA.Pod)
+	gp.forgetQueuedPodShare(podB.Pod)
+	gp.queuedPodShares.Range(func(key, value any) bool {
+		t.Errorf("unexpected queued pod share of %v", key)
+		return true
+	})
+}
+
+func TestPlugin_updateQuotaShares(t *testing.T) {
+	suit := newPluginTestSuit(t, nil, func(args *config.ElasticQuotaArgs) {
+		args.EnableQuotaFairQueueing = true
+		args.QuotaFairQueueingShareBase = config.QuotaShareBaseMin
+	})
+	gp := suit.createPlugin(t).(*Plugin)
+	gp.addQuota("quota-a", extension.RootQuotaName, 100, 1000, 10, 100, 100, 1000, false, "", "")
+	gp.addQuota("quota-b", extension.RootQuotaName, 100, 1000, 0, 0, 100, 1000, false, "", "")
+	gp.OnPodAdd(defaultCreatePodWithQuotaName("pod-a", "quota-a", 0, 2, 50))
+	pending := defaultCreatePodWithQuotaName("pod-b", "quota-a", 0, 2, 10)
+	pending.Spec.NodeName = ""
+	gp.OnPodAdd(pending)
+	// only the pods waiting in the scheduling queue are counted as pending
+	sched := frameworkext.NewFakeScheduler()
+	sched.Queue.Add(klog.Background(), pending)
+	gp.handle = &fakeSchedulerHandle{ExtendedHandle: gp.handle.(frameworkext.ExtendedHandle), scheduler: sched}
+
+	gp.updateQuotaShares()
+	assert.Equal(t, 0.5, gp.getQuotaShare("quota-a"), "the memory share is dominant")
+	pendingPods, err := testutil.GetGaugeMetricValue(ElasticQuotaPendingPods.WithLabelValues("quota-a", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), pendingPods)
+	sched.Queue.Delete(pending)
+	gp.updateQuotaShares()
+	pendingPods, err = testutil.GetGaugeMetricValue(ElasticQuotaPendingPods.WithLabelValues("quota-a", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), pendingPods)
+	assert.True(t, math.IsInf(gp.getQuotaShare("quota-b"), 1), "the quota without min is served last")
+	assert.True(t, math.IsInf(gp.getQuotaShare("unknown"), 1))
+	_, ok := gp.quotaSharesMetricTrees["quota-a"]
+	assert.True(t, ok)
+
+	gp.OnQuotaDelete(CreateQuota2("quota-b", extension.RootQuotaName, 100, 1000, 0, 0, 100, 1000, false, ""))
+	gp.updateQuotaShares()
+	_, ok = gp.quotaSharesMetricTrees["quota-b"]
+	assert.False(t, ok, "the metrics of the deleted quota are removed")
+}
+
+func TestDominantShare(t *testing.T) {
+	tests := []struct {
+		name string
+		used corev1.ResourceList
+		base corev1.ResourceList
+		want float64
+	}{
+		{
+			name: "max across resources",
+			used: createResourceList(3, 10),
+			base: createResourceList(10, 100),
+			want: 0.3,
+		},
+		{
+			name: "resources without base are ignored",
+			used: createResourceList(3, 10),
+			base: corev1.ResourceList{corev1.ResourceMemory: createResourceList(0, 20)[corev1.ResourceMemory]},
+			want: 0.5,
+		},
+		{
+			name: "empty used",
+			used: nil,
+			base: createResourceList(10, 100),
+			want: 0,
+		},
+		{
+			name: "no base",
+			used: createResourceList(1, 1),
+			base: createResourceList(0, 0),
+			want: math.Inf(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, dominantShare(tt.used, tt.base), 1e-9)
+		})
+	}
+}
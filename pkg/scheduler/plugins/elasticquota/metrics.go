@@ -51,6 +51,34 @@ var (
 			Buckets:   metrics.ExponentialBuckets(0.001, 2, 15),
 		},
 	)
+
+	ElasticQuotaPendingPods = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem: schedulermetrics.SchedulerSubsystem,
+			Name:      "elastic_quota_pending_pods",
+			Help:      "The number of pods of the ElasticQuota waiting in the scheduling queue",
+		},
+		[]string{"name", "tree"},
+	)
+
+	ElasticQuotaDominantShare = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem: schedulermetrics.SchedulerSubsystem,
+			Name:      "elastic_quota_dominant_share",
+			Help:      "The dominant share of the ElasticQuota used in quota fair queueing",
+		},
+		[]string{"name", "tree"},
+	)
+
+	ElasticQuotaPodCreationToReserveDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem: schedulermetrics.SchedulerSubsystem,
+			Name:      "elastic_quota_pod_creation_to_reserve_duration_seconds",
+			Help:      "The duration in seconds from pod creation until the pod is reserved in the ElasticQuota",
+			Buckets:   metrics.ExponentialBuckets(0.01, 2, 20),
+		},
+		[]string{"name"},
+	)
 )
 
 func init() {
@@ -58,6 +86,9 @@ func init() {
 		ElasticQuotaSpecMetric,
 		ElasticQuotaStatusMetric,
 		UpdateElasticQuotaStatusLatency,
+		ElasticQuotaPendingPods,
+		ElasticQuotaDominantShare,
+		ElasticQuotaPodCreationToReserveDuration,
 	)
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -107,10 +108,21 @@ type Plugin struct {
 	// This snapshot is updated periodically in background and doesn't need to stay in sync with quotaToTreeMap
 	quotaToTreeMapSnapshotLock sync.RWMutex
 	quotaToTreeMapSnapshot     map[string]string
+
+	// quotaShares stores the snapshot of the dominant share of each quota for the quota fair queueing
+	// It's replaced periodically in background if EnableQuotaFairQueueing, and read by the QueueSort without locks
+	quotaShares atomic.Pointer[map[string]float64]
+	// quotaSharesMetricTrees stores the tree of the quotas whose fair queueing metrics are recorded
+	quotaSharesMetricTrees map[string]string
+	// queuedPodShares stores the dominant share snapshotted for each queued pod, so that the order of the pods
+	// in the scheduling queue is stable between the refreshes of quotaShares
+	// The key is the pod UID, the value is the queuedPodShare
+	queuedPodShares sync.Map
 }
 
 var (
 	_ fwktype.EnqueueExtensions            = &Plugin{}
+	_ fwktype.QueueSortPlugin              = &Plugin{}
 	_ fwktype.PreFilterPlugin              = &Plugin{}
 	_ fwktype.PostFilterPlugin             = &Plugin{}
 	_ fwktype.ReservePlugin                = &Plugin{}
@@ -222,6 +234,12 @@ func (g *Plugin) Start() {
 		go wait.Until(g.updateQuotaSnapshot, updateInterval, nil)
 		klog.Infof("start background quota snapshot updater with interval %v", updateInterval)
 	}
+
+	if g.pluginArgs.EnableQuotaFairQueueing {
+		updateInterval := g.pluginArgs.QuotaFairQueueingUpdateInterval.Duration
+		go wait.Until(g.updateQuotaShares, updateInterval, nil)
+		klog.Infof("start background quota shares updater with interval %v", updateInterval)
+	}
 }
 
 func (g *Plugin) NewControllers() ([]frameworkext.Controller, error) {
@@ -632,6 +650,8 @@ func (g *Plugin) Reserve(ctx context.Context, state fwktype.CycleState, p *corev
 	}
 
 	mgr.ReservePod(quotaName, p)
+	g.forgetQueuedPodShare(p)
+	g.recordCreationToReserveTime(quotaName, p)
 	return fwktype.NewStatus(fwktype.Success, "")
 }
 
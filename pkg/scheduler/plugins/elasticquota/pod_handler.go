@@ -115,6 +115,7 @@ func (g *Plugin) OnPodDelete(obj interface{}) {
 }
 
 func (g *Plugin) handlePodDelete(pod *corev1.Pod) {
+	g.forgetQueuedPodShare(pod)
 	quotaName, treeID := g.getPodAssociateQuotaNameAndTreeID(pod)
 	if quotaName == "" {
 		return
@@ -0,0 +1,382 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyspread
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/networktopology"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	Name     = "NetworkTopologySpread"
+	stateKey = Name
+
+	ErrReasonNetworkTopologyNotFound   = "network topology not found"
+	ErrReasonNodeNotInNetworkTopology  = "node(s) didn't belong to the network topology layer"
+	ErrReasonSpreadConstraintsNotMatch = "node(s) didn't match network topology spread constraints"
+	ErrReasonPackConstraintsNotMatch   = "node(s) didn't match network topology pack constraints"
+)
+
+var (
+	_ fwktype.PreFilterPlugin = &Plugin{}
+	_ fwktype.FilterPlugin    = &Plugin{}
+	_ fwktype.PreScorePlugin  = &Plugin{}
+	_ fwktype.ScorePlugin     = &Plugin{}
+)
+
+// Plugin places the pods over the layers of the ClusterNetworkTopology according to the hierarchical spread and pack
+// constraints declared by the pod annotation.
+type Plugin struct {
+	handle frameworkext.ExtendedHandle
+}
+
+func New(_ context.Context, _ runtime.Object, handle fwktype.Handle) (fwktype.Plugin, error) {
+	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("expect handle to be type frameworkext.ExtendedHandle, got %T", handle)
+	}
+	return &Plugin{handle: extendedHandle}, nil
+}
+
+func (p *Plugin) Name() string {
+	return Name
+}
+
+type preFilterState struct {
+	nodeToTreeNode map[string]*networktopology.TreeNode
+	// constraints are sorted from the ancestor layers to the descendant layers.
+	constraints []*constraint
+}
+
+type constraint struct {
+	extension.NetworkTopologySpreadConstraint
+	selector labels.Selector
+	// scopeLayer is the layer of the nearest ancestor Pack constraint, within which the Spread constraint is evaluated.
+	scopeLayer schedulingv1alpha1.TopologyLayer
+	// scopeDomains records the domains of the layer under each domain of the scope layer.
+	scopeDomains  map[networktopology.TreeNodeMeta][]networktopology.TreeNodeMeta
+	podCounts     map[networktopology.TreeNodeMeta]int
+	totalPodCount int
+}
+
+func (s *preFilterState) Clone() fwktype.StateData {
+	copied := &preFilterState{
+		nodeToTreeNode: s.nodeToTreeNode,
+		constraints:    make([]*constraint, 0, len(s.constraints)),
+	}
+	for _, c := range s.constraints {
+		copiedConstraint := *c
+		copiedConstraint.podCounts = maps.Clone(c.podCounts)
+		copied.constraints = append(copied.constraints, &copiedConstraint)
+	}
+	return copied
+}
+
+func getPreFilterState(cycleState fwktype.CycleState) (*preFilterState, error) {
+	value, err := cycleState.Read(stateKey)
+	if err != nil {
+		return nil, err
+	}
+	state, ok := value.(*preFilterState)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to %s.preFilterState error", value, Name)
+	}
+	return state, nil
+}
+
+func (p *Plugin) PreFilter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodes []fwktype.NodeInfo) (*fwktype.PreFilterResult, *fwktype.Status) {
+	spreadConstraints, err := extension.GetNetworkTopologySpreadConstraints(pod)
+	if err != nil {
+		return nil, fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, err.Error())
+	}
+	if len(spreadConstraints) == 0 {
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+
+	var snapshot *networktopology.TreeSnapshot
+	if treeManager := p.handle.GetNetworkTopologyTreeManager(); treeManager != nil {
+		snapshot = treeManager.GetSnapshot()
+	}
+	if snapshot == nil || snapshot.TreeNode == nil {
+		for _, c := range spreadConstraints {
+			if c.WhenUnsatisfiable == corev1.DoNotSchedule {
+				return nil, fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrReasonNetworkTopologyNotFound)
+			}
+		}
+		klog.V(5).InfoS("network topology not found, skip the soft constraints", "pod", klog.KObj(pod))
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+
+	state, err := newPreFilterState(pod, spreadConstraints, snapshot.TreeNode)
+	if err != nil {
+		return nil, fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, err.Error())
+	}
+	allNodes, err := p.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return nil, fwktype.AsStatus(err)
+	}
+	for _, nodeInfo := range allNodes {
+		if nodeInfo.Node() == nil {
+			continue
+		}
+		treeNode := state.nodeToTreeNode[nodeInfo.Node().Name]
+		for _, podInfo := range nodeInfo.GetPods() {
+			state.updateWithPod(treeNode, podInfo.GetPod(), pod.Namespace, 1)
+		}
+	}
+	cycleState.Write(stateKey, state)
+	return nil, nil
+}
+
+func newPreFilterState(pod *corev1.Pod, spreadConstraints []extension.NetworkTopologySpreadConstraint, root *networktopology.TreeNode) (*preFilterState, error) {
+	state := &preFilterState{
+		nodeToTreeNode: map[string]*networktopology.TreeNode{},
+	}
+	layerDepth := map[schedulingv1alpha1.TopologyLayer]int{}
+	layerTreeNodes := map[schedulingv1alpha1.TopologyLayer][]*networktopology.TreeNode{}
+	var walk func(treeNode *networktopology.TreeNode, depth int)
+	walk = func(treeNode *networktopology.TreeNode, depth int) {
+		layerDepth[treeNode.Layer] = depth
+		layerTreeNodes[treeNode.Layer] = append(layerTreeNodes[treeNode.Layer], treeNode)
+		if treeNode.Layer == schedulingv1alpha1.NodeTopologyLayer {
+			state.nodeToTreeNode[treeNode.Name] = treeNode
+			return
+		}
+		for _, child := range treeNode.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	for i := range spreadConstraints {
+		spreadConstraint := spreadConstraints[i]
+		if _, ok := layerDepth[spreadConstraint.Layer]; !ok {
+			return nil, fmt.Errorf("network topology layer %q not found", spreadConstraint.Layer)
+		}
+		selector := labels.Nothing()
+		if spreadConstraint.LabelSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(spreadConstraint.LabelSelector)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(pod.Labels) > 0 {
+			selector = labels.SelectorFromSet(pod.Labels)
+		}
+		state.constraints = append(state.constraints, &constraint{
+			NetworkTopologySpreadConstraint: spreadConstraint,
+			selector:                        selector,
+			scopeLayer:                      schedulingv1alpha1.ClusterTopologyLayer,
+			podCounts:                       map[networktopology.TreeNodeMeta]int{},
+		})
+	}
+	sort.SliceStable(state.constraints, func(i, j int) bool {
+		return layerDepth[state.constraints[i].Layer] < layerDepth[state.constraints[j].Layer]
+	})
+
+	for i, c := range state.constraints {
+		if c.Policy != extension.NetworkTopologySpreadPolicySpread {
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			if state.constraints[j].Policy == extension.NetworkTopologySpreadPolicyPack &&
+				layerDepth[state.constraints[j].Layer] < layerDepth[c.Layer] {
+				c.scopeLayer = state.constraints[j].Layer
+				break
+			}
+		}
+		c.scopeDomains = map[networktopology.TreeNodeMeta][]networktopology.TreeNodeMeta{}
+		for _, domain := range layerTreeNodes[c.Layer] {
+			if scope := ancestorAt(domain, c.scopeLayer); scope != nil {
+				c.scopeDomains[scope.TreeNodeMeta] = append(c.scopeDomains[scope.TreeNodeMeta], domain.TreeNodeMeta)
+			}
+		}
+	}
+	return state, nil
+}
+
+// ancestorAt returns the tree node itself or its ancestor in the specified layer.
+func ancestorAt(treeNode *networktopology.TreeNode, layer schedulingv1alpha1.TopologyLayer) *networktopology.TreeNode {
+	for ; treeNode != nil; treeNode = treeNode.Parent {
+		if treeNode.Layer == layer {
+			return treeNode
+		}
+	}
+	return nil
+}
+
+func (s *preFilterState) updateWithPod(treeNode *networktopology.TreeNode, pod *corev1.Pod, namespace string, delta int) {
+	if treeNode == nil || pod.Namespace != namespace || pod.DeletionTimestamp != nil || reservationutil.IsReservePod(pod) {
+		return
+	}
+	podLabels := labels.Set(pod.Labels)
+	for _, c := range s.constraints {
+		if !c.selector.Matches(podLabels) {
+			continue
+		}
+		if domain := ancestorAt(treeNode, c.Layer); domain != nil {
+			c.podCounts[domain.TreeNodeMeta] += delta
+			c.totalPodCount += delta
+		}
+	}
+}
+
+// podCountRangeInScope returns the minimum and maximum number of matching pods among the sibling domains of the
+// tree node in the scope of the constraint.
+func (c *constraint) podCountRangeInScope(treeNode *networktopology.TreeNode) (minCount, maxCount int) {
+	scope := ancestorAt(treeNode, c.scopeLayer)
+	if scope == nil {
+		return 0, 0
+	}
+	for i, domain := range c.scopeDomains[scope.TreeNodeMeta] {
+		count := c.podCounts[domain]
+		if i == 0 || count < minCount {
+			minCount = count
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return minCount, maxCount
+}
+
+func (c *constraint) maxPodCount() int {
+	maxCount := 0
+	for _, count := range c.podCounts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return maxCount
+}
+
+func (p *Plugin) PreFilterExtensions() fwktype.PreFilterExtensions {
+	return p
+}
+
+func (p *Plugin) AddPod(ctx context.Context, cycleState fwktype.CycleState, podToSchedule *corev1.Pod, podInfoToAdd fwktype.PodInfo, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	state, err := getPreFilterState(cycleState)
+	if err != nil {
+		return fwktype.AsStatus(err)
+	}
+	state.updateWithPod(state.nodeToTreeNode[nodeInfo.Node().Name], podInfoToAdd.GetPod(), podToSchedule.Namespace, 1)
+	return nil
+}
+
+func (p *Plugin) RemovePod(ctx context.Context, cycleState fwktype.CycleState, podToSchedule *corev1.Pod, podInfoToRemove fwktype.PodInfo, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	state, err := getPreFilterState(cycleState)
+	if err != nil {
+		return fwktype.AsStatus(err)
+	}
+	state.updateWithPod(state.nodeToTreeNode[nodeInfo.Node().Name], podInfoToRemove.GetPod(), podToSchedule.Namespace, -1)
+	return nil
+}
+
+func (p *Plugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	state, err := getPreFilterState(cycleState)
+	if err != nil {
+		return fwktype.AsStatus(err)
+	}
+	treeNode := state.nodeToTreeNode[nodeInfo.Node().Name]
+	for _, c := range state.constraints {
+		if c.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+		domain := ancestorAt(treeNode, c.Layer)
+		if domain == nil {
+			return fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrReasonNodeNotInNetworkTopology)
+		}
+		count := c.podCounts[domain.TreeNodeMeta]
+		switch c.Policy {
+		case extension.NetworkTopologySpreadPolicyPack:
+			if c.totalPodCount > 0 && count == 0 {
+				return fwktype.NewStatus(fwktype.Unschedulable, ErrReasonPackConstraintsNotMatch)
+			}
+		case extension.NetworkTopologySpreadPolicySpread:
+			minCount, _ := c.podCountRangeInScope(treeNode)
+			if count+1-minCount > int(c.MaxSkew) {
+				return fwktype.NewStatus(fwktype.Unschedulable, ErrReasonSpreadConstraintsNotMatch)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) PreScore(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodes []fwktype.NodeInfo) *fwktype.Status {
+	if _, err := getPreFilterState(cycleState); err != nil {
+		return fwktype.NewStatus(fwktype.Skip)
+	}
+	return nil
+}
+
+// Score prefers the domains with more matching pods for the Pack constraints and the domains with fewer matching pods
+// among the siblings for the Spread constraints. The constraints on the ancestor layers are weighted higher than the
+// ones on the descendant layers.
+func (p *Plugin) Score(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) (int64, *fwktype.Status) {
+	state, err := getPreFilterState(cycleState)
+	if err != nil {
+		return 0, fwktype.AsStatus(err)
+	}
+	treeNode := state.nodeToTreeNode[nodeInfo.Node().Name]
+	if treeNode == nil {
+		return 0, nil
+	}
+	var score, totalWeight int64
+	for i, c := range state.constraints {
+		weight := int64(len(state.constraints) - i)
+		totalWeight += weight
+		domain := ancestorAt(treeNode, c.Layer)
+		if domain == nil {
+			continue
+		}
+		count := int64(c.podCounts[domain.TreeNodeMeta])
+		var constraintScore int64
+		switch c.Policy {
+		case extension.NetworkTopologySpreadPolicyPack:
+			if maxCount := int64(c.maxPodCount()); maxCount > 0 {
+				constraintScore = fwktype.MaxNodeScore * count / maxCount
+			}
+		case extension.NetworkTopologySpreadPolicySpread:
+			minCount, maxCount := c.podCountRangeInScope(treeNode)
+			constraintScore = fwktype.MaxNodeScore
+			if maxCount > minCount {
+				constraintScore = fwktype.MaxNodeScore * (int64(maxCount) - count) / int64(maxCount-minCount)
+			}
+		}
+		score += weight * constraintScore
+	}
+	return score / totalWeight, nil
+}
+
+func (p *Plugin) ScoreExtensions() fwktype.ScoreExtensions {
+	return nil
+}
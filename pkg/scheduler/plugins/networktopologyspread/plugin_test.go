@@ -0,0 +1,245 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyspread
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/backend/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/networktopology"
+)
+
+// newTestNodes returns 8 nodes, 2 nodes in each block, 2 blocks in each spine.
+func newTestNodes() []*corev1.Node {
+	var nodes []*corev1.Node
+	for i := 1; i <= 8; i++ {
+		nodes = append(nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("node-%d", i),
+				Labels: map[string]string{
+					networktopology.FakeSpineLabel: fmt.Sprintf("s%d", (i-1)/4+1),
+					networktopology.FakeBlockLabel: fmt.Sprintf("b%d", (i-1)/2+1),
+				},
+			},
+		})
+	}
+	return nodes
+}
+
+func newTestPod(name, nodeName string, constraints string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app": "test",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+	}
+	if constraints != "" {
+		pod.Annotations = map[string]string{
+			extension.AnnotationPodNetworkTopologySpreadConstraints: constraints,
+		}
+	}
+	return pod
+}
+
+func newTestPlugin(t *testing.T, nodes []*corev1.Node, pods []*corev1.Pod, withTopology bool) (*Plugin, fwktype.SharedLister) {
+	var treeManager networktopology.TreeManager
+	if withTopology {
+		treeManager, _ = networktopology.NewFakeTreeManager(networktopology.FakeClusterNetworkTopology, nodes)
+		ctx, cancel := context.WithCancel(context.TODO())
+		t.Cleanup(cancel)
+		treeManager.Run(ctx)
+	}
+	koordClientSet := koordfake.NewSimpleClientset()
+	extenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+		frameworkext.WithKoordinatorClientSet(koordClientSet),
+		frameworkext.WithKoordinatorSharedInformerFactory(koordinatorinformers.NewSharedInformerFactory(koordClientSet, 0)),
+		frameworkext.WithNetworkTopologyManager(treeManager),
+	)
+	assert.NoError(t, err)
+	snapshot := cache.NewSnapshot(pods, nodes)
+	fh, err := schedulertesting.NewFramework(
+		context.TODO(),
+		[]schedulertesting.RegisterPluginFunc{
+			schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+			schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+		},
+		"koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+		frameworkruntime.WithInformerFactory(informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)),
+	)
+	assert.NoError(t, err)
+	p, err := New(context.TODO(), nil, extenderFactory.NewFrameworkExtender(fh))
+	assert.NoError(t, err)
+	return p.(*Plugin), snapshot
+}
+
+func TestPreFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		constraints  string
+		withTopology bool
+		wantStatus   *fwktype.Status
+	}{
+		{
+			name:         "skip pod without constraints",
+			withTopology: true,
+			wantStatus:   fwktype.NewStatus(fwktype.Skip),
+		},
+		{
+			name:         "invalid constraints",
+			constraints:  `[{"layer":"BlockLayer","policy":"Balance"}]`,
+			withTopology: true,
+			wantStatus:   fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, `unsupported network topology spread policy "Balance"`),
+		},
+		{
+			name:         "unknown layer",
+			constraints:  `[{"layer":"RackLayer","policy":"Spread"}]`,
+			withTopology: true,
+			wantStatus:   fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, `network topology layer "RackLayer" not found`),
+		},
+		{
+			name:        "skip soft constraints without network topology",
+			constraints: `[{"layer":"BlockLayer","policy":"Spread"}]`,
+			wantStatus:  fwktype.NewStatus(fwktype.Skip),
+		},
+		{
+			name:        "hard constraints without network topology",
+			constraints: `[{"layer":"BlockLayer","policy":"Spread","whenUnsatisfiable":"DoNotSchedule"}]`,
+			wantStatus:  fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrReasonNetworkTopologyNotFound),
+		},
+		{
+			name:         "normal flow",
+			constraints:  `[{"layer":"BlockLayer","policy":"Spread"},{"layer":"SpineLayer","policy":"Pack"}]`,
+			withTopology: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newTestPlugin(t, newTestNodes(), nil, tt.withTopology)
+			cycleState := framework.NewCycleState()
+			_, status := p.PreFilter(context.TODO(), cycleState, newTestPod("test-pod", "", tt.constraints), nil)
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus.IsSuccess() {
+				state, err := getPreFilterState(cycleState)
+				assert.NoError(t, err)
+				// constraints are sorted from the ancestor layers
+				assert.Equal(t, "SpineLayer", string(state.constraints[0].Layer))
+				assert.Equal(t, "BlockLayer", string(state.constraints[1].Layer))
+				assert.Equal(t, "SpineLayer", string(state.constraints[1].scopeLayer))
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	nodes := newTestNodes()
+	existingPods := []*corev1.Pod{
+		newTestPod("existing-pod-1", "node-1", ""),
+	}
+	hardConstraints := `[{"layer":"SpineLayer","policy":"Pack","whenUnsatisfiable":"DoNotSchedule"},{"layer":"BlockLayer","policy":"Spread","whenUnsatisfiable":"DoNotSchedule"}]`
+	p, snapshot := newTestPlugin(t, nodes, existingPods, true)
+	pod := newTestPod("test-pod", "", hardConstraints)
+	cycleState := framework.NewCycleState()
+	_, status := p.PreFilter(context.TODO(), cycleState, pod, nil)
+	assert.True(t, status.IsSuccess())
+
+	wantStatuses := map[string]*fwktype.Status{
+		"node-1": fwktype.NewStatus(fwktype.Unschedulable, ErrReasonSpreadConstraintsNotMatch),
+		"node-2": fwktype.NewStatus(fwktype.Unschedulable, ErrReasonSpreadConstraintsNotMatch),
+		"node-3": nil,
+		"node-4": nil,
+		"node-5": fwktype.NewStatus(fwktype.Unschedulable, ErrReasonPackConstraintsNotMatch),
+		"node-8": fwktype.NewStatus(fwktype.Unschedulable, ErrReasonPackConstraintsNotMatch),
+	}
+	for nodeName, want := range wantStatuses {
+		nodeInfo, err := snapshot.NodeInfos().Get(nodeName)
+		assert.NoError(t, err)
+		assert.Equal(t, want, p.Filter(context.TODO(), cycleState, pod, nodeInfo), nodeName)
+	}
+
+	// the block b2 is allowed as well after a matching pod is added into it
+	nodeInfo3, _ := snapshot.NodeInfos().Get("node-3")
+	nodeInfo1, _ := snapshot.NodeInfos().Get("node-1")
+	podInfo, _ := framework.NewPodInfo(newTestPod("nominated-pod", "node-3", ""))
+	clonedState := cycleState.Clone()
+	assert.Nil(t, p.AddPod(context.TODO(), clonedState, pod, podInfo, nodeInfo3))
+	assert.Nil(t, p.Filter(context.TODO(), clonedState, pod, nodeInfo1))
+	assert.Equal(t, fwktype.NewStatus(fwktype.Unschedulable, ErrReasonSpreadConstraintsNotMatch), p.Filter(context.TODO(), cycleState, pod, nodeInfo1))
+
+	// any spine is allowed after the existing pod is removed
+	nodeInfo5, _ := snapshot.NodeInfos().Get("node-5")
+	existingPodInfo, _ := framework.NewPodInfo(existingPods[0])
+	clonedState = cycleState.Clone()
+	assert.Nil(t, p.RemovePod(context.TODO(), clonedState, pod, existingPodInfo, nodeInfo1))
+	assert.Nil(t, p.Filter(context.TODO(), clonedState, pod, nodeInfo5))
+}
+
+func TestScore(t *testing.T) {
+	nodes := newTestNodes()
+	existingPods := []*corev1.Pod{
+		newTestPod("existing-pod-1", "node-1", ""),
+	}
+	softConstraints := `[{"layer":"SpineLayer","policy":"Pack"},{"layer":"BlockLayer","policy":"Spread"}]`
+	p, snapshot := newTestPlugin(t, nodes, existingPods, true)
+	pod := newTestPod("test-pod", "", softConstraints)
+	cycleState := framework.NewCycleState()
+	_, status := p.PreFilter(context.TODO(), cycleState, pod, nil)
+	assert.True(t, status.IsSuccess())
+	assert.True(t, p.PreScore(context.TODO(), cycleState, pod, nil).IsSuccess())
+
+	wantScores := map[string]int64{
+		// packed in the spine, but not spread in the block
+		"node-1": (2*fwktype.MaxNodeScore + 0) / 3,
+		// packed in the spine, and spread in the block
+		"node-3": (2*fwktype.MaxNodeScore + fwktype.MaxNodeScore) / 3,
+		// not packed in the spine
+		"node-5": (0 + fwktype.MaxNodeScore) / 3,
+	}
+	for nodeName, want := range wantScores {
+		nodeInfo, err := snapshot.NodeInfos().Get(nodeName)
+		assert.NoError(t, err)
+		got, status := p.Score(context.TODO(), cycleState, pod, nodeInfo)
+		assert.True(t, status.IsSuccess())
+		assert.Equal(t, want, got, nodeName)
+	}
+
+	assert.True(t, p.PreScore(context.TODO(), framework.NewCycleState(), pod, nil).IsSkip())
+}
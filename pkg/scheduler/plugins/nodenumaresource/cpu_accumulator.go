@@ -85,6 +85,21 @@ func takePreferredCPUs(
 	return result, nil
 }
 
+// TakeCPUs allocates the CPUs in the same way as the scheduler does.
+// It is used by the components outside the scheduler to simulate the cpuset allocation, e.g. koord-descheduler.
+func TakeCPUs(
+	topology *CPUTopology,
+	maxRefCount int,
+	availableCPUs cpuset.CPUSet,
+	allocatedCPUs CPUDetails,
+	numCPUsNeeded int,
+	cpuBindPolicy schedulingconfig.CPUBindPolicy,
+	cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
+	numaAllocatedStrategy schedulingconfig.NUMAAllocateStrategy,
+) (cpuset.CPUSet, error) {
+	return takeCPUs(topology, maxRefCount, availableCPUs, allocatedCPUs, numCPUsNeeded, cpuBindPolicy, cpuExclusivePolicy, numaAllocatedStrategy)
+}
+
 func takeCPUs(
 	topology *CPUTopology,
 	maxRefCount int,
@@ -103,7 +118,12 @@ func takeCPUs(
 		return cpuset.NewCPUSet(), errors.New(ErrNotEnoughCPUs)
 	}
 
-	fullPCPUs := cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs
+	// The LLCAligned policy allocates full physical cores as the FullPCPUs policy does,
+	// and tries to pack them in the minimal number of LLC domains first.
+	fullPCPUs := cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs || cpuBindPolicy == schedulingconfig.CPUBindPolicyLLCAligned
+	if cpuBindPolicy == schedulingconfig.CPUBindPolicyLLCAligned && acc.takeLLCAlignedCPUs() {
+		return acc.result, nil
+	}
 	if fullPCPUs || acc.topology.CPUsPerCore() == 1 {
 		// According to the NUMA allocation strategy,
 		// select the NUMA Node with the most remaining amount or the least amount remaining
@@ -114,6 +134,7 @@ func takeCPUs(
 				freeCPUs := acc.freeCoresInNode(true, filterExclusive)
 				for _, cpus := range freeCPUs {
 					if len(cpus) >= acc.numCPUsNeeded {
+						cpus = acc.packInLLC(cpus)
 						acc.take(cpus[:acc.numCPUsNeeded]...)
 						return acc.result, nil
 					}
@@ -128,6 +149,7 @@ func takeCPUs(
 			freeCPUs := acc.freeCoresInSocket(true)
 			for _, cpus := range freeCPUs {
 				if len(cpus) >= acc.numCPUsNeeded {
+					cpus = acc.packInLLC(cpus)
 					acc.take(cpus[:acc.numCPUsNeeded]...)
 					return acc.result, nil
 				}
@@ -527,6 +549,154 @@ func (a *cpuAccumulator) freeCoresInSocket(filterFullFreeCore bool) [][]int {
 	return result
 }
 
+// freeCoresInLLC returns the logical cpus of the full free cores in LLC domains that sorted
+func (a *cpuAccumulator) freeCoresInLLC() [][]int {
+	allocatableCPUs := a.allocatableCPUs
+
+	cpusInCores := make(map[int][]int)
+	for _, cpuInfo := range allocatableCPUs {
+		if a.isCPUExclusiveNUMANodeLevel(&cpuInfo) {
+			continue
+		}
+		cpus := cpusInCores[cpuInfo.CoreID]
+		if len(cpus) == 0 {
+			cpus = make([]int, 0, a.topology.CPUsPerCore())
+		}
+		cpus = append(cpus, cpuInfo.CPUID)
+		cpusInCores[cpuInfo.CoreID] = cpus
+	}
+
+	coresInLLCs := make(map[int][]int)
+	for core, cpus := range cpusInCores {
+		if len(cpus) != a.topology.CPUsPerCore() {
+			continue
+		}
+		info := allocatableCPUs[cpus[0]]
+		coresInLLCs[info.LLCID] = append(coresInLLCs[info.LLCID], core)
+	}
+
+	llcIDs := make([]int, 0, len(coresInLLCs))
+	cpusInLLCs := make(map[int][]int)
+	for llc, cores := range coresInLLCs {
+		llcIDs = append(llcIDs, llc)
+		a.sortCores(allocatableCPUs, cores, cpusInCores)
+		cpusInCore := make([]int, 0, len(cores)*a.topology.CPUsPerCore())
+		for _, c := range cores {
+			cpus := cpusInCores[c]
+			sort.Ints(cpus)
+			cpusInCore = append(cpusInCore, cpus...)
+		}
+		cpusInLLCs[llc] = cpusInCore
+	}
+
+	sort.Slice(llcIDs, func(i, j int) bool {
+		iLLCFreeScore := len(cpusInLLCs[llcIDs[i]])
+		jLLCFreeScore := len(cpusInLLCs[llcIDs[j]])
+
+		if iLLCFreeScore != jLLCFreeScore {
+			if a.numaAllocateStrategy == schedulingconfig.NUMAMostAllocated {
+				return iLLCFreeScore < jLLCFreeScore
+			} else {
+				return iLLCFreeScore > jLLCFreeScore
+			}
+		}
+		return llcIDs[i] < llcIDs[j]
+	})
+
+	var result [][]int
+	for _, llc := range llcIDs {
+		result = append(result, cpusInLLCs[llc])
+	}
+
+	return result
+}
+
+// takeLLCAlignedCPUs takes the full free cores from the minimal number of LLC domains,
+// it returns false and takes nothing if the LLC domains cannot hold the needed cpus.
+func (a *cpuAccumulator) takeLLCAlignedCPUs() bool {
+	cpusPerLLC := a.topology.CPUsPerLLC()
+	if cpusPerLLC == 0 {
+		return false
+	}
+	freeCPUs := a.freeCoresInLLC()
+	numLLCsNeeded := (a.numCPUsNeeded + cpusPerLLC - 1) / cpusPerLLC
+	if numLLCsNeeded == 1 {
+		// According to the NUMA allocation strategy,
+		// select the LLC domain with the most remaining amount or the least amount remaining
+		for _, cpus := range freeCPUs {
+			if len(cpus) >= a.numCPUsNeeded {
+				a.take(cpus[:a.numCPUsNeeded]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	// The amount of CPUs needed exceeds a LLC domain, allocate from the LLC domains
+	// with the most remaining physical cores to span as few LLC domains as possible.
+	sort.SliceStable(freeCPUs, func(i, j int) bool {
+		return len(freeCPUs[i]) > len(freeCPUs[j])
+	})
+	if len(freeCPUs) < numLLCsNeeded {
+		return false
+	}
+	numCPUs := 0
+	for _, cpus := range freeCPUs[:numLLCsNeeded] {
+		numCPUs += len(cpus)
+	}
+	if numCPUs < a.numCPUsNeeded {
+		return false
+	}
+	for _, cpus := range freeCPUs[:numLLCsNeeded] {
+		if len(cpus) > a.numCPUsNeeded {
+			cpus = cpus[:a.numCPUsNeeded]
+		}
+		a.take(cpus...)
+		if a.isSatisfied() {
+			break
+		}
+	}
+	return true
+}
+
+// packInLLC reorders the logical cpus of the free cores so that the cpus in the same LLC domain are adjacent.
+// The LLC domains which can hold all the needed cpus come first according to the NUMA allocation strategy,
+// and the others are sorted by the number of free cpus in descending order to span as few LLC domains as possible.
+func (a *cpuAccumulator) packInLLC(cpus []int) []int {
+	var llcIDs []int
+	cpusInLLCs := make(map[int][]int)
+	for _, cpu := range cpus {
+		llcID := a.topology.CPUDetails[cpu].LLCID
+		if _, ok := cpusInLLCs[llcID]; !ok {
+			llcIDs = append(llcIDs, llcID)
+		}
+		cpusInLLCs[llcID] = append(cpusInLLCs[llcID], cpu)
+	}
+	if len(llcIDs) <= 1 {
+		return cpus
+	}
+
+	sort.SliceStable(llcIDs, func(i, j int) bool {
+		iLLCFreeScore := len(cpusInLLCs[llcIDs[i]])
+		jLLCFreeScore := len(cpusInLLCs[llcIDs[j]])
+		iSatisfied := iLLCFreeScore >= a.numCPUsNeeded
+		jSatisfied := jLLCFreeScore >= a.numCPUsNeeded
+		if iSatisfied != jSatisfied {
+			return iSatisfied
+		}
+		if iSatisfied && a.numaAllocateStrategy == schedulingconfig.NUMAMostAllocated {
+			return iLLCFreeScore < jLLCFreeScore
+		}
+		return iLLCFreeScore > jLLCFreeScore
+	})
+
+	result := make([]int, 0, len(cpus))
+	for _, llcID := range llcIDs {
+		result = append(result, cpusInLLCs[llcID]...)
+	}
+	return result
+}
+
 // freeCPUsInNode returns free logical cpus in nodes that sorted in ascending order.
 func (a *cpuAccumulator) freeCPUsInNode(filterExclusive bool) [][]int {
 	cpusInNodes := make(map[int][]int)
@@ -17,6 +17,7 @@ limitations under the License.
 package nodenumaresource
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -755,6 +756,159 @@ func BenchmarkTakeCPUsWithSpread(b *testing.B) {
 	}
 }
 
+// buildLLCCPUTopologyForTest builds the topology with multiple LLC domains in a NUMA node,
+// e.g. AMD EPYC processors share the L3 cache in each CCX.
+func buildLLCCPUTopologyForTest(numSockets, nodesPerSocket, llcsPerNode, coresPerLLC, cpusPerCore int) *CPUTopology {
+	builder := NewCPUTopologyBuilder()
+	var nodeID, llcID, coreID, cpuID int
+	for s := 0; s < numSockets; s++ {
+		for n := 0; n < nodesPerSocket; n++ {
+			for l := 0; l < llcsPerNode; l++ {
+				for c := 0; c < coresPerLLC; c++ {
+					for p := 0; p < cpusPerCore; p++ {
+						builder.AddCPUInfoWithLLC(s, nodeID, llcID, coreID, cpuID)
+						cpuID++
+					}
+					coreID++
+				}
+				llcID++
+			}
+			nodeID++
+		}
+	}
+	return builder.Result()
+}
+
+func TestTakeCPUsInLLC(t *testing.T) {
+	tests := []struct {
+		name          string
+		topology      *CPUTopology
+		allocatedCPUs cpuset.CPUSet
+		numCPUsNeeded int
+		cpuBindPolicy schedulingconfig.CPUBindPolicy
+		wantResult    cpuset.CPUSet
+		wantLLCs      int
+	}{
+		{
+			name:          "FullPCPUs packs in the next LLC if the partially allocated LLC is not enough",
+			topology:      buildLLCCPUTopologyForTest(1, 1, 16, 4, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1),
+			numCPUsNeeded: 8,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+			wantResult:    cpuset.NewCPUSet(8, 9, 10, 11, 12, 13, 14, 15),
+			wantLLCs:      1,
+		},
+		{
+			name:          "FullPCPUs packs in the most allocated LLC",
+			topology:      buildLLCCPUTopologyForTest(1, 1, 16, 4, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1),
+			numCPUsNeeded: 6,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+			wantResult:    cpuset.NewCPUSet(2, 3, 4, 5, 6, 7),
+			wantLLCs:      1,
+		},
+		{
+			name:          "LLCAligned takes the whole free LLCs",
+			topology:      buildLLCCPUTopologyForTest(1, 1, 16, 4, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1),
+			numCPUsNeeded: 16,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyLLCAligned,
+			wantResult:    cpuset.NewCPUSet(8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23),
+			wantLLCs:      2,
+		},
+		{
+			name:          "LLCAligned takes the LLC on the NPS4 node",
+			topology:      buildLLCCPUTopologyForTest(1, 4, 4, 4, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1, 8, 9, 16, 17),
+			numCPUsNeeded: 6,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyLLCAligned,
+			wantResult:    cpuset.NewCPUSet(2, 3, 4, 5, 6, 7),
+			wantLLCs:      1,
+		},
+		{
+			name:          "LLCAligned falls back to FullPCPUs if no LLC can hold the cpus",
+			topology:      buildLLCCPUTopologyForTest(1, 1, 2, 4, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1, 8, 9),
+			numCPUsNeeded: 8,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyLLCAligned,
+			wantResult:    cpuset.NewCPUSet(2, 3, 4, 5, 6, 7, 10, 11),
+			wantLLCs:      2,
+		},
+		{
+			name:          "LLCAligned on the Xeon node sharing LLC in the socket",
+			topology:      buildLLCCPUTopologyForTest(2, 1, 1, 16, 2),
+			allocatedCPUs: cpuset.NewCPUSet(0, 1),
+			numCPUsNeeded: 8,
+			cpuBindPolicy: schedulingconfig.CPUBindPolicyLLCAligned,
+			wantResult:    cpuset.NewCPUSet(2, 3, 4, 5, 6, 7, 8, 9),
+			wantLLCs:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availableCPUs := tt.topology.CPUDetails.CPUs().Difference(tt.allocatedCPUs)
+			allocatedCPUsDetails := tt.topology.CPUDetails.KeepOnly(tt.allocatedCPUs)
+			result, err := takeCPUs(
+				tt.topology, 1, availableCPUs, allocatedCPUsDetails,
+				tt.numCPUsNeeded, tt.cpuBindPolicy, schedulingconfig.CPUExclusivePolicyNone, schedulingconfig.NUMAMostAllocated)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResult.String(), result.String())
+			assert.Equal(t, tt.wantLLCs, tt.topology.CPUDetails.KeepOnly(result).LLCs().Size())
+			err = satisfiedRequiredCPUBindPolicy(schedulingconfig.CPUBindPolicyLLCAligned, result, tt.topology)
+			assert.Equal(t, tt.wantLLCs > (tt.numCPUsNeeded+tt.topology.CPUsPerLLC()-1)/tt.topology.CPUsPerLLC(), err != nil)
+		})
+	}
+}
+
+func BenchmarkTakeCPUsInLLC(b *testing.B) {
+	topologies := []struct {
+		name     string
+		topology *CPUTopology
+	}{
+		{
+			name:     "EPYC-2S-NPS1-CCX4",
+			topology: buildLLCCPUTopologyForTest(2, 1, 16, 4, 2),
+		},
+		{
+			name:     "EPYC-2S-NPS4-CCX8",
+			topology: buildLLCCPUTopologyForTest(2, 4, 2, 8, 2),
+		},
+		{
+			name:     "Xeon-2S",
+			topology: buildLLCCPUTopologyForTest(2, 1, 1, 32, 2),
+		},
+	}
+	policies := []schedulingconfig.CPUBindPolicy{
+		schedulingconfig.CPUBindPolicyFullPCPUs,
+		schedulingconfig.CPUBindPolicyLLCAligned,
+	}
+	b.ResetTimer()
+	for _, topo := range topologies {
+		// allocate one core in each LLC to make the LLCs fragmented
+		builder := cpuset.NewCPUSetBuilder()
+		for _, llc := range topo.topology.CPUDetails.LLCs().ToSlice() {
+			cpus := topo.topology.CPUDetails.CPUsInLLCs(llc).ToSlice()
+			builder.Add(cpus[:topo.topology.CPUsPerCore()]...)
+		}
+		allocatedCPUs := builder.Result()
+		availableCPUs := topo.topology.CPUDetails.CPUs().Difference(allocatedCPUs)
+		allocatedCPUsDetails := topo.topology.CPUDetails.KeepOnly(allocatedCPUs)
+		for _, policy := range policies {
+			for _, numCPUsNeeded := range []int{4, 8, 16, 32} {
+				b.Run(fmt.Sprintf("%s/%s/%dC", topo.name, policy, numCPUsNeeded), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						_, err := takeCPUs(
+							topo.topology, 1, availableCPUs, allocatedCPUsDetails, numCPUsNeeded, policy, schedulingconfig.CPUExclusivePolicyNone, schedulingconfig.NUMAMostAllocated)
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
 func TestTakePreferredCPUs(t *testing.T) {
 	topology := buildCPUTopologyForTest(2, 1, 16, 2)
 	cpus := topology.CPUDetails.CPUs()
@@ -29,27 +29,38 @@ type CPUTopology struct {
 	NumCores   int        `json:"numCores"`
 	NumNodes   int        `json:"numNodes"`
 	NumSockets int        `json:"numSockets"`
+	NumLLCs    int        `json:"numLLCs"`
 	CPUDetails CPUDetails `json:"cpuDetails"`
 }
 
 type CPUTopologyBuilder struct {
 	topologyTracker map[int] /*socket*/ map[int] /*node*/ map[int] /*core*/ struct{}
+	llcTracker      map[int]struct{}
 	topology        CPUTopology
 }
 
 func NewCPUTopologyBuilder() *CPUTopologyBuilder {
 	return &CPUTopologyBuilder{
 		topologyTracker: map[int]map[int]map[int]struct{}{},
+		llcTracker:      map[int]struct{}{},
 	}
 }
 
 func (b *CPUTopologyBuilder) AddCPUInfo(socketID, nodeID, coreID, cpuID int) *CPUTopologyBuilder {
+	return b.AddCPUInfoWithLLC(socketID, nodeID, 0, coreID, cpuID)
+}
+
+// AddCPUInfoWithLLC adds the CPU with the ID of the last-level cache it shares.
+// The CPUs in the same socket without the LLC info are considered to share one LLC domain.
+func (b *CPUTopologyBuilder) AddCPUInfoWithLLC(socketID, nodeID, llcID, coreID, cpuID int) *CPUTopologyBuilder {
 	coreID = socketID<<16 | coreID
+	llcID = socketID<<16 | llcID
 	cpuInfo := &CPUInfo{
 		CPUID:    cpuID,
 		CoreID:   coreID,
 		NodeID:   nodeID,
 		SocketID: socketID,
+		LLCID:    llcID,
 	}
 	if b.topology.CPUDetails == nil {
 		b.topology.CPUDetails = NewCPUDetails()
@@ -67,6 +78,10 @@ func (b *CPUTopologyBuilder) AddCPUInfo(socketID, nodeID, coreID, cpuID int) *CP
 		b.topology.NumCores++
 		b.topologyTracker[cpuInfo.SocketID][nodeID][coreID] = struct{}{}
 	}
+	if _, ok := b.llcTracker[llcID]; !ok {
+		b.topology.NumLLCs++
+		b.llcTracker[llcID] = struct{}{}
+	}
 	b.topology.NumCPUs = len(b.topology.CPUDetails)
 	return b
 }
@@ -104,6 +119,14 @@ func (topo *CPUTopology) CPUsPerNode() int {
 	return topo.NumCPUs / topo.NumNodes
 }
 
+// CPUsPerLLC returns the number of logical CPUs are associated with each last-level cache domain.
+func (topo *CPUTopology) CPUsPerLLC() int {
+	if topo.NumLLCs == 0 {
+		return 0
+	}
+	return topo.NumCPUs / topo.NumLLCs
+}
+
 // CPUDetails is a map from logical CPU ID to CPUInfo.
 type CPUDetails map[int]CPUInfo
 
@@ -112,12 +135,13 @@ func NewCPUDetails() CPUDetails {
 	return CPUDetails{}
 }
 
-// CPUInfo contains the NUMA, socket, and core IDs associated with a CPU.
+// CPUInfo contains the NUMA, socket, last-level cache and core IDs associated with a CPU.
 type CPUInfo struct {
 	CPUID           int                                 `json:"cpuID"`
 	CoreID          int                                 `json:"coreID"`
 	NodeID          int                                 `json:"nodeID"`
 	SocketID        int                                 `json:"socketID"`
+	LLCID           int                                 `json:"llcID"`
 	RefCount        int                                 `json:"refCount"`
 	ExclusivePolicy schedulingconfig.CPUExclusivePolicy `json:"exclusivePolicy"`
 }
@@ -234,6 +258,28 @@ func (d CPUDetails) CoresInSockets(ids ...int) cpuset.CPUSet {
 	return b.Result()
 }
 
+// LLCs returns the last-level cache IDs associated with the CPUs in this CPUDetails.
+func (d CPUDetails) LLCs() cpuset.CPUSet {
+	b := cpuset.NewCPUSetBuilder()
+	for _, info := range d {
+		b.Add(info.LLCID)
+	}
+	return b.Result()
+}
+
+// CPUsInLLCs returns the logical CPU IDs associated with the given last-level cache IDs in this CPUDetails.
+func (d CPUDetails) CPUsInLLCs(ids ...int) cpuset.CPUSet {
+	b := cpuset.NewCPUSetBuilder()
+	for _, id := range ids {
+		for cpu, info := range d {
+			if info.LLCID == id {
+				b.Add(cpu)
+			}
+		}
+	}
+	return b.Result()
+}
+
 // CPUs returns the logical CPU IDs in this CPUDetails.
 func (d CPUDetails) CPUs() cpuset.CPUSet {
 	b := cpuset.NewCPUSetBuilder()
@@ -76,6 +76,7 @@ var (
 	_ frameworkext.ReservationPreAllocationRestorePlugin = &Plugin{}
 	_ frameworkext.ReservationFilterPlugin               = &Plugin{}
 	_ frameworkext.ReservationPreBindPlugin              = &Plugin{}
+	_ frameworkext.CacheableFilterPlugin                 = &Plugin{}
 	_ topologymanager.NUMATopologyHintProvider           = &Plugin{}
 )
 
@@ -337,7 +338,8 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState fwktype.CycleState, p
 		}
 
 		if cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs ||
-			cpuBindPolicy == schedulingconfig.CPUBindPolicySpreadByPCPUs {
+			cpuBindPolicy == schedulingconfig.CPUBindPolicySpreadByPCPUs ||
+			cpuBindPolicy == schedulingconfig.CPUBindPolicyLLCAligned {
 			if requestedCPU%1000 != 0 {
 				return nil, fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrInvalidRequestedCPUs)
 			}
@@ -361,6 +363,33 @@ func (p *Plugin) PreFilterExtensions() fwktype.PreFilterExtensions {
 }
 
 func (p *Plugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	return frameworkext.RunFilterWithEquivalenceCache(p, cycleState, pod, nodeInfo, func() *fwktype.Status {
+		return p.filter(ctx, cycleState, pod, nodeInfo)
+	})
+}
+
+// IsFilterCacheable returns false when the pod is allocated with the designated resources, any reservation is on the
+// node, or the Filter admits the pod with the NUMA topology manager which stores the NUMA affinity into the CycleState.
+func (p *Plugin) IsFilterCacheable(cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) bool {
+	state, status := getPreFilterState(cycleState)
+	if !status.IsSuccess() || state.skip || state.designatedAllocation != nil {
+		return false
+	}
+	node := nodeInfo.Node()
+	restoreState := getReservationRestoreState(cycleState).getNodeState(node.Name)
+	if len(restoreState.matched) > 0 || len(restoreState.unmatched) > 0 || restoreState.preAllocationRInfo != nil {
+		return false
+	}
+	topologyOptions := p.topologyOptionsManager.GetTopologyOptions(node.Name)
+	numaTopologyPolicy := getNUMATopologyPolicy(node.Labels, topologyOptions.NUMATopologyPolicy)
+	numaTopologyPolicy, err := mergeTopologyPolicy(numaTopologyPolicy, state.podNUMATopologyPolicy)
+	if err != nil {
+		return true
+	}
+	return numaTopologyPolicy == extension.NUMATopologyPolicyNone || numaTopologyPolicy == extension.NUMATopologyPolicyBestEffort
+}
+
+func (p *Plugin) filter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
 		return status
@@ -405,7 +434,10 @@ func (p *Plugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod
 
 		requiredCPUBindPolicy := state.requiredCPUBindPolicy
 		if nodeCPUBindPolicy == extension.NodeCPUBindPolicyFullPCPUsOnly {
-			requiredCPUBindPolicy = schedulingconfig.CPUBindPolicyFullPCPUs
+			// the LLCAligned policy allocates full physical cores as well
+			if requiredCPUBindPolicy != schedulingconfig.CPUBindPolicyLLCAligned {
+				requiredCPUBindPolicy = schedulingconfig.CPUBindPolicyFullPCPUs
+			}
 		} else if nodeCPUBindPolicy == extension.NodeCPUBindPolicySpreadByPCPUs {
 			requiredCPUBindPolicy = schedulingconfig.CPUBindPolicySpreadByPCPUs
 		}
@@ -413,7 +445,8 @@ func (p *Plugin) Filter(ctx context.Context, cycleState fwktype.CycleState, pod
 			return fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrCPUBindPolicyConflict)
 		}
 
-		if requiredCPUBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs {
+		if requiredCPUBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs ||
+			requiredCPUBindPolicy == schedulingconfig.CPUBindPolicyLLCAligned {
 			if state.numCPUsNeeded%topologyOptions.CPUTopology.CPUsPerCore() != 0 {
 				return fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, ErrSMTAlignmentError)
 			}
@@ -25,12 +25,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 // BenchmarkPreFilter measures the cost of the PreFilter phase for a CPU-bind pod
@@ -131,6 +135,66 @@ func BenchmarkFilter_CPUBind(b *testing.B) {
 	}
 }
 
+// BenchmarkFilter_CPUBind_EquivalenceCache measures the cost of filtering a CPU-bind pod with the required
+// FullPCPUs bind policy across a 256-node cluster, with and without the Filter equivalence cache. Each iteration
+// filters an equivalent pod in a new cycle, so all iterations but the first hit the cache when it is enabled.
+func BenchmarkFilter_CPUBind_EquivalenceCache(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("FilterEquivalenceCache=%v", enabled), func(b *testing.B) {
+			defer utilfeature.SetFeatureGateDuringTest(b, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, enabled)()
+
+			const numNodes = 256
+			nodes := makeNUMANodes(numNodes)
+			for _, node := range nodes {
+				delete(node.Labels, extension.LabelNUMATopologyPolicy)
+			}
+			suit := newPluginTestSuit(b, nil, nodes)
+			p, err := suit.proxyNew(context.TODO(), suit.nodeNUMAResourceArgs, suit.Handle)
+			assert.NoError(b, err)
+			pl := p.(*Plugin)
+
+			topo := buildCPUTopologyForTest(2, 1, 8, 2)
+			for i := 0; i < numNodes; i++ {
+				nodeName := fmt.Sprintf("node-%d", i)
+				pl.topologyOptionsManager.UpdateTopologyOptions(nodeName, func(opts *TopologyOptions) {
+					opts.CPUTopology = topo
+				})
+				manager := pl.resourceManager.(*resourceManager)
+				manager.nodeAllocations[nodeName] = NewNodeAllocation(nodeName)
+			}
+
+			suit.start(b)
+
+			nodeInfos, err := suit.Handle.SnapshotSharedLister().NodeInfos().List()
+			assert.NoError(b, err)
+
+			pod := makeCPUBindPod(4)
+			state := &preFilterState{
+				requestCPUBind:        true,
+				requiredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+				numCPUsNeeded:         4,
+				requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("4"),
+				},
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cycleState := framework.NewCycleState()
+				cycleState.Write(stateKey, state.Clone().(*preFilterState))
+				topologymanager.InitStore(cycleState)
+				frameworkext.SetPodEquivalenceHash(cycleState, pod)
+				for _, nodeInfo := range nodeInfos {
+					status := pl.Filter(context.TODO(), cycleState, pod, nodeInfo)
+					if !status.IsSuccess() {
+						b.Fatalf("Filter failed: %v", status)
+					}
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkScore_CPUBind measures the cost of the Score phase for a CPU-bind pod.
 func BenchmarkScore_CPUBind(b *testing.B) {
 	const numNodes = 256
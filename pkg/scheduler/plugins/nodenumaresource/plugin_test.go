@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	clientfeatures "k8s.io/client-go/features"
 	"k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
@@ -51,15 +52,18 @@ import (
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	_ "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/scheme"
 	v1 "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/hinter"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/topologymanager"
 	"github.com/koordinator-sh/koordinator/pkg/util/bitmask"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 type mutableClientFeatureGates interface {
@@ -1249,6 +1253,52 @@ func TestFilterWithAmplifiedCPUs(t *testing.T) {
 	}
 }
 
+func TestFilterWithEquivalenceCache(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.FilterEquivalenceCache, true)()
+
+	node := makeNode("test-equivalence-node", map[corev1.ResourceName]string{"cpu": "16", "memory": "40Gi"}, 1.0)
+	suit := newPluginTestSuit(t, nil, []*corev1.Node{node})
+	p, err := suit.proxyNew(context.TODO(), suit.nodeNUMAResourceArgs, suit.Handle)
+	assert.NoError(t, err)
+	suit.start(t)
+	pl := p.(*Plugin)
+	pl.topologyOptionsManager.UpdateTopologyOptions(node.Name, func(options *TopologyOptions) {
+		options.CPUTopology = buildCPUTopologyForTest(2, 1, 4, 2)
+	})
+
+	newPod := func(name string) *corev1.Pod {
+		pod := makePod(map[corev1.ResourceName]string{"cpu": "8"}, true)
+		pod.Name = name
+		assert.NoError(t, extension.SetResourceSpec(pod, &extension.ResourceSpec{
+			RequiredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs,
+		}))
+		return pod
+	}
+	runFilter := func(pod *corev1.Pod) (*fwktype.Status, fwktype.CycleState) {
+		cycleState := framework.NewCycleState()
+		frameworkext.SetPodEquivalenceHash(cycleState, pod)
+		_, status := pl.PreFilter(context.TODO(), cycleState, pod, nil)
+		assert.True(t, status.IsSuccess())
+		nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get(node.Name)
+		assert.NoError(t, err)
+		return pl.Filter(context.TODO(), cycleState, pod, nodeInfo), cycleState
+	}
+
+	pod := newPod("pod-1")
+	status, cycleState := runFilter(pod)
+	assert.True(t, status.IsSuccess())
+	nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get(node.Name)
+	assert.NoError(t, err)
+	_, cached := equivalence.DefaultCache().Lookup(node.Name, nodeInfo.GetGeneration(), Name, frameworkext.GetPodEquivalenceHash(cycleState))
+	assert.True(t, cached)
+
+	// the CPUs allocated by an assigned pod invalidate the cached result through the pod event handler
+	handler := &podEventHandler{resourceManager: pl.resourceManager}
+	handler.OnAdd(makePodOnNode(map[corev1.ResourceName]string{"cpu": "16"}, node.Name, true), true)
+	status, _ = runFilter(newPod("pod-2"))
+	assert.False(t, status.IsSuccess())
+}
+
 func TestPlugin_FilterNominateReservation(t *testing.T) {
 	skipState := framework.NewCycleState()
 	skipState.Write(stateKey, &preFilterState{
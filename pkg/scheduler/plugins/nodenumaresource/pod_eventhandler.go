@@ -20,11 +20,15 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
+	resourceapi "k8s.io/component-helpers/resource"
 	fwktype "k8s.io/kube-scheduler/framework"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
@@ -97,6 +101,7 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 	if pod.Spec.NodeName == "" {
 		if oldPod != nil && oldPod.Spec.NodeName != "" {
 			c.resourceManager.Release(oldPod.Spec.NodeName, oldPod.UID)
+			equivalence.InvalidateNode(oldPod.Spec.NodeName)
 		}
 		return
 	}
@@ -122,15 +127,21 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 		return
 	}
 
+	numaNodeResources := resourceStatus.NUMANodeResources
+	if k8sfeature.DefaultFeatureGate.Enabled(features.ResizePod) && !reservationutil.IsReservePod(pod) {
+		// the cpuset cannot be resized in place, only the NUMA resources of the shared cpus follow the resized pod
+		requests := resourceapi.PodRequests(pod, resourceapi.PodResourcesOptions{UseStatusResources: true})
+		numaNodeResources = util.ScaleNUMANodeResourcesToRequests(numaNodeResources, requests, cpus.IsEmpty())
+	}
 	allocation := &PodAllocation{
 		UID:                pod.UID,
 		Namespace:          pod.Namespace,
 		Name:               pod.Name,
 		CPUSet:             cpus,
 		CPUExclusivePolicy: resourceSpec.PreferredCPUExclusivePolicy,
-		NUMANodeResources:  make([]NUMANodeResource, 0, len(resourceStatus.NUMANodeResources)),
+		NUMANodeResources:  make([]NUMANodeResource, 0, len(numaNodeResources)),
 	}
-	for _, numaNodeRes := range resourceStatus.NUMANodeResources {
+	for _, numaNodeRes := range numaNodeResources {
 		allocation.NUMANodeResources = append(allocation.NUMANodeResources, NUMANodeResource{
 			Node:      int(numaNodeRes.Node),
 			Resources: numaNodeRes.Resources,
@@ -138,6 +149,7 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 	}
 
 	c.resourceManager.Update(pod.Spec.NodeName, allocation)
+	equivalence.InvalidateNode(pod.Spec.NodeName)
 }
 
 func (c *podEventHandler) deletePod(pod *corev1.Pod) {
@@ -146,4 +158,5 @@ func (c *podEventHandler) deletePod(pod *corev1.Pod) {
 	}
 
 	c.resourceManager.Release(pod.Spec.NodeName, pod.UID)
+	equivalence.InvalidateNode(pod.Spec.NodeName)
 }
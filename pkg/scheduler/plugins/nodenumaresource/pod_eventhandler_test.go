@@ -21,11 +21,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 func TestPodEventHandler(t *testing.T) {
@@ -315,3 +319,114 @@ func TestPodEventHandler_UpdatePod_unassigned(t *testing.T) {
 		})
 	}
 }
+
+func TestPodEventHandler_UpdatePod_resized(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable         bool
+		resourceStatus string
+		requests       corev1.ResourceList
+		want           map[int]corev1.ResourceList
+	}{
+		{
+			name:           "ResizePod disabled, keep the NUMA resources in annotation",
+			enable:         false,
+			resourceStatus: `{"numaNodeResources":[{"node":0,"resources":{"cpu":"2","memory":"4Gi"}},{"node":1,"resources":{"cpu":"2","memory":"4Gi"}}]}`,
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			want: map[int]corev1.ResourceList{
+				0: {corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				1: {corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name:           "ResizePod enabled, scale the NUMA resources to the shrunk requests",
+			enable:         true,
+			resourceStatus: `{"numaNodeResources":[{"node":0,"resources":{"cpu":"2","memory":"4Gi"}},{"node":1,"resources":{"cpu":"2","memory":"4Gi"}}]}`,
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			want: map[int]corev1.ResourceList{
+				0: {corev1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				1: {corev1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name:           "ResizePod enabled, scale the NUMA resources to the expanded requests",
+			enable:         true,
+			resourceStatus: `{"numaNodeResources":[{"node":0,"resources":{"cpu":"4","memory":"8Gi"}}]}`,
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("6"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			want: map[int]corev1.ResourceList{
+				0: {corev1.ResourceCPU: *resource.NewMilliQuantity(6000, resource.DecimalSI), corev1.ResourceMemory: *resource.NewQuantity(16*1024*1024*1024, resource.BinarySI)},
+			},
+		},
+		{
+			name:           "ResizePod enabled, keep the cpus of the cpuset pod",
+			enable:         true,
+			resourceStatus: `{"cpuset":"0-3","numaNodeResources":[{"node":0,"resources":{"cpu":"4","memory":"8Gi"}}]}`,
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			want: map[int]corev1.ResourceList{
+				0: {corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: *resource.NewQuantity(4*1024*1024*1024, resource.BinarySI)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.ResizePod, tt.enable)()
+			cpuTopology := buildCPUTopologyForTest(2, 1, 4, 2)
+			topologyOptionsManager := NewTopologyOptionsManager()
+			topologyOptionsManager.UpdateTopologyOptions("test-node-1", func(options *TopologyOptions) {
+				options.CPUTopology = cpuTopology
+			})
+			resourceManager := &resourceManager{
+				topologyOptionsManager: topologyOptionsManager,
+				nodeAllocations:        map[string]*NodeAllocation{},
+			}
+			handler := &podEventHandler{
+				resourceManager: resourceManager,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:       uuid.NewUUID(),
+					Namespace: "default",
+					Name:      "test-pod",
+					Annotations: map[string]string{
+						extension.AnnotationResourceStatus: tt.resourceStatus,
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "test-node-1",
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: tt.requests,
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+				},
+			}
+			handler.OnAdd(pod, true)
+			got, ok := resourceManager.GetAllocatedNUMAResource("test-node-1", pod.UID)
+			assert.True(t, ok)
+			assert.Equal(t, len(tt.want), len(got))
+			for node, want := range tt.want {
+				gotNode := got[node]
+				assert.True(t, want.Cpu().Equal(*gotNode.Cpu()), "node %d cpu, want %v, got %v", node, want.Cpu(), gotNode.Cpu())
+				assert.True(t, want.Memory().Equal(*gotNode.Memory()), "node %d memory, want %v, got %v", node, want.Memory(), gotNode.Memory())
+			}
+		})
+	}
+}
@@ -324,7 +324,8 @@ func splitQuantity(resourceName corev1.ResourceName, quantity resource.Quantity,
 	if !options.requestCPUBind {
 		return *resource.NewMilliQuantity(quantity.MilliValue()/int64(numaNodeCount), quantity.Format)
 	}
-	if options.requiredCPUBindPolicy && options.cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs {
+	if options.requiredCPUBindPolicy && (options.cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs ||
+		options.cpuBindPolicy == schedulingconfig.CPUBindPolicyLLCAligned) {
 		cpusPerCore := int64(options.topologyOptions.CPUTopology.CPUsPerCore())
 		numOfPCPUs := quantity.Value() / cpusPerCore
 		numOfPCPUsPerNUMA := numOfPCPUs / int64(numaNodeCount)
@@ -660,7 +661,7 @@ func filterCPUsByRequiredCPUBindPolicy(policy schedulingconfig.CPUBindPolicy, av
 	builder := cpuset.NewCPUSetBuilder()
 	cpuDetails = cpuDetails.KeepOnly(availableCPUs)
 	switch policy {
-	case schedulingconfig.CPUBindPolicyFullPCPUs:
+	case schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUBindPolicyLLCAligned:
 		cpusInCore := map[int][]int{}
 		for _, info := range cpuDetails {
 			cpusInCore[info.CoreID] = append(cpusInCore[info.CoreID], info.CPUID)
@@ -702,6 +703,9 @@ func satisfiedRequiredCPUBindPolicy(policy schedulingconfig.CPUBindPolicy, cpus
 	satisfied := true
 	if policy == schedulingconfig.CPUBindPolicyFullPCPUs {
 		satisfied = determineFullPCPUs(cpus, topology.CPUDetails, topology.CPUsPerCore())
+	} else if policy == schedulingconfig.CPUBindPolicyLLCAligned {
+		satisfied = determineFullPCPUs(cpus, topology.CPUDetails, topology.CPUsPerCore()) &&
+			determineLLCAligned(cpus, topology.CPUDetails, topology.CPUsPerLLC())
 	} else if policy == schedulingconfig.CPUBindPolicySpreadByPCPUs {
 		satisfied = determineSpreadByPCPUs(cpus, topology.CPUDetails)
 	}
@@ -716,6 +720,16 @@ func determineFullPCPUs(cpus cpuset.CPUSet, details CPUDetails, cpusPerCore int)
 	return details.Cores().Size()*cpusPerCore == cpus.Size()
 }
 
+// determineLLCAligned checks whether the cpus span the minimal number of LLC domains.
+func determineLLCAligned(cpus cpuset.CPUSet, details CPUDetails, cpusPerLLC int) bool {
+	if cpusPerLLC == 0 {
+		return true
+	}
+	details = details.KeepOnly(cpus)
+	numLLCsNeeded := (cpus.Size() + cpusPerLLC - 1) / cpusPerLLC
+	return details.LLCs().Size() <= numLLCsNeeded
+}
+
 func determineSpreadByPCPUs(cpus cpuset.CPUSet, details CPUDetails) bool {
 	details = details.KeepOnly(cpus)
 	return details.Cores().Size() == cpus.Size()
@@ -26,6 +26,7 @@ import (
 	fwktype "k8s.io/kube-scheduler/framework"
 
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 )
 
@@ -94,6 +95,7 @@ func (m *nodeResourceTopologyEventHandler) OnDelete(obj interface{}) {
 		return
 	}
 	m.topologyManager.Delete(nodeResTopology.Name)
+	equivalence.InvalidateNode(nodeResTopology.Name)
 }
 
 func (m *nodeResourceTopologyEventHandler) updateNodeResourceTopology(oldNodeResTopology, newNodeResTopology *nrtv1alpha1.NodeResourceTopology) {
@@ -105,4 +107,5 @@ func (m *nodeResourceTopologyEventHandler) updateNodeResourceTopology(oldNodeRes
 		topologyOpts.MaxRefCount = options.MaxRefCount
 		*options = topologyOpts
 	})
+	equivalence.InvalidateNode(nodeName)
 }
@@ -186,7 +186,7 @@ func getPodAllocsCPUSet(podCPUAllocs extension.PodCPUAllocs) cpuset.CPUSet {
 func convertCPUTopology(reportedCPUTopology *extension.CPUTopology) *CPUTopology {
 	builder := NewCPUTopologyBuilder()
 	for _, info := range reportedCPUTopology.Detail {
-		builder.AddCPUInfo(int(info.Socket), int(info.Node), int(info.Core), int(info.ID))
+		builder.AddCPUInfoWithLLC(int(info.Socket), int(info.Node), int(info.L3), int(info.Core), int(info.ID))
 	}
 	return builder.Result()
 }
@@ -165,8 +165,10 @@ func TestTopologyOptionsManager(t *testing.T) {
 	assert.NotNil(t, topologyOptions.CPUTopology)
 	for k, v := range expectCPUTopology.CPUDetails {
 		v.CoreID = v.SocketID<<16 | v.CoreID
+		v.LLCID = v.SocketID << 16
 		expectCPUTopology.CPUDetails[k] = v
 	}
+	expectCPUTopology.NumLLCs = expectCPUTopology.NumSockets
 	assert.Equal(t, expectCPUTopology, topologyOptions.CPUTopology)
 
 	policy := topologyOptions.Policy
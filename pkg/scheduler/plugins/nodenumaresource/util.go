@@ -112,7 +112,10 @@ func getCPUBindPolicy(topologyOptions *TopologyOptions, node *corev1.Node, requi
 		cpuBindPolicy = schedulingconfig.CPUBindPolicySpreadByPCPUs
 		required = true
 	case extension.NodeCPUBindPolicyFullPCPUsOnly:
-		cpuBindPolicy = schedulingconfig.CPUBindPolicyFullPCPUs
+		// the LLCAligned policy allocates full physical cores as well
+		if cpuBindPolicy != schedulingconfig.CPUBindPolicyLLCAligned {
+			cpuBindPolicy = schedulingconfig.CPUBindPolicyFullPCPUs
+		}
 		required = true
 	}
 	return cpuBindPolicy, required, nil
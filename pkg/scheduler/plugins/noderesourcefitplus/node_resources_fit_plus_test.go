@@ -38,6 +38,7 @@ import (
 	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing/framework"
 	"k8s.io/utils/ptr"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
@@ -297,6 +298,110 @@ func TestPlugin_Score(t *testing.T) {
 	}
 }
 
+func TestPlugin_ScoreNetworkBandwidth(t *testing.T) {
+	var v1args v1.NodeResourcesFitPlusArgs
+	v1args.Resources = map[corev1.ResourceName]v1.ResourcesType{
+		extension.ResourceNetworkBandwidth: {Type: k8sConfig.LeastAllocated, Weight: 1},
+	}
+
+	var nodeResourcesFitPlusArgs config.NodeResourcesFitPlusArgs
+	err := v1.Convert_v1_NodeResourcesFitPlusArgs_To_config_NodeResourcesFitPlusArgs(&v1args, &nodeResourcesFitPlusArgs, nil)
+	assert.NoError(t, err)
+
+	var ptplugin fwktype.Plugin
+	proxyNew := NodeResourcesPluginFactoryProxy(New, &ptplugin)
+
+	cs := kubefake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(cs, 0)
+
+	var nodes []*corev1.Node
+	for _, name := range []string{"testNode1", "testNode2"} {
+		nodes = append(nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:                 resource.MustParse("96"),
+					corev1.ResourceMemory:              resource.MustParse("512Gi"),
+					extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+				},
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:                 resource.MustParse("96"),
+					corev1.ResourceMemory:              resource.MustParse("512Gi"),
+					extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+				},
+			},
+		})
+	}
+
+	newBandwidthPod := func(name, nodeName string, bandwidth string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{
+						Name: "test-container",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:                 resource.MustParse("4"),
+								extension.ResourceNetworkBandwidth: resource.MustParse(bandwidth),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:                 resource.MustParse("4"),
+								extension.ResourceNetworkBandwidth: resource.MustParse(bandwidth),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	// testNode1 has used 8G of the 10G bandwidth, while testNode2 has more cpu allocated but little bandwidth used.
+	pods := []*corev1.Pod{
+		newBandwidthPod("test-pod-0", "testNode1", "8G"),
+		newBandwidthPod("test-pod-1", "testNode2", "1G"),
+		newBandwidthPod("test-pod-2", "testNode2", "1G"),
+	}
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+	}
+
+	snapshot := newTestSharedLister(pods, nodes)
+	fh, err := schedulertesting.NewFramework(context.TODO(), registeredPlugins, "koord-scheduler",
+		frameworkruntime.WithClientSet(cs),
+		frameworkruntime.WithInformerFactory(informerFactory),
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+	)
+	assert.Nil(t, err)
+
+	p, err := proxyNew(context.TODO(), &nodeResourcesFitPlusArgs, fh)
+	assert.NotNil(t, p)
+	assert.Nil(t, err)
+
+	cycleState := framework.NewCycleState()
+	pod := newBandwidthPod("test-pod-3", "", "2G")
+	nodeInfos := make([]fwktype.NodeInfo, 0, len(nodes))
+	for _, n := range nodes {
+		ni, err := snapshot.Get(n.Name)
+		assert.NoError(t, err)
+		nodeInfos = append(nodeInfos, ni)
+	}
+	status := p.(fwktype.PreScorePlugin).PreScore(context.TODO(), cycleState, pod, nodeInfos)
+	assert.True(t, status.IsSuccess())
+
+	scoreNode1, status := p.(*Plugin).Score(context.TODO(), cycleState, pod, nodeInfos[0])
+	assert.True(t, status.IsSuccess())
+	scoreNode2, status := p.(*Plugin).Score(context.TODO(), cycleState, pod, nodeInfos[1])
+	assert.True(t, status.IsSuccess())
+	assert.Less(t, scoreNode1, scoreNode2, "the bandwidth-heavy pod should prefer the node with more free bandwidth")
+}
+
 func (f *testSharedLister) StorageInfos() fwktype.StorageInfoLister {
 	return f
 }
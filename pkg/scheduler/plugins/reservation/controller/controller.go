@@ -26,6 +26,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,6 +38,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	corelister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	componentresource "k8s.io/component-helpers/resource"
 	"k8s.io/klog/v2"
@@ -78,6 +80,8 @@ type Controller struct {
 	gcDuration                 time.Duration
 	gcInterval                 time.Duration
 	resyncInterval             time.Duration
+	idleReclaimer              *idleReclaimer
+	eventRecorder              events.EventRecorder
 
 	lock   sync.RWMutex
 	pods   map[string]map[types.UID]*corev1.Pod    // nodeName -> podUID -> pod
@@ -120,6 +124,10 @@ func New(
 	if args != nil && args.ResyncIntervalSeconds > 0 {
 		resyncInterval = time.Duration(args.ResyncIntervalSeconds) * time.Second
 	}
+	var idleReclaimer *idleReclaimer
+	if args != nil {
+		idleReclaimer = newIdleReclaimer(args.IdleReclaim)
+	}
 	return &Controller{
 		sharedInformerFactory:      sharedInformerFactory,
 		koordSharedInformerFactory: koordSharedInformerFactory,
@@ -134,6 +142,7 @@ func New(
 		gcDuration:                 gcDuration,
 		gcInterval:                 gcInterval,
 		resyncInterval:             resyncInterval,
+		idleReclaimer:              idleReclaimer,
 		pods:                       map[string]map[types.UID]*corev1.Pod{},
 		podToR:                     map[types.UID]types.UID{},
 		rToPod:                     map[types.UID]map[types.UID]*corev1.Pod{},
@@ -142,6 +151,11 @@ func New(
 
 func (c *Controller) Name() string { return Name }
 
+// SetEventRecorder sets the recorder to emit the events of the Reservations and their owners.
+func (c *Controller) SetEventRecorder(recorder events.EventRecorder) {
+	c.eventRecorder = recorder
+}
+
 func (c *Controller) Start() {
 	nodeInformer := c.sharedInformerFactory.Core().V1().Nodes().Informer()
 	nodeInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
@@ -181,6 +195,9 @@ func (c *Controller) Start() {
 	} else {
 		klog.V(4).InfoS("resync for reservations is disabled")
 	}
+	if c.idleReclaimer != nil {
+		go wait.Until(c.reclaimIdleReservations, c.idleReclaimer.checkInterval, nil)
+	}
 }
 
 func (c *Controller) worker() {
@@ -479,35 +496,11 @@ func RecordReservationResource(reservation *schedulingv1alpha1.Reservation) {
 	if reservation.Status.Allocatable == nil {
 		return
 	}
-	const (
-		MilliCorePerCore = 1000
-		BytesPerGiB      = 1024 * 1024 * 1024
-	)
 
 	resources := quotav1.ResourceNames(reservation.Status.Allocatable)
 	for _, resourceName := range resources {
-		allocatable := reservation.Status.Allocatable[resourceName]
-		allocated := reservation.Status.Allocated[resourceName]
-
-		var allocatableVal, allocatedVal float64
-		var unit string
-
-		switch resourceName {
-		case corev1.ResourceCPU:
-			// mCPU -> Core
-			allocatableVal = float64(allocatable.MilliValue()) / MilliCorePerCore
-			allocatedVal = float64(allocated.MilliValue()) / MilliCorePerCore
-			unit = metrics.UnitCore
-		case corev1.ResourceMemory:
-			// bytes -> GiB
-			allocatableVal = float64(allocatable.Value()) / BytesPerGiB
-			allocatedVal = float64(allocated.Value()) / BytesPerGiB
-			unit = metrics.UnitGiB
-		default:
-			allocatableVal = float64(allocatable.Value())
-			allocatedVal = float64(allocated.Value())
-			unit = metrics.UnitRaw
-		}
+		allocatableVal, unit := resourceValueWithUnit(resourceName, reservation.Status.Allocatable[resourceName])
+		allocatedVal, _ := resourceValueWithUnit(resourceName, reservation.Status.Allocated[resourceName])
 
 		// allocatable
 		metrics.RecordReservationResourceByTypeWithUnit(
@@ -525,3 +518,22 @@ func RecordReservationResource(reservation *schedulingv1alpha1.Reservation) {
 		}
 	}
 }
+
+// resourceValueWithUnit converts the resource quantity into the value reported in metrics.
+func resourceValueWithUnit(resourceName corev1.ResourceName, quantity resource.Quantity) (float64, string) {
+	const (
+		MilliCorePerCore = 1000
+		BytesPerGiB      = 1024 * 1024 * 1024
+	)
+
+	switch resourceName {
+	case corev1.ResourceCPU:
+		// mCPU -> Core
+		return float64(quantity.MilliValue()) / MilliCorePerCore, metrics.UnitCore
+	case corev1.ResourceMemory:
+		// bytes -> GiB
+		return float64(quantity.Value()) / BytesPerGiB, metrics.UnitGiB
+	default:
+		return float64(quantity.Value()), metrics.UnitRaw
+	}
+}
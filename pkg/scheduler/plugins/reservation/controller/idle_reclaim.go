@@ -0,0 +1,371 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	defaultIdleReclaimCheckInterval = 60 * time.Second
+	defaultIdleDuration             = time.Hour
+
+	reasonReservationIdleExpired   = "ReservationIdleExpired"
+	reasonReservationIdleShrunk    = "ReservationIdleShrunk"
+	reasonReservationIdleMigrating = "ReservationIdleMigrating"
+	reasonReservationIdleMigrated  = "ReservationIdleMigrated"
+	actionIdleReclaim              = "IdleReclaim"
+)
+
+// idleReclaimer tracks how long the Available Reservations keep idle.
+// The tracking is in memory, so the idle duration restarts when the scheduler restarts.
+type idleReclaimer struct {
+	policy               config.ReservationIdleReclaimPolicy
+	idleDuration         time.Duration
+	checkInterval        time.Duration
+	utilizationThreshold int32
+
+	lock      sync.Mutex
+	idleSince map[types.UID]time.Time
+}
+
+func newIdleReclaimer(args *config.ReservationIdleReclaimArgs) *idleReclaimer {
+	if args == nil || !args.Enabled {
+		return nil
+	}
+	r := &idleReclaimer{
+		policy:               args.Policy,
+		idleDuration:         defaultIdleDuration,
+		checkInterval:        defaultIdleReclaimCheckInterval,
+		utilizationThreshold: args.UtilizationThresholdPercent,
+		idleSince:            map[types.UID]time.Time{},
+	}
+	if r.policy == "" {
+		r.policy = config.ReservationIdleReclaimPolicyExpire
+	}
+	if args.IdleDurationSeconds > 0 {
+		r.idleDuration = time.Duration(args.IdleDurationSeconds) * time.Second
+	}
+	if args.CheckIntervalSeconds > 0 {
+		r.checkInterval = time.Duration(args.CheckIntervalSeconds) * time.Second
+	}
+	return r
+}
+
+// policyOf returns the reclaim policy of the Reservation, which can be overridden by the annotation.
+func (r *idleReclaimer) policyOf(reservation *schedulingv1alpha1.Reservation) config.ReservationIdleReclaimPolicy {
+	switch policy := config.ReservationIdleReclaimPolicy(reservation.Annotations[apiext.AnnotationReservationIdleReclaimPolicy]); policy {
+	case config.ReservationIdleReclaimPolicyNone, config.ReservationIdleReclaimPolicyExpire,
+		config.ReservationIdleReclaimPolicyShrink, config.ReservationIdleReclaimPolicyMigrate:
+		return policy
+	}
+	return r.policy
+}
+
+// isIdle checks if the allocated percentage of every reserved resource does not exceed the threshold.
+func (r *idleReclaimer) isIdle(reservation *schedulingv1alpha1.Reservation) bool {
+	for resourceName, allocatable := range reservation.Status.Allocatable {
+		if allocatable.IsZero() {
+			continue
+		}
+		allocated := reservation.Status.Allocated[resourceName]
+		if float64(allocated.MilliValue())*100 > float64(r.utilizationThreshold)*float64(allocatable.MilliValue()) {
+			return false
+		}
+	}
+	return true
+}
+
+// markIdle records the Reservation as idle and returns since when it keeps idle.
+func (r *idleReclaimer) markIdle(uid types.UID, now time.Time) time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	since, ok := r.idleSince[uid]
+	if !ok {
+		since = now
+		r.idleSince[uid] = since
+	}
+	return since
+}
+
+func (r *idleReclaimer) forget(uid types.UID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.idleSince, uid)
+}
+
+// retain forgets the Reservations not in the given set.
+func (r *idleReclaimer) retain(uids sets.Set[types.UID]) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for uid := range r.idleSince {
+		if !uids.Has(uid) {
+			delete(r.idleSince, uid)
+		}
+	}
+}
+
+// reclaimIdleReservations reclaims the Reservations which are Available but keep idle longer than the idle duration,
+// and records the idle reserved resources of each namespace.
+func (c *Controller) reclaimIdleReservations() {
+	reservations, err := c.reservationLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list reservations, abort the idle reclaim turn, err: %s", err)
+		return
+	}
+
+	now := time.Now()
+	tracked := sets.New[types.UID]()
+	idleResources := map[string]corev1.ResourceList{}
+	for _, reservation := range reservations {
+		if !reservationutil.IsReservationAvailable(reservation) {
+			continue
+		}
+		tracked.Insert(reservation.UID)
+		idle := c.idleReclaimer.isIdle(reservation)
+
+		if replacementName := reservation.Annotations[apiext.AnnotationReservationIdleReplacement]; replacementName != "" {
+			c.syncIdleMigration(reservation.DeepCopy(), replacementName, idle)
+			continue
+		}
+		if !idle {
+			c.idleReclaimer.forget(reservation.UID)
+			continue
+		}
+		idleFor := now.Sub(c.idleReclaimer.markIdle(reservation.UID, now))
+		if idleFor < c.idleReclaimer.idleDuration {
+			continue
+		}
+
+		namespace := reservationOwnerNamespace(reservation)
+		unallocated := quotav1.SubtractWithNonNegativeResult(reservation.Status.Allocatable, reservation.Status.Allocated)
+		idleResources[namespace] = quotav1.Add(idleResources[namespace], unallocated)
+
+		policy := c.idleReclaimer.policyOf(reservation)
+		if policy == config.ReservationIdleReclaimPolicyNone {
+			continue
+		}
+		if err = c.reclaimIdleReservation(reservation.DeepCopy(), policy, idleFor); err != nil {
+			klog.ErrorS(err, "failed to reclaim idle reservation", "reservation", klog.KObj(reservation), "policy", policy)
+		}
+	}
+	c.idleReclaimer.retain(tracked)
+
+	metrics.ResetReservationIdleResource()
+	for namespace, resources := range idleResources {
+		for resourceName, quantity := range resources {
+			value, unit := resourceValueWithUnit(resourceName, quantity)
+			metrics.RecordReservationIdleResource(namespace, string(resourceName), unit, value)
+		}
+	}
+}
+
+func (c *Controller) reclaimIdleReservation(reservation *schedulingv1alpha1.Reservation, policy config.ReservationIdleReclaimPolicy, idleFor time.Duration) error {
+	allocated := !quotav1.IsZero(reservation.Status.Allocated)
+	switch {
+	case policy == config.ReservationIdleReclaimPolicyMigrate && !allocated && canMigrateReservation(reservation):
+		return c.migrateIdleReservation(reservation, idleFor)
+	case policy != config.ReservationIdleReclaimPolicyExpire && allocated:
+		// the owners are running on the node, only the unallocated resources can be reclaimed
+		return c.shrinkIdleReservation(reservation, idleFor)
+	default:
+		if err := c.expireReservation(reservation); err != nil {
+			return err
+		}
+		c.idleReclaimer.forget(reservation.UID)
+		c.recordIdleReclaimEvent(reservation, reasonReservationIdleExpired,
+			"Reservation %s is expired since it has been idle for %v", reservation.Name, idleFor.Round(time.Second))
+		return nil
+	}
+}
+
+func (c *Controller) shrinkIdleReservation(reservation *schedulingv1alpha1.Reservation, idleFor time.Duration) error {
+	reservation.Status.Allocatable = quotav1.Mask(reservation.Status.Allocated, quotav1.ResourceNames(reservation.Status.Allocatable))
+	if err := c.updateReservationStatus(reservation); err != nil {
+		return err
+	}
+	c.idleReclaimer.forget(reservation.UID)
+	RecordReservationResource(reservation)
+	c.recordIdleReclaimEvent(reservation, reasonReservationIdleShrunk,
+		"Reservation %s is shrunk to the allocated resources %v since it has been idle for %v",
+		reservation.Name, util.DumpJSON(reservation.Status.Allocatable), idleFor.Round(time.Second))
+	return nil
+}
+
+// migrateIdleReservation creates a replacement of the idle Reservation which cannot be scheduled on the current node,
+// so the scheduler will place it on another node, preferring the packed ones. The idle Reservation is expired only
+// after the replacement is available.
+func (c *Controller) migrateIdleReservation(reservation *schedulingv1alpha1.Reservation, idleFor time.Duration) error {
+	replacement := newIdleReservationReplacement(reservation)
+	replacement, err := c.koordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), replacement, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create replacement, err: %w", err)
+	}
+	if reservation.Annotations == nil {
+		reservation.Annotations = map[string]string{}
+	}
+	reservation.Annotations[apiext.AnnotationReservationIdleReplacement] = replacement.Name
+	if _, err = c.koordClientSet.SchedulingV1alpha1().Reservations().Update(context.TODO(), reservation, metav1.UpdateOptions{}); err != nil {
+		// do not leave the replacement orphaned
+		_ = c.koordClientSet.SchedulingV1alpha1().Reservations().Delete(context.TODO(), replacement.Name, metav1.DeleteOptions{})
+		return fmt.Errorf("failed to record replacement %s, err: %w", replacement.Name, err)
+	}
+	klog.V(4).InfoS("Migrating idle reservation", "reservation", klog.KObj(reservation), "replacement", replacement.Name)
+	c.recordIdleReclaimEvent(reservation, reasonReservationIdleMigrating,
+		"Reservation %s is migrating from node %s to replacement %s since it has been idle for %v",
+		reservation.Name, reservation.Status.NodeName, replacement.Name, idleFor.Round(time.Second))
+	return nil
+}
+
+// syncIdleMigration expires the idle Reservation once its replacement is available. The migration is aborted if the
+// Reservation is allocated again, or the replacement fails, and then the Reservation keeps idle from now on.
+func (c *Controller) syncIdleMigration(reservation *schedulingv1alpha1.Reservation, replacementName string, idle bool) {
+	replacement, err := c.reservationLister.Get(replacementName)
+	if err != nil && !errors.IsNotFound(err) {
+		klog.V(4).InfoS("failed to get replacement of idle reservation", "reservation", klog.KObj(reservation), "replacement", replacementName, "err", err)
+		return
+	}
+	switch {
+	case idle && replacement != nil && reservationutil.IsReservationAvailable(replacement):
+		if err = c.expireReservation(reservation); err != nil {
+			return
+		}
+		c.idleReclaimer.forget(reservation.UID)
+		c.recordIdleReclaimEvent(reservation, reasonReservationIdleMigrated,
+			"Reservation %s is migrated to replacement %s on node %s", reservation.Name, replacement.Name, replacement.Status.NodeName)
+	case !idle || replacement == nil || reservationutil.IsReservationFailed(replacement) || reservationutil.IsReservationSucceeded(replacement):
+		if replacement != nil {
+			err = c.koordClientSet.SchedulingV1alpha1().Reservations().Delete(context.TODO(), replacement.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				klog.V(4).InfoS("failed to delete replacement of idle reservation", "reservation", klog.KObj(reservation), "replacement", replacementName, "err", err)
+				return
+			}
+		}
+		delete(reservation.Annotations, apiext.AnnotationReservationIdleReplacement)
+		if _, err = c.koordClientSet.SchedulingV1alpha1().Reservations().Update(context.TODO(), reservation, metav1.UpdateOptions{}); err != nil {
+			klog.V(4).InfoS("failed to abort migration of idle reservation", "reservation", klog.KObj(reservation), "err", err)
+			return
+		}
+		c.idleReclaimer.forget(reservation.UID)
+		klog.V(4).InfoS("Aborted migration of idle reservation", "reservation", klog.KObj(reservation), "replacement", replacementName, "idle", idle)
+	}
+}
+
+func canMigrateReservation(reservation *schedulingv1alpha1.Reservation) bool {
+	return reservation.Spec.Template != nil && reservation.Spec.Template.Spec.NodeName == ""
+}
+
+func newIdleReservationReplacement(reservation *schedulingv1alpha1.Reservation) *schedulingv1alpha1.Reservation {
+	replacement := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s", reservation.Name, utilrand.String(5)),
+			Labels:          map[string]string{},
+			Annotations:     map[string]string{},
+			OwnerReferences: reservation.OwnerReferences,
+		},
+		Spec: *reservation.Spec.DeepCopy(),
+	}
+	for k, v := range reservation.Labels {
+		replacement.Labels[k] = v
+	}
+	for k, v := range reservation.Annotations {
+		if k != apiext.AnnotationReservationIdleReplacement {
+			replacement.Annotations[k] = v
+		}
+	}
+	// keep the deadline of the idle Reservation
+	if spec := &replacement.Spec; spec.Expires == nil && spec.TTL != nil && spec.TTL.Duration > 0 {
+		expires := metav1.NewTime(reservation.CreationTimestamp.Add(spec.TTL.Duration))
+		spec.Expires = &expires
+		spec.TTL = nil
+	}
+	if nodeName := reservation.Status.NodeName; nodeName != "" {
+		appendNodeNotInAffinity(&replacement.Spec.Template.Spec, nodeName)
+	}
+	return replacement
+}
+
+// appendNodeNotInAffinity appends the required node affinity to skip the node to every node selector term.
+func appendNodeNotInAffinity(podSpec *corev1.PodSpec, nodeName string) {
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      metav1.ObjectNameField,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{nodeName},
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		terms = append(terms, corev1.NodeSelectorTerm{})
+	}
+	for i := range terms {
+		terms[i].MatchFields = append(terms[i].MatchFields, requirement)
+	}
+	nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+}
+
+// reservationOwnerNamespace returns the namespace of the first owner specifying the object or controller.
+func reservationOwnerNamespace(reservation *schedulingv1alpha1.Reservation) string {
+	for _, owner := range reservation.Spec.Owners {
+		if owner.Object != nil && owner.Object.Namespace != "" {
+			return owner.Object.Namespace
+		}
+		if owner.Controller != nil && owner.Controller.Namespace != "" {
+			return owner.Controller.Namespace
+		}
+	}
+	return ""
+}
+
+// recordIdleReclaimEvent emits the event on the Reservation and the owner objects and controllers.
+func (c *Controller) recordIdleReclaimEvent(reservation *schedulingv1alpha1.Reservation, reason, messageFmt string, args ...interface{}) {
+	if c.eventRecorder == nil {
+		return
+	}
+	c.eventRecorder.Eventf(reservation, nil, corev1.EventTypeNormal, reason, actionIdleReclaim, messageFmt, args...)
+	for _, regarding := range reservationutil.GetReservationOwnerReferences(reservation) {
+		c.eventRecorder.Eventf(regarding, reservation, corev1.EventTypeNormal, reason, actionIdleReclaim, messageFmt, args...)
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func newTestIdleReservation(name string, allocatable, allocated corev1.ResourceList) *schedulingv1alpha1.Reservation {
+	return &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               uuid.NewUUID(),
+			Name:              name,
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{
+						Kind:      "Pod",
+						Namespace: "default",
+						Name:      "test-pod",
+					},
+				},
+			},
+			TTL: &metav1.Duration{Duration: 24 * time.Hour},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:       schedulingv1alpha1.ReservationAvailable,
+			NodeName:    "test-node",
+			Allocatable: allocatable,
+			Allocated:   allocated,
+		},
+	}
+}
+
+func newTestIdleReclaimController(t *testing.T, args *config.ReservationIdleReclaimArgs, reservations ...*schedulingv1alpha1.Reservation) (*Controller, *koordfake.Clientset, *events.FakeRecorder) {
+	fakeClientSet := kubefake.NewSimpleClientset()
+	fakeKoordClientSet := koordfake.NewSimpleClientset()
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClientSet, 0)
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(fakeKoordClientSet, 0)
+	for _, v := range reservations {
+		_, err := fakeKoordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), v, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeClientSet, fakeKoordClientSet, &config.ReservationArgs{IdleReclaim: args})
+	recorder := events.NewFakeRecorder(100)
+	controller.SetEventRecorder(recorder)
+
+	koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Informer()
+	sharedInformerFactory.Start(nil)
+	koordSharedInformerFactory.Start(nil)
+	sharedInformerFactory.WaitForCacheSync(nil)
+	koordSharedInformerFactory.WaitForCacheSync(nil)
+	return controller, fakeKoordClientSet, recorder
+}
+
+func TestNewIdleReclaimer(t *testing.T) {
+	assert.Nil(t, newIdleReclaimer(nil))
+	assert.Nil(t, newIdleReclaimer(&config.ReservationIdleReclaimArgs{Policy: config.ReservationIdleReclaimPolicyShrink}))
+
+	r := newIdleReclaimer(&config.ReservationIdleReclaimArgs{Enabled: true})
+	assert.NotNil(t, r)
+	assert.Equal(t, config.ReservationIdleReclaimPolicyExpire, r.policy)
+	assert.Equal(t, defaultIdleDuration, r.idleDuration)
+	assert.Equal(t, defaultIdleReclaimCheckInterval, r.checkInterval)
+
+	r = newIdleReclaimer(&config.ReservationIdleReclaimArgs{
+		Enabled:              true,
+		Policy:               config.ReservationIdleReclaimPolicyMigrate,
+		IdleDurationSeconds:  600,
+		CheckIntervalSeconds: 10,
+	})
+	assert.Equal(t, config.ReservationIdleReclaimPolicyMigrate, r.policy)
+	assert.Equal(t, 600*time.Second, r.idleDuration)
+	assert.Equal(t, 10*time.Second, r.checkInterval)
+}
+
+func TestReclaimIdleReservations(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+	partialAllocated := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	tests := []struct {
+		name             string
+		policy           config.ReservationIdleReclaimPolicy
+		threshold        int32
+		annotations      map[string]string
+		allocated        corev1.ResourceList
+		idleFor          time.Duration
+		wantPhase        schedulingv1alpha1.ReservationPhase
+		wantAllocatable  corev1.ResourceList
+		wantReplacement  bool
+		wantEvents       int
+		wantStillTracked bool
+	}{
+		{
+			name:            "expire the idle reservation",
+			policy:          config.ReservationIdleReclaimPolicyExpire,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationFailed,
+			wantAllocatable: allocatable,
+			wantEvents:      2,
+		},
+		{
+			name:             "keep the reservation not idle long enough",
+			policy:           config.ReservationIdleReclaimPolicyExpire,
+			idleFor:          10 * time.Minute,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable:  allocatable,
+			wantStillTracked: true,
+		},
+		{
+			name:            "keep the reservation allocated above the threshold",
+			policy:          config.ReservationIdleReclaimPolicyExpire,
+			threshold:       10,
+			allocated:       partialAllocated,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable: allocatable,
+		},
+		{
+			name:            "expire the reservation allocated under the threshold",
+			policy:          config.ReservationIdleReclaimPolicyExpire,
+			threshold:       30,
+			allocated:       partialAllocated,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationFailed,
+			wantAllocatable: allocatable,
+			wantEvents:      2,
+		},
+		{
+			name:            "shrink the reservation to the allocated",
+			policy:          config.ReservationIdleReclaimPolicyShrink,
+			threshold:       30,
+			allocated:       partialAllocated,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable: partialAllocated,
+			wantEvents:      2,
+		},
+		{
+			name:            "shrink the reservation allocated nothing expires it",
+			policy:          config.ReservationIdleReclaimPolicyShrink,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationFailed,
+			wantAllocatable: allocatable,
+			wantEvents:      2,
+		},
+		{
+			name:             "annotation disables the reclaim",
+			policy:           config.ReservationIdleReclaimPolicyExpire,
+			annotations:      map[string]string{apiext.AnnotationReservationIdleReclaimPolicy: "None"},
+			idleFor:          2 * time.Hour,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable:  allocatable,
+			wantStillTracked: true,
+		},
+		{
+			name:            "annotation overrides the policy",
+			policy:          config.ReservationIdleReclaimPolicyExpire,
+			threshold:       30,
+			annotations:     map[string]string{apiext.AnnotationReservationIdleReclaimPolicy: "Shrink"},
+			allocated:       partialAllocated,
+			idleFor:         2 * time.Hour,
+			wantPhase:       schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable: partialAllocated,
+			wantEvents:      2,
+		},
+		{
+			name:             "migrate the idle reservation",
+			policy:           config.ReservationIdleReclaimPolicyMigrate,
+			idleFor:          2 * time.Hour,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+			wantAllocatable:  allocatable,
+			wantReplacement:  true,
+			wantEvents:       2,
+			wantStillTracked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := newTestIdleReservation("test-r", allocatable, tt.allocated)
+			reservation.Annotations = tt.annotations
+			controller, fakeKoordClientSet, recorder := newTestIdleReclaimController(t, &config.ReservationIdleReclaimArgs{
+				Enabled:                     true,
+				Policy:                      tt.policy,
+				UtilizationThresholdPercent: tt.threshold,
+			}, reservation)
+			controller.idleReclaimer.idleSince[reservation.UID] = time.Now().Add(-tt.idleFor)
+
+			controller.reclaimIdleReservations()
+
+			got, err := fakeKoordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), reservation.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, got.Status.Phase)
+			assert.True(t, quotav1.Equals(tt.wantAllocatable, got.Status.Allocatable), "got allocatable %v", got.Status.Allocatable)
+			assert.Len(t, recorder.Events, tt.wantEvents)
+			_, tracked := controller.idleReclaimer.idleSince[reservation.UID]
+			assert.Equal(t, tt.wantStillTracked, tracked)
+
+			replacementName := got.Annotations[apiext.AnnotationReservationIdleReplacement]
+			assert.Equal(t, tt.wantReplacement, replacementName != "")
+			if tt.wantReplacement {
+				replacement, err := fakeKoordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), replacementName, metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Nil(t, replacement.Spec.TTL)
+				assert.Equal(t, reservation.CreationTimestamp.Add(24*time.Hour).Unix(), replacement.Spec.Expires.Unix())
+				assert.Equal(t, reservation.Spec.Owners, replacement.Spec.Owners)
+				terms := replacement.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				assert.Equal(t, []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{
+								Key:      metav1.ObjectNameField,
+								Operator: corev1.NodeSelectorOpNotIn,
+								Values:   []string{"test-node"},
+							},
+						},
+					},
+				}, terms)
+			}
+		})
+	}
+}
+
+func TestSyncIdleMigration(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("4"),
+	}
+	tests := []struct {
+		name             string
+		replacementPhase schedulingv1alpha1.ReservationPhase
+		noReplacement    bool
+		allocated        corev1.ResourceList
+		wantPhase        schedulingv1alpha1.ReservationPhase
+		wantAborted      bool
+	}{
+		{
+			name:             "wait for the pending replacement",
+			replacementPhase: schedulingv1alpha1.ReservationPending,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+		},
+		{
+			name:             "expire the reservation after the replacement is available",
+			replacementPhase: schedulingv1alpha1.ReservationAvailable,
+			wantPhase:        schedulingv1alpha1.ReservationFailed,
+		},
+		{
+			name:             "abort if the replacement failed",
+			replacementPhase: schedulingv1alpha1.ReservationFailed,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+			wantAborted:      true,
+		},
+		{
+			name:          "abort if the replacement is missing",
+			noReplacement: true,
+			wantPhase:     schedulingv1alpha1.ReservationAvailable,
+			wantAborted:   true,
+		},
+		{
+			name:             "abort if the reservation is allocated again",
+			replacementPhase: schedulingv1alpha1.ReservationAvailable,
+			allocated:        allocatable,
+			wantPhase:        schedulingv1alpha1.ReservationAvailable,
+			wantAborted:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := newTestIdleReservation("test-r", allocatable, tt.allocated)
+			replacement := newIdleReservationReplacement(reservation)
+			replacement.Status = schedulingv1alpha1.ReservationStatus{Phase: tt.replacementPhase}
+			if tt.replacementPhase == schedulingv1alpha1.ReservationAvailable {
+				replacement.Status.NodeName = "packed-node"
+			}
+			reservation.Annotations = map[string]string{apiext.AnnotationReservationIdleReplacement: replacement.Name}
+			reservations := []*schedulingv1alpha1.Reservation{reservation}
+			if !tt.noReplacement {
+				reservations = append(reservations, replacement)
+			}
+			controller, fakeKoordClientSet, _ := newTestIdleReclaimController(t, &config.ReservationIdleReclaimArgs{Enabled: true}, reservations...)
+
+			controller.reclaimIdleReservations()
+
+			got, err := fakeKoordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), reservation.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, got.Status.Phase)
+			if tt.wantPhase == schedulingv1alpha1.ReservationFailed {
+				assert.True(t, reservationutil.IsReservationExpired(got))
+			}
+			_, migrating := got.Annotations[apiext.AnnotationReservationIdleReplacement]
+			assert.Equal(t, !tt.wantAborted, migrating)
+			if tt.wantAborted {
+				_, err = fakeKoordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), replacement.Name, metav1.GetOptions{})
+				assert.Error(t, err)
+			}
+		})
+	}
+}
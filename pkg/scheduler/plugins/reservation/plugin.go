@@ -174,6 +174,7 @@ func (pl *Plugin) NewControllers() ([]frameworkext.Controller, error) {
 		pl.handle.ClientSet(),
 		pl.handle.KoordinatorClientSet(),
 		pl.args)
+	reservationController.SetEventRecorder(pl.handle.EventRecorder())
 	return []frameworkext.Controller{reservationController}, nil
 }
 
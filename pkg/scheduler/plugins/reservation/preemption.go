@@ -58,7 +58,7 @@ var (
 // PreemptionMgr is a wrapper of defaultpreemption.DefaultPreemption, supporting the following preemption behaviors:
 // 1. a pod preempt a pod.
 // 2. a reservation preempt a pod.
-// TODO: support the reservation being preempted
+// 3. a pod or a reservation preempt an available reservation which is not allocated yet.
 type PreemptionMgr struct {
 	*defaultpreemption.DefaultPreemption
 	fh                frameworkext.ExtendedHandle
@@ -106,6 +106,7 @@ func (pm *PreemptionMgr) PostFilter(ctx context.Context, state fwktype.CycleStat
 
 	pe := preemption.NewEvaluator(Name, pm.fh, pm, false)
 	pe.PodLister = newDelegatingPodLister(pm.podLister, pm.reservationLister, pod)
+	pe.PreemptPod = pm.wrapPreemptPod(pe.PreemptPod)
 	klog.V(4).InfoS("Attempt to do reservation preemption in the PostFilter", "pod", klog.KObj(pod))
 
 	result, status := pe.Preempt(ctx, state, pod, m)
@@ -115,11 +116,23 @@ func (pm *PreemptionMgr) PostFilter(ctx context.Context, state fwktype.CycleStat
 	return result, status
 }
 
+// wrapPreemptPod makes the victim reserve pod preempted by failing its Reservation instead of deleting the pod.
+func (pm *PreemptionMgr) wrapPreemptPod(preemptPod func(ctx context.Context, c preemption.Candidate, preemptor, victim *corev1.Pod, pluginName string) error) func(ctx context.Context, c preemption.Candidate, preemptor, victim *corev1.Pod, pluginName string) error {
+	return func(ctx context.Context, c preemption.Candidate, preemptor, victim *corev1.Pod, pluginName string) error {
+		if reservationutil.IsReservePod(victim) {
+			return frameworkext.PreemptReservation(ctx, pm.fh, preemptor, victim)
+		}
+		return preemptPod(ctx, c, preemptor, victim, pluginName)
+	}
+}
+
 // SelectVictimsOnNode finds minimum set of pods on the given node that should be preempted in order to make enough room
 // for "pod" to be scheduled.
 // Note that both `state` and `nodeInfo` are deep-copied.
 // We delegate the function to extend the preemption rules:
 // If a pod is marked as non-preemptible, it will not be selected as the victim.
+// A reserve pod is selected as the victim only if its Reservation is available and not allocated yet, and its
+// priority is the effective priority of the Reservation.
 func (pm *PreemptionMgr) SelectVictimsOnNode(
 	ctx context.Context,
 	state fwktype.CycleState,
@@ -155,6 +168,9 @@ func (pm *PreemptionMgr) SelectVictimsOnNode(
 			corev1helpers.PodPriority(pi.GetPod()) >= podPriority {
 			continue
 		}
+		if reservationutil.IsReservePod(pi.GetPod()) && !frameworkext.IsReservePodPreemptible(pm.fh, pi.GetPod()) {
+			continue
+		}
 
 		potentialVictims = append(potentialVictims, pi)
 		if err := removePod(pi); err != nil {
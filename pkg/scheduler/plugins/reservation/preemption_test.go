@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/uuid"
 	fwktype "k8s.io/kube-scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/preemption"
 	"k8s.io/utils/ptr"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
@@ -367,6 +368,42 @@ func TestPreemptionMgrSelectVictimsOnNode(t *testing.T) {
 		},
 	}
 	testReservePod := reservationutil.NewReservePod(testReservation)
+	testLPReservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "lp-reservation2C4G",
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+					},
+					Priority: ptr.To[int32](extension.PriorityProdValueMin),
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: testNode.Name,
+		},
+	}
+	testLPReservePod := reservationutil.NewReservePod(testLPReservation)
+	testAllocatedLPReservation := testLPReservation.DeepCopy()
+	testAllocatedLPReservation.Status.CurrentOwners = []corev1.ObjectReference{
+		{
+			Namespace: testLPPod.Namespace,
+			Name:      testLPPod.Name,
+			UID:       testLPPod.UID,
+		},
+	}
 	testNodeInfo := framework.NewNodeInfo()
 	testNodeInfo.SetNode(testNode)
 	testNodeInfo.AddPod(testLPPod)
@@ -374,6 +411,9 @@ func TestPreemptionMgrSelectVictimsOnNode(t *testing.T) {
 	testNodeInfo1 := framework.NewNodeInfo()
 	testNodeInfo1.SetNode(testNode)
 	testNodeInfo1.AddPod(testLPPod)
+	testNodeInfo2 := framework.NewNodeInfo()
+	testNodeInfo2.SetNode(testNode)
+	testNodeInfo2.AddPod(testLPReservePod)
 	type fields struct {
 		pods         []*corev1.Pod
 		reservePods  []*corev1.Pod
@@ -441,6 +481,46 @@ func TestPreemptionMgrSelectVictimsOnNode(t *testing.T) {
 			want1: 0,
 			want2: fwktype.NewStatus(fwktype.Success),
 		},
+		{
+			name: "pod preempts an unallocated reservation",
+			fields: fields{
+				nodes: []*corev1.Node{
+					testNode,
+				},
+				reservations: []*schedulingv1alpha1.Reservation{
+					testLPReservation,
+				},
+			},
+			args: args{
+				state:    framework.NewCycleState(),
+				pod:      testHPPod,
+				nodeInfo: testNodeInfo2.Snapshot(),
+				pdbs:     nil,
+			},
+			want:  nil,
+			want1: 0,
+			want2: fwktype.NewStatus(fwktype.Success),
+		},
+		{
+			name: "pod cannot preempt an allocated reservation",
+			fields: fields{
+				nodes: []*corev1.Node{
+					testNode,
+				},
+				reservations: []*schedulingv1alpha1.Reservation{
+					testAllocatedLPReservation,
+				},
+			},
+			args: args{
+				state:    framework.NewCycleState(),
+				pod:      testHPPod,
+				nodeInfo: testNodeInfo2.Snapshot(),
+				pdbs:     nil,
+			},
+			want:  nil,
+			want1: 0,
+			want2: fwktype.NewStatus(fwktype.UnschedulableAndUnresolvable, "No preemption victims found for incoming pod"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -477,6 +557,130 @@ func TestPreemptionMgrSelectVictimsOnNode(t *testing.T) {
 	}
 }
 
+func TestPreemptionMgrPreemptReservation(t *testing.T) {
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node-0",
+		},
+	}
+	testPreemptor := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hp-pod",
+			Namespace: "test-ns",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: corev1.PodSpec{
+			Priority: ptr.To[int32](extension.PriorityProdValueMax),
+		},
+	}
+	testVictimPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-lp-pod",
+			Namespace: "test-ns",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: corev1.PodSpec{
+			Priority: ptr.To[int32](extension.PriorityProdValueMin),
+			NodeName: testNode.Name,
+		},
+	}
+	testReservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "test-reservation",
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Priority: ptr.To[int32](extension.PriorityProdValueMin),
+				},
+			},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Controller: &schedulingv1alpha1.ReservationControllerReference{
+						OwnerReference: metav1.OwnerReference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "test-deployment",
+							UID:        uuid.NewUUID(),
+						},
+						Namespace: "test-ns",
+					},
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: testNode.Name,
+		},
+	}
+	testAllocatedReservation := testReservation.DeepCopy()
+	testAllocatedReservation.Status.CurrentOwners = []corev1.ObjectReference{
+		{
+			Namespace: testVictimPod.Namespace,
+			Name:      testVictimPod.Name,
+			UID:       testVictimPod.UID,
+		},
+	}
+	tests := []struct {
+		name             string
+		reservation      *schedulingv1alpha1.Reservation
+		victim           *corev1.Pod
+		wantErr          bool
+		wantPodPreempted bool
+		wantPhase        schedulingv1alpha1.ReservationPhase
+	}{
+		{
+			name:             "delegate pod victim to the default preemption",
+			reservation:      testReservation,
+			victim:           testVictimPod,
+			wantPodPreempted: true,
+			// the reservation is untouched
+			wantPhase: schedulingv1alpha1.ReservationAvailable,
+		},
+		{
+			name:        "preempt unallocated reservation",
+			reservation: testReservation,
+			victim:      reservationutil.NewReservePod(testReservation),
+			wantPhase:   schedulingv1alpha1.ReservationFailed,
+		},
+		{
+			name:        "failed to preempt reservation allocated after victims selected",
+			reservation: testAllocatedReservation,
+			victim:      reservationutil.NewReservePod(testAllocatedReservation),
+			wantErr:     true,
+			wantPhase:   schedulingv1alpha1.ReservationAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuitWith(t, nil, []*corev1.Node{testNode}, func(args *config.ReservationArgs) {
+				args.EnablePreemption = true
+			})
+			p, err := suit.pluginFactory()
+			assert.NoError(t, err)
+			pl := p.(*Plugin)
+			_, err = pl.handle.KoordinatorClientSet().SchedulingV1alpha1().Reservations().Create(context.TODO(), tt.reservation, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			suit.start(t)
+
+			podPreempted := false
+			preemptPod := pl.preemptionMgr.wrapPreemptPod(func(ctx context.Context, c preemption.Candidate, preemptor, victim *corev1.Pod, pluginName string) error {
+				podPreempted = true
+				return nil
+			})
+			err = preemptPod(context.TODO(), nil, testPreemptor, tt.victim, Name)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantPodPreempted, podPreempted)
+
+			got, err := pl.handle.KoordinatorClientSet().SchedulingV1alpha1().Reservations().Get(context.TODO(), tt.reservation.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, got.Status.Phase)
+			assert.Equal(t, tt.wantPhase == schedulingv1alpha1.ReservationFailed, reservationutil.IsReservationPreempted(got))
+		})
+	}
+}
+
 func TestFilterPodsWithPDBViolation(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -0,0 +1,301 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadbatch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/equivalence"
+)
+
+const (
+	Name = "WorkloadBatch"
+)
+
+var (
+	_ fwktype.PreFilterPlugin                = &Plugin{}
+	_ frameworkext.FindOneNodePluginProvider = &Plugin{}
+	_ frameworkext.FindOneNodePlugin         = &Plugin{}
+)
+
+// Plugin places the pending pods created from the same template of a workload (e.g. the replicas of a
+// ReplicaSet) in one scheduling cycle. When a pod is popped, its pending siblings with the same owner and
+// spec hash are planned onto nodes by sharing the Filter and Score results of the popped pod, and then the
+// whole plan is handed to the inline batch scheduler, which runs Reserve/Assume/Permit/Bind for them.
+// The plan only shares the Filter results of the popped pod, so the constraints checked in Reserve (e.g.
+// the elastic quota) are enforced by the batch scheduler, where the siblings failing them are dropped
+// while the rest are still bound. If the popped pod fails itself, it falls back to the standard flow.
+//
+// The plugin only takes effect when the EnableInlineBatchSchedule feature is enabled. The scheduler only
+// uses one FindOneNodePlugin per profile, so it should not be enabled together with the network topology
+// aware Coscheduling in the same profile.
+type Plugin struct {
+	handle       frameworkext.ExtendedHandle
+	podIndexer   cache.Indexer
+	maxBatchSize int
+}
+
+func New(_ context.Context, args runtime.Object, handle fwktype.Handle) (fwktype.Plugin, error) {
+	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("handle is not a frameworkext.ExtendedHandle")
+	}
+	pluginArgs, ok := args.(*config.WorkloadBatchArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type WorkloadBatchArgs, got %T", args)
+	}
+	if err := validation.ValidateWorkloadBatchArgs(nil, pluginArgs); err != nil {
+		return nil, err
+	}
+	podInformer := handle.SharedInformerFactory().Core().V1().Pods().Informer()
+	if err := addControllerUIDIndexer(podInformer); err != nil {
+		return nil, err
+	}
+	return &Plugin{
+		handle:       extendedHandle,
+		podIndexer:   podInformer.GetIndexer(),
+		maxBatchSize: int(pluginArgs.MaxBatchSize),
+	}, nil
+}
+
+func (p *Plugin) Name() string {
+	return Name
+}
+
+// PreFilter does nothing but allows the plugin to be enabled in the PreFilter extension point, where the
+// FindOneNodePlugin is invoked.
+func (p *Plugin) PreFilter(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodes []fwktype.NodeInfo) (*fwktype.PreFilterResult, *fwktype.Status) {
+	return nil, fwktype.NewStatus(fwktype.Skip)
+}
+
+func (p *Plugin) PreFilterExtensions() fwktype.PreFilterExtensions {
+	return nil
+}
+
+func (p *Plugin) FindOneNodePlugin() frameworkext.FindOneNodePlugin {
+	return p
+}
+
+func (p *Plugin) FindOneNode(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, result *fwktype.PreFilterResult) (*frameworkext.BatchScheduleResult, *fwktype.Status) {
+	if !k8sfeature.DefaultFeatureGate.Enabled(features.EnableInlineBatchSchedule) || !isBatchCandidate(pod) {
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+	siblings := p.getPendingSiblings(pod)
+	if len(siblings) == 0 {
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+
+	nodes, err := p.listNodes(result)
+	if err != nil {
+		return nil, fwktype.AsStatus(err)
+	}
+	// The feasible nodes and their scores of the popped pod are shared with all its siblings, and
+	// the Filter is only rerun on the nodes where the siblings are planned.
+	feasibleNodes := p.findFeasibleNodes(ctx, cycleState, pod, nodes)
+	if len(feasibleNodes) == 0 {
+		// fallback to the standard flow to build the diagnosis and try the preemption
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+	p.sortNodesByScore(ctx, cycleState, pod, feasibleNodes)
+
+	pods := append([]*corev1.Pod{pod}, siblings...)
+	podToNodeName := p.placePods(ctx, cycleState, pod, pods, feasibleNodes)
+	if len(podToNodeName) <= 1 {
+		return nil, fwktype.NewStatus(fwktype.Skip)
+	}
+	planned := make([]*corev1.Pod, 0, len(podToNodeName))
+	for _, toPlace := range pods {
+		if _, ok := podToNodeName[toPlace.Namespace+"/"+toPlace.Name]; ok {
+			planned = append(planned, toPlace)
+		}
+	}
+	klog.V(4).InfoS("WorkloadBatch planned pods of the same workload", "pod", klog.KObj(pod),
+		"owner", metav1.GetControllerOf(pod).Name, "siblings", len(siblings), "planned", len(planned), "feasibleNodes", len(feasibleNodes))
+	return &frameworkext.BatchScheduleResult{
+		Pods:          planned,
+		PodToNodeName: podToNodeName,
+		Independent:   true,
+	}, nil
+}
+
+// getPendingSiblings returns at most maxBatchSize-1 pending pods which have the same owner and spec hash
+// as the given pod, excluding the given pod itself.
+func (p *Plugin) getPendingSiblings(pod *corev1.Pod) []*corev1.Pod {
+	controllerRef := metav1.GetControllerOf(pod)
+	objs, err := p.podIndexer.ByIndex(controllerUIDIndex, string(controllerRef.UID))
+	if err != nil || len(objs) <= 1 {
+		return nil
+	}
+	var schedulerCache frameworkext.SchedulerCache
+	if scheduler := p.handle.Scheduler(); scheduler != nil {
+		schedulerCache = scheduler.GetCache()
+	}
+	candidates := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		sibling, ok := obj.(*corev1.Pod)
+		if !ok || sibling.UID == pod.UID || sibling.Namespace != pod.Namespace ||
+			sibling.Spec.SchedulerName != pod.Spec.SchedulerName || !isBatchCandidate(sibling) {
+			continue
+		}
+		if schedulerCache != nil {
+			if assumed, _ := schedulerCache.IsAssumedPod(sibling); assumed {
+				continue
+			}
+		}
+		candidates = append(candidates, sibling)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sortSiblings(candidates)
+
+	specHash := equivalence.GetPodHash(pod)
+	siblings := make([]*corev1.Pod, 0, p.maxBatchSize-1)
+	for _, candidate := range candidates {
+		if len(siblings) >= p.maxBatchSize-1 {
+			break
+		}
+		if equivalence.GetPodHash(candidate) == specHash {
+			siblings = append(siblings, candidate)
+		}
+	}
+	return siblings
+}
+
+func (p *Plugin) listNodes(result *fwktype.PreFilterResult) ([]fwktype.NodeInfo, error) {
+	if result.AllNodes() {
+		return p.handle.SnapshotSharedLister().NodeInfos().List()
+	}
+	nodes := make([]fwktype.NodeInfo, 0, len(result.NodeNames))
+	for nodeName := range result.NodeNames {
+		nodeInfo, err := p.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, nodeInfo)
+	}
+	return nodes, nil
+}
+
+func (p *Plugin) findFeasibleNodes(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodes []fwktype.NodeInfo) []fwktype.NodeInfo {
+	feasible := make([]bool, len(nodes))
+	p.handle.Parallelizer().Until(ctx, len(nodes), func(piece int) {
+		feasible[piece] = p.handle.RunFilterPlugins(ctx, cycleState, pod, nodes[piece]).IsSuccess()
+	}, Name)
+	feasibleNodes := make([]fwktype.NodeInfo, 0, len(nodes))
+	for i, nodeInfo := range nodes {
+		if feasible[i] {
+			feasibleNodes = append(feasibleNodes, nodeInfo)
+		}
+	}
+	return feasibleNodes
+}
+
+// sortNodesByScore sorts the feasible nodes by the scores of the popped pod in descending order.
+func (p *Plugin) sortNodesByScore(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, nodes []fwktype.NodeInfo) {
+	if len(nodes) <= 1 {
+		return
+	}
+	// PreScore plugins write their states, so score with a cloned state to keep the cycle state untouched.
+	scoreState := cycleState.Clone()
+	if status := p.handle.RunPreScorePlugins(ctx, scoreState, pod, nodes); !status.IsSuccess() {
+		klog.V(4).InfoS("WorkloadBatch failed to run PreScore, keep the node order", "pod", klog.KObj(pod), "status", status)
+		return
+	}
+	nodeScores, status := p.handle.RunScorePlugins(ctx, scoreState, pod, nodes)
+	if !status.IsSuccess() {
+		klog.V(4).InfoS("WorkloadBatch failed to run Score, keep the node order", "pod", klog.KObj(pod), "status", status)
+		return
+	}
+	scores := make(map[string]int64, len(nodeScores))
+	for _, nodeScore := range nodeScores {
+		scores[nodeScore.Name] = nodeScore.TotalScore
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return scores[nodes[i].Node().Name] > scores[nodes[j].Node().Name]
+	})
+}
+
+// nodePlacement is the simulated state of a node where some pods have been planned.
+type nodePlacement struct {
+	nodeInfo   fwktype.NodeInfo
+	cycleState fwktype.CycleState
+}
+
+// placePods plans the pods onto the feasible nodes in a round-robin fashion following the node order, so the
+// pods are spread over the top-ranked nodes. A node is excluded once the popped pod no longer fits it after
+// the planned pods are added. It returns the planned node of each pod keyed by namespace/name.
+func (p *Plugin) placePods(ctx context.Context, cycleState fwktype.CycleState, pod *corev1.Pod, pods []*corev1.Pod, nodes []fwktype.NodeInfo) map[string]string {
+	placements := make([]*nodePlacement, len(nodes))
+	available := make([]int, len(nodes))
+	for i := range nodes {
+		available[i] = i
+	}
+	podToNodeName := make(map[string]string, len(pods))
+	cursor := 0
+	for _, toPlace := range pods {
+		placed := false
+		for len(available) > 0 && !placed {
+			i := cursor % len(available)
+			index := available[i]
+			placement := placements[index]
+			if placement == nil {
+				// the node is feasible for the popped pod since nothing has been planned on it
+				placement = &nodePlacement{
+					nodeInfo:   nodes[index].Snapshot(),
+					cycleState: cycleState.Clone(),
+				}
+				placements[index] = placement
+			} else if status := p.handle.RunFilterPlugins(ctx, placement.cycleState, pod, placement.nodeInfo); !status.IsSuccess() {
+				available = append(available[:i], available[i+1:]...)
+				continue
+			}
+
+			assumedPod := toPlace.DeepCopy()
+			assumedPod.Spec.NodeName = placement.nodeInfo.Node().Name
+			podInfo, _ := framework.NewPodInfo(assumedPod)
+			placement.nodeInfo.AddPodInfo(podInfo)
+			if status := p.handle.RunPreFilterExtensionAddPod(ctx, placement.cycleState, pod, podInfo, placement.nodeInfo); !status.IsSuccess() {
+				klog.V(5).InfoS("WorkloadBatch failed to add the planned pod", "pod", klog.KObj(toPlace), "node", assumedPod.Spec.NodeName, "status", status)
+				available = append(available[:i], available[i+1:]...)
+				continue
+			}
+			podToNodeName[toPlace.Namespace+"/"+toPlace.Name] = assumedPod.Spec.NodeName
+			cursor = i + 1
+			placed = true
+		}
+		if !placed {
+			break
+		}
+	}
+	return podToNodeName
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadbatch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/backend/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulermetrics "k8s.io/kubernetes/pkg/scheduler/metrics"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing/framework"
+	"k8s.io/utils/ptr"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+func init() {
+	schedulermetrics.Register()
+}
+
+type fakeFitFilterPlugin struct{}
+
+func (f *fakeFitFilterPlugin) Name() string { return "FakeFitFilterPlugin" }
+
+func (f *fakeFitFilterPlugin) Filter(_ context.Context, _ fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	if insufficient := noderesources.Fits(pod, nodeInfo, nil, noderesources.ResourceRequestsOptions{}); len(insufficient) != 0 {
+		var reasons []string
+		for _, r := range insufficient {
+			reasons = append(reasons, r.Reason)
+		}
+		return fwktype.NewStatus(fwktype.Unschedulable, reasons...)
+	}
+	return nil
+}
+
+func newTestNode(name string, cpu string) *corev1.Node {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:  resource.MustParse(cpu),
+		corev1.ResourcePods: resource.MustParse("110"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Capacity:    resources,
+			Allocatable: resources,
+		},
+	}
+}
+
+func newTestPod(name string, ownerUID types.UID, cpu string, creation int) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(time.Unix(int64(creation), 0)),
+			Labels: map[string]string{
+				"app": "test",
+			},
+		},
+		Spec: corev1.PodSpec{
+			SchedulerName: "koord-scheduler",
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: fmt.Sprintf("kube-api-access-%s", name),
+				},
+			},
+		},
+	}
+	if ownerUID != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "test-rs",
+				UID:        ownerUID,
+				Controller: ptr.To(true),
+			},
+		}
+	}
+	return pod
+}
+
+func newTestPlugin(t *testing.T, nodes []*corev1.Node, pendingPods []*corev1.Pod, maxBatchSize int32) *Plugin {
+	koordClientSet := koordfake.NewSimpleClientset()
+	extenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+		frameworkext.WithKoordinatorClientSet(koordClientSet),
+		frameworkext.WithKoordinatorSharedInformerFactory(koordinatorinformers.NewSharedInformerFactory(koordClientSet, 0)),
+	)
+	assert.NoError(t, err)
+	sharedInformerFactory := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)
+	fh, err := schedulertesting.NewFramework(
+		context.TODO(),
+		[]schedulertesting.RegisterPluginFunc{
+			schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+			schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+			schedulertesting.RegisterFilterPlugin("FakeFitFilterPlugin", func(_ context.Context, _ runtime.Object, _ fwktype.Handle) (fwktype.Plugin, error) {
+				return &fakeFitFilterPlugin{}, nil
+			}),
+		},
+		"koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(cache.NewSnapshot(nil, nodes)),
+		frameworkruntime.WithInformerFactory(sharedInformerFactory),
+	)
+	assert.NoError(t, err)
+	extender := extenderFactory.NewFrameworkExtender(fh)
+	extender.SetConfiguredPlugins(fh.ListPlugins())
+
+	p, err := New(context.TODO(), &config.WorkloadBatchArgs{MaxBatchSize: maxBatchSize}, extender)
+	assert.NoError(t, err)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	for _, pod := range pendingPods {
+		assert.NoError(t, podInformer.GetIndexer().Add(pod))
+	}
+	return p.(*Plugin)
+}
+
+func TestIsBatchCandidate(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "pod of workload",
+			pod:  newTestPod("pod-1", "rs-uid", "1", 0),
+			want: true,
+		},
+		{
+			name: "pod without owner",
+			pod:  newTestPod("pod-1", "", "1", 0),
+			want: false,
+		},
+		{
+			name: "gang pod",
+			pod: func() *corev1.Pod {
+				pod := newTestPod("pod-1", "rs-uid", "1", 0)
+				pod.Annotations = map[string]string{extension.AnnotationGangName: "gang-a"}
+				return pod
+			}(),
+			want: false,
+		},
+		{
+			name: "pod with pvc",
+			pod: func() *corev1.Pod {
+				pod := newTestPod("pod-1", "rs-uid", "1", 0)
+				pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+					},
+				})
+				return pod
+			}(),
+			want: false,
+		},
+		{
+			name: "nominated pod",
+			pod: func() *corev1.Pod {
+				pod := newTestPod("pod-1", "rs-uid", "1", 0)
+				pod.Status.NominatedNodeName = "node-1"
+				return pod
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBatchCandidate(tt.pod))
+		})
+	}
+}
+
+func TestFindOneNode(t *testing.T) {
+	newPendingPods := func(count int) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i := 0; i < count; i++ {
+			pods = append(pods, newTestPod(fmt.Sprintf("pod-%d", i), "rs-uid", "1", i))
+		}
+		return pods
+	}
+	tests := []struct {
+		name            string
+		disableFeature  bool
+		nodes           []*corev1.Node
+		pendingPods     []*corev1.Pod
+		maxBatchSize    int32
+		wantStatus      *fwktype.Status
+		wantPlannedPods []string
+		wantPodsOnNodes map[string]int
+	}{
+		{
+			name:           "feature disabled",
+			disableFeature: true,
+			nodes:          []*corev1.Node{newTestNode("node-1", "4")},
+			pendingPods:    newPendingPods(2),
+			maxBatchSize:   100,
+			wantStatus:     fwktype.NewStatus(fwktype.Skip),
+		},
+		{
+			name:         "no pending siblings",
+			nodes:        []*corev1.Node{newTestNode("node-1", "4")},
+			pendingPods:  newPendingPods(1),
+			maxBatchSize: 100,
+			wantStatus:   fwktype.NewStatus(fwktype.Skip),
+		},
+		{
+			name:         "no feasible nodes",
+			nodes:        []*corev1.Node{newTestNode("node-1", "500m")},
+			pendingPods:  newPendingPods(3),
+			maxBatchSize: 100,
+			wantStatus:   fwktype.NewStatus(fwktype.Skip),
+		},
+		{
+			name:  "skip siblings of different template or assigned",
+			nodes: []*corev1.Node{newTestNode("node-1", "8")},
+			pendingPods: append(newPendingPods(3),
+				newTestPod("pod-large", "rs-uid", "2", 10),
+				newTestPod("pod-other-owner", "other-rs-uid", "1", 11),
+				func() *corev1.Pod {
+					pod := newTestPod("pod-assigned", "rs-uid", "1", 12)
+					pod.Spec.NodeName = "node-1"
+					return pod
+				}(),
+			),
+			maxBatchSize:    100,
+			wantPlannedPods: []string{"pod-0", "pod-1", "pod-2"},
+			wantPodsOnNodes: map[string]int{"node-1": 3},
+		},
+		{
+			name:            "spread siblings over nodes",
+			nodes:           []*corev1.Node{newTestNode("node-1", "4"), newTestNode("node-2", "4"), newTestNode("node-3", "4")},
+			pendingPods:     newPendingPods(6),
+			maxBatchSize:    100,
+			wantPlannedPods: []string{"pod-0", "pod-1", "pod-2", "pod-3", "pod-4", "pod-5"},
+			wantPodsOnNodes: map[string]int{"node-1": 2, "node-2": 2, "node-3": 2},
+		},
+		{
+			name:            "plan until nodes are full",
+			nodes:           []*corev1.Node{newTestNode("node-1", "2"), newTestNode("node-2", "1")},
+			pendingPods:     newPendingPods(6),
+			maxBatchSize:    100,
+			wantPlannedPods: []string{"pod-0", "pod-1", "pod-2"},
+			wantPodsOnNodes: map[string]int{"node-1": 2, "node-2": 1},
+		},
+		{
+			name:            "limited by max batch size",
+			nodes:           []*corev1.Node{newTestNode("node-1", "8")},
+			pendingPods:     newPendingPods(6),
+			maxBatchSize:    3,
+			wantPlannedPods: []string{"pod-0", "pod-1", "pod-2"},
+			wantPodsOnNodes: map[string]int{"node-1": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, k8sfeature.DefaultMutableFeatureGate, features.EnableInlineBatchSchedule, !tt.disableFeature)()
+			p := newTestPlugin(t, tt.nodes, tt.pendingPods, tt.maxBatchSize)
+			pod := tt.pendingPods[0]
+			result, status := p.FindOneNode(context.TODO(), framework.NewCycleState(), pod, nil)
+			assert.Equal(t, tt.wantStatus, status)
+			if !status.IsSuccess() {
+				assert.Nil(t, result)
+				return
+			}
+			// the replicas do not depend on each other, so the failed ones are dropped by the batch scheduler
+			assert.True(t, result.Independent)
+			var plannedPods []string
+			podsOnNodes := map[string]int{}
+			for _, planned := range result.Pods {
+				plannedPods = append(plannedPods, planned.Name)
+				podsOnNodes[result.PodToNodeName[planned.Namespace+"/"+planned.Name]]++
+			}
+			assert.Equal(t, tt.wantPlannedPods, plannedPods)
+			assert.Equal(t, tt.wantPodsOnNodes, podsOnNodes)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadbatch
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	// controllerUIDIndex is the lookup name for the index function, which is to index pods by the UID of
+	// their controller owner.
+	controllerUIDIndex = "workloadbatch.controllerUID"
+)
+
+// controllerUIDIndexFunc indexes the pending pods by the UID of their controller owner.
+func controllerUIDIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return []string{}, nil
+	}
+	if pod.Spec.NodeName != "" {
+		return []string{}, nil
+	}
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil {
+		return []string{}, nil
+	}
+	return []string{string(controllerRef.UID)}, nil
+}
+
+func addControllerUIDIndexer(informer cache.SharedIndexInformer) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[controllerUIDIndex]; ok {
+		return nil
+	}
+	return informer.AddIndexers(cache.Indexers{controllerUIDIndex: controllerUIDIndexFunc})
+}
+
+// isBatchCandidate checks if the pod can be placed together with its siblings. Pods owned by a gang,
+// reserve pods, gated pods and pods binding volumes or resource claims must be scheduled one by one.
+func isBatchCandidate(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Spec.NodeName != "" || pod.Status.NominatedNodeName != "" {
+		return false
+	}
+	if metav1.GetControllerOf(pod) == nil {
+		return false
+	}
+	if extension.GetGangName(pod) != "" || reservationutil.IsReservePod(pod) {
+		return false
+	}
+	if len(pod.Spec.SchedulingGates) > 0 || len(pod.Spec.ResourceClaims) > 0 {
+		return false
+	}
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.PersistentVolumeClaim != nil || volume.Ephemeral != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// sortSiblings sorts the sibling pods by their creation time, then their names.
+func sortSiblings(pods []*corev1.Pod) {
+	sort.Slice(pods, func(i, j int) bool {
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
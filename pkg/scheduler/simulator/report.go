@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const (
+	// DefaultNodePoolName is the node pool of the nodes without the node pool label.
+	DefaultNodePoolName = "<none>"
+
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+)
+
+// Report is the result of a simulation.
+type Report struct {
+	Placements    []*Placement     `json:"placements"`
+	Scheduled     int              `json:"scheduled"`
+	Unschedulable int              `json:"unschedulable"`
+	NodePools     []*NodePoolUsage `json:"nodePools,omitempty"`
+	Quotas        []*QuotaUsage    `json:"quotas,omitempty"`
+}
+
+// NodePoolUsage is the resulting utilization of the nodes grouped by the node pool label.
+type NodePoolUsage struct {
+	Name        string              `json:"name"`
+	Nodes       int                 `json:"nodes"`
+	Allocatable corev1.ResourceList `json:"allocatable"`
+	Requested   corev1.ResourceList `json:"requested"`
+}
+
+// QuotaUsage is the resulting usage of an ElasticQuota.
+type QuotaUsage struct {
+	Name string              `json:"name"`
+	Min  corev1.ResourceList `json:"min,omitempty"`
+	Max  corev1.ResourceList `json:"max,omitempty"`
+	Used corev1.ResourceList `json:"used"`
+}
+
+// BuildReport summarizes the placements against the snapshot. The pods already assigned in the snapshot and
+// the placed pods are accounted into the node pools and quotas.
+func BuildReport(snapshot *Snapshot, placements []*Placement, nodePoolLabel string) *Report {
+	report := &Report{Placements: placements}
+	assignedPods := make([]*corev1.Pod, 0, len(snapshot.Pods)+len(placements))
+	for _, pod := range snapshot.Pods {
+		if pod.Spec.NodeName != "" {
+			assignedPods = append(assignedPods, pod)
+		}
+	}
+	for _, placement := range placements {
+		if placement.Node == "" {
+			report.Unschedulable++
+			continue
+		}
+		report.Scheduled++
+		pod := placement.Pod.DeepCopy()
+		pod.Spec.NodeName = placement.Node
+		assignedPods = append(assignedPods, pod)
+	}
+	report.NodePools = buildNodePoolUsages(snapshot.Nodes, assignedPods, nodePoolLabel)
+	report.Quotas = buildQuotaUsages(snapshot.ElasticQuotas, assignedPods)
+	return report
+}
+
+func buildNodePoolUsages(nodes []*corev1.Node, pods []*corev1.Pod, nodePoolLabel string) []*NodePoolUsage {
+	pools := map[string]*NodePoolUsage{}
+	nodePools := make(map[string]*NodePoolUsage, len(nodes))
+	for _, node := range nodes {
+		name := DefaultNodePoolName
+		if nodePoolLabel != "" && node.Labels[nodePoolLabel] != "" {
+			name = node.Labels[nodePoolLabel]
+		}
+		pool := pools[name]
+		if pool == nil {
+			pool = &NodePoolUsage{Name: name, Allocatable: corev1.ResourceList{}, Requested: corev1.ResourceList{}}
+			pools[name] = pool
+		}
+		pool.Nodes++
+		pool.Allocatable = quotav1.Add(pool.Allocatable, node.Status.Allocatable)
+		nodePools[node.Name] = pool
+	}
+	for _, pod := range pods {
+		if pool := nodePools[pod.Spec.NodeName]; pool != nil {
+			pool.Requested = quotav1.Add(pool.Requested, util.GetPodRequest(pod))
+			pool.Requested = quotav1.Add(pool.Requested, corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(1, resource.DecimalSI)})
+		}
+	}
+	usages := make([]*NodePoolUsage, 0, len(pools))
+	for _, pool := range pools {
+		usages = append(usages, pool)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+	return usages
+}
+
+func buildQuotaUsages(quotas []*schedv1alpha1.ElasticQuota, pods []*corev1.Pod) []*QuotaUsage {
+	usages := map[string]*QuotaUsage{}
+	for _, quota := range quotas {
+		usages[quota.Name] = &QuotaUsage{
+			Name: quota.Name,
+			Min:  quota.Spec.Min,
+			Max:  quota.Spec.Max,
+			Used: corev1.ResourceList{},
+		}
+	}
+	for _, pod := range pods {
+		name := getPodQuotaName(pod, quotas)
+		usage := usages[name]
+		if usage == nil {
+			if name != extension.DefaultQuotaName {
+				continue
+			}
+			usage = &QuotaUsage{Name: name, Used: corev1.ResourceList{}}
+			usages[name] = usage
+		}
+		usage.Used = quotav1.Add(usage.Used, util.GetPodRequest(pod))
+	}
+	result := make([]*QuotaUsage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// getPodQuotaName associates the pod with the quota in the same way as the ElasticQuota plugin: the quota
+// label first, then the quota named after the namespace, then the quota declaring the namespace.
+func getPodQuotaName(pod *corev1.Pod, quotas []*schedv1alpha1.ElasticQuota) string {
+	if name := extension.GetQuotaName(pod); name != "" {
+		return name
+	}
+	for _, quota := range quotas {
+		if quota.Namespace == pod.Namespace && quota.Name == pod.Namespace {
+			return quota.Name
+		}
+	}
+	for _, quota := range quotas {
+		for _, namespace := range extension.GetAnnotationQuotaNamespaces(quota) {
+			if namespace == pod.Namespace {
+				return quota.Name
+			}
+		}
+	}
+	return extension.DefaultQuotaName
+}
+
+// Print writes the report in the format, table or json.
+func (r *Report) Print(w io.Writer, format string) error {
+	switch format {
+	case OutputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(r)
+	case OutputFormatTable, "":
+		return r.printTable(w)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func (r *Report) printTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "POD\tNODE\tREASON\n")
+	for _, placement := range r.Placements {
+		node := placement.Node
+		if node == "" {
+			node = "<unschedulable>"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", placement.Key, node, placement.Reason)
+	}
+	fmt.Fprintf(tw, "\nScheduled: %d, Unschedulable: %d\n", r.Scheduled, r.Unschedulable)
+
+	fmt.Fprintf(tw, "\nNODE POOL\tNODES\tRESOURCE\tREQUESTED\tALLOCATABLE\tUTILIZATION\n")
+	for _, pool := range r.NodePools {
+		for _, name := range sortedResourceNames(pool.Allocatable) {
+			allocatable, requested := pool.Allocatable[name], pool.Requested[name]
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", pool.Name, pool.Nodes, name, requested.String(), allocatable.String(), percentage(requested, allocatable))
+		}
+	}
+
+	if len(r.Quotas) > 0 {
+		fmt.Fprintf(tw, "\nQUOTA\tRESOURCE\tUSED\tMIN\tMAX\tUSED/MAX\n")
+		for _, quota := range r.Quotas {
+			for _, name := range sortedResourceNames(quotav1.Add(quota.Used, quota.Max)) {
+				used, minQuantity, maxQuantity := quota.Used[name], quota.Min[name], quota.Max[name]
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", quota.Name, name, used.String(), minQuantity.String(), maxQuantity.String(), percentage(used, maxQuantity))
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func sortedResourceNames(resourceList corev1.ResourceList) []corev1.ResourceName {
+	names := quotav1.ResourceNames(resourceList)
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func percentage(used, total resource.Quantity) string {
+	if total.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(used.MilliValue())*100/float64(total.MilliValue()))
+}
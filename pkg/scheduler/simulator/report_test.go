@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+)
+
+func TestBuildReport(t *testing.T) {
+	existing := newTestPod("existing", "1", nil)
+	existing.Spec.NodeName = "node-1"
+	pending := newTestPod("pending", "1", nil)
+	snapshot := NewSnapshot([]runtime.Object{
+		newTestNode("node-1", "4", map[string]string{"pool": "gpu"}),
+		newTestNode("node-2", "4", map[string]string{"pool": "gpu"}),
+		newTestNode("node-3", "8", nil),
+		existing,
+		pending,
+		&schedv1alpha1.ElasticQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-a"},
+			Spec: schedv1alpha1.ElasticQuotaSpec{
+				Min: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Max: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			},
+		},
+	})
+	placements := []*Placement{
+		{Pod: newTestPod("pod-1", "2", map[string]string{extension.LabelQuotaName: "tenant-a"}), Key: "default/pod-1", Node: "node-2"},
+		{Pod: newTestPod("pod-2", "2", map[string]string{extension.LabelQuotaName: "tenant-a"}), Key: "default/pod-2", Node: "node-3"},
+		{Pod: newTestPod("pod-3", "16", nil), Key: "default/pod-3", Reason: "0/3 nodes are available: 3 Insufficient cpu."},
+	}
+	report := BuildReport(snapshot, placements, "pool")
+	assert.Equal(t, 2, report.Scheduled)
+	assert.Equal(t, 1, report.Unschedulable)
+
+	assert.Len(t, report.NodePools, 2)
+	assert.Equal(t, DefaultNodePoolName, report.NodePools[0].Name)
+	assert.Equal(t, "gpu", report.NodePools[1].Name)
+	assert.Equal(t, 2, report.NodePools[1].Nodes)
+	assert.Equal(t, int64(3000), report.NodePools[1].Requested.Cpu().MilliValue())
+	assert.Equal(t, int64(8000), report.NodePools[1].Allocatable.Cpu().MilliValue())
+	assert.Equal(t, int64(2), report.NodePools[1].Requested.Pods().Value())
+
+	assert.Len(t, report.Quotas, 2)
+	assert.Equal(t, extension.DefaultQuotaName, report.Quotas[0].Name)
+	assert.Equal(t, int64(1000), report.Quotas[0].Used.Cpu().MilliValue(), "the existing pod goes to the default quota")
+	assert.Equal(t, "tenant-a", report.Quotas[1].Name)
+	assert.Equal(t, int64(4000), report.Quotas[1].Used.Cpu().MilliValue())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, report.Print(buf, OutputFormatTable))
+	assert.Contains(t, buf.String(), "<unschedulable>")
+	assert.Contains(t, buf.String(), "Scheduled: 2, Unschedulable: 1")
+	assert.Contains(t, buf.String(), "50.0%")
+
+	buf.Reset()
+	assert.NoError(t, report.Print(buf, OutputFormatJSON))
+	decoded := &Report{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, "node-2", decoded.Placements[0].Node)
+	assert.Error(t, report.Print(buf, "yaml"))
+}
+
+func TestGetPodQuotaName(t *testing.T) {
+	quotas := []*schedv1alpha1.ElasticQuota{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ns-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "quota-b", Annotations: map[string]string{extension.AnnotationQuotaNamespaces: `["ns-c"]`}}},
+	}
+	tests := []struct {
+		namespace string
+		labels    map[string]string
+		want      string
+	}{
+		{namespace: "ns-a", labels: map[string]string{extension.LabelQuotaName: "quota-x"}, want: "quota-x"},
+		{namespace: "ns-a", want: "ns-a"},
+		{namespace: "ns-c", want: "quota-b"},
+		{namespace: "ns-d", want: extension.DefaultQuotaName},
+	}
+	for _, tt := range tests {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Labels: tt.labels}}
+		assert.Equal(t, tt.want, getPodQuotaName(pod, quotas))
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/backend/cache"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/batch"
+	batchframework "github.com/koordinator-sh/koordinator/pkg/scheduler/batch/framework"
+)
+
+const cleanupReasonSimulationFailure = "simulation_failure"
+
+// SchedulePodFunc selects the node for the pod, it is usually the SchedulePod of the scheduler.
+type SchedulePodFunc func(ctx context.Context, fwk schedulerframework.Framework, state fwktype.CycleState, pod *corev1.Pod) (scheduler.ScheduleResult, error)
+
+// Placement is the simulated scheduling result of a pod.
+type Placement struct {
+	Pod  *corev1.Pod `json:"-"`
+	Key  string      `json:"pod"`
+	Node string      `json:"node,omitempty"`
+	// Reason is the unschedulable reason if the pod fails to be placed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Simulator places the pods one by one with the scheduling framework. The node of each pod is selected by
+// the SchedulePodFunc, then the pod is reserved and assumed on the node by the batch Engine, so that the
+// following pods see the resources consumed by it. The pods are never bound.
+type Simulator struct {
+	fwk         schedulerframework.Framework
+	schedulePod SchedulePodFunc
+	cache       cache.Cache
+	engine      *batch.Engine
+}
+
+func New(fwk schedulerframework.Framework, schedulePod SchedulePodFunc, c cache.Cache) *Simulator {
+	return &Simulator{
+		fwk:         fwk,
+		schedulePod: schedulePod,
+		cache:       c,
+		engine:      batch.NewEngine(c),
+	}
+}
+
+// Schedule places the pods in order and returns the placements in the same order.
+func (s *Simulator) Schedule(ctx context.Context, pods []*corev1.Pod) []*Placement {
+	placements := make([]*Placement, 0, len(pods))
+	for _, pod := range pods {
+		if ctx.Err() != nil {
+			placements = append(placements, &Placement{Pod: pod, Key: batchframework.GetPodKey(pod), Reason: ctx.Err().Error()})
+			continue
+		}
+		placements = append(placements, s.scheduleOne(ctx, pod))
+	}
+	return placements
+}
+
+func (s *Simulator) scheduleOne(ctx context.Context, pod *corev1.Pod) *Placement {
+	logger := klog.FromContext(ctx)
+	placement := &Placement{Pod: pod, Key: batchframework.GetPodKey(pod)}
+	result, err := s.schedulePod(ctx, s.fwk, schedulerframework.NewCycleState(), pod)
+	if err != nil {
+		placement.Reason = err.Error()
+		return placement
+	}
+
+	nodeName := result.SuggestedHost
+	jobRequest := &batchframework.JobRequest{
+		SchedulerName: pod.Spec.SchedulerName,
+		Namespace:     pod.Namespace,
+		JobName:       pod.Name,
+		MinMember:     1,
+		PodsByNode: map[string]map[string]batchframework.PodRequest{
+			nodeName: {placement.Key: {NodeName: nodeName, Pod: pod}},
+		},
+	}
+	podRequestsByNode, err := batch.ValidateAndGroupByRequest(jobRequest)
+	if err != nil {
+		placement.Reason = err.Error()
+		return placement
+	}
+	jobResult := &batchframework.JobResult{}
+	var assumedPods []*batchframework.AssumeContext
+	assumedPodLock := &sync.Mutex{}
+	nodeSnapshot := &sync.Map{}
+	parallelizer := s.fwk.Parallelizer()
+	s.engine.RunSchedulingCycle(ctx, logger, parallelizer, s.fwk, jobRequest, jobResult, podRequestsByNode, &assumedPods, assumedPodLock, nodeSnapshot, nil)
+	if status := jobResult.PodStatus(placement.Key); !status.IsSuccess() {
+		placement.Reason = status.Message()
+		s.engine.CleanupAssumedPods(ctx, logger, parallelizer, s.fwk, jobRequest, jobResult, assumedPods, nodeSnapshot,
+			func(pod *corev1.Pod) error {
+				return s.cache.ForgetPod(logger, pod)
+			}, status, cleanupReasonSimulationFailure)
+		return placement
+	}
+	placement.Node = nodeName
+	return placement
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	fwktype "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/backend/cache"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/parallelize"
+	schedulermetrics "k8s.io/kubernetes/pkg/scheduler/metrics"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/metrics"
+)
+
+func init() {
+	metrics.Register()
+	schedulermetrics.Register()
+}
+
+// fakeExtender is a trimmed frameworkext.FrameworkExtender driving the batch Engine, calling an
+// un-overridden method panics.
+type fakeExtender struct {
+	frameworkext.FrameworkExtender
+	snapshot      *cache.Snapshot
+	reserveStatus map[string]*fwktype.Status
+	unreserved    []string
+}
+
+func (f *fakeExtender) Parallelizer() fwktype.Parallelizer { return parallelize.NewParallelizer(1) }
+
+func (f *fakeExtender) SnapshotSharedLister() fwktype.SharedLister { return f.snapshot }
+
+func (f *fakeExtender) ProfileName() string { return "koord-scheduler" }
+
+func (f *fakeExtender) EventRecorder() events.EventRecorder { return &events.FakeRecorder{} }
+
+func (f *fakeExtender) Scheduler() frameworkext.Scheduler { return nil }
+
+func (f *fakeExtender) DeleteNominatedPodIfExists(pod *corev1.Pod) {}
+
+func (f *fakeExtender) RunPreFilterPlugins(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod) (*fwktype.PreFilterResult, *fwktype.Status, sets.Set[string]) {
+	return nil, nil, nil
+}
+
+func (f *fakeExtender) RunFilterPluginsWithNominatedPods(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod, nodeInfo fwktype.NodeInfo) *fwktype.Status {
+	return nil
+}
+
+func (f *fakeExtender) RunReservePluginsReserve(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod, nodeName string) *fwktype.Status {
+	return f.reserveStatus[pod.Name]
+}
+
+func (f *fakeExtender) RunReservePluginsUnreserve(ctx context.Context, state fwktype.CycleState, pod *corev1.Pod, nodeName string) {
+	f.unreserved = append(f.unreserved, pod.Name)
+}
+
+// fakeSchedulePod places the pod on the first node with enough cpu in the snapshot updated from the cache.
+func fakeSchedulePod(c cache.Cache, snapshot *cache.Snapshot) SchedulePodFunc {
+	return func(ctx context.Context, fwk schedulerframework.Framework, state fwktype.CycleState, pod *corev1.Pod) (scheduler.ScheduleResult, error) {
+		if err := c.UpdateSnapshot(klog.FromContext(ctx), snapshot); err != nil {
+			return scheduler.ScheduleResult{}, err
+		}
+		nodeInfos, err := snapshot.NodeInfos().List()
+		if err != nil {
+			return scheduler.ScheduleResult{}, err
+		}
+		request := pod.Spec.Containers[0].Resources.Requests.Cpu().MilliValue()
+		for _, nodeInfo := range nodeInfos {
+			if nodeInfo.GetRequested().GetMilliCPU()+request <= nodeInfo.GetAllocatable().GetMilliCPU() {
+				return scheduler.ScheduleResult{SuggestedHost: nodeInfo.Node().Name, EvaluatedNodes: len(nodeInfos), FeasibleNodes: 1}, nil
+			}
+		}
+		return scheduler.ScheduleResult{}, fmt.Errorf("0/%d nodes are available: %d Insufficient cpu.", len(nodeInfos), len(nodeInfos))
+	}
+}
+
+func newTestNode(name, cpu string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
+
+func newTestPod(name, cpu string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name), Labels: labels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSimulatorSchedule(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := cache.New(ctx, time.Hour, nil)
+	logger := klog.FromContext(ctx)
+	c.AddNode(logger, newTestNode("node-1", "4", nil))
+	c.AddNode(logger, newTestNode("node-2", "4", nil))
+	existing := newTestPod("existing", "2", nil)
+	existing.Spec.NodeName = "node-1"
+	assert.NoError(t, c.AddPod(logger, existing))
+
+	snapshot := cache.NewEmptySnapshot()
+	fwk := &fakeExtender{
+		snapshot: snapshot,
+		reserveStatus: map[string]*fwktype.Status{
+			"pod-4": fwktype.NewStatus(fwktype.Unschedulable, "reserve failed"),
+		},
+	}
+	s := New(fwk, fakeSchedulePod(c, snapshot), c)
+	pods := []*corev1.Pod{
+		newTestPod("pod-1", "2", nil),
+		newTestPod("pod-2", "3", nil),
+		newTestPod("pod-3", "2", nil),
+		newTestPod("pod-4", "1", nil),
+	}
+	placements := s.Schedule(ctx, pods)
+	assert.Len(t, placements, 4)
+	assert.Equal(t, "node-1", placements[0].Node)
+	assert.Equal(t, "node-2", placements[1].Node)
+	assert.Equal(t, "", placements[2].Node)
+	assert.Contains(t, placements[2].Reason, "Insufficient cpu")
+	assert.Equal(t, "", placements[3].Node)
+	assert.Contains(t, placements[3].Reason, "reserve failed")
+	assert.Equal(t, []string{"pod-4"}, fwk.unreserved)
+
+	// the assumed pods are kept in the cache and the failed ones are forgotten
+	for i, placement := range placements {
+		assumed, _ := c.IsAssumedPod(pods[i])
+		assert.Equal(t, placement.Node != "", assumed, placement.Key)
+	}
+}
+
+func TestSimulatorScheduleContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := cache.New(ctx, time.Hour, nil)
+	cancel()
+	s := New(&fakeExtender{snapshot: cache.NewEmptySnapshot()}, nil, c)
+	placements := s.Schedule(ctx, []*corev1.Pod{newTestPod("pod-1", "1", nil)})
+	assert.Len(t, placements, 1)
+	assert.Equal(t, context.Canceled.Error(), placements[0].Reason)
+}
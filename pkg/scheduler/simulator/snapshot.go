@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator runs the koord-scheduler profile offline against a cluster snapshot to answer
+// capacity-planning questions, e.g. whether a new tenant fits into the cluster and its quotas.
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	nrtscheme "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/scheme"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	schedscheme "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned/scheme"
+	koordscheme "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/scheme"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(koordscheme.AddToScheme(scheme))
+	utilruntime.Must(schedscheme.AddToScheme(scheme))
+	utilruntime.Must(nrtscheme.AddToScheme(scheme))
+}
+
+// Snapshot is the cluster state the simulation runs on.
+type Snapshot struct {
+	Nodes         []*corev1.Node
+	Pods          []*corev1.Pod
+	ElasticQuotas []*schedv1alpha1.ElasticQuota
+	// Objects are all the objects of the snapshot, including the nodes, pods and quotas above.
+	Objects []runtime.Object
+}
+
+// NewSnapshot builds the Snapshot from the objects. The terminated pods are dropped.
+func NewSnapshot(objects []runtime.Object) *Snapshot {
+	snapshot := &Snapshot{}
+	for _, obj := range objects {
+		switch t := obj.(type) {
+		case *corev1.Node:
+			snapshot.Nodes = append(snapshot.Nodes, t)
+		case *corev1.Pod:
+			if util.IsPodTerminated(t) {
+				continue
+			}
+			snapshot.Pods = append(snapshot.Pods, t)
+		case *schedv1alpha1.ElasticQuota:
+			snapshot.ElasticQuotas = append(snapshot.ElasticQuotas, t)
+		}
+		snapshot.Objects = append(snapshot.Objects, obj)
+	}
+	return snapshot
+}
+
+// Add appends the objects to the snapshot.
+func (s *Snapshot) Add(objects ...runtime.Object) {
+	other := NewSnapshot(objects)
+	s.Nodes = append(s.Nodes, other.Nodes...)
+	s.Pods = append(s.Pods, other.Pods...)
+	s.ElasticQuotas = append(s.ElasticQuotas, other.ElasticQuotas...)
+	s.Objects = append(s.Objects, other.Objects...)
+}
+
+// LoadObjectsFromFiles decodes the objects from the YAML or JSON files.
+func LoadObjectsFromFiles(files ...string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := LoadObjects(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s, err: %w", file, err)
+		}
+		objects = append(objects, objs...)
+	}
+	return objects, nil
+}
+
+// LoadObjects decodes the objects from a multi-document YAML or JSON stream, e.g. the output of
+// `kubectl get nodes,pods,elasticquotas,reservations,devices -A -o yaml`. The lists are flattened and
+// the objects of unknown kinds are skipped.
+func LoadObjects(r io.Reader) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		objs, err := decodeObjects(doc)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+}
+
+func decodeObjects(data []byte) ([]runtime.Object, error) {
+	obj, _, err := codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			klog.Warningf("Skip the object of unknown kind, err: %v", err)
+			return nil, nil
+		}
+		if runtime.IsMissingKind(err) || runtime.IsMissingVersion(err) {
+			// e.g. an empty document with comments only
+			return nil, nil
+		}
+		return nil, err
+	}
+	if list, ok := obj.(*corev1.List); ok {
+		var objects []runtime.Object
+		for i := range list.Items {
+			objs, err := decodeObjects(list.Items[i].Raw)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+		}
+		return objects, nil
+	}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	return []runtime.Object{obj}, nil
+}
+
+// ExpandWorkloads expands the hypothetical workloads into the pods to schedule. Pods are taken as they are,
+// the Deployments, ReplicaSets, StatefulSets and Jobs are expanded into the pods of their templates. The other
+// objects, e.g. the ElasticQuota of a new tenant, are returned as they are to be added to the snapshot.
+func ExpandWorkloads(objects []runtime.Object) (pods []*corev1.Pod, others []runtime.Object) {
+	for _, obj := range objects {
+		switch t := obj.(type) {
+		case *corev1.Pod:
+			pods = append(pods, newWorkloadPod(t.ObjectMeta, t.Spec, t.Namespace, t.Name))
+		case *appsv1.Deployment:
+			pods = append(pods, expandTemplate(t.ObjectMeta, &t.Spec.Template, replicasOrDefault(t.Spec.Replicas))...)
+		case *appsv1.ReplicaSet:
+			pods = append(pods, expandTemplate(t.ObjectMeta, &t.Spec.Template, replicasOrDefault(t.Spec.Replicas))...)
+		case *appsv1.StatefulSet:
+			pods = append(pods, expandTemplate(t.ObjectMeta, &t.Spec.Template, replicasOrDefault(t.Spec.Replicas))...)
+		case *batchv1.Job:
+			pods = append(pods, expandTemplate(t.ObjectMeta, &t.Spec.Template, replicasOrDefault(t.Spec.Parallelism))...)
+		default:
+			others = append(others, obj)
+		}
+	}
+	return pods, others
+}
+
+func replicasOrDefault(replicas *int32) int {
+	if replicas == nil {
+		return 1
+	}
+	return int(*replicas)
+}
+
+func expandTemplate(owner metav1.ObjectMeta, template *corev1.PodTemplateSpec, replicas int) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		pods = append(pods, newWorkloadPod(template.ObjectMeta, template.Spec, owner.Namespace, fmt.Sprintf("%s-%d", owner.Name, i)))
+	}
+	return pods
+}
+
+func newWorkloadPod(objectMeta metav1.ObjectMeta, spec corev1.PodSpec, namespace, name string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Spec:       *spec.DeepCopy(),
+	}
+	pod.Namespace = namespace
+	if pod.Namespace == "" {
+		pod.Namespace = corev1.NamespaceDefault
+	}
+	pod.Name = name
+	pod.UID = uuid.NewUUID()
+	pod.ResourceVersion = ""
+	pod.CreationTimestamp = metav1.Now()
+	pod.Spec.NodeName = ""
+	pod.Status = corev1.PodStatus{Phase: corev1.PodPending}
+	return pod
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	schedv1alpha1 "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+)
+
+const testSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node-1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-1
+    namespace: default
+  spec:
+    nodeName: node-1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-2
+    namespace: default
+  status:
+    phase: Succeeded
+---
+# comments only
+---
+apiVersion: v1
+kind: NodeList
+items:
+- metadata:
+    name: node-2
+---
+apiVersion: scheduling.sigs.k8s.io/v1alpha1
+kind: ElasticQuota
+metadata:
+  name: quota-a
+  namespace: default
+---
+apiVersion: scheduling.koordinator.sh/v1alpha1
+kind: Reservation
+metadata:
+  name: reservation-a
+---
+apiVersion: unknown.example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`
+
+func TestLoadObjects(t *testing.T) {
+	objects, err := LoadObjects(strings.NewReader(testSnapshot))
+	assert.NoError(t, err)
+	assert.Len(t, objects, 6)
+
+	snapshot := NewSnapshot(objects)
+	assert.Len(t, snapshot.Nodes, 2)
+	assert.Equal(t, "node-2", snapshot.Nodes[1].Name)
+	assert.Len(t, snapshot.Pods, 1, "terminated pods are dropped")
+	assert.Len(t, snapshot.ElasticQuotas, 1)
+	assert.Len(t, snapshot.Objects, 5)
+	_, ok := snapshot.Objects[4].(*schedulingv1alpha1.Reservation)
+	assert.True(t, ok)
+
+	_, err = LoadObjects(strings.NewReader("apiVersion: v1\nkind: Pod\nmetadata: [invalid\n"))
+	assert.Error(t, err)
+}
+
+func TestExpandWorkloads(t *testing.T) {
+	replicas := int32(2)
+	objects := []runtime.Object{
+		&corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}},
+		&appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{SchedulerName: "koord-scheduler"}},
+			},
+		},
+		&schedv1alpha1.ElasticQuota{},
+	}
+	objects[0].(*corev1.Pod).Name = "pod-a"
+	objects[1].(*appsv1.Deployment).Name = "deploy-a"
+	objects[1].(*appsv1.Deployment).Namespace = "tenant-a"
+
+	pods, others := ExpandWorkloads(objects)
+	assert.Len(t, pods, 3)
+	assert.Len(t, others, 1)
+	assert.Equal(t, "default/pod-a", pods[0].Namespace+"/"+pods[0].Name)
+	assert.Empty(t, pods[0].Spec.NodeName)
+	assert.Equal(t, corev1.PodPending, pods[0].Status.Phase)
+	assert.Equal(t, "tenant-a/deploy-a-1", pods[2].Namespace+"/"+pods[2].Name)
+	assert.Equal(t, "koord-scheduler", pods[2].Spec.SchedulerName)
+	assert.NotEqual(t, pods[1].UID, pods[2].UID)
+}
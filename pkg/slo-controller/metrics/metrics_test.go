@@ -80,3 +80,10 @@ func TestNodeResourceCollectors(t *testing.T) {
 	RecordNodeExtendedResourceAllocatableInternal(testNode, string(extension.BatchCPU), UnitInteger, 30000)
 	RecordNodeExtendedResourceAllocatableInternal(testNode, string(extension.BatchMemory), UnitInteger, 60<<30)
 }
+
+func TestNodeSLOStatusCollectors(t *testing.T) {
+	RecordNodeSLOStrategyNodes("testStrategy", "Applied", "", 10)
+	RecordNodeSLOStrategyNodes("testStrategy", "Failed", "test", 2)
+	RecordNodeSLOStaleNodes(1)
+	ResetNodeSLOStrategyNodes()
+}
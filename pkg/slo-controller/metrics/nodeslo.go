@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/koordinator-sh/koordinator/pkg/util/metrics/koordmanager"
+)
+
+func init() {
+	koordmanager.InternalMustRegister(NodeSLOStatusCollectors...)
+}
+
+const (
+	StrategyKey = "strategy"
+	StateKey    = "state"
+)
+
+var (
+	NodeSLOStrategyNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: SLOControllerSubsystem,
+		Name:      "nodeslo_strategy_nodes",
+		Help:      "the number of nodes reporting the NodeSLO strategy in each state",
+	}, []string{StrategyKey, StateKey, ReasonKey})
+
+	NodeSLOStaleNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: SLOControllerSubsystem,
+		Name:      "nodeslo_stale_nodes",
+		Help:      "the number of nodes whose observed NodeSLO generation is behind the spec",
+	})
+
+	NodeSLOStatusCollectors = []prometheus.Collector{
+		NodeSLOStrategyNodes,
+		NodeSLOStaleNodes,
+	}
+)
+
+func ResetNodeSLOStrategyNodes() {
+	NodeSLOStrategyNodes.Reset()
+}
+
+func RecordNodeSLOStrategyNodes(strategy, state, reason string, value float64) {
+	NodeSLOStrategyNodes.With(prometheus.Labels{StrategyKey: strategy, StateKey: state, ReasonKey: reason}).Set(value)
+}
+
+func RecordNodeSLOStaleNodes(value float64) {
+	NodeSLOStaleNodes.Set(value)
+}
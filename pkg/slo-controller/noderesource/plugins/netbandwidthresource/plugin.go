@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbandwidthresource
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const PluginName = "NetBandwidthResource"
+
+const (
+	ResetResourcesMsg  = "reset node network bandwidth since the total bandwidth is unknown"
+	UpdateResourcesMsg = "node network bandwidth from the total bandwidth"
+
+	NeedSyncForResourceDiffMsg = "network bandwidth diff is big than threshold"
+)
+
+// ResourceNames defines the network bandwidth resource names to update.
+var ResourceNames = []corev1.ResourceName{extension.ResourceNetworkBandwidth}
+
+var client ctrlclient.Client
+
+type Plugin struct{}
+
+func (p *Plugin) Name() string {
+	return PluginName
+}
+
+// +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodeslos,verbs=get;list;watch
+
+func (p *Plugin) Setup(opt *framework.Option) error {
+	client = opt.Client
+	return nil
+}
+
+func (p *Plugin) NeedSync(strategy *configuration.ColocationStrategy, oldNode, newNode *corev1.Node) (bool, string) {
+	for _, resourceName := range ResourceNames {
+		if util.IsResourceDiff(oldNode.Status.Allocatable, newNode.Status.Allocatable, resourceName,
+			*strategy.ResourceDiffThreshold) {
+			klog.V(4).InfoS("need sync node since resource diff bigger than threshold", "node", newNode.Name,
+				"resource", resourceName, "threshold", *strategy.ResourceDiffThreshold)
+			return true, NeedSyncForResourceDiffMsg
+		}
+	}
+
+	return false, ""
+}
+
+func (p *Plugin) Prepare(_ *configuration.ColocationStrategy, node *corev1.Node, nr *framework.NodeResource) error {
+	for _, resourceName := range ResourceNames {
+		if nr.Resets[resourceName] {
+			delete(node.Status.Allocatable, resourceName)
+			delete(node.Status.Capacity, resourceName)
+			continue
+		}
+
+		q := nr.Resources[resourceName]
+		if q == nil {
+			continue
+		}
+		node.Status.Allocatable[resourceName] = *q
+		node.Status.Capacity[resourceName] = *q
+	}
+
+	return nil
+}
+
+// Reset keeps the network bandwidth on the node since it is a physical resource rather than a reclaimed one.
+func (p *Plugin) Reset(node *corev1.Node, message string) []framework.ResourceItem {
+	return nil
+}
+
+// Calculate calculates the network bandwidth resource using the formula below:
+// Allocatable[NetBandwidth] = NodeSLO.SystemStrategy.TotalNetworkBandwidth
+// The total bandwidth in the NodeSLO has been merged from the node annotation `node.koordinator.sh/network-bandwidth`
+// and the slo-controller-config. The node annotation is used directly if the NodeSLO is not created yet.
+func (p *Plugin) Calculate(_ *configuration.ColocationStrategy, node *corev1.Node, _ *corev1.PodList, _ *framework.ResourceMetrics) ([]framework.ResourceItem, error) {
+	if node == nil || node.Status.Allocatable == nil {
+		return nil, fmt.Errorf("missing essential arguments")
+	}
+
+	total, err := p.getNodeTotalBandwidth(node)
+	if err != nil {
+		return nil, err
+	}
+	if total == nil || total.Value() <= 0 {
+		klog.V(5).InfoS("total network bandwidth not found, reset network bandwidth on node", "node", node.Name)
+		return p.resetNetBandwidthNodeResource()
+	}
+
+	return p.calculate(node, total), nil
+}
+
+func (p *Plugin) calculate(node *corev1.Node, total *resource.Quantity) []framework.ResourceItem {
+	bandwidth := resource.NewQuantity(total.Value(), resource.DecimalSI)
+	metrics.RecordNodeExtendedResourceAllocatableInternal(node, string(extension.ResourceNetworkBandwidth), metrics.UnitInteger, float64(bandwidth.Value()))
+	klog.V(6).InfoS("calculate network bandwidth for node", "node", node.Name, "bandwidth", bandwidth.String())
+
+	return []framework.ResourceItem{
+		{
+			Name:     extension.ResourceNetworkBandwidth,
+			Quantity: bandwidth,
+			Message:  UpdateResourcesMsg,
+		},
+	}
+}
+
+func (p *Plugin) getNodeTotalBandwidth(node *corev1.Node) (*resource.Quantity, error) {
+	nodeSLO := &slov1alpha1.NodeSLO{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: node.Name}, nodeSLO)
+	if err == nil {
+		if nodeSLO.Spec.SystemStrategy != nil {
+			return &nodeSLO.Spec.SystemStrategy.TotalNetworkBandwidth, nil
+		}
+		return nil, nil
+	}
+	if !errors.IsNotFound(err) {
+		klog.V(4).InfoS("failed to get nodeSLO for node", "node", node.Name, "err", err)
+		return nil, fmt.Errorf("failed to get nodeSLO: %w", err)
+	}
+
+	// nodeSLO not found, fallback to the node annotation
+	total, err := extension.GetNodeTotalBandwidth(node.Annotations)
+	if err != nil {
+		klog.V(4).InfoS("failed to get total network bandwidth from node annotation", "node", node.Name, "err", err)
+		return nil, err
+	}
+	return total, nil
+}
+
+func (p *Plugin) resetNetBandwidthNodeResource() ([]framework.ResourceItem, error) {
+	items := make([]framework.ResourceItem, len(ResourceNames))
+	for i := range ResourceNames {
+		items[i] = framework.ResourceItem{
+			Name:    ResourceNames[i],
+			Reset:   true,
+			Message: ResetResourcesMsg,
+		}
+	}
+	return items, nil
+}
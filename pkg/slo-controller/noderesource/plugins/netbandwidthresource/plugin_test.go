@@ -0,0 +1,272 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbandwidthresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util/testutil"
+)
+
+func getTestNode(annotations map[string]string, extraResources corev1.ResourceList) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-node",
+			Annotations: annotations,
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("400Gi"),
+			},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("400Gi"),
+			},
+		},
+	}
+	for name, q := range extraResources {
+		node.Status.Allocatable[name] = q
+		node.Status.Capacity[name] = q
+	}
+	return node
+}
+
+func TestPlugin(t *testing.T) {
+	t.Run("test", func(t *testing.T) {
+		p := &Plugin{}
+		assert.Equal(t, PluginName, p.Name())
+
+		testScheme := runtime.NewScheme()
+		testOpt := &framework.Option{
+			Scheme:  testScheme,
+			Client:  fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			Builder: builder.ControllerManagedBy(&testutil.FakeManager{}),
+		}
+		err := p.Setup(testOpt)
+		assert.NoError(t, err)
+
+		got := p.Reset(nil, "")
+		assert.Nil(t, got)
+	})
+}
+
+func TestPluginNeedSync(t *testing.T) {
+	testStrategy := &configuration.ColocationStrategy{
+		Enable:                ptr.To[bool](true),
+		ResourceDiffThreshold: ptr.To[float64](0.1),
+	}
+	testNode := getTestNode(nil, corev1.ResourceList{
+		extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+	})
+	testNodeNotChanged := getTestNode(nil, corev1.ResourceList{
+		extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+	})
+	testNodeChanged := getTestNode(nil, corev1.ResourceList{
+		extension.ResourceNetworkBandwidth: resource.MustParse("25G"),
+	})
+	p := &Plugin{}
+	got, msg := p.NeedSync(testStrategy, testNode, testNodeNotChanged)
+	assert.False(t, got)
+	assert.Equal(t, "", msg)
+	got, msg = p.NeedSync(testStrategy, testNode, testNodeChanged)
+	assert.True(t, got)
+	assert.Equal(t, NeedSyncForResourceDiffMsg, msg)
+}
+
+func TestPluginPrepare(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     *corev1.Node
+		nr       *framework.NodeResource
+		wantNode *corev1.Node
+	}{
+		{
+			name:     "nothing to prepare",
+			node:     getTestNode(nil, nil),
+			nr:       framework.NewNodeResource(),
+			wantNode: getTestNode(nil, nil),
+		},
+		{
+			name: "prepare network bandwidth",
+			node: getTestNode(nil, nil),
+			nr: framework.NewNodeResource(framework.ResourceItem{
+				Name:     extension.ResourceNetworkBandwidth,
+				Quantity: resource.NewQuantity(10000000000, resource.DecimalSI),
+			}),
+			wantNode: getTestNode(nil, corev1.ResourceList{
+				extension.ResourceNetworkBandwidth: *resource.NewQuantity(10000000000, resource.DecimalSI),
+			}),
+		},
+		{
+			name: "reset network bandwidth",
+			node: getTestNode(nil, corev1.ResourceList{
+				extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+			}),
+			nr: framework.NewNodeResource(framework.ResourceItem{
+				Name:  extension.ResourceNetworkBandwidth,
+				Reset: true,
+			}),
+			wantNode: getTestNode(nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plugin{}
+			err := p.Prepare(nil, tt.node, tt.nr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNode, tt.node)
+		})
+	}
+}
+
+func TestPluginCalculate(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	err := clientgoscheme.AddToScheme(testScheme)
+	assert.NoError(t, err)
+	err = slov1alpha1.AddToScheme(testScheme)
+	assert.NoError(t, err)
+	testNodeSLO := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Spec: slov1alpha1.NodeSLOSpec{
+			SystemStrategy: &slov1alpha1.SystemStrategy{
+				TotalNetworkBandwidth: resource.MustParse("25G"),
+			},
+		},
+	}
+	testNodeSLOWithoutBandwidth := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Spec: slov1alpha1.NodeSLOSpec{
+			SystemStrategy: &slov1alpha1.SystemStrategy{
+				TotalNetworkBandwidth: resource.MustParse("0"),
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		node    *corev1.Node
+		nodeSLO *slov1alpha1.NodeSLO
+		want    []framework.ResourceItem
+		wantErr bool
+	}{
+		{
+			name:    "missing essential arguments",
+			node:    &corev1.Node{},
+			wantErr: true,
+		},
+		{
+			name: "reset when nodeSLO and node annotation are both missing",
+			node: getTestNode(nil, nil),
+			want: []framework.ResourceItem{
+				{
+					Name:    extension.ResourceNetworkBandwidth,
+					Reset:   true,
+					Message: ResetResourcesMsg,
+				},
+			},
+		},
+		{
+			name: "calculate from node annotation when nodeSLO is missing",
+			node: getTestNode(map[string]string{
+				extension.AnnotationNodeBandwidth: "10G",
+			}, nil),
+			want: []framework.ResourceItem{
+				{
+					Name:     extension.ResourceNetworkBandwidth,
+					Quantity: resource.NewQuantity(10000000000, resource.DecimalSI),
+					Message:  UpdateResourcesMsg,
+				},
+			},
+		},
+		{
+			name: "failed to parse node annotation",
+			node: getTestNode(map[string]string{
+				extension.AnnotationNodeBandwidth: "invalid",
+			}, nil),
+			wantErr: true,
+		},
+		{
+			name: "calculate from nodeSLO",
+			node: getTestNode(map[string]string{
+				extension.AnnotationNodeBandwidth: "10G",
+			}, nil),
+			nodeSLO: testNodeSLO,
+			want: []framework.ResourceItem{
+				{
+					Name:     extension.ResourceNetworkBandwidth,
+					Quantity: resource.NewQuantity(25000000000, resource.DecimalSI),
+					Message:  UpdateResourcesMsg,
+				},
+			},
+		},
+		{
+			name:    "reset when total bandwidth in nodeSLO is zero",
+			node:    getTestNode(nil, nil),
+			nodeSLO: testNodeSLOWithoutBandwidth,
+			want: []framework.ResourceItem{
+				{
+					Name:    extension.ResourceNetworkBandwidth,
+					Reset:   true,
+					Message: ResetResourcesMsg,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientBuilder := fake.NewClientBuilder().WithScheme(testScheme)
+			if tt.nodeSLO != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.nodeSLO)
+			}
+			client = clientBuilder.Build()
+			defer func() {
+				client = nil
+			}()
+
+			p := &Plugin{}
+			got, gotErr := p.Calculate(nil, tt.node, nil, nil)
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].Name, got[i].Name)
+				assert.Equal(t, tt.want[i].Reset, got[i].Reset)
+				assert.Equal(t, tt.want[i].Message, got[i].Message)
+				if tt.want[i].Quantity != nil {
+					assert.Equal(t, tt.want[i].Quantity.Value(), got[i].Quantity.Value())
+				}
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/cpunormalization"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/gpudeviceresource"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/midresource"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/netbandwidthresource"
 	rdmadeviceresource "github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/rdmadevicereource"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/resourceamplification"
 )
@@ -36,6 +37,7 @@ func init() {
 	addPluginOption(&resourceamplification.Plugin{}, true)
 	addPluginOption(&gpudeviceresource.Plugin{}, true)
 	addPluginOption(&rdmadeviceresource.Plugin{}, true)
+	addPluginOption(&netbandwidthresource.Plugin{}, true)
 }
 
 func addPlugins(filter framework.FilterFn) {
@@ -56,6 +58,7 @@ var (
 		&batchresource.Plugin{},
 		&gpudeviceresource.Plugin{},
 		&rdmadeviceresource.Plugin{},
+		&netbandwidthresource.Plugin{},
 	}
 	// NodePreUpdatePlugin implements node resource pre-updating.
 	nodePreUpdatePlugins = []framework.NodePreUpdatePlugin{
@@ -69,6 +72,7 @@ var (
 		&batchresource.Plugin{},
 		&gpudeviceresource.Plugin{},
 		&rdmadeviceresource.Plugin{},
+		&netbandwidthresource.Plugin{},
 	}
 	// NodeSyncPlugin implements the check of resource updating.
 	nodeStatusCheckPlugins = []framework.NodeStatusCheckPlugin{
@@ -76,6 +80,7 @@ var (
 		&batchresource.Plugin{},
 		&gpudeviceresource.Plugin{},
 		&rdmadeviceresource.Plugin{},
+		&netbandwidthresource.Plugin{},
 	}
 	// nodeMetaCheckPlugins implements the check of node meta updating.
 	nodeMetaCheckPlugins = []framework.NodeMetaCheckPlugin{
@@ -91,5 +96,6 @@ var (
 		&batchresource.Plugin{},
 		&gpudeviceresource.Plugin{},
 		&rdmadeviceresource.Plugin{},
+		&netbandwidthresource.Plugin{},
 	}
 )
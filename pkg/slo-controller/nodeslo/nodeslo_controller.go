@@ -232,6 +232,9 @@ func Add(mgr ctrl.Manager) error {
 func (r *NodeSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	configMapCacheHandler := NewSLOCfgHandlerForConfigMapEvent(r.Client, DefaultSLOCfg(), r.Recorder)
 	r.sloCfgCache = configMapCacheHandler
+	if err := mgr.Add(newStatusCollector(r.Client)); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&slov1alpha1.NodeSLO{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&corev1.Node{}, &nodemetric.EnqueueRequestForNode{
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+)
+
+const defaultStatusCollectInterval = time.Minute
+
+var _ manager.Runnable = &statusCollector{}
+var _ manager.LeaderElectionRunnable = &statusCollector{}
+
+// statusCollector aggregates the strategy states reported by koordlet in the NodeSLO status into the metrics,
+// so that the rollout of the slo config can be observed in the cluster level.
+type statusCollector struct {
+	client   client.Client
+	interval time.Duration
+}
+
+func newStatusCollector(c client.Client) *statusCollector {
+	return &statusCollector{
+		client:   c,
+		interval: defaultStatusCollectInterval,
+	}
+}
+
+func (c *statusCollector) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, c.collect, c.interval)
+	return nil
+}
+
+func (c *statusCollector) NeedLeaderElection() bool {
+	return true
+}
+
+type strategyStateKey struct {
+	strategy string
+	state    slov1alpha1.NodeSLOStrategyState
+	reason   string
+}
+
+func (c *statusCollector) collect(ctx context.Context) {
+	nodeSLOList := &slov1alpha1.NodeSLOList{}
+	if err := c.client.List(ctx, nodeSLOList); err != nil {
+		klog.Warningf("failed to list NodeSLOs for collecting status, err: %v", err)
+		return
+	}
+
+	strategyNodes := map[strategyStateKey]int{}
+	staleNodes := 0
+	for i := range nodeSLOList.Items {
+		nodeSLO := &nodeSLOList.Items[i]
+		if nodeSLO.Status.ObservedGeneration < nodeSLO.Generation {
+			staleNodes++
+		}
+		for _, condition := range nodeSLO.Status.Conditions {
+			key := strategyStateKey{strategy: condition.Name, state: condition.State, reason: condition.Reason}
+			strategyNodes[key]++
+		}
+	}
+
+	metrics.ResetNodeSLOStrategyNodes()
+	for key, count := range strategyNodes {
+		metrics.RecordNodeSLOStrategyNodes(key.strategy, string(key.state), key.reason, float64(count))
+	}
+	metrics.RecordNodeSLOStaleNodes(float64(staleNodes))
+	klog.V(5).Infof("collect NodeSLO status finished, nodes %d, stale nodes %d", len(nodeSLOList.Items), staleNodes)
+}
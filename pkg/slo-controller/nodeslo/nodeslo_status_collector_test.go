@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+)
+
+func Test_statusCollector_collect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	slov1alpha1.AddToScheme(scheme)
+	nodeSLOs := []*slov1alpha1.NodeSLO{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node-0", Generation: 2},
+			Status: slov1alpha1.NodeSLOStatus{
+				ObservedGeneration: 2,
+				Conditions: []slov1alpha1.NodeSLOStrategyCondition{
+					{Name: "GroupIdentity", State: slov1alpha1.NodeSLOStrategyApplied, ObservedGeneration: 2},
+					{Name: "ResctrlReconcile", State: slov1alpha1.NodeSLOStrategyApplied, ObservedGeneration: 2},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node-1", Generation: 3},
+			Status: slov1alpha1.NodeSLOStatus{
+				ObservedGeneration: 2,
+				Conditions: []slov1alpha1.NodeSLOStrategyCondition{
+					{Name: "GroupIdentity", State: slov1alpha1.NodeSLOStrategyFailed, Reason: "UpdateCallbackFailed", ObservedGeneration: 2},
+					{Name: "ResctrlReconcile", State: slov1alpha1.NodeSLOStrategyUnsupported, Reason: "ResctrlUnsupported", ObservedGeneration: 2},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node-2", Generation: 1},
+		},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, nodeSLO := range nodeSLOs {
+		builder.WithObjects(nodeSLO)
+	}
+	c := newStatusCollector(builder.Build())
+
+	// the stale series are removed
+	metrics.RecordNodeSLOStrategyNodes("GroupIdentity", string(slov1alpha1.NodeSLOStrategyFailed), "ParseRuleFailed", 1)
+
+	c.collect(context.TODO())
+	assert.Equal(t, 4, testutil.CollectAndCount(metrics.NodeSLOStrategyNodes))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NodeSLOStrategyNodes.WithLabelValues(
+		"GroupIdentity", string(slov1alpha1.NodeSLOStrategyApplied), "")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NodeSLOStrategyNodes.WithLabelValues(
+		"GroupIdentity", string(slov1alpha1.NodeSLOStrategyFailed), "UpdateCallbackFailed")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NodeSLOStrategyNodes.WithLabelValues(
+		"ResctrlReconcile", string(slov1alpha1.NodeSLOStrategyUnsupported), "ResctrlUnsupported")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.NodeSLOStaleNodes))
+}
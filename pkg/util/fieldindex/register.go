@@ -68,6 +68,18 @@ var indexDescriptors = []fieldIndexDescriptor{
 			return []string{pod.Labels[extension.LabelQuotaName]}
 		},
 	},
+	{
+		description: "index pod by annotation.MutatedByColocationProfiles",
+		obj:         &corev1.Pod{},
+		field:       "annotation.mutatedByColocationProfiles",
+		indexerFunc: func(obj client.Object) []string {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return []string{}
+			}
+			return extension.GetColocationProfilesMutatingPod(pod)
+		},
+	},
 	{
 		description: "index elastic quota by annotation.namespaces",
 		obj:         &apiv1alpha1.ElasticQuota{},
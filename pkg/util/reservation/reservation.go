@@ -260,6 +260,25 @@ func IsReservationExpired(r *schedulingv1alpha1.Reservation) bool {
 	return false
 }
 
+func IsReservationPreempted(r *schedulingv1alpha1.Reservation) bool {
+	if r == nil || r.Status.Phase != schedulingv1alpha1.ReservationFailed {
+		return false
+	}
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == schedulingv1alpha1.ReservationConditionReady {
+			return condition.Status == schedulingv1alpha1.ConditionStatusFalse &&
+				condition.Reason == schedulingv1alpha1.ReasonReservationPreempted
+		}
+	}
+	return false
+}
+
+// IsReservationPreemptible checks if the Reservation can be preempted by a higher priority pod without disrupting
+// any running pods, i.e. it is available and not allocated by any owner yet.
+func IsReservationPreemptible(r *schedulingv1alpha1.Reservation) bool {
+	return IsReservationAvailable(r) && len(r.Status.CurrentOwners) == 0 && !r.Spec.PreAllocation
+}
+
 func GetReservationNodeName(r *schedulingv1alpha1.Reservation) string {
 	return r.Status.NodeName
 }
@@ -329,6 +348,46 @@ func SetReservationExpired(r *schedulingv1alpha1.Reservation) {
 	}
 }
 
+// SetReservationPreempted marks the Reservation as failed since it is preempted by a higher priority pod.
+func SetReservationPreempted(r *schedulingv1alpha1.Reservation, message string) {
+	r.Status.Phase = schedulingv1alpha1.ReservationFailed
+	condition := schedulingv1alpha1.ReservationCondition{
+		Type:               schedulingv1alpha1.ReservationConditionReady,
+		Status:             schedulingv1alpha1.ConditionStatusFalse,
+		Reason:             schedulingv1alpha1.ReasonReservationPreempted,
+		Message:            message,
+		LastProbeTime:      metav1.Now(),
+		LastTransitionTime: metav1.Now(),
+	}
+	for i := range r.Status.Conditions {
+		if r.Status.Conditions[i].Type == schedulingv1alpha1.ReservationConditionReady {
+			r.Status.Conditions[i] = condition
+			return
+		}
+	}
+	r.Status.Conditions = append(r.Status.Conditions, condition)
+}
+
+// GetReservationOwnerReferences returns the object references of the Reservation owners which specify an object
+// or a controller, so that the owners can be notified about the changes of the Reservation.
+func GetReservationOwnerReferences(r *schedulingv1alpha1.Reservation) []*corev1.ObjectReference {
+	var refs []*corev1.ObjectReference
+	for _, owner := range r.Spec.Owners {
+		if owner.Object != nil && owner.Object.Name != "" {
+			refs = append(refs, owner.Object.DeepCopy())
+		} else if owner.Controller != nil {
+			refs = append(refs, &corev1.ObjectReference{
+				APIVersion: owner.Controller.APIVersion,
+				Kind:       owner.Controller.Kind,
+				Namespace:  owner.Controller.Namespace,
+				Name:       owner.Controller.Name,
+				UID:        owner.Controller.UID,
+			})
+		}
+	}
+	return refs
+}
+
 func SetReservationSucceeded(r *schedulingv1alpha1.Reservation) {
 	r.Status.Phase = schedulingv1alpha1.ReservationSucceeded
 	idx := -1
@@ -445,10 +504,26 @@ func ParseReservationOwnerMatchers(owners []schedulingv1alpha1.ReservationOwner)
 	return ownerMatchers, nil
 }
 
+// DefaultReservationOwnerGangNamespace defaults the namespace of the gang references without a namespace to the
+// namespace of the reserve pod, so that a gang reservation never matches the same-named gangs in other namespaces.
+// The gang references are copied since the owners are shared with the reservation object.
+func DefaultReservationOwnerGangNamespace(matchers []ReservationOwnerMatcher, namespace string) {
+	for i := range matchers {
+		gangRef := matchers[i].Gang
+		if gangRef == nil || len(gangRef.Namespace) > 0 {
+			continue
+		}
+		gangRef = gangRef.DeepCopy()
+		gangRef.Namespace = namespace
+		matchers[i].Gang = gangRef
+	}
+}
+
 func (m *ReservationOwnerMatcher) Match(pod *corev1.Pod) bool {
 	if MatchObjectRef(pod, m.Object) &&
 		MatchReservationControllerReference(pod, m.Controller) &&
-		MatchLabels(pod.Labels, m.Selector) {
+		MatchLabels(pod.Labels, m.Selector) &&
+		MatchReservationGang(pod, m.Gang) {
 		return true
 	}
 	return false
@@ -500,6 +575,18 @@ func MatchReservationControllerReference(pod *corev1.Pod, controllerRef *schedul
 	return false
 }
 
+// MatchReservationGang checks if the pod is a member of the gang referenced by the reservation owner.
+func MatchReservationGang(pod *corev1.Pod, gangRef *schedulingv1alpha1.ReservationGangReference) bool {
+	if gangRef == nil {
+		return true
+	}
+	if len(gangRef.Namespace) > 0 && gangRef.Namespace != pod.Namespace {
+		return false
+	}
+	gangName := extension.GetGangName(pod)
+	return len(gangName) > 0 && gangName == gangRef.Name
+}
+
 func MatchLabels(podLabels map[string]string, selector labels.Selector) bool {
 	if selector == nil {
 		return true
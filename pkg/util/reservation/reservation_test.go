@@ -31,6 +31,7 @@ import (
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 )
 
 func TestIsReservationActive(t *testing.T) {
@@ -208,6 +209,149 @@ func TestIsReservationExpired(t *testing.T) {
 	}
 }
 
+func TestIsReservationPreemptible(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  *schedulingv1alpha1.Reservation
+		want bool
+	}{
+		{
+			name: "not panic for nil",
+			arg:  nil,
+			want: false,
+		},
+		{
+			name: "pending reservation is not preemptible",
+			arg: &schedulingv1alpha1.Reservation{
+				Status: schedulingv1alpha1.ReservationStatus{
+					Phase: schedulingv1alpha1.ReservationPending,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "unallocated available reservation is preemptible",
+			arg: &schedulingv1alpha1.Reservation{
+				Status: schedulingv1alpha1.ReservationStatus{
+					Phase:    schedulingv1alpha1.ReservationAvailable,
+					NodeName: "test-node-0",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "allocated reservation is not preemptible",
+			arg: &schedulingv1alpha1.Reservation{
+				Status: schedulingv1alpha1.ReservationStatus{
+					Phase:    schedulingv1alpha1.ReservationAvailable,
+					NodeName: "test-node-0",
+					CurrentOwners: []corev1.ObjectReference{
+						{
+							Namespace: "default",
+							Name:      "test-pod",
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "pre-allocation reservation is not preemptible",
+			arg: &schedulingv1alpha1.Reservation{
+				Spec: schedulingv1alpha1.ReservationSpec{
+					PreAllocation: true,
+				},
+				Status: schedulingv1alpha1.ReservationStatus{
+					Phase:    schedulingv1alpha1.ReservationAvailable,
+					NodeName: "test-node-0",
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsReservationPreemptible(tt.arg))
+		})
+	}
+}
+
+func TestSetReservationPreempted(t *testing.T) {
+	r := &schedulingv1alpha1.Reservation{
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: "test-node-0",
+			Conditions: []schedulingv1alpha1.ReservationCondition{
+				{
+					Type:   schedulingv1alpha1.ReservationConditionScheduled,
+					Status: schedulingv1alpha1.ConditionStatusTrue,
+					Reason: schedulingv1alpha1.ReasonReservationScheduled,
+				},
+				{
+					Type:   schedulingv1alpha1.ReservationConditionReady,
+					Status: schedulingv1alpha1.ConditionStatusTrue,
+					Reason: schedulingv1alpha1.ReasonReservationAvailable,
+				},
+			},
+		},
+	}
+	assert.False(t, IsReservationPreempted(r))
+	SetReservationPreempted(r, "preempted by pod default/test-pod")
+	assert.True(t, IsReservationPreempted(r))
+	assert.True(t, IsReservationFailed(r))
+	assert.False(t, IsReservationExpired(r))
+	assert.Len(t, r.Status.Conditions, 2)
+	assert.Equal(t, "preempted by pod default/test-pod", r.Status.Conditions[1].Message)
+
+	r = &schedulingv1alpha1.Reservation{}
+	SetReservationPreempted(r, "")
+	assert.True(t, IsReservationPreempted(r))
+	assert.Len(t, r.Status.Conditions, 1)
+}
+
+func TestGetReservationOwnerReferences(t *testing.T) {
+	r := &schedulingv1alpha1.Reservation{
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{
+						Namespace: "default",
+						Name:      "test-pod",
+					},
+				},
+				{
+					Controller: &schedulingv1alpha1.ReservationControllerReference{
+						OwnerReference: metav1.OwnerReference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "test-deployment",
+						},
+						Namespace: "default",
+					},
+				},
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test"},
+					},
+				},
+			},
+		},
+	}
+	want := []*corev1.ObjectReference{
+		{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "default",
+			Name:       "test-deployment",
+		},
+	}
+	assert.Equal(t, want, GetReservationOwnerReferences(r))
+}
+
 func TestGetReservationSchedulerName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -433,11 +577,185 @@ func TestMatchReservationOwners(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "match gang",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "test",
+						Annotations: map[string]string{
+							apiext.AnnotationGangName: "job-a",
+						},
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name:      "job-a",
+									Namespace: "test",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "match gang declared by the PodGroup label in the reservation namespace",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "test",
+						Labels: map[string]string{
+							v1alpha1.PodGroupLabel: "job-a",
+						},
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Template: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "test",
+							},
+						},
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name: "job-a",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "failed to match gang outside the reservation namespace",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "other",
+						Annotations: map[string]string{
+							apiext.AnnotationGangName: "job-a",
+						},
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Template: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "test",
+							},
+						},
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name: "job-a",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "failed to match gang in another namespace",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "other",
+						Annotations: map[string]string{
+							apiext.AnnotationGangName: "job-a",
+						},
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name:      "job-a",
+									Namespace: "test",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "failed to match non-gang pod",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "test",
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name: "job-a",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "failed to match gang and labels ANDed",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod-0",
+						Namespace: "test",
+						Labels: map[string]string{
+							"aaa": "ccc",
+						},
+						Annotations: map[string]string{
+							apiext.AnnotationGangName: "job-a",
+						},
+					},
+				},
+				r: &schedulingv1alpha1.Reservation{
+					Spec: schedulingv1alpha1.ReservationSpec{
+						Owners: []schedulingv1alpha1.ReservationOwner{
+							{
+								Gang: &schedulingv1alpha1.ReservationGangReference{
+									Name: "job-a",
+								},
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										"aaa": "bbb",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			matchers, err := ParseReservationOwnerMatchers(tt.args.r.Spec.Owners)
 			assert.NoError(t, err)
+			DefaultReservationOwnerGangNamespace(matchers, NewReservePod(tt.args.r).Namespace)
 			got := MatchReservationOwners(tt.args.pod, matchers)
 			assert.Equal(t, tt.want, got)
 		})
@@ -1171,3 +1489,34 @@ func TestTolerateUnschedulable(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultReservationOwnerGangNamespace(t *testing.T) {
+	owners := []schedulingv1alpha1.ReservationOwner{
+		{
+			Gang: &schedulingv1alpha1.ReservationGangReference{
+				Name: "job-a",
+			},
+		},
+		{
+			Gang: &schedulingv1alpha1.ReservationGangReference{
+				Name:      "job-b",
+				Namespace: "other",
+			},
+		},
+		{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"aaa": "bbb",
+				},
+			},
+		},
+	}
+	matchers, err := ParseReservationOwnerMatchers(owners)
+	assert.NoError(t, err)
+	DefaultReservationOwnerGangNamespace(matchers, "test")
+	assert.Equal(t, "test", matchers[0].Gang.Namespace)
+	assert.Equal(t, "other", matchers[1].Gang.Namespace)
+	assert.Nil(t, matchers[2].Gang)
+	// the owners of the reservation are not modified
+	assert.Equal(t, "", owners[0].Gang.Namespace)
+}
@@ -25,6 +25,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
 )
 
 const (
@@ -337,3 +339,79 @@ func LessThanOrEqualCompletely(a corev1.ResourceList, b corev1.ResourceList) boo
 
 	return result
 }
+
+// ScaleNUMANodeResourcesToRequests scales the NUMA resources allocated to a pod to its current requests, which may
+// be resized in place. The memory, and the cpu if scaleCPU, are spread over the same NUMA nodes in proportion to
+// the original allocation, where the last NUMA node takes the remainder to keep the total equal to the request.
+// The original slice is returned if nothing is scaled.
+func ScaleNUMANodeResourcesToRequests(numaNodeResources []extension.NUMANodeResource, requests corev1.ResourceList, scaleCPU bool) []extension.NUMANodeResource {
+	if len(numaNodeResources) == 0 {
+		return numaNodeResources
+	}
+	resourceNames := []corev1.ResourceName{corev1.ResourceMemory}
+	if scaleCPU {
+		resourceNames = append(resourceNames, corev1.ResourceCPU)
+	}
+
+	var scaled []extension.NUMANodeResource
+	for _, resourceName := range resourceNames {
+		var allocated int64
+		lastNode := -1
+		for i, numaNodeRes := range numaNodeResources {
+			if value := getNUMAResourceValue(numaNodeRes.Resources, resourceName); value > 0 {
+				allocated += value
+				lastNode = i
+			}
+		}
+		request := getNUMAResourceValue(requests, resourceName)
+		if allocated <= 0 || request == allocated {
+			continue
+		}
+		if scaled == nil {
+			scaled = make([]extension.NUMANodeResource, 0, len(numaNodeResources))
+			for _, numaNodeRes := range numaNodeResources {
+				scaled = append(scaled, extension.NUMANodeResource{
+					Node:      numaNodeRes.Node,
+					Resources: numaNodeRes.Resources.DeepCopy(),
+				})
+			}
+		}
+		remaining := request
+		for i := range scaled {
+			value := getNUMAResourceValue(scaled[i].Resources, resourceName)
+			if value == 0 {
+				continue
+			}
+			if i < lastNode {
+				value = int64(float64(value) * float64(request) / float64(allocated))
+			} else {
+				value = remaining
+			}
+			remaining -= value
+			setNUMAResourceValue(scaled[i].Resources, resourceName, value)
+		}
+	}
+	if scaled == nil {
+		return numaNodeResources
+	}
+	return scaled
+}
+
+func getNUMAResourceValue(resources corev1.ResourceList, resourceName corev1.ResourceName) int64 {
+	quantity, ok := resources[resourceName]
+	if !ok {
+		return 0
+	}
+	if resourceName == corev1.ResourceCPU {
+		return quantity.MilliValue()
+	}
+	return quantity.Value()
+}
+
+func setNUMAResourceValue(resources corev1.ResourceList, resourceName corev1.ResourceName, value int64) {
+	if resourceName == corev1.ResourceCPU {
+		resources[resourceName] = *resource.NewMilliQuantity(value, resource.DecimalSI)
+		return
+	}
+	resources[resourceName] = *resource.NewQuantity(value, resource.BinarySI)
+}
@@ -1188,3 +1188,55 @@ func TestMinQuant(t *testing.T) {
 		})
 	}
 }
+
+func TestScaleNUMANodeResourcesToRequests(t *testing.T) {
+	numaNodeResources := []extension.NUMANodeResource{
+		{Node: 0, Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")}},
+		{Node: 1, Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")}},
+	}
+	tests := []struct {
+		name     string
+		requests corev1.ResourceList
+		scaleCPU bool
+		want     []corev1.ResourceList
+	}{
+		{
+			name:     "nothing is scaled",
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+			scaleCPU: true,
+			want: []corev1.ResourceList{
+				{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name:     "scale up in proportion and the last NUMA node takes the remainder",
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5"), corev1.ResourceMemory: resource.MustParse("12Gi")},
+			scaleCPU: true,
+			want: []corev1.ResourceList{
+				{corev1.ResourceCPU: resource.MustParse("2500m"), corev1.ResourceMemory: resource.MustParse("6Gi")},
+				{corev1.ResourceCPU: resource.MustParse("2500m"), corev1.ResourceMemory: resource.MustParse("6Gi")},
+			},
+		},
+		{
+			name:     "keep the cpu if not scaled",
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			scaleCPU: false,
+			want: []corev1.ResourceList{
+				{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScaleNUMANodeResourcesToRequests(numaNodeResources, tt.requests, tt.scaleCPU)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.True(t, quotav1.Equals(tt.want[i], got[i].Resources), "NUMA node %d, want %v, got %v", i, tt.want[i], got[i].Resources)
+			}
+		})
+	}
+	// the original allocation is never modified
+	assert.True(t, numaNodeResources[0].Resources.Memory().Equal(resource.MustParse("4Gi")))
+}
@@ -105,9 +105,19 @@ func (q *QuotaWrapper) ChildRequest(request v1.ResourceList) *QuotaWrapper {
 }
 
 func (q *QuotaWrapper) Admission(request v1.ResourceList) *QuotaWrapper {
-	raw, err := json.Marshal(request)
+	admission, err := extension.GetElasticQuotaAdmission(q.ElasticQuota)
+	if err == nil {
+		admission.Limit = request
+		_ = extension.SetElasticQuotaAdmission(q.ElasticQuota, admission)
+	}
+	return q
+}
+
+func (q *QuotaWrapper) AdmittedWorkloads(workloads map[string]extension.AdmittedWorkload) *QuotaWrapper {
+	admission, err := extension.GetElasticQuotaAdmission(q.ElasticQuota)
 	if err == nil {
-		q.ElasticQuota.Annotations[extension.AnnotationAdmission] = string(raw)
+		admission.Workloads = workloads
+		_ = extension.SetElasticQuotaAdmission(q.ElasticQuota, admission)
 	}
 	return q
 }
@@ -34,4 +34,6 @@ const (
 	Node                         = "node"
 	Pod                          = "pod"
 	Reservation                  = "reservation"
+	Job                          = "job"
+	PodGroup                     = "podgroup"
 )
@@ -97,6 +97,13 @@ func (h *PodMutatingHandler) clusterColocationProfileMutatingPod(ctx context.Con
 		if extension.ShouldSkipUpdateResource(profile) {
 			skipUpdateResourceFromProfile = true
 		}
+		if rollout := getRolloutStatus(profile); rollout != nil {
+			assignRolloutBucket(pod)
+			if !extension.IsPodInColocationProfileRollout(rollout, pod) {
+				klog.V(4).Infof("skip mutate Pod %s/%s by clusterColocationProfile %s, held by rollout", pod.Namespace, pod.Name, profile.Name)
+				continue
+			}
+		}
 		skip, err := shouldSkipProfile(profile)
 		if err != nil {
 			return mutated, err
@@ -109,6 +116,7 @@ func (h *PodMutatingHandler) clusterColocationProfileMutatingPod(ctx context.Con
 		if err != nil {
 			return mutated, err
 		}
+		extension.SetPodMutatedByColocationProfile(pod, profile.Name)
 		mutated = true
 		klog.V(4).Infof("mutate Pod %s/%s by clusterColocationProfile %s", pod.Namespace, pod.Name, profile.Name)
 	}
@@ -163,6 +171,38 @@ func shouldSkipProfile(profile *configv1alpha1.ClusterColocationProfile) (bool,
 	return percent == 0 || (percent != 100 && randIntnFn(100) > percent), nil
 }
 
+// getRolloutStatus returns the rollout progress of the profile which the webhook follows.
+// It takes the progress recorded by the colocation-profile controller, and starts from the first step if the
+// controller has not observed the current mutation. It returns nil if the profile does not stage the rollout.
+func getRolloutStatus(profile *configv1alpha1.ClusterColocationProfile) *configv1alpha1.ColocationProfileRolloutStatus {
+	rollout := profile.Spec.Rollout
+	if rollout == nil || len(rollout.Steps) <= 0 {
+		return nil
+	}
+	status := &configv1alpha1.ColocationProfileRolloutStatus{
+		Percent: rollout.Steps[0].Percent,
+		Paused:  rollout.Paused,
+	}
+	if observed := profile.Status.Rollout; observed != nil &&
+		observed.ProfileHash == extension.GetColocationProfileMutationHash(profile) {
+		status.CurrentStep = observed.CurrentStep
+		status.Percent = observed.Percent
+	}
+	return status
+}
+
+// assignRolloutBucket assigns the rollout bucket to the pod since the pod has no UID on creation.
+// The controller buckets the pod the same way, so the pod held here is mutated when the rollout proceeds.
+func assignRolloutBucket(pod *corev1.Pod) {
+	if _, ok := pod.Annotations[extension.AnnotationColocationProfileRolloutBucket]; ok {
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[extension.AnnotationColocationProfileRolloutBucket] = strconv.Itoa(randIntnFn(100))
+}
+
 func (h *PodMutatingHandler) doMutateByColocationProfile(ctx context.Context, pod *corev1.Pod, profile *configv1alpha1.ClusterColocationProfile) error {
 	if len(profile.Spec.Labels) > 0 {
 		if pod.Labels == nil {
@@ -170,6 +170,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -284,6 +285,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -379,6 +381,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -494,6 +497,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -894,6 +898,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -1034,6 +1039,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -1173,6 +1179,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":                      "valueA",
 						"test-patch-annotation":                "patch-b",
 						extension.AnnotationSkipUpdateResource: "true",
@@ -1317,6 +1324,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -1463,6 +1471,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelSchedulerName:   "koordinator-scheduler",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -1606,6 +1615,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelPodPriority:   "1111",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":         "valueA",
 						"test-patch-annotation":   "patch-b",
 						"annotation-key-to-load":  "test-annotation-value",
@@ -1761,6 +1771,7 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 						extension.LabelSchedulerName:   "koordinator-scheduler",
 					},
 					Annotations: map[string]string{
+						extension.AnnotationMutatedByColocationProfiles: "test-profile",
 						"testAnnotationA":       "valueA",
 						"test-patch-annotation": "patch-b",
 					},
@@ -1862,6 +1873,134 @@ func TestClusterColocationProfileMutatingPod(t *testing.T) {
 	}
 }
 
+func TestClusterColocationProfileMutatingPodWithRollout(t *testing.T) {
+	testProfile := &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-profile",
+			Annotations: map[string]string{
+				extension.AnnotationSkipUpdateResource: "true",
+			},
+		},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"koordinator-colocation-pod": "true",
+				},
+			},
+			Labels: map[string]string{
+				"testLabelA": "valueA",
+			},
+			Rollout: &configv1alpha1.ClusterColocationProfileRollout{
+				Steps: []configv1alpha1.ClusterColocationProfileRolloutStep{
+					{Percent: 30},
+					{Percent: 60},
+				},
+			},
+		},
+	}
+	observedStatus := func(profile *configv1alpha1.ClusterColocationProfile, percent int32) *configv1alpha1.ColocationProfileRolloutStatus {
+		return &configv1alpha1.ColocationProfileRolloutStatus{
+			ProfileHash: extension.GetColocationProfileMutationHash(profile),
+			CurrentStep: 1,
+			Percent:     percent,
+		}
+	}
+	tests := []struct {
+		name        string
+		podBucket   string
+		paused      bool
+		observed    func(profile *configv1alpha1.ClusterColocationProfile) *configv1alpha1.ColocationProfileRolloutStatus
+		randBucket  int
+		wantMutated bool
+		wantBucket  string
+	}{
+		{
+			name:        "pod in the first step",
+			randBucket:  10,
+			wantMutated: true,
+			wantBucket:  "10",
+		},
+		{
+			name:        "pod held by the first step",
+			randBucket:  50,
+			wantMutated: false,
+			wantBucket:  "50",
+		},
+		{
+			name: "pod in the step observed by the controller",
+			observed: func(profile *configv1alpha1.ClusterColocationProfile) *configv1alpha1.ColocationProfileRolloutStatus {
+				return observedStatus(profile, 60)
+			},
+			randBucket:  50,
+			wantMutated: true,
+			wantBucket:  "50",
+		},
+		{
+			name: "ignore the step observed for the previous mutation",
+			observed: func(profile *configv1alpha1.ClusterColocationProfile) *configv1alpha1.ColocationProfileRolloutStatus {
+				status := observedStatus(profile, 60)
+				status.ProfileHash = "previous-hash"
+				return status
+			},
+			randBucket:  50,
+			wantMutated: false,
+			wantBucket:  "50",
+		},
+		{
+			name:        "keep the bucket assigned before",
+			podBucket:   "20",
+			randBucket:  50,
+			wantMutated: true,
+			wantBucket:  "20",
+		},
+		{
+			name:        "rollout paused",
+			paused:      true,
+			randBucket:  10,
+			wantMutated: false,
+			wantBucket:  "10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().Build()
+			handler := &PodMutatingHandler{
+				Client:  client,
+				Decoder: admission.NewDecoder(scheme.Scheme),
+			}
+			profile := testProfile.DeepCopy()
+			profile.Spec.Rollout.Paused = tt.paused
+			if tt.observed != nil {
+				profile.Status.Rollout = tt.observed(profile)
+			}
+			assert.NoError(t, client.Create(context.TODO(), profile))
+			defer SetRandIntnFnWhenTest(func(int) int { return tt.randBucket })()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-pod-1",
+					Labels: map[string]string{
+						"koordinator-colocation-pod": "true",
+					},
+				},
+			}
+			if tt.podBucket != "" {
+				pod.Annotations = map[string]string{
+					extension.AnnotationColocationProfileRolloutBucket: tt.podBucket,
+				}
+			}
+			req := newAdmission(admissionv1.Create, runtime.RawExtension{}, runtime.RawExtension{}, "")
+			mutated, err := handler.clusterColocationProfileMutatingPod(context.TODO(), req, pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMutated, mutated)
+			assert.Equal(t, tt.wantMutated, pod.Labels["testLabelA"] == "valueA")
+			assert.Equal(t, tt.wantMutated, extension.IsPodMutatedByColocationProfile(pod, profile.Name))
+			assert.Equal(t, tt.wantBucket, pod.Annotations[extension.AnnotationColocationProfileRolloutBucket])
+		})
+	}
+}
+
 // BenchmarkClusterColocationProfile_NoPatchNoNamespaceSelector benchmarks the common
 // hot path where profiles use Selector (no NamespaceSelector) and no StrategicMergePatch.
 // This simulates the production scenario of ~1000 pod QPS with multiple colocation profiles.
@@ -43,7 +43,7 @@ func (h *PodMutatingHandler) extendedResourceSpecMutatingPod(ctx context.Context
 }
 
 func (h *PodMutatingHandler) mutateByExtendedResources(pod *corev1.Pod) (bool, error) {
-	// dump batch-resource of pod.spec.containers[*].resources.requests/limits into ExtendedResourceSpec{}
+	// dump batch-resource and net-bandwidth of pod.spec.containers[*].resources.requests/limits into ExtendedResourceSpec{}
 	extendedResourceSpec := &extension.ExtendedResourceSpec{}
 	containersSpec := map[string]extension.ExtendedResourceContainerSpec{}
 
@@ -53,6 +53,7 @@ func (h *PodMutatingHandler) mutateByExtendedResources(pod *corev1.Pod) (bool, e
 		r := getContainerExtendedResourcesRequirement(container, []corev1.ResourceName{
 			extension.BatchCPU,
 			extension.BatchMemory,
+			extension.ResourceNetworkBandwidth,
 		})
 		if r == nil {
 			continue
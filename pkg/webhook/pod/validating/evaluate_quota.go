@@ -18,20 +18,18 @@ package validating
 
 import (
 	"context"
-	"fmt"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
-	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
 	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
 	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/workload"
 )
 
 // podTransformersForQuotaEvaluation defines the necessary pod transformers used before quota evaluation.
@@ -66,17 +64,8 @@ func (h *PodValidatingHandler) evaluateQuota(ctx context.Context, req admission.
 		return true, "", nil
 	}
 
-	quotaList := &v1alpha1.ElasticQuotaList{}
-	err := h.Client.List(context.TODO(), quotaList, client.MatchingFields{"metadata.name": quotaName})
+	quota, err := quotaevaluate.GetQuotaByName(ctx, h.Client, quotaName)
 	if err != nil {
-		return false, "", err
-	}
-
-	if len(quotaList.Items) == 0 {
-		err := fmt.Errorf("elastic quota %v not found", quotaName)
-		return false, err.Error(), err
-	} else if len(quotaList.Items) > 1 {
-		err := fmt.Errorf("more than one elastic quota %v found", quotaName)
 		return false, err.Error(), err
 	}
 
@@ -86,11 +75,18 @@ func (h *PodValidatingHandler) evaluateQuota(ctx context.Context, req admission.
 	}
 
 	attribute := &quotaevaluate.Attributes{
-		QuotaNamespace: quotaList.Items[0].Namespace,
-		QuotaName:      quotaList.Items[0].Name,
+		QuotaNamespace: quota.Namespace,
+		QuotaName:      quota.Name,
 		Operation:      req.Operation,
 		Pod:            newPod,
 	}
+	// the pods of the admitted workloads take the requests reserved when admitting the workloads.
+	if req.Operation == admissionv1.Create && utilfeature.DefaultFeatureGate.Enabled(features.WorkloadQuotaAdmission) {
+		attribute.OwnerWorkload, err = workload.GetAdmittedWorkloadOfPod(ctx, h.Client, newPod, quota)
+		if err != nil {
+			return false, err.Error(), err
+		}
+	}
 
 	err = h.QuotaEvaluator.Evaluate(attribute)
 	if err != nil {
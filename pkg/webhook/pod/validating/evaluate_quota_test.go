@@ -22,11 +22,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -40,6 +44,18 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
 )
 
+func jobPod(jobName string) *corev1.Pod {
+	pod := elasticquota.MakePod("ns1", "pod1").Label("quota.scheduling.koordinator.sh/name", "quota1").
+		Container(corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}).Obj()
+	pod.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "batch/v1", Kind: "Job", Name: jobName, UID: types.UID(jobName), Controller: ptr.To(true)},
+	}
+	return pod
+}
+
 func TestEvaluateQuota(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -47,11 +63,14 @@ func TestEvaluateQuota(t *testing.T) {
 		oldPod      *corev1.Pod
 		newPod      *corev1.Pod
 		quota       *v1alpha1.ElasticQuota
+		objects     []client.Object
 		prepareFn   func() func()
 		wantAllowed bool
 		wantReason  string
 		wantErr     bool
 		wantUsed    corev1.ResourceList
+		// wantWorkloads checks the admitted workloads of the quota if set
+		wantWorkloads map[string]extension.AdmittedWorkload
 	}{
 		{
 			name:      "normal case 1",
@@ -472,6 +491,121 @@ func TestEvaluateQuota(t *testing.T) {
 				"example.io/gpu": resource.MustParse("1"),
 			},
 		},
+		{
+			name:      "pod of the admitted job takes the reservation",
+			operation: admissionv1.Create,
+			newPod:    jobPod("job1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).AdmittedWorkloads(map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				}},
+			}).Obj(),
+			prepareFn: func() func() {
+				return utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate,
+					features.WorkloadQuotaAdmission, true)
+			},
+			wantAllowed: true,
+			wantReason:  "",
+			wantErr:     false,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantWorkloads: map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Consumed: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}},
+			},
+		},
+		{
+			name:      "standalone pod exceeding the quota with the reservation of the admitted job",
+			operation: admissionv1.Create,
+			newPod: elasticquota.MakePod("ns1", "pod1").Label("quota.scheduling.koordinator.sh/name", "quota1").
+				Container(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}).Obj(),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).AdmittedWorkloads(map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}},
+			}).Obj(),
+			prepareFn: func() func() {
+				return utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate,
+					features.WorkloadQuotaAdmission, true)
+			},
+			wantAllowed: false,
+			wantReason:  "exceeded quota: kube-system/quota1, requested: cpu=2, used: cpu=4, limited: cpu=4",
+			wantErr:     true,
+		},
+		{
+			name:      "pod of the admitted job beyond the admitted requests",
+			operation: admissionv1.Create,
+			newPod:    jobPod("job1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).AdmittedWorkloads(map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				}},
+			}).Obj(),
+			prepareFn: func() func() {
+				return utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate,
+					features.WorkloadQuotaAdmission, true)
+			},
+			wantAllowed: false,
+			wantReason:  "exceeded quota: kube-system/quota1, requested: cpu=1, used: cpu=4, limited: cpu=4",
+			wantErr:     true,
+		},
+		{
+			name:      "pod of the job with a faked admission annotation",
+			operation: admissionv1.Create,
+			newPod:    jobPod("job1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			objects: []client.Object{
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns1",
+						Name:      "job1",
+						UID:       "job1",
+						Annotations: map[string]string{
+							extension.AnnotationAdmission: `{"cpu":"4","memory":"4Gi"}`,
+						},
+					},
+				},
+			},
+			prepareFn: func() func() {
+				return utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate,
+					features.WorkloadQuotaAdmission, true)
+			},
+			wantAllowed: false,
+			wantReason:  "exceeded quota: kube-system/quota1, requested: cpu=2, used: cpu=4, limited: cpu=4",
+			wantErr:     true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -496,6 +630,7 @@ func TestEvaluateQuota(t *testing.T) {
 			if tc.quota != nil {
 				clientBuilder.WithObjects(tc.quota)
 			}
+			clientBuilder.WithObjects(tc.objects...)
 			client := clientBuilder.Build()
 
 			decoder := admission.NewDecoder(scheme)
@@ -535,6 +670,13 @@ func TestEvaluateQuota(t *testing.T) {
 				assert.NoError(t, err)
 				t.Logf("want %v, got %v", util.DumpJSON(tc.wantUsed), util.DumpJSON(newUsed))
 				assert.Equal(t, tc.wantUsed, newUsed)
+				if tc.wantWorkloads != nil {
+					workloads, err := extension.GetAdmittedWorkloads(newQuota)
+					assert.NoError(t, err)
+					for key, want := range tc.wantWorkloads {
+						assert.True(t, quotav1.Equals(want.Consumed, workloads[key].Consumed), "want consumed %v, got %v", want.Consumed, workloads[key].Consumed)
+					}
+				}
 			}
 			if gotAllowed != tc.wantAllowed {
 				t.Errorf("evaluateQuota gotAllowed = %v, want %v", gotAllowed, tc.wantAllowed)
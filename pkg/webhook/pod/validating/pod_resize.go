@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	apiresource "k8s.io/component-helpers/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+const podResizeSubResource = "resize"
+
+func isPodResizeRequest(req admission.Request) bool {
+	return req.AdmissionRequest.Resource.Resource == "pods" &&
+		req.AdmissionRequest.SubResource == podResizeSubResource &&
+		req.Operation == admissionv1.Update
+}
+
+func (h *PodValidatingHandler) validatingPodResizeFn(ctx context.Context, req admission.Request) (allowed bool, reason string, err error) {
+	newPod := &corev1.Pod{}
+	if err = h.Decoder.DecodeRaw(req.Object, newPod); err != nil {
+		return false, "", err
+	}
+	oldPod := &corev1.Pod{}
+	if err = h.Decoder.DecodeRaw(req.OldObject, oldPod); err != nil {
+		return false, "", err
+	}
+
+	start := time.Now()
+	allowed, reason, err = h.podResizeValidatingPod(ctx, req, newPod, oldPod)
+	metrics.RecordWebhookDurationMilliseconds(metrics.ValidatingWebhook,
+		metrics.Pod, string(req.Operation), err, PodResize, time.Since(start).Seconds())
+	return
+}
+
+func (h *PodValidatingHandler) podResizeValidatingPod(ctx context.Context, req admission.Request, newPod, oldPod *corev1.Pod) (bool, string, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.PodResizeAdmission) {
+		return true, "", nil
+	}
+
+	allErrs := validateCPUSetPodResize(newPod, oldPod)
+	numaErrs, err := h.validateNUMAPodResize(ctx, newPod, oldPod)
+	if err != nil {
+		return false, err.Error(), err
+	}
+	allErrs = append(allErrs, numaErrs...)
+	if err := allErrs.ToAggregate(); err != nil {
+		return false, err.Error(), err
+	}
+	return h.evaluateQuotaOnResize(ctx, req, newPod, oldPod)
+}
+
+// validateCPUSetPodResize rejects the cpu resize of the pod bound to the cpuset allocated by koord-scheduler,
+// since the cpuset cannot be reallocated in place.
+func validateCPUSetPodResize(newPod, oldPod *corev1.Pod) field.ErrorList {
+	allErrs := field.ErrorList{}
+	resourceStatus, err := extension.GetResourceStatus(oldPod.Annotations)
+	if err != nil || resourceStatus.CPUSet == "" {
+		return allErrs
+	}
+
+	oldContainers := make(map[string]*corev1.Container, len(oldPod.Spec.Containers))
+	for i := range oldPod.Spec.Containers {
+		oldContainers[oldPod.Spec.Containers[i].Name] = &oldPod.Spec.Containers[i]
+	}
+	for i := range newPod.Spec.Containers {
+		container := &newPod.Spec.Containers[i]
+		oldContainer, ok := oldContainers[container.Name]
+		if !ok {
+			continue
+		}
+		if !container.Resources.Requests.Cpu().Equal(*oldContainer.Resources.Requests.Cpu()) ||
+			!container.Resources.Limits.Cpu().Equal(*oldContainer.Resources.Limits.Cpu()) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containers").Index(i).Child("resources"),
+				fmt.Sprintf("cpu of the pod bound to cpuset %s cannot be resized", resourceStatus.CPUSet)))
+		}
+	}
+	return allErrs
+}
+
+// validateNUMAPodResize validates the scale-up of the pod bound to the NUMA nodes allocated by koord-scheduler.
+// The kubelet only checks the resized pod against the node allocatable, while the NUMA nodes of the pod cannot be
+// reallocated in place. So the resized requests are spread over the allocated NUMA nodes as koord-scheduler does,
+// and the scale-up is rejected if any of the NUMA nodes cannot hold it besides the other pods on the node.
+// The cpu of the cpuset-bound pod is validated by validateCPUSetPodResize, and the device resources allocated by
+// koord-scheduler are not resizable in place.
+func (h *PodValidatingHandler) validateNUMAPodResize(ctx context.Context, newPod, oldPod *corev1.Pod) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	resourceStatus, err := extension.GetResourceStatus(oldPod.Annotations)
+	if err != nil || len(resourceStatus.NUMANodeResources) == 0 || oldPod.Spec.NodeName == "" {
+		return allErrs, nil
+	}
+
+	scaleCPU := resourceStatus.CPUSet == ""
+	oldRequests := apiresource.PodRequests(oldPod, apiresource.PodResourcesOptions{})
+	newRequests := apiresource.PodRequests(newPod, apiresource.PodResourcesOptions{})
+	var scaledUpResources []corev1.ResourceName
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if resourceName == corev1.ResourceCPU && !scaleCPU {
+			continue
+		}
+		newQuantity, oldQuantity := newRequests[resourceName], oldRequests[resourceName]
+		if newQuantity.Cmp(oldQuantity) > 0 {
+			scaledUpResources = append(scaledUpResources, resourceName)
+		}
+	}
+	if len(scaledUpResources) == 0 {
+		return allErrs, nil
+	}
+
+	nodeName := oldPod.Spec.NodeName
+	nrt := &topologyv1alpha1.NodeResourceTopology{}
+	if err = h.Client.Get(ctx, types.NamespacedName{Name: nodeName}, nrt); err != nil {
+		if errors.IsNotFound(err) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containers"),
+				fmt.Sprintf("the pod bound to NUMA nodes cannot be scaled up since the NodeResourceTopology of node %s is not found", nodeName)))
+			return allErrs, nil
+		}
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err = h.Client.List(ctx, podList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName),
+	}); err != nil {
+		return nil, err
+	}
+
+	used := map[int32]corev1.ResourceList{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.UID == oldPod.UID || util.IsPodTerminated(pod) {
+			continue
+		}
+		podResourceStatus, err := extension.GetResourceStatus(pod.Annotations)
+		if err != nil {
+			continue
+		}
+		// the other pods may be resized as well, which are counted with their resized requests like koord-scheduler
+		podRequests := apiresource.PodRequests(pod, apiresource.PodResourcesOptions{UseStatusResources: true})
+		for _, numaNodeRes := range util.ScaleNUMANodeResourcesToRequests(podResourceStatus.NUMANodeResources, podRequests, podResourceStatus.CPUSet == "") {
+			used[numaNodeRes.Node] = quotav1.Add(used[numaNodeRes.Node], numaNodeRes.Resources)
+		}
+	}
+
+	zoneAllocatable := util.ZoneListToZoneResourceList(nrt.Zones)
+	for _, numaNodeRes := range util.ScaleNUMANodeResourcesToRequests(resourceStatus.NUMANodeResources, newRequests, scaleCPU) {
+		allocatable := zoneAllocatable[util.GenNodeZoneName(int(numaNodeRes.Node))]
+		for _, resourceName := range scaledUpResources {
+			requested, ok := numaNodeRes.Resources[resourceName]
+			if !ok {
+				continue
+			}
+			requested.Add(used[numaNodeRes.Node][resourceName])
+			if free := allocatable[resourceName]; requested.Cmp(free) > 0 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containers"),
+					fmt.Sprintf("insufficient %s on NUMA node %d to scale up the pod, requested: %s, allocatable: %s",
+						resourceName, numaNodeRes.Node, requested.String(), free.String())))
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+// evaluateQuotaOnResize evaluates the increment of the requests of the resized pod against its quota.
+func (h *PodValidatingHandler) evaluateQuotaOnResize(ctx context.Context, req admission.Request, newPod, oldPod *corev1.Pod) (bool, string, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.EnableQuotaAdmission) {
+		return true, "", nil
+	}
+
+	quotaName := elasticquota.GetQuotaName(newPod, h.Client)
+	// quota is system quota or empty, skip it.
+	if quotaName == "" || quotaName == extension.DefaultQuotaName ||
+		quotaName == extension.SystemQuotaName || quotaName == extension.RootQuotaName {
+		return true, "", nil
+	}
+
+	quota, err := quotaevaluate.GetQuotaByName(ctx, h.Client, quotaName)
+	if err != nil {
+		return false, err.Error(), err
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaEvaluationTransformPod) {
+		transformPodForQuotaEvaluation(newPod)
+		transformPodForQuotaEvaluation(oldPod)
+	}
+
+	attribute := &quotaevaluate.Attributes{
+		QuotaNamespace: quota.Namespace,
+		QuotaName:      quota.Name,
+		Operation:      req.Operation,
+		Pod:            newPod,
+		OldPod:         oldPod,
+	}
+	if err = h.QuotaEvaluator.Evaluate(attribute); err != nil {
+		return false, err.Error(), err
+	}
+	return true, "", nil
+}
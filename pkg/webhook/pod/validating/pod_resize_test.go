@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+func TestPodResizeValidatingPod(t *testing.T) {
+	makePod := func(cpu, memory string, cpuset string) *corev1.Pod {
+		pod := elasticquota.MakePod("ns1", "pod1").Label("quota.scheduling.koordinator.sh/name", "quota1").
+			Container(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			}).Obj()
+		if cpuset != "" {
+			pod.Annotations = map[string]string{
+				extension.AnnotationResourceStatus: `{"cpuset":"` + cpuset + `"}`,
+			}
+		}
+		return pod
+	}
+	makeNUMAPod := func(cpu, memory string) *corev1.Pod {
+		pod := makePod(cpu, memory, "")
+		pod.Spec.NodeName = "node1"
+		pod.Annotations = map[string]string{
+			extension.AnnotationResourceStatus: `{"numaNodeResources":[{"node":0,"resources":{"cpu":"2","memory":"4Gi"}}]}`,
+		}
+		return pod
+	}
+	// the NUMA node 0 of node1 has 8 cpus and 16Gi memory allocatable
+	nrt := &topologyv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Zones: util.ZoneResourceListToZoneList(map[string]corev1.ResourceList{
+			util.GenNodeZoneName(0): {
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+		}),
+	}
+	otherNUMAPod := elasticquota.MakePod("ns1", "pod2").Container(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("10Gi"),
+	}).Obj()
+	otherNUMAPod.UID = "pod2"
+	otherNUMAPod.Spec.NodeName = "node1"
+	otherNUMAPod.Annotations = map[string]string{
+		extension.AnnotationResourceStatus: `{"numaNodeResources":[{"node":0,"resources":{"cpu":"2","memory":"10Gi"}}]}`,
+	}
+	testCases := []struct {
+		name        string
+		disabled    bool
+		oldPod      *corev1.Pod
+		newPod      *corev1.Pod
+		quota       *v1alpha1.ElasticQuota
+		objects     []client.Object
+		wantAllowed bool
+		wantReason  string
+		wantErr     bool
+		wantUsed    corev1.ResourceList
+	}{
+		{
+			name:     "PodResizeAdmission disabled",
+			disabled: true,
+			oldPod:   makePod("2", "4Gi", "0-1"),
+			newPod:   makePod("4", "4Gi", "0-1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: true,
+		},
+		{
+			name:   "expand within the quota",
+			oldPod: makePod("2", "4Gi", ""),
+			newPod: makePod("4", "4Gi", ""),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		{
+			name:   "expand exceeds the quota",
+			oldPod: makePod("2", "4Gi", ""),
+			newPod: makePod("4", "4Gi", ""),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: false,
+			wantReason:  "exceeded quota: kube-system/quota1, requested: cpu=2, used: cpu=3, limited: cpu=4",
+			wantErr:     true,
+		},
+		{
+			name:   "shrink requests nothing from the quota",
+			oldPod: makePod("4", "4Gi", ""),
+			newPod: makePod("2", "4Gi", ""),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).Obj(),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+		{
+			name:   "forbid resizing cpu of the cpuset pod",
+			oldPod: makePod("2", "4Gi", "0-1"),
+			newPod: makePod("4", "4Gi", "0-1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).Obj(),
+			wantAllowed: false,
+			wantReason:  "spec.containers[0].resources: Forbidden: cpu of the pod bound to cpuset 0-1 cannot be resized",
+			wantErr:     true,
+		},
+		{
+			name:   "allow resizing memory of the cpuset pod",
+			oldPod: makePod("2", "4Gi", "0-1"),
+			newPod: makePod("2", "6Gi", "0-1"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		{
+			name:    "allow scaling up the NUMA-bound pod within the NUMA node",
+			oldPod:  makeNUMAPod("2", "4Gi"),
+			newPod:  makeNUMAPod("2", "6Gi"),
+			objects: []client.Object{nrt, otherNUMAPod},
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		{
+			name:    "forbid scaling up the NUMA-bound pod beyond the NUMA node",
+			oldPod:  makeNUMAPod("2", "4Gi"),
+			newPod:  makeNUMAPod("2", "8Gi"),
+			objects: []client.Object{nrt, otherNUMAPod},
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			}).Obj(),
+			wantAllowed: false,
+			wantReason:  "spec.containers: Forbidden: insufficient memory on NUMA node 0 to scale up the pod, requested: 18Gi, allocatable: 16Gi",
+			wantErr:     true,
+		},
+		{
+			name:   "forbid scaling up the NUMA-bound pod without the NodeResourceTopology",
+			oldPod: makeNUMAPod("2", "4Gi"),
+			newPod: makeNUMAPod("2", "6Gi"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).Obj(),
+			wantAllowed: false,
+			wantReason:  "spec.containers: Forbidden: the pod bound to NUMA nodes cannot be scaled up since the NodeResourceTopology of node node1 is not found",
+			wantErr:     true,
+		},
+		{
+			name:   "allow scaling down the NUMA-bound pod",
+			oldPod: makeNUMAPod("2", "4Gi"),
+			newPod: makeNUMAPod("1", "2Gi"),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).Obj(),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.EnableQuotaAdmission, true)()
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.PodResizeAdmission, !tc.disabled)()
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.AddToScheme(scheme)
+			_ = topologyv1alpha1.AddToScheme(scheme)
+			_ = clientgoscheme.AddToScheme(scheme)
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&v1alpha1.ElasticQuota{},
+				"metadata.name", func(object client.Object) []string {
+					eq, ok := object.(*v1alpha1.ElasticQuota)
+					if !ok {
+						return []string{}
+					}
+					return []string{eq.Name}
+				}).WithIndex(&corev1.Pod{}, "spec.nodeName", func(object client.Object) []string {
+				pod, ok := object.(*corev1.Pod)
+				if !ok {
+					return []string{}
+				}
+				return []string{pod.Spec.NodeName}
+			}).WithObjects(append(tc.objects, tc.quota)...).Build()
+
+			decoder := admission.NewDecoder(scheme)
+			h := &PodValidatingHandler{
+				Client:  client,
+				Decoder: decoder,
+			}
+			quotaAccessor := quotaevaluate.NewQuotaAccessor(h.Client, h.Client)
+			h.QuotaEvaluator = quotaevaluate.NewQuotaEvaluator(quotaAccessor, 16, make(chan struct{}))
+
+			req := newAdmissionRequest(admissionv1.Update,
+				runtime.RawExtension{Raw: []byte(util.DumpJSON(tc.newPod))},
+				runtime.RawExtension{Raw: []byte(util.DumpJSON(tc.oldPod))}, podResizeSubResource)
+			gotAllowed, gotReason, err := h.validatingPodFn(context.TODO(), admission.Request{AdmissionRequest: req})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.wantAllowed, gotAllowed)
+			assert.Equal(t, tc.wantReason, gotReason)
+			if tc.wantUsed != nil {
+				newQuota := &v1alpha1.ElasticQuota{}
+				assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{
+					Namespace: tc.quota.Namespace,
+					Name:      tc.quota.Name,
+				}, newQuota))
+				newUsed, err := extension.GetChildRequest(newQuota)
+				assert.NoError(t, err)
+				assert.True(t, util.IsResourceListEqual(tc.wantUsed, newUsed), "want %v, got %v", tc.wantUsed, newUsed)
+			}
+		})
+	}
+}
@@ -38,6 +38,7 @@ const (
 	EvaluateQuota            = "EvaluateQuota"
 	DeviceResource           = "DeviceResource"
 	EnhancedValidation       = "EnhancedValidation"
+	PodResize                = "PodResize"
 )
 
 // PodValidatingHandler handles Pod
@@ -67,6 +68,9 @@ func shouldIgnoreIfNotPod(req admission.Request) bool {
 
 func (h *PodValidatingHandler) validatingPodFn(ctx context.Context, req admission.Request) (allowed bool, reason string, err error) {
 	allowed = true
+	if isPodResizeRequest(req) {
+		return h.validatingPodResizeFn(ctx, req)
+	}
 	if shouldIgnoreIfNotPod(req) {
 		return
 	}
@@ -25,7 +25,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/webhook/util/framework"
 )
 
-// +kubebuilder:webhook:path=/validate-pod,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1;v1beta1,groups="",resources=pods,verbs=create;update,versions=v1,name=vpod.koordinator.sh
+// +kubebuilder:webhook:path=/validate-pod,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1;v1beta1,groups="",resources=pods;pods/resize,verbs=create;update,versions=v1,name=vpod.koordinator.sh
 
 var (
 	// HandlerMap contains admission webhook handlers
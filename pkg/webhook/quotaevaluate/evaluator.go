@@ -29,6 +29,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -180,6 +181,17 @@ type quotaCheckContext struct {
 	delta corev1.ResourceList
 	names sets.Set[corev1.ResourceName]
 
+	// workloads are the workloads admitted into the quota, with the pending changes of this batch applied.
+	workloads map[string]extension.AdmittedWorkload
+	// workloadChanges are the admitted workloads changed in this batch, a nil value means released.
+	// They are applied again on the refreshed quota when retrying the update.
+	workloadChanges map[string]*extension.AdmittedWorkload
+	// workloadUpdates counts the changes of the admitted workloads in this batch.
+	workloadUpdates int
+	// reserved is the sum of the requests of the admitted workloads not taken by their members yet.
+	// It is evaluated together with used but never written into the child request.
+	reserved corev1.ResourceList
+
 	// totalJitterSpent accumulates per-batch jitter allocated to UpdateQuotaStatus
 	// calls. Persists across recursive checkQuota calls; capped at
 	// QuotaUpdateMaxTotalJitter so jitter cannot exhaust Evaluate()'s timeout.
@@ -200,6 +212,57 @@ type Attributes struct {
 	QuotaName      string
 	Operation      admissionv1.Operation
 	Pod            *corev1.Pod
+	// OldPod is set when the Pod is resized in place, and only the increment of the requests is evaluated.
+	OldPod *corev1.Pod
+	// Workload is set instead of Pod when all members of a workload are admitted at once.
+	Workload *Workload
+	// OwnerWorkload is set when the Pod is a member of an admitted workload, and the requests of the Pod
+	// are taken from the reservation of the workload first.
+	OwnerWorkload *Workload
+}
+
+// Workload is a group of pods, e.g. a Job or a PodGroup, whose requests are admitted into the quota atomically.
+// The admitted workloads are recorded in the admission of the quota, and the requests not taken by the members
+// yet are reserved. Only the increment of the requests is evaluated when the workload is admitted again,
+// e.g. scaled up.
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       types.UID
+	// Requests is the sum of the requests of the members.
+	Requests corev1.ResourceList
+	// Release removes the workload from the admitted workloads of the quota, e.g. the workload is finished.
+	Release bool
+}
+
+// Key returns the key of the workload in the admitted workloads of the quota.
+func (w *Workload) Key() string {
+	return WorkloadKey(w.Kind, w.Namespace, w.Name)
+}
+
+// WorkloadKey returns the key of the workload in the admitted workloads of the quota.
+func WorkloadKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func (a *Attributes) usage() (corev1.ResourceList, error) {
+	usage, err := PodUsageFunc(a.Pod, clock.RealClock{})
+	if err != nil || a.OldPod == nil {
+		return usage, err
+	}
+	oldUsage, err := PodUsageFunc(a.OldPod, clock.RealClock{})
+	if err != nil {
+		return nil, err
+	}
+	// only the increased resources are requested from the quota, the shrunk ones are released by the quota manager.
+	increment := corev1.ResourceList{}
+	for name, quantity := range quotav1.Subtract(usage, oldUsage) {
+		if quantity.Sign() > 0 {
+			increment[name] = quantity
+		}
+	}
+	return increment, nil
 }
 
 // Evaluator is used to see if quota constraints are satisfied.
@@ -332,12 +395,13 @@ func (e *quotaEvaluator) checkQuota(quota *v1alpha1.ElasticQuota, admissionAttri
 	ctx = e.initCtx(ctx, quota)
 
 	fastPathOK := false
-	// fast retry only happens when quota is changed in last round and resource names keep unchanged
-	if ctx.fatal == nil && len(ctx.delta) > 0 {
+	// fast retry only happens when quota is changed in last round and resource names keep unchanged,
+	// and no admitted workload is changed since their records must be rebuilt on the refreshed quota.
+	if ctx.fatal == nil && len(ctx.delta) > 0 && len(ctx.workloadChanges) == 0 {
 		newUsage := quotav1.Add(ctx.used, ctx.delta)
 		maskResourceList(newUsage, ctx.names)
 
-		if allowed, _ := quotav1.LessThanOrEqual(newUsage, ctx.admission); !allowed {
+		if allowed, _ := quotav1.LessThanOrEqual(quotav1.Add(newUsage, ctx.reserved), ctx.admission); !allowed {
 			// cached delta is no longer admissible; drop it and rebuild via slow path
 			ctx.delta = corev1.ResourceList{}
 		} else {
@@ -348,19 +412,21 @@ func (e *quotaEvaluator) checkQuota(quota *v1alpha1.ElasticQuota, admissionAttri
 
 	if !fastPathOK {
 		changed := 0
+		ctx.resetWorkloadChanges(quota)
 		for i := range admissionAttributes {
 			admissionAttribute := admissionAttributes[i]
 			if !IsDefaultDeny(admissionAttribute.result) {
 				continue
 			}
 			oldUsed := ctx.used.DeepCopy()
+			oldWorkloadUpdates := ctx.workloadUpdates
 			err := e.checkRequest(quota, admissionAttribute.attributes, ctx)
 			if err != nil {
 				admissionAttribute.result = err
 				continue
 			}
 
-			if !quotav1.Equals(oldUsed, ctx.used) {
+			if !quotav1.Equals(oldUsed, ctx.used) || ctx.workloadUpdates != oldWorkloadUpdates {
 				changed++
 			} else {
 				admissionAttribute.result = nil
@@ -379,13 +445,16 @@ func (e *quotaEvaluator) checkQuota(quota *v1alpha1.ElasticQuota, admissionAttri
 		}
 	}
 
+	newQuota := quota.DeepCopy()
+	if newQuota.Annotations == nil {
+		newQuota.Annotations = make(map[string]string)
+	}
 	data, updateErr := json.Marshal(ctx.used)
 	if updateErr == nil {
-		newQuota := quota.DeepCopy()
-		if newQuota.Annotations == nil {
-			newQuota.Annotations = make(map[string]string)
-		}
 		newQuota.Annotations[extension.AnnotationChildRequest] = string(data)
+		updateErr = setAdmittedWorkloads(newQuota, ctx)
+	}
+	if updateErr == nil {
 		jitter := e.jitterForNextUpdate(ctx, remainingRetries)
 		if jitter > 0 {
 			ctx.totalJitterSpent += jitter
@@ -445,13 +514,75 @@ func (e *quotaEvaluator) initCtx(ctx *quotaCheckContext, quota *v1alpha1.Elastic
 	if ctx.names.Len() == 0 || !names.Equal(ctx.names) {
 		ctx.names, ctx.delta = names, corev1.ResourceList{}
 	}
+	workloads, workloadsErr := extension.GetAdmittedWorkloads(quota)
+	if workloadsErr != nil && ctx.fatal == nil {
+		ctx.fatal = workloadsErr
+	}
+	ctx.workloads = workloads
+	for key, workload := range ctx.workloadChanges {
+		if workload == nil {
+			delete(ctx.workloads, key)
+		} else {
+			ctx.workloads[key] = *workload
+		}
+	}
+	ctx.updateReserved()
 	return ctx
 }
 
+func (ctx *quotaCheckContext) updateReserved() {
+	ctx.reserved = corev1.ResourceList{}
+	for _, workload := range ctx.workloads {
+		util.AddResourceList(ctx.reserved, workload.Reserved())
+	}
+}
+
+// resetWorkloadChanges drops the pending changes of the admitted workloads before the batch is checked again.
+func (ctx *quotaCheckContext) resetWorkloadChanges(quota *v1alpha1.ElasticQuota) {
+	if len(ctx.workloadChanges) == 0 {
+		return
+	}
+	ctx.workloadChanges = nil
+	if workloads, err := extension.GetAdmittedWorkloads(quota); err == nil {
+		ctx.workloads = workloads
+	}
+	ctx.updateReserved()
+}
+
+func (ctx *quotaCheckContext) setWorkload(key string, workload *extension.AdmittedWorkload) {
+	if ctx.workloadChanges == nil {
+		ctx.workloadChanges = map[string]*extension.AdmittedWorkload{}
+	}
+	ctx.workloadChanges[key] = workload
+	ctx.workloadUpdates++
+	if workload == nil {
+		delete(ctx.workloads, key)
+	} else {
+		ctx.workloads[key] = *workload
+	}
+	ctx.updateReserved()
+}
+
+// setAdmittedWorkloads records the admitted workloads into the admission of the quota if changed in this batch.
+func setAdmittedWorkloads(quota *v1alpha1.ElasticQuota, ctx *quotaCheckContext) error {
+	if len(ctx.workloadChanges) == 0 {
+		return nil
+	}
+	admission, err := extension.GetElasticQuotaAdmission(quota)
+	if err != nil {
+		return err
+	}
+	admission.Workloads = ctx.workloads
+	return extension.SetElasticQuotaAdmission(quota, admission)
+}
+
 // Handles returns true if the evaluator should process this operation.
 // For Update, the caller (evaluateQuota) has already checked the EnableQuotaAdmissionOnUpdate feature gate
 // before constructing the Attributes, so no redundant gate check is needed here.
 func (e *quotaEvaluator) Handles(a *Attributes) bool {
+	if a.Workload != nil && a.Workload.Release {
+		return true
+	}
 	if a.Operation == admissionv1.Create || a.Operation == admissionv1.Update {
 		return true
 	}
@@ -492,22 +623,134 @@ func (e *quotaEvaluator) checkRequest(quota *v1alpha1.ElasticQuota, a *Attribute
 		return ctx.fatal
 	}
 
+	if a.Workload != nil {
+		return e.checkWorkloadRequest(quota, a.Workload, ctx)
+	}
+
 	if ctx.names.Intersection(podResourcesSet).Len() == 0 {
 		return nil
 	}
 
-	requestedUsage, err := PodUsageFunc(a.Pod, clock.RealClock{})
+	requestedUsage, err := a.usage()
 	if err != nil {
 		return err
 	}
+	if a.OwnerWorkload != nil {
+		return e.checkMemberUsage(quota, a.OwnerWorkload, requestedUsage, ctx)
+	}
+	return e.checkUsage(quota, requestedUsage, ctx)
+}
 
+// checkWorkloadRequest admits the increment of the workload requests since last admitted, and records the
+// workload with the requests into the admitted workloads of the quota.
+func (e *quotaEvaluator) checkWorkloadRequest(quota *v1alpha1.ElasticQuota, workload *Workload, ctx *quotaCheckContext) error {
+	key := workload.Key()
+	admitted, ok := ctx.workloads[key]
+	if workload.Release {
+		if ok && admitted.UID == workload.UID {
+			ctx.setWorkload(key, nil)
+		}
+		return nil
+	}
+
+	if ok && admitted.UID != workload.UID {
+		// the workload is recreated, drop the reservation of the previous one.
+		ctx.setWorkload(key, nil)
+		ok = false
+	}
+
+	if ctx.names.Intersection(podResourcesSet).Len() > 0 {
+		requestedUsage := workload.Requests.DeepCopy()
+		if ok {
+			requestedUsage = corev1.ResourceList{}
+			for name, quantity := range quotav1.Subtract(workload.Requests, admitted.Requests) {
+				if quantity.Sign() > 0 {
+					requestedUsage[name] = quantity
+				}
+			}
+		}
+		// the requests are reserved rather than used until the members are created.
+		if err := e.checkAdmission(quota, requestedUsage, ctx); err != nil {
+			return err
+		}
+	}
+	if ok && quotav1.Equals(admitted.Requests, workload.Requests) {
+		return nil
+	}
+	newAdmitted := &extension.AdmittedWorkload{UID: workload.UID, Requests: workload.Requests.DeepCopy()}
+	if ok {
+		newAdmitted.Consumed = admitted.Consumed
+	}
+	ctx.setWorkload(key, newAdmitted)
+	return nil
+}
+
+// checkMemberUsage takes the requests of the member pod from the reservation of its admitted workload, and
+// evaluates the requests exceeding the reservation, e.g. the pods beyond the MinResources of a PodGroup.
+func (e *quotaEvaluator) checkMemberUsage(quota *v1alpha1.ElasticQuota, workload *Workload, requestedUsage corev1.ResourceList, ctx *quotaCheckContext) error {
+	key := workload.Key()
+	admitted, ok := ctx.workloads[key]
+	if !ok || admitted.UID != workload.UID {
+		return e.checkUsage(quota, requestedUsage, ctx)
+	}
+	maskResourceList(requestedUsage, ctx.names)
+	if len(requestedUsage) == 0 {
+		return nil
+	}
+
+	reserved := admitted.Reserved()
+	taken, exceeded := corev1.ResourceList{}, corev1.ResourceList{}
+	for name, quantity := range requestedUsage {
+		quantity = quantity.DeepCopy()
+		if r, ok := reserved[name]; ok {
+			if quantity.Cmp(r) <= 0 {
+				taken[name] = quantity
+				continue
+			}
+			taken[name] = r.DeepCopy()
+			quantity.Sub(r)
+		}
+		exceeded[name] = quantity
+	}
+	// the taken requests move from the reservation to the usage.
+	if err := e.checkAdmission(quota, exceeded, ctx); err != nil {
+		return err
+	}
+
+	ctx.used = quotav1.Add(ctx.used, requestedUsage)
+	util.AddResourceList(ctx.delta, requestedUsage)
+	if len(taken) > 0 {
+		admitted.Consumed = quotav1.Add(admitted.Consumed, taken)
+		ctx.setWorkload(key, &admitted)
+	}
+	return nil
+}
+
+func (e *quotaEvaluator) checkUsage(quota *v1alpha1.ElasticQuota, requestedUsage corev1.ResourceList, ctx *quotaCheckContext) error {
 	// Filter requestedUsage to only include resources in quota.Spec.Max, removing zeros.
 	maskResourceList(requestedUsage, ctx.names)
 	if len(requestedUsage) == 0 {
 		return nil
 	}
+	if err := e.checkAdmission(quota, requestedUsage, ctx); err != nil {
+		return err
+	}
+
+	// Accumulate delta and update cached usage for next iteration
+	ctx.used = quotav1.Add(ctx.used, requestedUsage)
+	util.AddResourceList(ctx.delta, requestedUsage)
+
+	return nil
+}
+
+// checkAdmission checks if the requests fit in the admission of the quota besides the used and the reserved.
+func (e *quotaEvaluator) checkAdmission(quota *v1alpha1.ElasticQuota, requestedUsage corev1.ResourceList, ctx *quotaCheckContext) error {
+	maskResourceList(requestedUsage, ctx.names)
+	if len(requestedUsage) == 0 {
+		return nil
+	}
 
-	used, admission := ctx.used, ctx.admission
+	used, admission := quotav1.Add(ctx.used, ctx.reserved), ctx.admission
 
 	// Use quotav1.Add (non-destructive) to preserve original 'used' for error messages
 	newUsage := quotav1.Add(used, requestedUsage)
@@ -523,11 +766,6 @@ func (e *quotaEvaluator) checkRequest(quota *v1alpha1.ElasticQuota, a *Attribute
 			prettyPrint(failedUsed),
 			prettyPrint(failedHard))
 	}
-
-	// Accumulate delta and update cached usage for next iteration
-	ctx.used = newUsage
-	util.AddResourceList(ctx.delta, requestedUsage)
-
 	return nil
 }
 
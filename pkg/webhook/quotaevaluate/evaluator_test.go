@@ -661,3 +661,30 @@ func TestJitterForNextUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributesUsageWithOldPod(t *testing.T) {
+	oldPod := elasticquota.MakePod("ns1", "pod1").Container(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	}).Obj()
+	newPod := elasticquota.MakePod("ns1", "pod1").Container(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("3"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}).Obj()
+
+	a := &Attributes{Operation: admissionv1.Update, Pod: newPod}
+	usage, err := a.usage()
+	assert.NoError(t, err)
+	assert.True(t, util.IsResourceListEqual(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("3"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}, usage))
+
+	// only the increment is requested on the resize
+	a.OldPod = oldPod
+	usage, err = a.usage()
+	assert.NoError(t, err)
+	assert.True(t, util.IsResourceListEqual(corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}, usage), "got %v", usage)
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package quotaevaluate
 
 import (
+	"context"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
@@ -36,3 +40,17 @@ func GetQuotaAdmission(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, error
 
 	return admission, nil
 }
+
+// GetQuotaByName gets the unique ElasticQuota with the name across namespaces.
+func GetQuotaByName(ctx context.Context, c client.Reader, quotaName string) (*v1alpha1.ElasticQuota, error) {
+	quotaList := &v1alpha1.ElasticQuotaList{}
+	if err := c.List(ctx, quotaList, client.MatchingFields{"metadata.name": quotaName}); err != nil {
+		return nil, err
+	}
+	if len(quotaList.Items) == 0 {
+		return nil, fmt.Errorf("elastic quota %v not found", quotaName)
+	} else if len(quotaList.Items) > 1 {
+		return nil, fmt.Errorf("more than one elastic quota %v found", quotaName)
+	}
+	return &quotaList.Items[0], nil
+}
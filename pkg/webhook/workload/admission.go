@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	apiresource "k8s.io/component-helpers/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+const (
+	KindJob      = "Job"
+	KindPodGroup = "PodGroup"
+)
+
+// IsAdmitted returns true if the workload has been admitted into the quota. The admitted workloads are
+// recorded in the quota with their UIDs, so that the users can't fake the admission on their workloads.
+func IsAdmitted(quota *v1alpha1.ElasticQuota, kind string, obj metav1.Object) (bool, error) {
+	workloads, err := extension.GetAdmittedWorkloads(quota)
+	if err != nil {
+		return false, err
+	}
+	admitted, ok := workloads[quotaevaluate.WorkloadKey(kind, obj.GetNamespace(), obj.GetName())]
+	return ok && admitted.UID == obj.GetUID(), nil
+}
+
+// JobTemplatePod builds the pod from the template of the Job to resolve the quota and requests of its members.
+// The quota label of the Job is inherited if the template doesn't specify one.
+func JobTemplatePod(job *batchv1.Job) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   job.Namespace,
+			Labels:      map[string]string{},
+			Annotations: job.Spec.Template.Annotations,
+		},
+		Spec: *job.Spec.Template.Spec.DeepCopy(),
+	}
+	for k, v := range job.Spec.Template.Labels {
+		pod.Labels[k] = v
+	}
+	if quotaName := job.Labels[extension.LabelQuotaName]; quotaName != "" && pod.Labels[extension.LabelQuotaName] == "" {
+		pod.Labels[extension.LabelQuotaName] = quotaName
+	}
+	return pod
+}
+
+// JobRequests is the sum of the requests of the pods of the Job running in parallel, which is bounded by
+// the completions of the Job.
+func JobRequests(job *batchv1.Job, templatePod *corev1.Pod) corev1.ResourceList {
+	parallelism := int32(1)
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+	if job.Spec.Completions != nil && *job.Spec.Completions < parallelism {
+		parallelism = *job.Spec.Completions
+	}
+	podRequests := apiresource.PodRequests(templatePod, apiresource.PodResourcesOptions{})
+	requests := corev1.ResourceList{}
+	for i := int32(0); i < parallelism; i++ {
+		requests = quotav1.Add(requests, podRequests)
+	}
+	return requests
+}
+
+// PodGroupPod builds a pod carrying the namespace and labels of the PodGroup to resolve its quota.
+func PodGroupPod(podGroup *v1alpha1.PodGroup) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: podGroup.Namespace,
+			Labels:    podGroup.Labels,
+		},
+	}
+}
+
+// GetAdmittedWorkloadOfPod returns the Job or the PodGroup admitted into the quota which the pod is a member of,
+// nil if the pod is not a member of any admitted workload.
+func GetAdmittedWorkloadOfPod(ctx context.Context, c client.Reader, pod *corev1.Pod, quota *v1alpha1.ElasticQuota) (*quotaevaluate.Workload, error) {
+	workloads, err := extension.GetAdmittedWorkloads(quota)
+	if err != nil || len(workloads) == 0 {
+		return nil, err
+	}
+
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == KindJob && owner.APIVersion == batchv1.SchemeGroupVersion.String() {
+		admitted, ok := workloads[quotaevaluate.WorkloadKey(KindJob, pod.Namespace, owner.Name)]
+		if ok && admitted.UID == owner.UID {
+			return &quotaevaluate.Workload{Kind: KindJob, Namespace: pod.Namespace, Name: owner.Name, UID: owner.UID}, nil
+		}
+	}
+
+	if gangName := extension.GetGangName(pod); gangName != "" {
+		admitted, ok := workloads[quotaevaluate.WorkloadKey(KindPodGroup, pod.Namespace, gangName)]
+		if !ok {
+			return nil, nil
+		}
+		podGroup := &v1alpha1.PodGroup{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: gangName}, podGroup)
+		if err == nil && podGroup.UID == admitted.UID {
+			return &quotaevaluate.Workload{Kind: KindPodGroup, Namespace: pod.Namespace, Name: gangName, UID: podGroup.UID}, nil
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+func makeJob(name string, parallelism *int32) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels: map[string]string{
+				extension.LabelQuotaName: "quota1",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Parallelism: parallelism,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("1"),
+									corev1.ResourceMemory: resource.MustParse("2Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestJobRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		parallelism *int32
+		completions *int32
+		want        corev1.ResourceList
+	}{
+		{
+			name:        "parallelism defaults to 1",
+			parallelism: nil,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
+		{
+			name:        "multiplied by parallelism",
+			parallelism: ptr.To[int32](3),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3"),
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		{
+			name:        "bounded by completions",
+			parallelism: ptr.To[int32](3),
+			completions: ptr.To[int32](2),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		{
+			name:        "zero parallelism",
+			parallelism: ptr.To[int32](0),
+			want:        corev1.ResourceList{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := makeJob("job1", tt.parallelism)
+			job.Spec.Completions = tt.completions
+			got := JobRequests(job, JobTemplatePod(job))
+			assert.True(t, equalResourceList(tt.want, got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func equalResourceList(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if v.Cmp(b[k]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestJobTemplatePod(t *testing.T) {
+	job := makeJob("job1", nil)
+	pod := JobTemplatePod(job)
+	assert.Equal(t, "ns1", pod.Namespace)
+	assert.Equal(t, "quota1", pod.Labels[extension.LabelQuotaName], "inherit the quota of the job")
+
+	job.Spec.Template.Labels = map[string]string{extension.LabelQuotaName: "quota2"}
+	pod = JobTemplatePod(job)
+	assert.Equal(t, "quota2", pod.Labels[extension.LabelQuotaName], "the quota of the template takes precedence")
+	assert.Equal(t, "quota2", job.Spec.Template.Labels[extension.LabelQuotaName])
+}
+
+func TestIsAdmitted(t *testing.T) {
+	job := makeJob("job1", nil)
+	quota := &v1alpha1.ElasticQuota{ObjectMeta: metav1.ObjectMeta{Name: "quota1"}}
+	admitted, err := IsAdmitted(quota, KindJob, job)
+	assert.NoError(t, err)
+	assert.False(t, admitted)
+
+	job.Annotations = map[string]string{extension.AnnotationAdmission: `{"cpu":"1"}`}
+	admitted, err = IsAdmitted(quota, KindJob, job)
+	assert.NoError(t, err)
+	assert.False(t, admitted, "the annotation on the workload set by the users must be ignored")
+
+	quota.Annotations = map[string]string{extension.AnnotationAdmission: `{"workloads":{"Job/ns1/job1":{"uid":"job1","requests":{"cpu":"1"}}}}`}
+	admitted, err = IsAdmitted(quota, KindJob, job)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+
+	job.UID = "recreated"
+	admitted, err = IsAdmitted(quota, KindJob, job)
+	assert.NoError(t, err)
+	assert.False(t, admitted)
+}
+
+func TestGetAdmittedWorkloadOfPod(t *testing.T) {
+	admittedJob := makeJob("admitted-job", nil)
+	pendingJob := makeJob("pending-job", nil)
+	pendingJob.Annotations = map[string]string{extension.AnnotationAdmission: `{"cpu":"1"}`}
+	admittedPodGroup := &v1alpha1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "admitted-gang",
+			UID:       "admitted-gang",
+		},
+	}
+	recreatedPodGroup := &v1alpha1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "recreated-gang",
+			UID:       "new-uid",
+		},
+	}
+	quota := &v1alpha1.ElasticQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "quota1",
+			Annotations: map[string]string{
+				extension.AnnotationAdmission: `{"limit":{"cpu":"10"},"workloads":{"Job/ns1/admitted-job":{"uid":"admitted-job"},` +
+					`"PodGroup/ns1/admitted-gang":{"uid":"admitted-gang"},"PodGroup/ns1/recreated-gang":{"uid":"old-uid"}}}`,
+			},
+		},
+	}
+
+	jobPod := func(job *batchv1.Job, uid types.UID) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      job.Name + "-xxx",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "batch/v1",
+						Kind:       KindJob,
+						Name:       job.Name,
+						UID:        uid,
+						Controller: ptr.To(true),
+					},
+				},
+			},
+		}
+	}
+	gangPod := func(gangName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      "pod1",
+				Labels: map[string]string{
+					v1alpha1.PodGroupLabel: gangName,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want *quotaevaluate.Workload
+	}{
+		{
+			name: "pod of the admitted job",
+			pod:  jobPod(admittedJob, admittedJob.UID),
+			want: &quotaevaluate.Workload{Kind: KindJob, Namespace: "ns1", Name: "admitted-job", UID: "admitted-job"},
+		},
+		{
+			name: "pod of the job with a faked admission annotation",
+			pod:  jobPod(pendingJob, pendingJob.UID),
+		},
+		{
+			name: "pod of the job with mismatched uid",
+			pod:  jobPod(admittedJob, "other"),
+		},
+		{
+			name: "pod of the admitted pod group",
+			pod:  gangPod("admitted-gang"),
+			want: &quotaevaluate.Workload{Kind: KindPodGroup, Namespace: "ns1", Name: "admitted-gang", UID: "admitted-gang"},
+		},
+		{
+			name: "pod of the recreated pod group",
+			pod:  gangPod("recreated-gang"),
+		},
+		{
+			name: "pod of the missing pod group",
+			pod:  gangPod("missing-gang"),
+		},
+		{
+			name: "standalone pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "pod1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.AddToScheme(scheme)
+			_ = clientgoscheme.AddToScheme(scheme)
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects([]client.Object{admittedJob, pendingJob, admittedPodGroup, recreatedPodGroup}...).Build()
+			got, err := GetAdmittedWorkloadOfPod(context.TODO(), c, tt.pod, quota)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/workload"
+)
+
+// evaluateJobQuota admits the pods of the Job running in parallel into the quota at once. A suspended Job is
+// regarded as queued, it's admitted when resumed. The increment is admitted again when the Job is scaled up,
+// and the admitted requests are released when the Job is suspended or finished.
+func (h *WorkloadValidatingHandler) evaluateJobQuota(ctx context.Context, req admission.Request, job, oldJob *batchv1.Job) error {
+	if req.AdmissionRequest.SubResource == "status" {
+		if oldJob != nil && isJobFinished(job) && !isJobFinished(oldJob) {
+			h.releaseWorkloadQuota(ctx, job)
+		}
+		return nil
+	}
+	if ptr.Deref(job.Spec.Suspend, false) {
+		if oldJob != nil && !ptr.Deref(oldJob.Spec.Suspend, false) {
+			h.releaseWorkloadQuota(ctx, job)
+		}
+		return nil
+	}
+
+	templatePod := jobTemplatePod(job)
+	requests := workload.JobRequests(job, templatePod)
+	if req.Operation == admissionv1.Update && !ptr.Deref(oldJob.Spec.Suspend, false) &&
+		quotav1.Equals(workload.JobRequests(oldJob, jobTemplatePod(oldJob)), requests) {
+		return nil
+	}
+	return h.evaluateWorkloadQuota(ctx, job, workload.KindJob, templatePod, requests)
+}
+
+// evaluatePodGroupQuota admits the MinResources of the PodGroup into the quota at once, the increment is admitted
+// again when the MinResources changes, and the admitted requests are released when the PodGroup is finished.
+func (h *WorkloadValidatingHandler) evaluatePodGroupQuota(ctx context.Context, req admission.Request, podGroup, oldPodGroup *v1alpha1.PodGroup) error {
+	if isPodGroupFinished(podGroup) {
+		if oldPodGroup != nil && !isPodGroupFinished(oldPodGroup) {
+			h.releaseWorkloadQuota(ctx, podGroup)
+		}
+		return nil
+	}
+	if quotav1.IsZero(podGroup.Spec.MinResources) {
+		if oldPodGroup != nil && !quotav1.IsZero(oldPodGroup.Spec.MinResources) {
+			h.releaseWorkloadQuota(ctx, podGroup)
+		}
+		return nil
+	}
+	if req.Operation == admissionv1.Update && quotav1.Equals(oldPodGroup.Spec.MinResources, podGroup.Spec.MinResources) {
+		return nil
+	}
+	return h.evaluateWorkloadQuota(ctx, podGroup, workload.KindPodGroup, workload.PodGroupPod(podGroup), podGroup.Spec.MinResources)
+}
+
+func (h *WorkloadValidatingHandler) evaluateWorkloadQuota(ctx context.Context, obj client.Object,
+	kind string, pod *corev1.Pod, requests corev1.ResourceList) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.EnableQuotaAdmission) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.WorkloadQuotaAdmission) {
+		return nil
+	}
+
+	quota, err := h.getWorkloadQuota(ctx, pod)
+	if quota == nil || err != nil {
+		return err
+	}
+
+	attribute := &quotaevaluate.Attributes{
+		QuotaNamespace: quota.Namespace,
+		QuotaName:      quota.Name,
+		Operation:      admissionv1.Create,
+		Workload: &quotaevaluate.Workload{
+			Kind:      kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			UID:       obj.GetUID(),
+			Requests:  requests,
+		},
+	}
+	if err = h.QuotaEvaluator.Evaluate(attribute); err != nil {
+		return fmt.Errorf("%s %s/%s is not admitted as a whole, %v", kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// releaseWorkloadQuota removes the workload from the admitted workloads of its quota. It never fails the request
+// since the pods of the workload are already gone or going to be deleted.
+func (h *WorkloadValidatingHandler) releaseWorkloadQuota(ctx context.Context, obj client.Object) {
+	var kind string
+	var pod *corev1.Pod
+	switch t := obj.(type) {
+	case *batchv1.Job:
+		kind, pod = workload.KindJob, jobTemplatePod(t)
+	case *v1alpha1.PodGroup:
+		kind, pod = workload.KindPodGroup, workload.PodGroupPod(t)
+	default:
+		return
+	}
+
+	quota, err := h.getWorkloadQuota(ctx, pod)
+	if quota == nil || err != nil {
+		return
+	}
+	if admitted, err := workload.IsAdmitted(quota, kind, obj); err != nil || !admitted {
+		return
+	}
+	attribute := &quotaevaluate.Attributes{
+		QuotaNamespace: quota.Namespace,
+		QuotaName:      quota.Name,
+		Operation:      admissionv1.Delete,
+		Workload: &quotaevaluate.Workload{
+			Kind:      kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			UID:       obj.GetUID(),
+			Release:   true,
+		},
+	}
+	if err = h.QuotaEvaluator.Evaluate(attribute); err != nil {
+		klog.ErrorS(err, "Failed to release workload from quota", "kind", kind, "workload", klog.KObj(obj), "quota", klog.KObj(quota))
+	}
+}
+
+// getWorkloadQuota returns the quota of the workload, nil if the workload belongs to the system quotas.
+func (h *WorkloadValidatingHandler) getWorkloadQuota(ctx context.Context, pod *corev1.Pod) (*v1alpha1.ElasticQuota, error) {
+	quotaName := elasticquota.GetQuotaName(pod, h.Client)
+	// quota is system quota or empty, skip it.
+	if quotaName == "" || quotaName == extension.DefaultQuotaName ||
+		quotaName == extension.SystemQuotaName || quotaName == extension.RootQuotaName {
+		return nil, nil
+	}
+	return quotaevaluate.GetQuotaByName(ctx, h.Client, quotaName)
+}
+
+func jobTemplatePod(job *batchv1.Job) *corev1.Pod {
+	templatePod := workload.JobTemplatePod(job)
+	if utilfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaEvaluationTransformPod) {
+		transformer.TransformReplaceResources(templatePod)
+	}
+	return templatePod
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func isPodGroupFinished(podGroup *v1alpha1.PodGroup) bool {
+	return podGroup.Status.Phase == v1alpha1.PodGroupFinished || podGroup.Status.Phase == v1alpha1.PodGroupFailed
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+func makeJob(parallelism int32, suspend bool) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "job1",
+			UID:       "job1",
+		},
+		Spec: batchv1.JobSpec{
+			Parallelism: ptr.To(parallelism),
+			Suspend:     ptr.To(suspend),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						extension.LabelQuotaName: "quota1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("1"),
+									corev1.ResourceMemory: resource.MustParse("2Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makePodGroup(minResources corev1.ResourceList) *v1alpha1.PodGroup {
+	return &v1alpha1.PodGroup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "PodGroup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "gang1",
+			UID:       "gang1",
+			Labels: map[string]string{
+				extension.LabelQuotaName: "quota1",
+			},
+		},
+		Spec: v1alpha1.PodGroupSpec{
+			MinMember:    2,
+			MinResources: minResources,
+		},
+	}
+}
+
+func finishedJob(job *batchv1.Job) *batchv1.Job {
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	return job
+}
+
+func newTestHandler(t *testing.T, quota *v1alpha1.ElasticQuota) *WorkloadValidatingHandler {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&v1alpha1.ElasticQuota{},
+		"metadata.name", func(object client.Object) []string {
+			eq, ok := object.(*v1alpha1.ElasticQuota)
+			if !ok {
+				return []string{}
+			}
+			return []string{eq.Name}
+		}).WithObjects(quota).Build()
+
+	h := &WorkloadValidatingHandler{
+		Client:  c,
+		Decoder: admission.NewDecoder(scheme),
+	}
+	quotaAccessor := quotaevaluate.NewQuotaAccessor(h.Client, h.Client)
+	h.QuotaEvaluator = quotaevaluate.NewQuotaEvaluator(quotaAccessor, 16, make(chan struct{}))
+	return h
+}
+
+func newAdmissionRequest(op admissionv1.Operation, resource, subResource string, object, oldObject runtime.Object) admission.Request {
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Resource:    metav1.GroupVersionResource{Resource: resource},
+			SubResource: subResource,
+			Operation:   op,
+		},
+	}
+	if object != nil {
+		req.Object = runtime.RawExtension{Raw: []byte(util.DumpJSON(object))}
+	}
+	if oldObject != nil {
+		req.OldObject = runtime.RawExtension{Raw: []byte(util.DumpJSON(oldObject))}
+	}
+	return req
+}
+
+func TestWorkloadValidatingHandler_Handle(t *testing.T) {
+	quotaMax := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+	jobAdmitted := map[string]extension.AdmittedWorkload{
+		"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		}},
+	}
+	testCases := []struct {
+		name          string
+		operation     admissionv1.Operation
+		resource      string
+		subResource   string
+		object        runtime.Object
+		oldObject     runtime.Object
+		quota         *v1alpha1.ElasticQuota
+		disableGate   bool
+		wantAllowed   bool
+		wantReason    string
+		wantUsed      corev1.ResourceList
+		wantWorkloads map[string]extension.AdmittedWorkload
+	}{
+		{
+			name:        "admit job within quota",
+			operation:   admissionv1.Create,
+			resource:    "jobs",
+			object:      makeJob(2, false),
+			quota:       elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).Obj(),
+			wantAllowed: true,
+			wantUsed:    corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}},
+			},
+		},
+		{
+			name:      "deny job exceeding quota as a whole",
+			operation: admissionv1.Create,
+			resource:  "jobs",
+			object:    makeJob(3, false),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			}).Obj(),
+			wantAllowed: false,
+			wantReason:  "Job ns1/job1 is not admitted as a whole, exceeded quota: kube-system/quota1, requested: cpu=3, used: cpu=2, limited: cpu=4",
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:      "suspended job is queued",
+			operation: admissionv1.Create,
+			resource:  "jobs",
+			object:    makeJob(3, true),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:        "admit job when resumed",
+			operation:   admissionv1.Update,
+			resource:    "jobs",
+			object:      makeJob(2, false),
+			oldObject:   makeJob(2, true),
+			quota:       elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).Obj(),
+			wantAllowed: true,
+			wantUsed:    corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				}},
+			},
+		},
+		{
+			name:        "scale up admitted job, admit the increment",
+			operation:   admissionv1.Update,
+			resource:    "jobs",
+			object:      makeJob(3, false),
+			oldObject:   makeJob(1, false),
+			quota:       elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed: true,
+			wantUsed:    corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{
+				"Job/ns1/job1": {UID: "job1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3"),
+					corev1.ResourceMemory: resource.MustParse("6Gi"),
+				}},
+			},
+		},
+		{
+			name:      "deny scaling up admitted job exceeding quota",
+			operation: admissionv1.Update,
+			resource:  "jobs",
+			object:    makeJob(4, false),
+			oldObject: makeJob(1, false),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			}).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed: false,
+			wantReason:  "Job ns1/job1 is not admitted as a whole, exceeded quota: kube-system/quota1, requested: cpu=3, used: cpu=3, limited: cpu=4",
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+			wantWorkloads: jobAdmitted,
+		},
+		{
+			name:          "update job without changing requests, skip",
+			operation:     admissionv1.Update,
+			resource:      "jobs",
+			object:        makeJob(1, false),
+			oldObject:     makeJob(1, false),
+			quota:         elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: jobAdmitted,
+		},
+		{
+			name:          "release finished job",
+			operation:     admissionv1.Update,
+			resource:      "jobs",
+			subResource:   "status",
+			object:        finishedJob(makeJob(1, false)),
+			oldObject:     makeJob(1, false),
+			quota:         elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:          "release deleted job",
+			operation:     admissionv1.Delete,
+			resource:      "jobs",
+			oldObject:     makeJob(1, false),
+			quota:         elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:      "deleted job with another uid, keep the admitted one",
+			operation: admissionv1.Delete,
+			resource:  "jobs",
+			oldObject: func() *batchv1.Job {
+				job := makeJob(1, false)
+				job.UID = "other"
+				return job
+			}(),
+			quota:         elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: jobAdmitted,
+		},
+		{
+			name:      "admit pod group with min resources",
+			operation: admissionv1.Create,
+			resource:  "podgroups",
+			object: makePodGroup(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("3"),
+			}),
+			quota:       elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).Obj(),
+			wantAllowed: true,
+			wantUsed:    corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{
+				"PodGroup/ns1/gang1": {UID: "gang1", Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("3"),
+				}},
+			},
+		},
+		{
+			name:      "deny pod group exceeding quota with the reservation of admitted job",
+			operation: admissionv1.Create,
+			resource:  "podgroups",
+			object: makePodGroup(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			}),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			}).AdmittedWorkloads(jobAdmitted).Obj(),
+			wantAllowed: false,
+			wantReason:  "PodGroup ns1/gang1 is not admitted as a whole, exceeded quota: kube-system/quota1, requested: cpu=2, used: cpu=3, limited: cpu=4",
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+			wantWorkloads: jobAdmitted,
+		},
+		{
+			name:      "release finished pod group",
+			operation: admissionv1.Update,
+			resource:  "podgroups",
+			object: func() *v1alpha1.PodGroup {
+				podGroup := makePodGroup(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")})
+				podGroup.Status.Phase = v1alpha1.PodGroupFinished
+				return podGroup
+			}(),
+			oldObject: makePodGroup(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}),
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).AdmittedWorkloads(map[string]extension.AdmittedWorkload{
+				"PodGroup/ns1/gang1": {UID: "gang1", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+			}).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:          "pod group without min resources, skip",
+			operation:     admissionv1.Create,
+			resource:      "podgroups",
+			object:        makePodGroup(nil),
+			quota:         elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(quotaMax).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+		{
+			name:        "feature gate disabled, skip",
+			operation:   admissionv1.Create,
+			resource:    "jobs",
+			object:      makeJob(3, false),
+			disableGate: true,
+			quota: elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}).Obj(),
+			wantAllowed:   true,
+			wantUsed:      corev1.ResourceList{},
+			wantWorkloads: map[string]extension.AdmittedWorkload{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.EnableQuotaAdmission, true)()
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.WorkloadQuotaAdmission, !tc.disableGate)()
+
+			h := newTestHandler(t, tc.quota)
+			resp := h.Handle(context.TODO(), newAdmissionRequest(tc.operation, tc.resource, tc.subResource, tc.object, tc.oldObject))
+			assert.Equal(t, tc.wantAllowed, resp.Allowed)
+			if !tc.wantAllowed {
+				assert.Equal(t, tc.wantReason, resp.Result.Message)
+			}
+			assert.Empty(t, resp.Patches)
+
+			newQuota := &v1alpha1.ElasticQuota{}
+			err := h.Client.Get(context.TODO(), types.NamespacedName{
+				Namespace: tc.quota.Namespace,
+				Name:      tc.quota.Name,
+			}, newQuota)
+			assert.NoError(t, err)
+			newUsed, err := extension.GetChildRequest(newQuota)
+			assert.NoError(t, err)
+			assert.True(t, quotav1.Equals(tc.wantUsed, newUsed), "want used %v, got %v", tc.wantUsed, newUsed)
+			workloads, err := extension.GetAdmittedWorkloads(newQuota)
+			assert.NoError(t, err)
+			assert.Len(t, workloads, len(tc.wantWorkloads))
+			for key, want := range tc.wantWorkloads {
+				assert.Equal(t, want.UID, workloads[key].UID)
+				assert.True(t, quotav1.Equals(want.Requests, workloads[key].Requests), "want requests %v, got %v", want.Requests, workloads[key].Requests)
+			}
+		})
+	}
+}
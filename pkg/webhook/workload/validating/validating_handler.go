@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+const (
+	EvaluateQuota = "EvaluateQuota"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=scheduling.sigs.k8s.io,resources=podgroups,verbs=get;list;watch
+
+// WorkloadValidatingHandler admits Jobs and PodGroups into the quota as a whole. The validating webhook is
+// used since the UID of the workload, which is recorded in the quota, is only set after the mutating ones.
+type WorkloadValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder admission.Decoder
+
+	// QuotaEvaluator evaluate workload quota usage
+	QuotaEvaluator quotaevaluate.Evaluator
+}
+
+var _ admission.Handler = &WorkloadValidatingHandler{}
+
+// Handle handles admission requests.
+func (h *WorkloadValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var obj, oldObj client.Object
+	var objectType string
+	switch req.AdmissionRequest.Resource.Resource {
+	case "jobs":
+		obj, oldObj, objectType = &batchv1.Job{}, &batchv1.Job{}, metrics.Job
+	case "podgroups":
+		obj, oldObj, objectType = &v1alpha1.PodGroup{}, &v1alpha1.PodGroup{}, metrics.PodGroup
+	default:
+		return admission.Allowed("")
+	}
+	if req.AdmissionRequest.SubResource != "" && req.AdmissionRequest.SubResource != "status" {
+		return admission.Allowed("")
+	}
+	switch req.Operation {
+	case admissionv1.Create:
+		oldObj = nil
+	case admissionv1.Update:
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case admissionv1.Delete:
+		// the deleted object is only carried in the OldObject
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		obj = nil
+	default:
+		return admission.Allowed("")
+	}
+	if obj != nil {
+		if err := h.Decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	start := time.Now()
+	var err error
+	switch t := obj.(type) {
+	case *batchv1.Job:
+		oldJob, _ := oldObj.(*batchv1.Job)
+		err = h.evaluateJobQuota(ctx, req, t, oldJob)
+	case *v1alpha1.PodGroup:
+		oldPodGroup, _ := oldObj.(*v1alpha1.PodGroup)
+		err = h.evaluatePodGroupQuota(ctx, req, t, oldPodGroup)
+	case nil:
+		h.releaseWorkloadQuota(ctx, oldObj)
+	}
+	metrics.RecordWebhookDurationMilliseconds(metrics.ValidatingWebhook,
+		objectType, string(req.Operation), err, EvaluateQuota, time.Since(start).Seconds())
+	if err != nil {
+		klog.V(4).Infof("Failed to admit %s %s/%s into quota, err: %v", objectType, req.Namespace, req.Name, err)
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// InjectClient injects the client into the WorkloadValidatingHandler
+func (h *WorkloadValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder into the WorkloadValidatingHandler
+func (h *WorkloadValidatingHandler) InjectDecoder(d admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/util/framework"
+)
+
+// +kubebuilder:webhook:path=/validate-workload,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1;v1beta1,groups=batch,resources=jobs;jobs/status,verbs=create;update;delete,versions=v1,name=vjob.koordinator.sh
+// +kubebuilder:webhook:path=/validate-workload,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1;v1beta1,groups=scheduling.sigs.k8s.io,resources=podgroups,verbs=create;update;delete,versions=v1alpha1,name=vpodgroup.koordinator.sh
+
+var (
+	// HandlerBuilderMap contains admission webhook handlers
+	HandlerBuilderMap = map[string]framework.HandlerBuilder{
+		"validate-workload": &workloadValidateBuilder{},
+	}
+)
+
+var _ framework.HandlerBuilder = &workloadValidateBuilder{}
+
+type workloadValidateBuilder struct {
+	mgr manager.Manager
+}
+
+func (b *workloadValidateBuilder) WithControllerManager(mgr ctrl.Manager) framework.HandlerBuilder {
+	b.mgr = mgr
+	return b
+}
+
+func (b *workloadValidateBuilder) Build() admission.Handler {
+	h := &WorkloadValidatingHandler{
+		Client:  b.mgr.GetClient(),
+		Decoder: admission.NewDecoder(b.mgr.GetScheme()),
+	}
+	quotaAccessor := quotaevaluate.NewQuotaAccessor(h.Client, b.mgr.GetAPIReader())
+	h.QuotaEvaluator = quotaevaluate.NewQuotaEvaluator(quotaAccessor, 4, make(chan struct{}))
+	return h
+}
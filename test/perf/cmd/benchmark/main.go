@@ -33,6 +33,7 @@ import (
 	// Add a new line here for each new scenario package.
 	_ "github.com/koordinator-sh/koordinator/test/perf/pkg/scenarios/basic"
 	_ "github.com/koordinator-sh/koordinator/test/perf/pkg/scenarios/gang"
+	_ "github.com/koordinator-sh/koordinator/test/perf/pkg/scenarios/workload"
 )
 
 func main() {
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workload implements the workload batch scheduling benchmark scenario.
+//
+// Design:
+//   - cfg.PodCount is split into ceil(PodCount/WorkloadReplicas) workloads,
+//     each of which is a set of identical-template pods controlled by the
+//     same owner, i.e. what a scaled-up ReplicaSet produces.
+//   - The owner of each workload is a ConfigMap rather than a real
+//     ReplicaSet: a ReplicaSet controller would adopt, create or delete the
+//     benchmark pods and skew the burst. koord-scheduler groups the pending
+//     pods by the controller UID only, so the owner kind is irrelevant.
+//   - The scenario measures the WorkloadBatch plugin only when the
+//     koord-scheduler under test enables it in its profile together with the
+//     EnableInlineBatchSchedule feature gate; otherwise it degrades into the
+//     basic scenario and can be used as the one-by-one baseline.
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/koordinator-sh/koordinator/test/perf/pkg/scenarios"
+	perftypes "github.com/koordinator-sh/koordinator/test/perf/pkg/types"
+)
+
+func init() {
+	scenarios.Register(func() scenarios.Scenario { return &WorkloadScenario{} })
+}
+
+// workloadOwner is the owner of the pods of one workload created in Setup.
+type workloadOwner struct {
+	name string
+	uid  types.UID
+}
+
+// WorkloadScenario benchmarks koord-scheduler's WorkloadBatch plugin by
+// creating ceil(podCount/workloadReplicas) workloads of identical pods.
+type WorkloadScenario struct {
+	namespace string
+	owners    []workloadOwner // set in Setup, reused by Pods
+}
+
+func (s *WorkloadScenario) Name() string { return "workload" }
+
+// Setup creates the namespace then one owner ConfigMap per workload of
+// cfg.WorkloadReplicas pods.
+func (s *WorkloadScenario) Setup(
+	ctx context.Context,
+	client kubernetes.Interface,
+	_ dynamic.Interface,
+	cfg perftypes.ScenarioConfig,
+	runID string,
+) error {
+	ns := cfg.Namespace
+	if ns == "" {
+		ns = "benchmark"
+	}
+	s.namespace = ns
+
+	if _, err := client.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get namespace %q: %w", ns, err)
+		}
+		if _, createErr := client.CoreV1().Namespaces().Create(ctx,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}},
+			metav1.CreateOptions{},
+		); createErr != nil {
+			return fmt.Errorf("failed to create namespace %q: %w", ns, createErr)
+		}
+	}
+
+	replicas := workloadReplicas(cfg)
+	runIDPrefix := shortID(runID)
+	numWorkloads := (cfg.PodCount + replicas - 1) / replicas
+	s.owners = make([]workloadOwner, 0, numWorkloads)
+
+	for i := 0; i < numWorkloads; i++ {
+		name := fmt.Sprintf("bench-workload-%s-%03d", runIDPrefix, i)
+		cm, err := client.CoreV1().ConfigMaps(ns).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					perftypes.RunIDLabel: runID,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create workload owner %q: %w", name, err)
+		}
+		s.owners = append(s.owners, workloadOwner{name: cm.Name, uid: cm.UID})
+	}
+	return nil
+}
+
+// Pods returns cfg.PodCount pods split evenly across the workloads created
+// in Setup. Pods of the same workload share the owner and the pod template,
+// so koord-scheduler can place them in one batch scheduling cycle.
+func (s *WorkloadScenario) Pods(cfg perftypes.ScenarioConfig, runID string) ([]*corev1.Pod, error) {
+	ns := s.namespace
+	if ns == "" {
+		ns = "benchmark"
+	}
+	schedulerName := cfg.SchedulerName
+	if schedulerName == "" {
+		schedulerName = "koord-scheduler"
+	}
+	if len(s.owners) == 0 {
+		return nil, fmt.Errorf("no workload owners, Setup must be called before Pods")
+	}
+	replicas := workloadReplicas(cfg)
+
+	var podResources corev1.ResourceRequirements
+	if len(cfg.ResourceRequests) > 0 {
+		rl := corev1.ResourceList{}
+		for k, v := range cfg.ResourceRequests {
+			qty, err := resource.ParseQuantity(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource quantity %q=%q: %w", k, v, err)
+			}
+			rl[corev1.ResourceName(k)] = qty
+		}
+		podResources = corev1.ResourceRequirements{Requests: rl, Limits: rl}
+	}
+
+	runIDPrefix := shortID(runID)
+	isController := true
+	pods := make([]*corev1.Pod, 0, cfg.PodCount)
+	for i := 0; i < cfg.PodCount; i++ {
+		ownerIdx := i / replicas
+		if ownerIdx >= len(s.owners) {
+			ownerIdx = len(s.owners) - 1
+		}
+		owner := s.owners[ownerIdx]
+
+		labels := map[string]string{
+			perftypes.RunIDLabel: runID,
+			"app":                owner.name,
+		}
+		for k, v := range cfg.Labels {
+			labels[k] = v
+		}
+		if cfg.QoSClass != "" {
+			labels["koordinator.sh/qosClass"] = cfg.QoSClass
+		}
+
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("bench-workload-pod-%s-%05d", runIDPrefix, i),
+				Namespace:   ns,
+				Labels:      labels,
+				Annotations: cfg.Annotations,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       owner.name,
+					UID:        owner.uid,
+					Controller: &isController,
+				}},
+			},
+			Spec: corev1.PodSpec{
+				SchedulerName: schedulerName,
+				Containers: []corev1.Container{{
+					Name:      "pause",
+					Image:     "registry.k8s.io/pause:3.9",
+					Resources: podResources,
+				}},
+				NodeSelector: map[string]string{"type": "kwok"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "kwok.x-k8s.io/node",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			},
+		})
+	}
+	return pods, nil
+}
+
+// Teardown deletes all owner ConfigMaps and pods created for this run.
+func (s *WorkloadScenario) Teardown(
+	ctx context.Context,
+	client kubernetes.Interface,
+	_ dynamic.Interface,
+	runID string,
+) error {
+	ns := s.namespace
+	if ns == "" {
+		ns = "benchmark"
+	}
+	labelSel := fmt.Sprintf("%s=%s", perftypes.RunIDLabel, runID)
+	policy := metav1.DeletePropagationBackground
+
+	if err := client.CoreV1().ConfigMaps(ns).DeleteCollection(ctx,
+		metav1.DeleteOptions{PropagationPolicy: &policy},
+		metav1.ListOptions{LabelSelector: labelSel},
+	); err != nil {
+		return fmt.Errorf("failed to delete workload owners for run %q: %w", runID, err)
+	}
+
+	return client.CoreV1().Pods(ns).DeleteCollection(ctx,
+		metav1.DeleteOptions{PropagationPolicy: &policy},
+		metav1.ListOptions{LabelSelector: labelSel},
+	)
+}
+
+// workloadReplicas returns the pod count of each workload, defaulting to
+// a single workload owning all the pods.
+func workloadReplicas(cfg perftypes.ScenarioConfig) int {
+	if cfg.WorkloadReplicas <= 0 {
+		return cfg.PodCount
+	}
+	return cfg.WorkloadReplicas
+}
+
+func shortID(runID string) string {
+	if len(runID) > 8 {
+		return runID[:8]
+	}
+	return runID
+}
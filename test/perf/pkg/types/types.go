@@ -43,6 +43,7 @@ type ScenarioConfig struct {
 	QoSClass         string                 `yaml:"qosClass"`
 	GangSize         int                    `yaml:"gangSize"`
 	MinMember        int                    `yaml:"minMember"`
+	WorkloadReplicas int                    `yaml:"workloadReplicas"`
 	ResourceRequests map[string]string      `yaml:"resourceRequests"`
 	Labels           map[string]string      `yaml:"labels"`
 	Annotations      map[string]string      `yaml:"annotations"`
@@ -106,6 +107,9 @@ func (c ScenarioConfig) Validate() error {
 	if c.ClientBurst <= 0 {
 		return fmt.Errorf("clientBurst must be > 0, got %d", c.ClientBurst)
 	}
+	if c.WorkloadReplicas < 0 {
+		return fmt.Errorf("workloadReplicas must be >= 0, got %d", c.WorkloadReplicas)
+	}
 	if c.NodeCreationWorkers < 0 {
 		return fmt.Errorf("nodeCreationWorkers must be >= 0, got %d", c.NodeCreationWorkers)
 	}